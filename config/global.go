@@ -31,11 +31,122 @@ const AppNotInDesiredStateWaitTimeSeconds = 65
 // Monitor app details after vist for 15 minutes (900 seconds)
 const MonitorAppDetailTTL = 900
 
+// A container instance that has crashed at least CrashLoopMinCrashes times in the
+// last CrashLoopWindowSeconds is considered to be in a crash loop
+const CrashLoopMinCrashes = 3
+const CrashLoopWindowSeconds = 600
+
+// Crash history is persisted locally (per foundation API endpoint) so crash counts
+// are available beyond the 24 hours of app.crash events loaded from the cloud controller.
+// Live crashes are written to the store every CrashHistorySaveSeconds (and at exit).
+const CrashHistoryRetentionDays = 30
+const CrashHistorySaveSeconds = 60
+
+// Number of container lifecycle events (creating, created, healthy, crashed, etc)
+// kept for each app.  Used by the app container lifecycle view.
+const MaxContainerLifecycleEventHistory = 500
+
+// Number of staging builds kept for each app.  A staging build with no STG log
+// output for StagingStaleSeconds is considered ended (outcome unknown).
+const MaxStagingHistory = 10
+const StagingStaleSeconds = 900
+
+// Number of deployments (push, restage, rolling deploy) kept for each app.  A deployment
+// that has not replaced all old containers within DeploymentTimeoutSeconds is ended.
+const MaxDeploymentHistory = 5
+const DeploymentTimeoutSeconds = 1800
+
+// Cell reported remaining memory (CapacityRemainingMemory) that differs from the cell memory
+// capacity less the memory reserved by containers by more then this percent of capacity
+// is flagged as a discrepancy in the cell resource breakdown
+const CellMemoryDiscrepancyPercent = 5
+
+// A container using at least NoisyNeighborMinCpuPercent CPU that is also at least
+// NoisyNeighborMinCpuShare percent of all container CPU on its cell is a noisy neighbor
+// suspect when a container of another app on the same cell has a last 10 second average
+// response time NoisyNeighborLatencyFactor times (or more) its last 60 second average
+const NoisyNeighborMinCpuPercent = 80
+const NoisyNeighborMinCpuShare = 40
+const NoisyNeighborLatencyFactor = 1.5
+
+// A platform component (router, doppler, etc) that has not sent a well-known
+// metric for this long is shown as STALE in the platform health view
+const ComponentMetricStaleSeconds = 180
+
+// Number of recent log lines kept for each monitored app (app detail view visited
+// within MonitorAppDetailTTL).  Used by the app log tail view.
+const MaxAppLogLineHistory = 1000
+
+// Task state is only available from the cloud controller (/v3/tasks), reload no
+// more often then this while tasks are active or being viewed
+const TaskMetadataRefreshSeconds = 30
+
+// Completed tasks (and their log counts) are dropped once they have ended more
+// then TaskRetentionSeconds ago
+const TaskRetentionSeconds = 3600
+
+// Percent of an org or space quota limit (any dimension) at which a WARN or ALERT
+// message is displayed.  Can be changed with the -quota-warn and -quota-alert options.
+var QuotaWarnPercent = 80
+var QuotaAlertPercent = 95
+
+// Quota reservation is sampled every QuotaSampleSeconds and the samples from the last
+// QuotaForecastWindowSeconds are used to forecast when a quota limit will be reached
+const QuotaSampleSeconds = 60
+const QuotaForecastWindowSeconds = 3600
+const QuotaForecastMinSamples = 5
+
+// Display a WARN message if a quota limit is forecast to be reached within this many hours
+const QuotaForecastWarnHours = 24
+
+// A quota limit forecast to be reached further out than this is not shown
+const QuotaForecastHorizonDays = 365
+
+// Ingest pipeline.  Envelopes from each nozzle are put on a queue of IngestNozzleQueueSize
+// (dropped when full) and handed to one of the ingest partitions (one per CPU up to
+// MaxIngestPartitions) which each have a queue of IngestPartitionQueueSize
+const IngestNozzleQueueSize = 10000
+const IngestPartitionQueueSize = 1000
+const MaxIngestPartitions = 8
+
+// Show a data loss alert if a nozzle had messages dropped (by doppler or the ingest
+// queue) or was disconnected as a slow consumer within this many seconds
+const NozzleDataLossAlertSeconds = 60
+
+// Firehose nozzles (sharing one subscription) are automatically scaled between NozzleMinCount
+// and NozzleMaxCount.  Can be changed with the -nozzles-min and -nozzles-max options.
+// Every NozzleScaleCheckSeconds a nozzle is added if doppler dropped messages or closed a nozzle
+// as a slow consumer.  A nozzle is removed if one less nozzle would still receive less then
+// NozzleScaleDownRate envelopes per second each for NozzleScaleDownSeconds.  No scaling is done
+// within NozzleScaleCooldownSeconds of the last change so the new count can take effect.
+const MaxNozzleCount = 10
+
+var NozzleMinCount = 1
+var NozzleMaxCount = MaxNozzleCount
+
+const NozzleScaleCheckSeconds = 10
+const NozzleScaleCooldownSeconds = 60
+const NozzleScaleDownRate = 1000
+const NozzleScaleDownSeconds = 300
+
+// Requests to a domain that doesn't fit in MaxDomainBucket are counted in the OTHER domain.
+// When a domain has MaxHostBucket hosts the HostEvictPercent least recently active dynamically
+// added hosts (not from route metadata) are folded into the OTHER host of the domain.  When a
+// route has MaxUserAgentBucket user agents the least recently seen is folded into OTHER.
 const MaxDomainBucket = 100
 const MaxHostBucket = 10000
+const HostEvictPercent = 10
 const MaxUserAgentBucket = 100
 const MaxForwarderBucket = 100
 
+// Approximate memory the live event stats may use.  Checked every StatsMemoryCheckSeconds.
+// When over the limit the least recently active dynamically added hosts are folded into the
+// OTHER host of their domain, then the least recently active apps that are not in the app
+// metadata are removed, until memory is at StatsMemoryTargetPercent of the limit.
+const MaxStatsMemoryMB = 256
+const StatsMemoryCheckSeconds = 30
+const StatsMemoryTargetPercent = 90
+
 // Number of records to retrieve per cloud controller REST call.
 const ResultsPerPage = 100
 const ResultsPerV3Page = 1000
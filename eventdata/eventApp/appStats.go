@@ -16,6 +16,7 @@
 package eventApp
 
 import (
+	"sort"
 	"time"
 
 	"github.com/cloudfoundry/sonde-go/events"
@@ -36,7 +37,11 @@ type AppStats struct {
 	NonContainerStdout int64
 	NonContainerStderr int64
 
+	// Containers of the "web" process
 	ContainerArray []*ContainerStats
+	// Containers of all other process types (worker, clock, etc)
+	// Key: process type
+	ProcessContainerMap map[string][]*ContainerStats
 	// Key: instanceId
 	ContainerTrafficMap map[string]*TrafficStats
 
@@ -47,6 +52,23 @@ type AppStats struct {
 	// because container stats entries and come and go (scale up/down)
 	// but we want to keep all crash info regardless of container status
 	ContainerCrashInfo []*crashData.ContainerCrashInfo
+
+	// Lifecycle events (creating, created, healthy, crashed, etc) of all
+	// containers -- kept in AppStats for the same reason as crash info
+	LifecycleEvents []*ContainerLifecycleEvent
+
+	// Key: task name
+	TaskMap map[string]*TaskStats
+
+	// Recent staging builds, oldest first
+	StagingArray []*StagingStats
+
+	// Last app version seen in an API event and last package_updated_at seen in
+	// the app metadata -- a change in either is a deployment
+	AppVersion       string
+	PackageUpdatedAt string
+	// Recent deployments, oldest first
+	Deployments []*DeploymentStats
 }
 
 func NewAppStats(appId string) *AppStats {
@@ -67,12 +89,28 @@ func ConvertFromMap(statsMap map[string]*AppStats, appMdMgr *app.AppMetadataMana
 	return s
 }
 
-func (as *AppStats) AddCrashInfo(containerIndex int, crashTime *time.Time, exitDescription string) {
+// Containers of all process types (web, worker, etc).  Slots in the returned
+// array may be nil the same as ContainerArray
+func (as *AppStats) AllContainers() []*ContainerStats {
+	if len(as.ProcessContainerMap) == 0 {
+		return as.ContainerArray
+	}
+	containers := make([]*ContainerStats, 0, len(as.ContainerArray))
+	containers = append(containers, as.ContainerArray...)
+	for _, containerArray := range as.ProcessContainerMap {
+		containers = append(containers, containerArray...)
+	}
+	return containers
+}
+
+func (as *AppStats) AddCrashInfo(containerIndex int, crashTime *time.Time, exitDescription string, cellIp string) {
 	crashInfo := crashData.NewContainerCrashInfo(containerIndex, crashTime, exitDescription)
+	crashInfo.CellIp = cellIp
 	if as.ContainerCrashInfo == nil {
 		as.ContainerCrashInfo = make([]*crashData.ContainerCrashInfo, 0, 10)
 	}
 	as.ContainerCrashInfo = append(as.ContainerCrashInfo, crashInfo)
+	crashData.RecordCrash(as.AppId, crashInfo)
 }
 
 func (as *AppStats) CrashSince(since time.Duration) []*crashData.ContainerCrashInfo {
@@ -115,3 +153,91 @@ func (as *AppStats) Crash24hCount() int {
 	//return as.CrashCount(-2 * time.Minute)
 	return as.CrashCountSince(-24 * time.Hour)
 }
+
+// New app stats with the stats of the same app from different ingest partitions.
+// The container metrics of a process other than web are partitioned by process
+// guid so such an app is updated by two partitions.  Counters are added together
+// and the containers of each process are combined.  The given stats are not modified.
+func MergeAppStats(appStatsList []*AppStats) *AppStats {
+	if len(appStatsList) == 1 {
+		return appStatsList[0]
+	}
+	merged := *appStatsList[0]
+	merged.ProcessContainerMap = make(map[string][]*ContainerStats)
+	for processType, containerArray := range appStatsList[0].ProcessContainerMap {
+		merged.ProcessContainerMap[processType] = containerArray
+	}
+	merged.ContainerTrafficMap = make(map[string]*TrafficStats)
+	for instanceId, trafficStats := range appStatsList[0].ContainerTrafficMap {
+		merged.ContainerTrafficMap[instanceId] = trafficStats
+	}
+	merged.TaskMap = make(map[string]*TaskStats)
+	for key, taskStats := range appStatsList[0].TaskMap {
+		merged.TaskMap[key] = taskStats
+	}
+
+	for _, other := range appStatsList[1:] {
+		if merged.AppUUID == nil {
+			merged.AppUUID = other.AppUUID
+		}
+		merged.NonContainerStdout = merged.NonContainerStdout + other.NonContainerStdout
+		merged.NonContainerStderr = merged.NonContainerStderr + other.NonContainerStderr
+		merged.ContainerArray = mergeContainerArrays(merged.ContainerArray, other.ContainerArray)
+		for processType, containerArray := range other.ProcessContainerMap {
+			merged.ProcessContainerMap[processType] = mergeContainerArrays(merged.ProcessContainerMap[processType], containerArray)
+		}
+		// HTTP requests of an app are only counted by the partition that owns the app guid
+		for instanceId, trafficStats := range other.ContainerTrafficMap {
+			if merged.ContainerTrafficMap[instanceId] == nil {
+				merged.ContainerTrafficMap[instanceId] = trafficStats
+			}
+		}
+		if len(other.ContainerTrafficMap) > 0 || merged.TotalTraffic == nil {
+			merged.TotalTraffic = other.TotalTraffic
+		}
+		for key, taskStats := range other.TaskMap {
+			if merged.TaskMap[key] == nil {
+				merged.TaskMap[key] = taskStats
+			}
+		}
+		if len(other.ContainerCrashInfo) > 0 {
+			crashInfoList := make([]*crashData.ContainerCrashInfo, 0, len(merged.ContainerCrashInfo)+len(other.ContainerCrashInfo))
+			crashInfoList = append(append(crashInfoList, merged.ContainerCrashInfo...), other.ContainerCrashInfo...)
+			// Crash info is kept oldest first (see CrashSince)
+			sort.SliceStable(crashInfoList, func(i, j int) bool {
+				return timeBefore(crashInfoList[i].CrashTime, crashInfoList[j].CrashTime)
+			})
+			merged.ContainerCrashInfo = crashInfoList
+		}
+		if len(other.LifecycleEvents) > 0 {
+			lifecycleEvents := make([]*ContainerLifecycleEvent, 0, len(merged.LifecycleEvents)+len(other.LifecycleEvents))
+			lifecycleEvents = append(append(lifecycleEvents, merged.LifecycleEvents...), other.LifecycleEvents...)
+			sort.SliceStable(lifecycleEvents, func(i, j int) bool {
+				return timeBefore(lifecycleEvents[i].EventTime, lifecycleEvents[j].EventTime)
+			})
+			merged.LifecycleEvents = lifecycleEvents
+		}
+		// Staging and deployments are only tracked from log and API events of the app guid
+		if len(merged.StagingArray) == 0 {
+			merged.StagingArray = other.StagingArray
+		}
+		if len(merged.Deployments) == 0 {
+			merged.Deployments = other.Deployments
+		}
+		if merged.AppVersion == "" {
+			merged.AppVersion = other.AppVersion
+		}
+		if merged.PackageUpdatedAt == "" {
+			merged.PackageUpdatedAt = other.PackageUpdatedAt
+		}
+	}
+	return &merged
+}
+
+// Nil times sort first
+func timeBefore(time1 *time.Time, time2 *time.Time) bool {
+	if time1 == nil || time2 == nil {
+		return time1 == nil && time2 != nil
+	}
+	return time1.Before(*time2)
+}
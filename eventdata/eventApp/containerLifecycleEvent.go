@@ -0,0 +1,58 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventApp
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+)
+
+type LifecycleEventType string
+
+const (
+	LIFECYCLE_CREATING   LifecycleEventType = "CREATING"
+	LIFECYCLE_CREATED    LifecycleEventType = "CREATED"
+	LIFECYCLE_HEALTHY    LifecycleEventType = "HEALTHY"
+	LIFECYCLE_CRASHED    LifecycleEventType = "CRASHED"
+	LIFECYCLE_DESTROYING LifecycleEventType = "DESTROYING"
+	LIFECYCLE_EVACUATED  LifecycleEventType = "EVACUATED"
+)
+
+// A single lifecycle event of an application container instance
+type ContainerLifecycleEvent struct {
+	ContainerIndex int
+	EventType      LifecycleEventType
+	EventTime      *time.Time
+	CellIp         string
+	Message        string
+}
+
+func NewContainerLifecycleEvent(containerIndex int, eventType LifecycleEventType, eventTime *time.Time, cellIp string, message string) *ContainerLifecycleEvent {
+	return &ContainerLifecycleEvent{ContainerIndex: containerIndex, EventType: eventType,
+		EventTime: eventTime, CellIp: cellIp, Message: message}
+}
+
+func (as *AppStats) AddLifecycleEvent(event *ContainerLifecycleEvent) {
+	if len(as.LifecycleEvents) >= config.MaxContainerLifecycleEventHistory {
+		// Copy to a new array instead of shifting in place so a cloned AppStats
+		// that shares the old array is not modified
+		keep := config.MaxContainerLifecycleEventHistory - 1
+		lifecycleEvents := make([]*ContainerLifecycleEvent, 0, config.MaxContainerLifecycleEventHistory)
+		lifecycleEvents = append(lifecycleEvents, as.LifecycleEvents[len(as.LifecycleEvents)-keep:]...)
+		as.LifecycleEvents = lifecycleEvents
+	}
+	as.LifecycleEvents = append(as.LifecycleEvents, event)
+}
@@ -19,10 +19,12 @@ import (
 	"time"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/process"
 )
 
 type ContainerStats struct {
 	ContainerIndex          int
+	ProcessType             string
 	Ip                      string
 	ContainerMetric         *events.ContainerMetric
 	LastUpdateTime          *time.Time
@@ -38,6 +40,72 @@ type ContainerStats struct {
 }
 
 func NewContainerStats(containerIndex int) *ContainerStats {
-	stats := &ContainerStats{ContainerIndex: containerIndex}
+	stats := &ContainerStats{ContainerIndex: containerIndex, ProcessType: process.WebProcessType}
 	return stats
 }
+
+func NewProcessContainerStats(processType string, containerIndex int) *ContainerStats {
+	stats := &ContainerStats{ContainerIndex: containerIndex, ProcessType: processType}
+	return stats
+}
+
+// New container array with the containers of both arrays.  A container in both
+// is combined by mergeContainerStats.  The given arrays are not modified.
+func mergeContainerArrays(containerArray []*ContainerStats, otherContainerArray []*ContainerStats) []*ContainerStats {
+	if len(otherContainerArray) == 0 {
+		return containerArray
+	}
+	if len(containerArray) == 0 {
+		return otherContainerArray
+	}
+	size := len(containerArray)
+	if len(otherContainerArray) > size {
+		size = len(otherContainerArray)
+	}
+	merged := make([]*ContainerStats, size)
+	copy(merged, containerArray)
+	for i, other := range otherContainerArray {
+		switch {
+		case other == nil:
+		case merged[i] == nil:
+			merged[i] = other
+		default:
+			merged[i] = mergeContainerStats(merged[i], other)
+		}
+	}
+	return merged
+}
+
+// New container stats with the log counts of both added together and the most
+// recent metric and cell messages of either
+func mergeContainerStats(cs *ContainerStats, other *ContainerStats) *ContainerStats {
+	merged := *cs
+	merged.OutCount = cs.OutCount + other.OutCount
+	merged.ErrCount = cs.ErrCount + other.ErrCount
+	merged.CreateCount = cs.CreateCount + other.CreateCount
+	if timeBefore(merged.LastMetricUpdateTime, other.LastMetricUpdateTime) {
+		merged.LastMetricUpdateTime = other.LastMetricUpdateTime
+		merged.ContainerMetric = other.ContainerMetric
+		merged.Ip = other.Ip
+	}
+	if timeBefore(merged.LastUpdateTime, other.LastUpdateTime) {
+		merged.LastUpdateTime = other.LastUpdateTime
+	}
+	if timeBefore(merged.CellLastStartMsgTime, other.CellLastStartMsgTime) {
+		merged.CellLastStartMsgTime = other.CellLastStartMsgTime
+		merged.CellLastStartMsgText = other.CellLastStartMsgText
+	}
+	if timeBefore(merged.CellLastCreatingMsgTime, other.CellLastCreatingMsgTime) {
+		merged.CellLastCreatingMsgTime = other.CellLastCreatingMsgTime
+	}
+	if timeBefore(merged.CellCreatedMsgTime, other.CellCreatedMsgTime) {
+		merged.CellCreatedMsgTime = other.CellCreatedMsgTime
+	}
+	if timeBefore(merged.CellHealthyMsgTime, other.CellHealthyMsgTime) {
+		merged.CellHealthyMsgTime = other.CellHealthyMsgTime
+	}
+	if merged.Ip == "" {
+		merged.Ip = other.Ip
+	}
+	return &merged
+}
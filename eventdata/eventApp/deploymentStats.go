@@ -0,0 +1,140 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventApp
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+)
+
+// HTTP request and error (5xx) counts
+type HttpCountSnapshot struct {
+	HttpCount      int64
+	HttpErrorCount int64
+}
+
+// A change of the application version (push, restage, rolling deploy)
+// while top is running
+type DeploymentStats struct {
+	StartTime *time.Time
+	EndTime   *time.Time
+	TimedOut  bool
+	// What the deployment was detected from
+	Trigger    string
+	OldVersion string
+	NewVersion string
+
+	// HTTP counts of each container (key: instanceId) when the deployment was detected.
+	// Used to calculate the HTTP counts after the deployment started
+	BaselineTraffic map[string]*HttpCountSnapshot
+	// HTTP counts of all containers from when top started until the deployment was detected
+	Before *HttpCountSnapshot
+}
+
+func NewDeploymentStats(startTime *time.Time, trigger string, containerTrafficMap map[string]*TrafficStats) *DeploymentStats {
+	stats := &DeploymentStats{StartTime: startTime, Trigger: trigger}
+	stats.BaselineTraffic = make(map[string]*HttpCountSnapshot)
+	stats.Before = &HttpCountSnapshot{}
+	for instanceId, containerTraffic := range containerTrafficMap {
+		snapshot := containerTraffic.HttpCounts()
+		stats.BaselineTraffic[instanceId] = snapshot
+		stats.Before.HttpCount += snapshot.HttpCount
+		stats.Before.HttpErrorCount += snapshot.HttpErrorCount
+	}
+	return stats
+}
+
+// HTTP counts of all containers since the deployment was detected
+func (ds *DeploymentStats) After(containerTrafficMap map[string]*TrafficStats) *HttpCountSnapshot {
+	after := &HttpCountSnapshot{}
+	for instanceId, containerTraffic := range containerTrafficMap {
+		snapshot := containerTraffic.HttpCounts()
+		after.HttpCount += snapshot.HttpCount
+		after.HttpErrorCount += snapshot.HttpErrorCount
+		baseline := ds.BaselineTraffic[instanceId]
+		if baseline != nil {
+			after.HttpCount -= baseline.HttpCount
+			after.HttpErrorCount -= baseline.HttpErrorCount
+		}
+	}
+	return after
+}
+
+// Number of (web process) containers started before and after the deployment started
+func (ds *DeploymentStats) ContainerCounts(containerArray []*ContainerStats) (oldCount int, newCount int) {
+	for _, containerStats := range containerArray {
+		if containerStats == nil {
+			continue
+		}
+		createTime := containerStats.CellLastCreatingMsgTime
+		if createTime != nil && !createTime.Before(*ds.StartTime) {
+			newCount++
+		} else {
+			oldCount++
+		}
+	}
+	return oldCount, newCount
+}
+
+func (ts *TrafficStats) HttpCounts() *HttpCountSnapshot {
+	snapshot := &HttpCountSnapshot{}
+	for _, httpStatusCodeMap := range ts.HttpInfoMap {
+		for statusCode, httpInfo := range httpStatusCodeMap {
+			if httpInfo == nil {
+				continue
+			}
+			snapshot.HttpCount += httpInfo.HttpCount
+			if statusCode >= 500 && statusCode < 600 {
+				snapshot.HttpErrorCount += httpInfo.HttpCount
+			}
+		}
+	}
+	return snapshot
+}
+
+// The deployment in progress or nil if there is none
+func (as *AppStats) ActiveDeployment() *DeploymentStats {
+	if len(as.Deployments) == 0 {
+		return nil
+	}
+	deployment := as.Deployments[len(as.Deployments)-1]
+	if deployment.EndTime != nil {
+		return nil
+	}
+	return deployment
+}
+
+// Record the start of a deployment.  If a deployment is already in progress
+// it is the same deployment seen from a different source.
+func (as *AppStats) StartDeployment(startTime *time.Time, trigger string) *DeploymentStats {
+	deployment := as.ActiveDeployment()
+	if deployment != nil {
+		if startTime.Before(*deployment.StartTime) {
+			deployment.StartTime = startTime
+		}
+		return deployment
+	}
+	deployment = NewDeploymentStats(startTime, trigger, as.ContainerTrafficMap)
+	if len(as.Deployments) >= config.MaxDeploymentHistory {
+		// Copy to a new array so a cloned AppStats sharing the old array is not modified
+		keep := config.MaxDeploymentHistory - 1
+		deployments := make([]*DeploymentStats, 0, config.MaxDeploymentHistory)
+		deployments = append(deployments, as.Deployments[len(as.Deployments)-keep:]...)
+		as.Deployments = deployments
+	}
+	as.Deployments = append(as.Deployments, deployment)
+	return deployment
+}
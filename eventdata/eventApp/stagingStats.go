@@ -0,0 +1,73 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventApp
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+)
+
+// Stats for a single staging (build) of an app as seen on the firehose.
+// Staging logs with a source type of "STG".  Staging is considered complete
+// when the staging container is destroyed.
+type StagingStats struct {
+	StartTime   *time.Time
+	EndTime     *time.Time
+	LastLogTime *time.Time
+	// Buildpack detected from the staging log output
+	Buildpack string
+	// Exit status of the staging process, empty if not seen
+	ExitStatus string
+	OutCount   int64
+	ErrCount   int64
+}
+
+func NewStagingStats(startTime *time.Time) *StagingStats {
+	stats := &StagingStats{StartTime: startTime}
+	return stats
+}
+
+// Staging has ended if the staging container was destroyed or
+// there has been no staging output for a while
+func (ss *StagingStats) IsEnded(now time.Time) bool {
+	if ss.EndTime != nil {
+		return true
+	}
+	return ss.LastLogTime != nil && now.Sub(*ss.LastLogTime) > config.StagingStaleSeconds*time.Second
+}
+
+// The in-progress staging of the app or nil if the app is not staging
+func (as *AppStats) CurrentStaging(now time.Time) *StagingStats {
+	if len(as.StagingArray) == 0 {
+		return nil
+	}
+	stagingStats := as.StagingArray[len(as.StagingArray)-1]
+	if stagingStats.IsEnded(now) {
+		return nil
+	}
+	return stagingStats
+}
+
+func (as *AppStats) AddStaging(stagingStats *StagingStats) {
+	if len(as.StagingArray) >= config.MaxStagingHistory {
+		// Copy to a new array so a cloned AppStats sharing the old array is not modified
+		keep := config.MaxStagingHistory - 1
+		stagingArray := make([]*StagingStats, 0, config.MaxStagingHistory)
+		stagingArray = append(stagingArray, as.StagingArray[len(as.StagingArray)-keep:]...)
+		as.StagingArray = stagingArray
+	}
+	as.StagingArray = append(as.StagingArray, stagingStats)
+}
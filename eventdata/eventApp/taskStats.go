@@ -0,0 +1,36 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventApp
+
+import "time"
+
+// Stats for a single task (cf run-task) as seen on the firehose.  Tasks
+// log with a source type of "APP/TASK/<task name>/<index>" so the task name
+// is the only identifier available from the event stream.  The TaskGuid is
+// resolved from the task metadata and is empty until the metadata is loaded.
+type TaskStats struct {
+	TaskName     string
+	TaskGuid     string
+	OutCount     int64
+	ErrCount     int64
+	FirstLogTime *time.Time
+	LastLogTime  *time.Time
+}
+
+func NewTaskStats(taskName string) *TaskStats {
+	stats := &TaskStats{TaskName: taskName}
+	return stats
+}
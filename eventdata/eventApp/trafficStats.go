@@ -35,16 +35,16 @@ type TrafficStats struct {
 	InstanceIndex int32
 
 	ResponseL60Time    *util.AvgTracker
-	AvgResponseL60Time float64 // updated after a clone of this object
-	EventL60Rate       int     // updated after a clone of this object
+	AvgResponseL60Time float64 // updated when a snapshot is taken
+	EventL60Rate       int     // updated when a snapshot is taken
 
 	ResponseL10Time    *util.AvgTracker
-	AvgResponseL10Time float64 // updated after a clone of this object
-	EventL10Rate       int     // updated after a clone of this object
+	AvgResponseL10Time float64 // updated when a snapshot is taken
+	EventL10Rate       int     // updated when a snapshot is taken
 
 	ResponseL1Time    *util.AvgTracker
-	AvgResponseL1Time float64 // updated after a clone of this object
-	EventL1Rate       int     // updated after a clone of this object
+	AvgResponseL1Time float64 // updated when a snapshot is taken
+	EventL1Rate       int     // updated when a snapshot is taken
 
 	HttpInfoMap map[events.Method]map[int32]*HttpInfo
 }
@@ -0,0 +1,226 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventCell
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// A cell stat that can be populated from a ValueMetric
+type CellMetric string
+
+const (
+	CELL_METRIC_NUM_CPUS                    CellMetric = "NumOfCpus"
+	CELL_METRIC_CAPACITY_MEMORY_TOTAL       CellMetric = "CapacityMemoryTotal"
+	CELL_METRIC_CAPACITY_MEMORY_REMAINING   CellMetric = "CapacityMemoryRemaining"
+	CELL_METRIC_CAPACITY_DISK_TOTAL         CellMetric = "CapacityDiskTotal"
+	CELL_METRIC_CAPACITY_DISK_REMAINING     CellMetric = "CapacityDiskRemaining"
+	CELL_METRIC_CAPACITY_TOTAL_CONTAINERS   CellMetric = "CapacityTotalContainers"
+	CELL_METRIC_CAPACITY_REMAIN_CONTAINERS  CellMetric = "CapacityRemainingContainers"
+	CELL_METRIC_CONTAINER_COUNT             CellMetric = "ContainerCount"
+	CELL_METRIC_HOST_CPU_USER               CellMetric = "HostCpuUser"
+	CELL_METRIC_HOST_CPU_SYS                CellMetric = "HostCpuSys"
+	CELL_METRIC_HOST_CPU_WAIT               CellMetric = "HostCpuWait"
+	CELL_METRIC_HOST_LOAD_1M                CellMetric = "HostLoad1m"
+	CELL_METRIC_HOST_MEM_PERCENT            CellMetric = "HostMemPercent"
+	CELL_METRIC_HOST_DISK_SYSTEM_PERCENT    CellMetric = "HostDiskSystemPercent"
+	CELL_METRIC_HOST_DISK_EPHEMERAL_PERCENT CellMetric = "HostDiskEphemeralPercent"
+)
+
+// Host (BOSH system) metrics are sent for every VM in the foundation, not only cells
+func (m CellMetric) IsHostMetric() bool {
+	switch m {
+	case CELL_METRIC_HOST_CPU_USER, CELL_METRIC_HOST_CPU_SYS, CELL_METRIC_HOST_CPU_WAIT,
+		CELL_METRIC_HOST_LOAD_1M, CELL_METRIC_HOST_MEM_PERCENT,
+		CELL_METRIC_HOST_DISK_SYSTEM_PERCENT, CELL_METRIC_HOST_DISK_EPHEMERAL_PERCENT:
+		return true
+	}
+	return false
+}
+
+func (m CellMetric) isValid() bool {
+	switch m {
+	case CELL_METRIC_NUM_CPUS, CELL_METRIC_CAPACITY_MEMORY_TOTAL, CELL_METRIC_CAPACITY_MEMORY_REMAINING,
+		CELL_METRIC_CAPACITY_DISK_TOTAL, CELL_METRIC_CAPACITY_DISK_REMAINING,
+		CELL_METRIC_CAPACITY_TOTAL_CONTAINERS, CELL_METRIC_CAPACITY_REMAIN_CONTAINERS,
+		CELL_METRIC_CONTAINER_COUNT:
+		return true
+	}
+	return m.IsHostMetric()
+}
+
+// Maps a ValueMetric (by envelope origin and metric name) to a cell stat
+type CellMetricMapping struct {
+	Origin string     `json:"origin"`
+	Name   string     `json:"name"`
+	Metric CellMetric `json:"metric"`
+}
+
+// Metric names sent by the diego cell rep and garden, the BOSH system metrics
+// forwarder (bosh-system-metrics-forwarder) and the loggregator system metrics
+// agent (system_metrics_agent).  Additional or changed names can be supplied
+// with the -cell-metrics option.
+var DefaultCellMetricMappings = []*CellMetricMapping{
+	// Can we assume that all rep orgins are cflinuxfs2 diego cells? Might be a bad idea
+	// 12/28/2017 - Added garden-linux to support PCF 2.0 small footprint
+	// Can't use "diego_cell" in job name as isolation segment job name could be custom job name
+	{"rep", "CapacityTotalMemory", CELL_METRIC_CAPACITY_MEMORY_TOTAL},
+	{"rep", "CapacityRemainingMemory", CELL_METRIC_CAPACITY_MEMORY_REMAINING},
+	{"rep", "CapacityTotalDisk", CELL_METRIC_CAPACITY_DISK_TOTAL},
+	{"rep", "CapacityRemainingDisk", CELL_METRIC_CAPACITY_DISK_REMAINING},
+	{"rep", "CapacityTotalContainers", CELL_METRIC_CAPACITY_TOTAL_CONTAINERS},
+	{"rep", "CapacityRemainingContainers", CELL_METRIC_CAPACITY_REMAIN_CONTAINERS},
+	{"rep", "ContainerCount", CELL_METRIC_CONTAINER_COUNT},
+	// ISSUE: numCPUS - PCF 2.6 no longer sending metric "numCPUS"
+	{"rep", "numCPUS", CELL_METRIC_NUM_CPUS},
+	{"garden-linux", "numCPUS", CELL_METRIC_NUM_CPUS},
+	{"garden-linux", "CapacityTotalMemory", CELL_METRIC_CAPACITY_MEMORY_TOTAL},
+	{"garden-linux", "CapacityRemainingMemory", CELL_METRIC_CAPACITY_MEMORY_REMAINING},
+	{"garden-linux", "CapacityTotalDisk", CELL_METRIC_CAPACITY_DISK_TOTAL},
+	{"garden-linux", "CapacityRemainingDisk", CELL_METRIC_CAPACITY_DISK_REMAINING},
+	{"garden-linux", "CapacityTotalContainers", CELL_METRIC_CAPACITY_TOTAL_CONTAINERS},
+	{"garden-linux", "CapacityRemainingContainers", CELL_METRIC_CAPACITY_REMAIN_CONTAINERS},
+	{"garden-linux", "ContainerCount", CELL_METRIC_CONTAINER_COUNT},
+
+	{"bosh-system-metrics-forwarder", "system.cpu.user", CELL_METRIC_HOST_CPU_USER},
+	{"bosh-system-metrics-forwarder", "system.cpu.sys", CELL_METRIC_HOST_CPU_SYS},
+	{"bosh-system-metrics-forwarder", "system.cpu.wait", CELL_METRIC_HOST_CPU_WAIT},
+	{"bosh-system-metrics-forwarder", "system.load.1m", CELL_METRIC_HOST_LOAD_1M},
+	{"bosh-system-metrics-forwarder", "system.mem.percent", CELL_METRIC_HOST_MEM_PERCENT},
+	{"bosh-system-metrics-forwarder", "system.disk.system.percent", CELL_METRIC_HOST_DISK_SYSTEM_PERCENT},
+	{"bosh-system-metrics-forwarder", "system.disk.ephemeral.percent", CELL_METRIC_HOST_DISK_EPHEMERAL_PERCENT},
+
+	{"system_metrics_agent", "system_cpu_user", CELL_METRIC_HOST_CPU_USER},
+	{"system_metrics_agent", "system_cpu_sys", CELL_METRIC_HOST_CPU_SYS},
+	{"system_metrics_agent", "system_cpu_wait", CELL_METRIC_HOST_CPU_WAIT},
+	{"system_metrics_agent", "system_load_1m", CELL_METRIC_HOST_LOAD_1M},
+	{"system_metrics_agent", "system_mem_percent", CELL_METRIC_HOST_MEM_PERCENT},
+	{"system_metrics_agent", "system_disk_system_percent", CELL_METRIC_HOST_DISK_SYSTEM_PERCENT},
+	{"system_metrics_agent", "system_disk_ephemeral_percent", CELL_METRIC_HOST_DISK_EPHEMERAL_PERCENT},
+}
+
+var cellMetricMappingMap = buildCellMetricMappingMap(DefaultCellMetricMappings)
+
+// Origins that send cell (non-host) metrics.  A metric from one of these
+// origins identifies the sending VM as a diego cell.
+var cellOriginMap = buildCellOriginMap(DefaultCellMetricMappings)
+
+func cellMetricMappingKey(origin, name string) string {
+	return origin + "|" + name
+}
+
+func buildCellMetricMappingMap(mappings []*CellMetricMapping) map[string]CellMetric {
+	mappingMap := make(map[string]CellMetric)
+	for _, mapping := range mappings {
+		mappingMap[cellMetricMappingKey(mapping.Origin, mapping.Name)] = mapping.Metric
+	}
+	return mappingMap
+}
+
+func buildCellOriginMap(mappings []*CellMetricMapping) map[string]bool {
+	originMap := make(map[string]bool)
+	for _, mapping := range mappings {
+		if !mapping.Metric.IsHostMetric() {
+			originMap[mapping.Origin] = true
+		}
+	}
+	return originMap
+}
+
+// Load additional metric mappings from a JSON file containing an array of
+// {"origin": "...", "name": "...", "metric": "..."}.  Mappings in the file
+// replace a default mapping with the same origin and name.  An empty metric
+// removes the mapping.
+func LoadCellMetricMappings(filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	fileMappings := make([]*CellMetricMapping, 0)
+	if err := json.Unmarshal(data, &fileMappings); err != nil {
+		return fmt.Errorf("Unable to parse cell metric mapping file %v: %v", filename, err)
+	}
+	mappings := make([]*CellMetricMapping, 0, len(DefaultCellMetricMappings)+len(fileMappings))
+	mappings = append(mappings, DefaultCellMetricMappings...)
+	for _, mapping := range fileMappings {
+		if mapping.Metric != "" && !mapping.Metric.isValid() {
+			return fmt.Errorf("Unknown cell metric %v for origin %v name %v in %v", mapping.Metric, mapping.Origin, mapping.Name, filename)
+		}
+		mappings = append(mappings, mapping)
+	}
+	mappingMap := buildCellMetricMappingMap(mappings)
+	for key, metric := range mappingMap {
+		if metric == "" {
+			delete(mappingMap, key)
+		}
+	}
+	cellMetricMappingMap = mappingMap
+	cellOriginMap = buildCellOriginMap(mappings)
+	return nil
+}
+
+// Find the cell stat the given metric is mapped to
+func FindCellMetric(origin, name string) (CellMetric, bool) {
+	metric, found := cellMetricMappingMap[cellMetricMappingKey(origin, name)]
+	return metric, found
+}
+
+func IsCellOrigin(origin string) bool {
+	return cellOriginMap[origin]
+}
+
+func (cs *CellStats) SetMetric(metric CellMetric, value float64) {
+	switch metric {
+	case CELL_METRIC_NUM_CPUS:
+		cs.NumOfCpus = int(value)
+	case CELL_METRIC_CAPACITY_MEMORY_TOTAL:
+		cs.CapacityMemoryTotal = int64(value)
+	case CELL_METRIC_CAPACITY_MEMORY_REMAINING:
+		cs.CapacityMemoryRemaining = int64(value)
+	case CELL_METRIC_CAPACITY_DISK_TOTAL:
+		cs.CapacityDiskTotal = int64(value)
+	case CELL_METRIC_CAPACITY_DISK_REMAINING:
+		cs.CapacityDiskRemaining = int64(value)
+	case CELL_METRIC_CAPACITY_TOTAL_CONTAINERS:
+		cs.CapacityTotalContainers = int(value)
+	case CELL_METRIC_CAPACITY_REMAIN_CONTAINERS:
+		cs.CapacityRemainingContainers = int(value)
+	case CELL_METRIC_CONTAINER_COUNT:
+		cs.ContainerCount = int(value)
+	case CELL_METRIC_HOST_CPU_USER:
+		cs.HostCpuUser = value
+		cs.HostMetricsReported = true
+	case CELL_METRIC_HOST_CPU_SYS:
+		cs.HostCpuSys = value
+		cs.HostMetricsReported = true
+	case CELL_METRIC_HOST_CPU_WAIT:
+		cs.HostCpuWait = value
+		cs.HostMetricsReported = true
+	case CELL_METRIC_HOST_LOAD_1M:
+		cs.HostLoad1m = value
+		cs.HostMetricsReported = true
+	case CELL_METRIC_HOST_MEM_PERCENT:
+		cs.HostMemPercent = value
+		cs.HostMetricsReported = true
+	case CELL_METRIC_HOST_DISK_SYSTEM_PERCENT:
+		cs.HostDiskSystemPercent = value
+		cs.HostMetricsReported = true
+	case CELL_METRIC_HOST_DISK_EPHEMERAL_PERCENT:
+		cs.HostDiskEphemeralPercent = value
+		cs.HostMetricsReported = true
+	}
+}
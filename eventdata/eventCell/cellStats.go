@@ -23,7 +23,8 @@ type CellStats struct {
 	JobName              string
 	JobIndex             string
 
-	//NumOfCpus                   int  // ISSUE: numCPUS - PCF 2.6 no longer sending metric "numCPUS"
+	// ISSUE: numCPUS - PCF 2.6 no longer sending metric "numCPUS" (zero unless mapped, see cellMetricMapping)
+	NumOfCpus                   int
 	CapacityMemoryTotal         int64
 	CapacityMemoryRemaining     int64
 	CapacityDiskTotal           int64
@@ -31,6 +32,16 @@ type CellStats struct {
 	CapacityTotalContainers     int
 	CapacityRemainingContainers int
 	ContainerCount              int
+
+	// Host (BOSH system) metrics.  Only available if HostMetricsReported
+	HostMetricsReported      bool
+	HostCpuUser              float64
+	HostCpuSys               float64
+	HostCpuWait              float64
+	HostLoad1m               float64
+	HostMemPercent           float64
+	HostDiskSystemPercent    float64
+	HostDiskEphemeralPercent float64
 }
 
 func NewCellStats(cellIp string) *CellStats {
@@ -42,3 +53,49 @@ func NewCellStats(cellIp string) *CellStats {
 func (cs *CellStats) Id() string {
 	return cs.Ip
 }
+
+// New cell stats with the metrics of the same cell from different ingest partitions.
+// The metrics are gauges so each is taken from the first stats that reported it.
+// The given stats are not modified.
+func MergeCellStats(cellStatsList []*CellStats) *CellStats {
+	if len(cellStatsList) == 1 {
+		return cellStatsList[0]
+	}
+	merged := *cellStatsList[0]
+	for _, other := range cellStatsList[1:] {
+		if merged.DeploymentName == "" {
+			merged.DeploymentName = other.DeploymentName
+			merged.JobName = other.JobName
+			merged.JobIndex = other.JobIndex
+		}
+		if merged.NumOfCpus == 0 {
+			merged.NumOfCpus = other.NumOfCpus
+		}
+		if merged.CapacityMemoryTotal == 0 {
+			merged.CapacityMemoryTotal = other.CapacityMemoryTotal
+			merged.CapacityMemoryRemaining = other.CapacityMemoryRemaining
+		}
+		if merged.CapacityDiskTotal == 0 {
+			merged.CapacityDiskTotal = other.CapacityDiskTotal
+			merged.CapacityDiskRemaining = other.CapacityDiskRemaining
+		}
+		if merged.CapacityTotalContainers == 0 {
+			merged.CapacityTotalContainers = other.CapacityTotalContainers
+			merged.CapacityRemainingContainers = other.CapacityRemainingContainers
+		}
+		if merged.ContainerCount == 0 {
+			merged.ContainerCount = other.ContainerCount
+		}
+		if !merged.HostMetricsReported && other.HostMetricsReported {
+			merged.HostMetricsReported = true
+			merged.HostCpuUser = other.HostCpuUser
+			merged.HostCpuSys = other.HostCpuSys
+			merged.HostCpuWait = other.HostCpuWait
+			merged.HostLoad1m = other.HostLoad1m
+			merged.HostMemPercent = other.HostMemPercent
+			merged.HostDiskSystemPercent = other.HostDiskSystemPercent
+			merged.HostDiskEphemeralPercent = other.HostDiskEphemeralPercent
+		}
+	}
+	return &merged
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventComponent
+
+import "github.com/cloudfoundry/sonde-go/events"
+
+const (
+	COMPONENT_ROUTER  = "Router"
+	COMPONENT_DOPPLER = "Doppler"
+	COMPONENT_UAA     = "UAA"
+	COMPONENT_CC      = "Cloud Controller"
+	COMPONENT_BBS     = "Diego BBS"
+)
+
+// A well-known ValueMetric or CounterEvent sent by a core platform component.
+// Counter thresholds are compared to the count per minute, value thresholds to
+// the last value.  A threshold of zero is not checked.
+type ComponentMetricDef struct {
+	Component string
+	Origin    string
+	EventType events.Envelope_EventType
+	Name      string
+	Label     string
+	// Multiplier applied to the value as sent (e.g., nanoseconds to milliseconds)
+	Scale          float64
+	WarnThreshold  float64
+	AlertThreshold float64
+	// Included in the one line summary of the component instance
+	Summary bool
+}
+
+func (def *ComponentMetricDef) IsCounter() bool {
+	return def.EventType == events.Envelope_CounterEvent
+}
+
+var componentMetricDefs = []*ComponentMetricDef{
+	{COMPONENT_ROUTER, "gorouter", events.Envelope_ValueMetric, "latency", "LATENCY_MS", 1, 500, 2000, true},
+	{COMPONENT_ROUTER, "gorouter", events.Envelope_CounterEvent, "total_requests", "REQ/MIN", 1, 0, 0, true},
+	{COMPONENT_ROUTER, "gorouter", events.Envelope_CounterEvent, "bad_gateways", "502/MIN", 1, 1, 50, true},
+	{COMPONENT_ROUTER, "gorouter", events.Envelope_CounterEvent, "responses.5xx", "5XX/MIN", 1, 10, 100, false},
+	{COMPONENT_ROUTER, "gorouter", events.Envelope_CounterEvent, "backend_exhausted_conns", "EXHAUSTED/MIN", 1, 1, 10, false},
+
+	{COMPONENT_DOPPLER, "DopplerServer", events.Envelope_CounterEvent, "listeners.receivedEnvelopes", "INGRESS/MIN", 1, 0, 0, true},
+	{COMPONENT_DOPPLER, "DopplerServer", events.Envelope_CounterEvent, "TruncatingBuffer.DroppedMessages", "DROPPED/MIN", 1, 1, 1000, true},
+	{COMPONENT_DOPPLER, "DopplerServer", events.Envelope_ValueMetric, "subscriptions", "SUBSCRIPTIONS", 1, 0, 0, false},
+	{COMPONENT_DOPPLER, "doppler", events.Envelope_CounterEvent, "ingress", "INGRESS/MIN", 1, 0, 0, true},
+	{COMPONENT_DOPPLER, "doppler", events.Envelope_CounterEvent, "dropped", "DROPPED/MIN", 1, 1, 1000, true},
+	{COMPONENT_DOPPLER, "doppler", events.Envelope_CounterEvent, "TruncatingBuffer.DroppedMessages", "TRUNC_DROP/MIN", 1, 1, 1000, false},
+	{COMPONENT_DOPPLER, "doppler", events.Envelope_ValueMetric, "subscriptions", "SUBSCRIPTIONS", 1, 0, 0, false},
+	{COMPONENT_DOPPLER, "loggregator.doppler", events.Envelope_CounterEvent, "ingress", "INGRESS/MIN", 1, 0, 0, true},
+	{COMPONENT_DOPPLER, "loggregator.doppler", events.Envelope_CounterEvent, "dropped", "DROPPED/MIN", 1, 1, 1000, true},
+	{COMPONENT_DOPPLER, "loggregator.doppler", events.Envelope_ValueMetric, "subscriptions", "SUBSCRIPTIONS", 1, 0, 0, false},
+
+	{COMPONENT_UAA, "uaa", events.Envelope_ValueMetric, "requests.global.completed.count", "REQUESTS", 1, 0, 0, true},
+	{COMPONENT_UAA, "uaa", events.Envelope_ValueMetric, "requests.global.unhealthy.count", "UNHEALTHY_REQ", 1, 0, 0, true},
+	{COMPONENT_UAA, "uaa", events.Envelope_ValueMetric, "server.inflight.count", "INFLIGHT", 1, 50, 200, false},
+
+	{COMPONENT_CC, "cc", events.Envelope_ValueMetric, "job_queue_length.total", "JOB_QUEUE", 1, 100, 1000, true},
+	{COMPONENT_CC, "cc", events.Envelope_ValueMetric, "failed_job_count.total", "FAILED_JOBS", 1, 0, 0, true},
+	{COMPONENT_CC, "cc", events.Envelope_ValueMetric, "requests.outstanding", "OUTSTANDING_REQ", 1, 10, 20, false},
+	{COMPONENT_CC, "cc", events.Envelope_ValueMetric, "requests.outstanding.gauge", "OUTSTANDING_REQ", 1, 10, 20, false},
+
+	{COMPONENT_BBS, "bbs", events.Envelope_ValueMetric, "LRPsDesired", "LRP_DESIRED", 1, 0, 0, true},
+	{COMPONENT_BBS, "bbs", events.Envelope_ValueMetric, "LRPsRunning", "LRP_RUNNING", 1, 0, 0, true},
+	{COMPONENT_BBS, "bbs", events.Envelope_ValueMetric, "LRPsMissing", "LRP_MISSING", 1, 1, 10, true},
+	{COMPONENT_BBS, "bbs", events.Envelope_ValueMetric, "LRPsExtra", "LRP_EXTRA", 1, 0, 0, false},
+	{COMPONENT_BBS, "bbs", events.Envelope_ValueMetric, "CrashedActualLRPs", "LRP_CRASHED", 1, 0, 0, false},
+	// RequestLatency is sent in nanoseconds
+	{COMPONENT_BBS, "bbs", events.Envelope_ValueMetric, "RequestLatency", "LATENCY_MS", 1.0 / 1000000, 1000, 5000, false},
+}
+
+var componentMetricDefMap = buildComponentMetricDefMap()
+
+func componentMetricDefKey(origin string, eventType events.Envelope_EventType, name string) string {
+	return origin + "|" + eventType.String() + "|" + name
+}
+
+func buildComponentMetricDefMap() map[string]*ComponentMetricDef {
+	defMap := make(map[string]*ComponentMetricDef)
+	for _, def := range componentMetricDefs {
+		defMap[componentMetricDefKey(def.Origin, def.EventType, def.Name)] = def
+	}
+	return defMap
+}
+
+// Find the definition of a well-known component metric.  Returns nil if the
+// metric is not one we interpret.
+func FindComponentMetricDef(origin string, eventType events.Envelope_EventType, name string) *ComponentMetricDef {
+	return componentMetricDefMap[componentMetricDefKey(origin, eventType, name)]
+}
+
+// Definitions of all metrics of the given component & origin in display order
+func ComponentMetricDefs(origin string) []*ComponentMetricDef {
+	defs := make([]*ComponentMetricDef, 0)
+	for _, def := range componentMetricDefs {
+		if def.Origin == origin {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
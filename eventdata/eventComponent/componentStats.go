@@ -0,0 +1,211 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventComponent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+)
+
+const (
+	STATUS_OK    = "OK"
+	STATUS_WARN  = "WARN"
+	STATUS_ALERT = "ALERT"
+	STATUS_STALE = "STALE"
+)
+
+var statusRank = map[string]int{STATUS_OK: 0, STATUS_STALE: 1, STATUS_WARN: 2, STATUS_ALERT: 3}
+
+// Return the more severe of the two status
+func WorseStatus(status1, status2 string) string {
+	if statusRank[status2] > statusRank[status1] {
+		return status2
+	}
+	return status1
+}
+
+type ComponentMetricValue struct {
+	// Last value of a ValueMetric or total of a CounterEvent (scaled)
+	Value float64
+	// Counter deltas in the current minute and in the last full minute
+	MinuteDelta     uint64
+	LastMinuteDelta uint64
+	MinuteStartTime *time.Time
+	LastUpdateTime  *time.Time
+}
+
+// Value compared to the thresholds -- count per minute for counters
+func (mv *ComponentMetricValue) ThresholdValue(def *ComponentMetricDef) float64 {
+	if def.IsCounter() {
+		return float64(mv.LastMinuteDelta)
+	}
+	return mv.Value
+}
+
+func (mv *ComponentMetricValue) Status(def *ComponentMetricDef, now time.Time) string {
+	if mv.LastUpdateTime == nil || now.Sub(*mv.LastUpdateTime) > config.ComponentMetricStaleSeconds*time.Second {
+		return STATUS_STALE
+	}
+	value := mv.ThresholdValue(def)
+	switch {
+	case def.AlertThreshold > 0 && value >= def.AlertThreshold:
+		return STATUS_ALERT
+	case def.WarnThreshold > 0 && value >= def.WarnThreshold:
+		return STATUS_WARN
+	}
+	return STATUS_OK
+}
+
+func (mv *ComponentMetricValue) FormatValue(def *ComponentMetricDef) string {
+	value := mv.ThresholdValue(def)
+	if value == float64(int64(value)) {
+		return fmt.Sprintf("%v", int64(value))
+	}
+	return fmt.Sprintf("%.1f", value)
+}
+
+// A single instance of a platform component (e.g., router/0)
+type ComponentStats struct {
+	Component      string
+	Origin         string
+	DeploymentName string
+	JobName        string
+	JobIndex       string
+	Ip             string
+
+	// Key: metric name
+	MetricMap      map[string]*ComponentMetricValue
+	LastUpdateTime *time.Time
+}
+
+func NewComponentStats(component, origin, deploymentName, jobName, jobIndex, ip string) *ComponentStats {
+	return &ComponentStats{
+		Component:      component,
+		Origin:         origin,
+		DeploymentName: deploymentName,
+		JobName:        jobName,
+		JobIndex:       jobIndex,
+		Ip:             ip,
+		MetricMap:      make(map[string]*ComponentMetricValue),
+	}
+}
+
+func ComponentStatsKey(origin, deploymentName, jobName, jobIndex string) string {
+	return fmt.Sprintf("%v|%v|%v|%v", origin, deploymentName, jobName, jobIndex)
+}
+
+func (cs *ComponentStats) Id() string {
+	return ComponentStatsKey(cs.Origin, cs.DeploymentName, cs.JobName, cs.JobIndex)
+}
+
+func (cs *ComponentStats) getMetricValue(def *ComponentMetricDef, now *time.Time) *ComponentMetricValue {
+	metricValue := cs.MetricMap[def.Name]
+	if metricValue == nil {
+		metricValue = &ComponentMetricValue{MinuteStartTime: now}
+		cs.MetricMap[def.Name] = metricValue
+	}
+	metricValue.LastUpdateTime = now
+	cs.LastUpdateTime = now
+	return metricValue
+}
+
+func (cs *ComponentStats) SetValue(def *ComponentMetricDef, value float64, now *time.Time) {
+	metricValue := cs.getMetricValue(def, now)
+	metricValue.Value = value * def.Scale
+}
+
+func (cs *ComponentStats) AddCount(def *ComponentMetricDef, delta, total uint64, now *time.Time) {
+	metricValue := cs.getMetricValue(def, now)
+	elapsed := now.Sub(*metricValue.MinuteStartTime)
+	if elapsed >= time.Minute {
+		if elapsed < 2*time.Minute {
+			metricValue.LastMinuteDelta = metricValue.MinuteDelta
+		} else {
+			// No counts for over a minute
+			metricValue.LastMinuteDelta = 0
+		}
+		metricValue.MinuteDelta = 0
+		metricValue.MinuteStartTime = now
+	}
+	metricValue.MinuteDelta = metricValue.MinuteDelta + delta
+	metricValue.Value = float64(total) * def.Scale
+}
+
+// Overall status of the component instance -- the worst status of any of its metrics
+func (cs *ComponentStats) Status(now time.Time) string {
+	status := STATUS_OK
+	if cs.LastUpdateTime == nil || now.Sub(*cs.LastUpdateTime) > config.ComponentMetricStaleSeconds*time.Second {
+		return STATUS_STALE
+	}
+	for _, def := range ComponentMetricDefs(cs.Origin) {
+		metricValue := cs.MetricMap[def.Name]
+		if metricValue == nil {
+			continue
+		}
+		metricStatus := metricValue.Status(def, now)
+		// A single metric not sent recently does not make the instance stale
+		if metricStatus != STATUS_STALE {
+			status = WorseStatus(status, metricStatus)
+		}
+	}
+	return status
+}
+
+// New component stats with the metrics of the same instance from different ingest
+// partitions.  Counter deltas are added together, values are taken from the most
+// recent update.  The given stats are not modified.
+func MergeComponentStats(componentStatsList []*ComponentStats) *ComponentStats {
+	if len(componentStatsList) == 1 {
+		return componentStatsList[0]
+	}
+	merged := *componentStatsList[0]
+	merged.MetricMap = make(map[string]*ComponentMetricValue)
+	for name, metricValue := range componentStatsList[0].MetricMap {
+		merged.MetricMap[name] = metricValue
+	}
+	for _, other := range componentStatsList[1:] {
+		if merged.Ip == "" {
+			merged.Ip = other.Ip
+		}
+		if timeBefore(merged.LastUpdateTime, other.LastUpdateTime) {
+			merged.LastUpdateTime = other.LastUpdateTime
+		}
+		for name, otherMetricValue := range other.MetricMap {
+			metricValue := merged.MetricMap[name]
+			if metricValue == nil {
+				merged.MetricMap[name] = otherMetricValue
+				continue
+			}
+			mergedMetricValue := *metricValue
+			if timeBefore(metricValue.LastUpdateTime, otherMetricValue.LastUpdateTime) {
+				mergedMetricValue = *otherMetricValue
+			}
+			mergedMetricValue.MinuteDelta = metricValue.MinuteDelta + otherMetricValue.MinuteDelta
+			mergedMetricValue.LastMinuteDelta = metricValue.LastMinuteDelta + otherMetricValue.LastMinuteDelta
+			merged.MetricMap[name] = &mergedMetricValue
+		}
+	}
+	return &merged
+}
+
+// Nil times sort first
+func timeBefore(time1 *time.Time, time2 *time.Time) bool {
+	if time1 == nil || time2 == nil {
+		return time1 == nil && time2 != nil
+	}
+	return time1.Before(*time2)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventdata
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventComponent"
+)
+
+// Interpret well-known metrics sent by core platform components (router, doppler,
+// UAA, cloud controller, BBS).  Used by the platform health view.
+func (ed *EventData) componentMetricEvent(msg *events.Envelope) {
+
+	var def *eventComponent.ComponentMetricDef
+	switch msg.GetEventType() {
+	case events.Envelope_ValueMetric:
+		def = eventComponent.FindComponentMetricDef(msg.GetOrigin(), events.Envelope_ValueMetric, msg.GetValueMetric().GetName())
+	case events.Envelope_CounterEvent:
+		def = eventComponent.FindComponentMetricDef(msg.GetOrigin(), events.Envelope_CounterEvent, msg.GetCounterEvent().GetName())
+	}
+	if def == nil {
+		return
+	}
+
+	componentStats := ed.getComponentStats(def, msg)
+	now := time.Now()
+	if def.IsCounter() {
+		counterEvent := msg.GetCounterEvent()
+		componentStats.AddCount(def, counterEvent.GetDelta(), counterEvent.GetTotal(), &now)
+	} else {
+		componentStats.SetValue(def, msg.GetValueMetric().GetValue(), &now)
+	}
+}
+
+func (ed *EventData) getComponentStats(def *eventComponent.ComponentMetricDef, msg *events.Envelope) *eventComponent.ComponentStats {
+	key := eventComponent.ComponentStatsKey(msg.GetOrigin(), msg.GetDeployment(), msg.GetJob(), msg.GetIndex())
+	componentStats := ed.ComponentMap[key]
+	if componentStats == nil {
+		componentStats = eventComponent.NewComponentStats(def.Component, msg.GetOrigin(),
+			msg.GetDeployment(), msg.GetJob(), msg.GetIndex(), msg.GetIp())
+		ed.ComponentMap[key] = componentStats
+	}
+	return componentStats
+}
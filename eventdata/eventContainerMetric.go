@@ -19,17 +19,27 @@ import (
 	"time"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/process"
 )
 
 func (ed *EventData) containerMetricEvent(msg *events.Envelope) {
 
 	containerMetric := msg.GetContainerMetric()
 
+	// The applicationId of a container metric is the process guid.  For the web process
+	// this is the same as the app guid but other process types (worker, clock, etc) have
+	// their own guid that we need to map back to the app.
 	appId := containerMetric.GetApplicationId()
+	processType := process.WebProcessType
+	processMetadata := ed.eventProcessor.GetMetadataManager().GetProcessMdManager().FindProcess(appId)
+	if processMetadata != nil && !processMetadata.IsWeb() && processMetadata.AppGuid != "" {
+		appId = processMetadata.AppGuid
+		processType = processMetadata.Type
+	}
 
 	appStats := ed.getAppStats(appId)
 	instNum := int(*containerMetric.InstanceIndex)
-	containerStats := ed.getContainerStats(appStats, instNum)
+	containerStats := ed.getProcessContainerStats(appStats, processType, instNum)
 
 	now := time.Unix(0, msg.GetTimestamp())
 	containerStats.LastMetricUpdateTime = &now
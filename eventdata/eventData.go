@@ -26,11 +26,12 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventComponent"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventEventType"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/process"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
-	"github.com/mohae/deepcopy"
 )
 
 type EventData struct {
@@ -67,6 +68,9 @@ type EventData struct {
 
 	EventTypeMap map[events.Envelope_EventType]*eventEventType.EventTypeStats
 
+	// Platform component instances.  Key: origin|deployment|job|index
+	ComponentMap map[string]*eventComponent.ComponentStats
+
 	EnableRouteTracking bool
 	TotalEvents         int64
 	mu                  *sync.Mutex
@@ -76,6 +80,8 @@ type EventData struct {
 	apiUrlRegexp        *regexp.Regexp
 	urlPCF17Regexp      *regexp.Regexp
 	routeUrlRegexp      *regexp.Regexp
+	snapshot            *snapshotTracker
+	memory              *memoryTracker
 }
 
 func NewEventData(mu *sync.Mutex, eventProcessor *EventProcessor) *EventData {
@@ -100,6 +106,7 @@ func NewEventData(mu *sync.Mutex, eventProcessor *EventProcessor) *EventData {
 		CellMap:        make(map[string]*eventCell.CellStats),
 		DomainMap:      make(map[string]*eventRoute.DomainStats),
 		EventTypeMap:   make(map[events.Envelope_EventType]*eventEventType.EventTypeStats),
+		ComponentMap:   make(map[string]*eventComponent.ComponentStats),
 		TotalEvents:    0,
 		mu:             mu,
 		logHttpAccess:  logHttpAccess,
@@ -108,32 +115,42 @@ func NewEventData(mu *sync.Mutex, eventProcessor *EventProcessor) *EventData {
 		apiUrlRegexp:   apiUrlRegexp,
 		urlPCF17Regexp: urlPCF17Regexp,
 		routeUrlRegexp: routeUrlRegexp,
+		snapshot:       newSnapshotTracker(),
+		memory:         newMemoryTracker(),
 	}
 
 }
 
 func (ed *EventData) Process(instanceId int, msg *events.Envelope) {
+	ed.process(instanceId, msg, httpStatsAll)
+}
+
+// Process an envelope updating only the given scope of stats if it is an HttpStartStop
+// envelope that is handed to more than one partition (see forEachPartition)
+func (ed *EventData) process(instanceId int, msg *events.Envelope, httpScope httpStatsScope) {
 
 	ed.mu.Lock()
 	defer ed.mu.Unlock()
 
-	ed.UpdateEventStats(msg)
+	// Count the envelope once -- by the partition that owns the app
+	if httpScope != httpStatsRoute {
+		ed.UpdateEventStats(msg)
+	}
 
 	eventType := msg.GetEventType()
 	switch eventType {
 	case events.Envelope_HttpStartStop:
-		ed.httpStartStopEvent(msg)
+		ed.httpStartStopEvent(msg, httpScope)
 	case events.Envelope_ContainerMetric:
 		ed.containerMetricEvent(msg)
 	case events.Envelope_LogMessage:
 		ed.logMessageEvent(msg)
 	case events.Envelope_ValueMetric:
 		ed.valueMetricEvent(msg)
+		ed.componentMetricEvent(msg)
 	case events.Envelope_CounterEvent:
-		// Message that is sent on nozzle when its not keeping up.
-		// https://docs.cloudfoundry.org/loggregator/log-ops-guide.html#slow-noz
-		if msg.CounterEvent.GetName() == "TruncatingBuffer.DroppedMessages" &&
-			(msg.GetOrigin() == "DopplerServer" || msg.GetOrigin() == "doppler") {
+		ed.componentMetricEvent(msg)
+		if IsDroppedMessagesEvent(msg) {
 			ed.droppedMessages(instanceId, msg)
 		}
 	case events.Envelope_Error:
@@ -161,117 +178,134 @@ func (ed *EventData) FindEventTypeStats(eventType events.Envelope_EventType) *ev
 	return eventTypeStats
 }
 
-// Yuck -- need to figure out a getter way to clone the current data object
-// Specifically around RateCounter and AvgTracker object
+// Take a snapshot of the event data for display.  Only the apps and domains that have
+// changed since the last snapshot are copied (see snapshotTracker)
 func (ed *EventData) Clone() *EventData {
 
 	ed.mu.Lock()
 	defer ed.mu.Unlock()
 
-	clone := deepcopy.Copy(ed).(*EventData)
-	now := time.Now()
-	clone.StatsTime = now
-	clone.eventProcessor = ed.eventProcessor
+	ed.updateDeployments()
 
+	now := time.Now()
+	ed.pruneTasks(now)
 	for _, appStat := range ed.AppMap {
 
-		//toplog.Info("XXXX  appId %v size: %v", appStat.AppId, len(appStat.ContainerArray))
-
 		// Check if this app has been deleted
 		if ed.eventProcessor.GetMetadataManager().GetAppMdManager().IsDeletedFromCache(appStat.AppId) {
 			delete(ed.AppMap, appStat.AppId)
-			delete(clone.AppMap, appStat.AppId)
 			continue
 		}
 
-		clonedAppStat := clone.AppMap[appStat.AppId]
-		/*
-			httpAllCount := int64(0)
-			http2xxCount := int64(0)
-			http3xxCount := int64(0)
-			http4xxCount := int64(0)
-			http5xxCount := int64(0)
-		*/
-
-		responseL60TimeArray := make([]*util.AvgTracker, 0)
-		responseL10TimeArray := make([]*util.AvgTracker, 0)
-		responseL1TimeArray := make([]*util.AvgTracker, 0)
-		totalTraffic := eventApp.NewTrafficStats()
-
-		for instanceId, containerTraffic := range appStat.ContainerTrafficMap {
+		// The time based values are refreshed on the realtime data so that the copies held
+		// by earlier snapshots are never modified.  Only apps whose values changed are copied.
+		changed := updateTrafficRates(appStat)
 
-			rate60 := containerTraffic.ResponseL60Time.Rate()
-			clone.AppMap[appStat.AppId].ContainerTrafficMap[instanceId].EventL60Rate = rate60
-			totalTraffic.EventL60Rate = totalTraffic.EventL60Rate + rate60
-
-			clone.AppMap[appStat.AppId].ContainerTrafficMap[instanceId].AvgResponseL60Time = containerTraffic.ResponseL60Time.Avg()
-
-			rate10 := containerTraffic.ResponseL10Time.Rate()
-			clone.AppMap[appStat.AppId].ContainerTrafficMap[instanceId].EventL10Rate = rate10
-			totalTraffic.EventL10Rate = totalTraffic.EventL10Rate + rate10
+		// Check if we need to remove data for any old containers -- containers that haven't reported in for awhile
+		if removeOldContainers(now, appStat.ContainerArray) {
+			changed = true
+		}
+		for _, containerArray := range appStat.ProcessContainerMap {
+			if removeOldContainers(now, containerArray) {
+				changed = true
+			}
+		}
 
-			clone.AppMap[appStat.AppId].ContainerTrafficMap[instanceId].AvgResponseL10Time = containerTraffic.ResponseL10Time.Avg()
+		if changed {
+			ed.markAppDirty(appStat.AppId)
+		}
+	}
 
-			rate1 := containerTraffic.ResponseL1Time.Rate()
-			clone.AppMap[appStat.AppId].ContainerTrafficMap[instanceId].EventL1Rate = rate1
-			totalTraffic.EventL1Rate = totalTraffic.EventL1Rate + rate1
+	clone := ed.snapshotCopy()
+	clone.StatsTime = now
+	clone.eventProcessor = ed.eventProcessor
 
-			clone.AppMap[appStat.AppId].ContainerTrafficMap[instanceId].AvgResponseL1Time = containerTraffic.ResponseL1Time.Avg()
+	ed.snapshotTaken(clone)
+	return clone
+}
 
-			/*
-				httpAllCount = httpAllCount + containerTraffic.HttpAllCount
-				http2xxCount = http2xxCount + containerTraffic.Http2xxCount
-				http3xxCount = http3xxCount + containerTraffic.Http3xxCount
-				http4xxCount = http4xxCount + containerTraffic.Http4xxCount
-				http5xxCount = http5xxCount + containerTraffic.Http5xxCount
-			*/
+// Update the per container and total traffic rates and average response times
+// of the realtime app stats.  Returns true if any value changed.
+func updateTrafficRates(appStat *eventApp.AppStats) bool {
+
+	changed := false
+
+	responseL60TimeArray := make([]*util.AvgTracker, 0)
+	responseL10TimeArray := make([]*util.AvgTracker, 0)
+	responseL1TimeArray := make([]*util.AvgTracker, 0)
+	totalTraffic := eventApp.NewTrafficStats()
+
+	for _, containerTraffic := range appStat.ContainerTrafficMap {
+
+		rate60 := containerTraffic.ResponseL60Time.Rate()
+		avg60 := containerTraffic.ResponseL60Time.Avg()
+		rate10 := containerTraffic.ResponseL10Time.Rate()
+		avg10 := containerTraffic.ResponseL10Time.Avg()
+		rate1 := containerTraffic.ResponseL1Time.Rate()
+		avg1 := containerTraffic.ResponseL1Time.Avg()
+
+		if containerTraffic.EventL60Rate != rate60 || containerTraffic.AvgResponseL60Time != avg60 ||
+			containerTraffic.EventL10Rate != rate10 || containerTraffic.AvgResponseL10Time != avg10 ||
+			containerTraffic.EventL1Rate != rate1 || containerTraffic.AvgResponseL1Time != avg1 {
+			containerTraffic.EventL60Rate = rate60
+			containerTraffic.AvgResponseL60Time = avg60
+			containerTraffic.EventL10Rate = rate10
+			containerTraffic.AvgResponseL10Time = avg10
+			containerTraffic.EventL1Rate = rate1
+			containerTraffic.AvgResponseL1Time = avg1
+			changed = true
+		}
 
-			responseL60TimeArray = append(responseL60TimeArray, containerTraffic.ResponseL60Time)
-			responseL10TimeArray = append(responseL10TimeArray, containerTraffic.ResponseL10Time)
-			responseL1TimeArray = append(responseL1TimeArray, containerTraffic.ResponseL1Time)
+		totalTraffic.EventL60Rate = totalTraffic.EventL60Rate + rate60
+		totalTraffic.EventL10Rate = totalTraffic.EventL10Rate + rate10
+		totalTraffic.EventL1Rate = totalTraffic.EventL1Rate + rate1
 
-		}
+		responseL60TimeArray = append(responseL60TimeArray, containerTraffic.ResponseL60Time)
+		responseL10TimeArray = append(responseL10TimeArray, containerTraffic.ResponseL10Time)
+		responseL1TimeArray = append(responseL1TimeArray, containerTraffic.ResponseL1Time)
+	}
 
-		totalTraffic.AvgResponseL60Time = util.AvgMultipleTrackers(responseL60TimeArray)
-		totalTraffic.AvgResponseL10Time = util.AvgMultipleTrackers(responseL10TimeArray)
-		totalTraffic.AvgResponseL1Time = util.AvgMultipleTrackers(responseL1TimeArray)
+	totalTraffic.AvgResponseL60Time = util.AvgMultipleTrackers(responseL60TimeArray)
+	totalTraffic.AvgResponseL10Time = util.AvgMultipleTrackers(responseL10TimeArray)
+	totalTraffic.AvgResponseL1Time = util.AvgMultipleTrackers(responseL1TimeArray)
+
+	oldTotal := appStat.TotalTraffic
+	if oldTotal == nil ||
+		oldTotal.EventL60Rate != totalTraffic.EventL60Rate || oldTotal.AvgResponseL60Time != totalTraffic.AvgResponseL60Time ||
+		oldTotal.EventL10Rate != totalTraffic.EventL10Rate || oldTotal.AvgResponseL10Time != totalTraffic.AvgResponseL10Time ||
+		oldTotal.EventL1Rate != totalTraffic.EventL1Rate || oldTotal.AvgResponseL1Time != totalTraffic.AvgResponseL1Time {
+		appStat.TotalTraffic = totalTraffic
+		changed = true
+	}
 
-		/*
-			totalTraffic.HttpAllCount = httpAllCount
-			totalTraffic.Http2xxCount = http2xxCount
-			totalTraffic.Http3xxCount = http3xxCount
-			totalTraffic.Http4xxCount = http4xxCount
-			totalTraffic.Http5xxCount = http5xxCount
-		*/
-		clonedAppStat.TotalTraffic = totalTraffic
+	return changed
+}
 
-		// Check if we need to remove data for any old containers -- containers that haven't reported in for awhile
-		for containerIndex, cs := range appStat.ContainerArray {
-			if cs != nil {
-				// If we haven't gotten a container update in DeadContainerSeconds then remove the entire container data
-				if cs.LastUpdateTime == nil || now.Sub(*cs.LastUpdateTime) > time.Second*config.DeadContainerSeconds {
-					// Remove container stats for realtime data and the clone
-					appStat.ContainerArray[containerIndex] = nil
-					clonedAppStat.ContainerArray[containerIndex] = nil
-				} else {
-					if cs.ContainerMetric != nil {
-						// If we haven't gotten a container update in StaleContainerSeconds then remove just the
-						// container metrics not the entire container data
-						if cs.LastUpdateTime == nil || now.Sub(*cs.LastUpdateTime) > time.Second*config.StaleContainerSeconds {
-							// Remove container stats for realtime data and the clone
-							appStat.ContainerArray[containerIndex].ContainerMetric = nil
-							clonedAppStat.ContainerArray[containerIndex].ContainerMetric = nil
-						}
+// Remove container data from the realtime container array for any container that
+// hasn't reported in for awhile.  Returns true if any container data was removed.
+func removeOldContainers(now time.Time, containerArray []*eventApp.ContainerStats) bool {
+	removed := false
+	for containerIndex, cs := range containerArray {
+		if cs != nil {
+			// If we haven't gotten a container update in DeadContainerSeconds then remove the entire container data
+			if cs.LastUpdateTime == nil || now.Sub(*cs.LastUpdateTime) > time.Second*config.DeadContainerSeconds {
+				containerArray[containerIndex] = nil
+				removed = true
+			} else {
+				if cs.ContainerMetric != nil {
+					// If we haven't gotten a container update in StaleContainerSeconds then remove just the
+					// container metrics not the entire container data
+					if now.Sub(*cs.LastUpdateTime) > time.Second*config.StaleContainerSeconds {
+						cs.ContainerMetric = nil
+						removed = true
 					}
-
 				}
 
 			}
-		}
 
+		}
 	}
-
-	return clone
+	return removed
 }
 
 func (ed *EventData) GetTotalEvents() int64 {
@@ -286,7 +320,18 @@ func (ed *EventData) Clear() {
 	ed.AppMap = make(map[string]*eventApp.AppStats)
 	ed.CellMap = make(map[string]*eventCell.CellStats)
 	ed.DomainMap = make(map[string]*eventRoute.DomainStats)
+	ed.ComponentMap = make(map[string]*eventComponent.ComponentStats)
 	ed.TotalEvents = 0
+	ed.snapshot = newSnapshotTracker()
+	ed.memory = newMemoryTracker()
+}
+
+// Message that is sent on nozzle when its not keeping up.
+// https://docs.cloudfoundry.org/loggregator/log-ops-guide.html#slow-noz
+func IsDroppedMessagesEvent(msg *events.Envelope) bool {
+	return msg.GetEventType() == events.Envelope_CounterEvent &&
+		msg.GetCounterEvent().GetName() == "TruncatingBuffer.DroppedMessages" &&
+		(msg.GetOrigin() == "DopplerServer" || msg.GetOrigin() == "doppler")
 }
 
 func (ed *EventData) droppedMessages(instanceId int, msg *events.Envelope) {
@@ -309,6 +354,8 @@ func (ed *EventData) getAppStats(appId string) *eventApp.AppStats {
 		appStats = eventApp.NewAppStats(appId)
 		ed.AppMap[appId] = appStats
 	}
+	// Caller is about to update the app stats
+	ed.markAppDirty(appId)
 	return appStats
 }
 
@@ -320,40 +367,9 @@ func (ed *EventData) getCellStats(cellIp string) *eventCell.CellStats {
 		ed.CellMap[cellIp] = cellStats
 	}
 
-	// TODO: Is this the best place for this??
-	if cellStats.StackGroupId == "" {
-		ed.assignStackGroupId(cellStats)
-	}
-	if cellStats.IsolationSegmentGuid == "" {
-		ed.AssignIsolationSegment(cellStats)
-	}
 	return cellStats
 }
 
-func (ed *EventData) assignStackGroupId(cellStats *eventCell.CellStats) {
-	// Look for a container running on cell to determine which stack the cell is running
-	// TODO: This is not very efficient -- if end up with a cell that has no containers yet
-	// this loop will run every time the cell metric comes in.
-
-	ep := ed.eventProcessor
-	mdMgr := ep.GetMetadataManager()
-	mdStackMgr := mdMgr.GetStackMdManager()
-
-	for _, appStats := range ed.AppMap {
-		for _, containerStats := range appStats.ContainerArray {
-			if containerStats != nil && cellStats.Ip == containerStats.Ip {
-				appMetadata := ed.eventProcessor.GetMetadataManager().GetAppMdManager().FindItem(appStats.AppId)
-				stackGroup := mdStackMgr.FindStackGroupByStackGuid(appMetadata.StackGuid)
-				// stackGroup can be nil if the metadata hasn't been loaded yet.
-				if stackGroup != nil {
-					cellStats.StackGroupId = stackGroup.Guid
-				}
-				return
-			}
-		}
-	}
-}
-
 func (ed *EventData) AssignIsolationSegment(cellStats *eventCell.CellStats) {
 	for _, appStats := range ed.AppMap {
 		for _, containerStats := range appStats.ContainerArray {
@@ -393,6 +409,91 @@ func (ed *EventData) getContainerStats(appStats *eventApp.AppStats, instIndex in
 	return containerStats
 }
 
+// Get the container stats of the given process type (web, worker, etc).  Containers of the
+// web process are tracked in ContainerArray, all other process types in ProcessContainerMap
+func (ed *EventData) getProcessContainerStats(appStats *eventApp.AppStats, processType string, instIndex int) *eventApp.ContainerStats {
+
+	if processType == "" || processType == process.WebProcessType {
+		return ed.getContainerStats(appStats, instIndex)
+	}
+
+	if appStats.ProcessContainerMap == nil {
+		appStats.ProcessContainerMap = make(map[string][]*eventApp.ContainerStats)
+	}
+
+	containerArray := appStats.ProcessContainerMap[processType]
+	if len(containerArray) <= instIndex {
+		caArray := make([]*eventApp.ContainerStats, instIndex+1)
+		copy(caArray, containerArray)
+		containerArray = caArray
+		appStats.ProcessContainerMap[processType] = containerArray
+	}
+
+	containerStats := containerArray[instIndex]
+	if containerStats == nil {
+		// New process instance (container) we haven't seen yet
+		containerStats = eventApp.NewProcessContainerStats(processType, instIndex)
+		containerArray[instIndex] = containerStats
+	}
+	return containerStats
+}
+
+// Get the stats of the task that logged at msgTime.  TaskMap is keyed by task GUID which is
+// found from the task metadata as task names can be reused.  Until the task metadata is
+// loaded the stats are keyed by task name and are moved to the GUID key once it is known.
+func (ed *EventData) getTaskStats(appStats *eventApp.AppStats, taskName string, msgTime time.Time) *eventApp.TaskStats {
+
+	if appStats.TaskMap == nil {
+		appStats.TaskMap = make(map[string]*eventApp.TaskStats)
+	}
+
+	taskMetadata := ed.eventProcessor.GetMetadataManager().GetTaskMdManager().FindByAppAndName(appStats.AppId, taskName, msgTime)
+	if taskMetadata == nil {
+		taskStats := appStats.TaskMap[taskName]
+		if taskStats == nil {
+			// New task we haven't seen yet
+			taskStats = eventApp.NewTaskStats(taskName)
+			appStats.TaskMap[taskName] = taskStats
+		}
+		return taskStats
+	}
+
+	taskStats := appStats.TaskMap[taskMetadata.Guid]
+	if taskStats == nil {
+		taskStats = appStats.TaskMap[taskName]
+		if taskStats != nil {
+			// Task logged before its metadata was loaded
+			delete(appStats.TaskMap, taskName)
+		} else {
+			taskStats = eventApp.NewTaskStats(taskName)
+		}
+		taskStats.TaskGuid = taskMetadata.Guid
+		appStats.TaskMap[taskMetadata.Guid] = taskStats
+	}
+	return taskStats
+}
+
+// Remove the stats of tasks that have not logged for TaskRetentionSeconds and are no
+// longer in the task metadata (or have completed).  Caller must hold ed.mu
+func (ed *EventData) pruneTasks(now time.Time) {
+	taskMdMgr := ed.eventProcessor.GetMetadataManager().GetTaskMdManager()
+	for _, appStats := range ed.AppMap {
+		for key, taskStats := range appStats.TaskMap {
+			if taskStats.LastLogTime == nil || now.Sub(*taskStats.LastLogTime) <= config.TaskRetentionSeconds*time.Second {
+				continue
+			}
+			if taskStats.TaskGuid != "" {
+				taskMetadata := taskMdMgr.FindLoadedItem(taskStats.TaskGuid)
+				if taskMetadata != nil && !taskMetadata.IsComplete() {
+					continue
+				}
+			}
+			delete(appStats.TaskMap, key)
+			ed.markAppDirty(appStats.AppId)
+		}
+	}
+}
+
 func formatUUID(uuid *events.UUID) string {
 	if uuid == nil {
 		return ""
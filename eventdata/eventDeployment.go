@@ -0,0 +1,117 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventdata
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+const (
+	DEPLOYMENT_TRIGGER_API_START   = "API start"
+	DEPLOYMENT_TRIGGER_API_RESTAGE = "API restage"
+	DEPLOYMENT_TRIGGER_API_DEPLOY  = "API deployment"
+	DEPLOYMENT_TRIGGER_PACKAGE     = "Package updated"
+	DEPLOYMENT_TRIGGER_VERSION     = "Version changed"
+)
+
+var (
+	// API log messages that start a new version of the app:
+	//   Updated app with guid 1234 ({"state"=>"STARTED"})
+	//   Restaged app with guid 1234
+	//   Creating deployment for app with guid 1234  (v3 rolling deploy)
+	regexApiStart   = regexp.MustCompile(`"state"\s*=>\s*"STARTED"`)
+	regexApiRestage = regexp.MustCompile(`(?i)restag`)
+	regexApiDeploy  = regexp.MustCompile(`(?i)creat\w* deployment`)
+)
+
+// Check an API log message for the start of a deployment
+func (ed *EventData) detectApiDeployment(appStats *eventApp.AppStats, logText string, msgTime *time.Time) {
+	trigger := ""
+	switch {
+	case regexApiDeploy.MatchString(logText):
+		trigger = DEPLOYMENT_TRIGGER_API_DEPLOY
+	case regexApiRestage.MatchString(logText):
+		trigger = DEPLOYMENT_TRIGGER_API_RESTAGE
+	case regexApiStart.MatchString(logText):
+		trigger = DEPLOYMENT_TRIGGER_API_START
+	default:
+		return
+	}
+	appStats.StartDeployment(msgTime, trigger)
+}
+
+// Check the app version reported in an API event (e.g., crash payload) for a change
+func (ed *EventData) detectVersionChange(appStats *eventApp.AppStats, version string, eventTime *time.Time) {
+	if version == "" {
+		return
+	}
+	if appStats.AppVersion != "" && appStats.AppVersion != version {
+		deployment := appStats.StartDeployment(eventTime, DEPLOYMENT_TRIGGER_VERSION)
+		if deployment.OldVersion == "" {
+			deployment.OldVersion = appStats.AppVersion
+		}
+		deployment.NewVersion = version
+	}
+	appStats.AppVersion = version
+}
+
+// Check for package_updated_at changes in the app metadata and end any deployment
+// that has replaced all the old containers (or timed out).  Caller must hold ed.mu
+func (ed *EventData) updateDeployments() {
+	now := time.Now()
+	mdMgr := ed.eventProcessor.GetMetadataManager()
+	appMdMgr := mdMgr.GetAppMdManager()
+	for _, appStats := range ed.AppMap {
+
+		appMetadata := appMdMgr.FindItem(appStats.AppId)
+		packageUpdatedAt := appMetadata.PackageUpdatedAt
+		if packageUpdatedAt != "" && appStats.PackageUpdatedAt != packageUpdatedAt {
+			if appStats.PackageUpdatedAt != "" {
+				startTime := now
+				if updatedTime, err := time.Parse("2006-01-02T15:04:05Z", packageUpdatedAt); err == nil {
+					startTime = updatedTime
+				}
+				appStats.StartDeployment(&startTime, DEPLOYMENT_TRIGGER_PACKAGE)
+			}
+			appStats.PackageUpdatedAt = packageUpdatedAt
+			ed.markAppDirty(appStats.AppId)
+		}
+
+		deployment := appStats.ActiveDeployment()
+		if deployment == nil {
+			continue
+		}
+		oldCount, newCount := deployment.ContainerCounts(appStats.AllContainers())
+		desiredCount := mdMgr.FindDesiredContainers(appMetadata)
+		switch {
+		case appMetadata.State == "STOPPED":
+			deployment.EndTime = &now
+		case oldCount == 0 && newCount > 0 && newCount >= desiredCount:
+			deployment.EndTime = &now
+		case now.Sub(*deployment.StartTime) > config.DeploymentTimeoutSeconds*time.Second:
+			deployment.EndTime = &now
+			deployment.TimedOut = true
+			toplog.Info("Deployment of app %v timed out with %v old containers", appMetadata.Name, oldCount)
+		}
+		if deployment.EndTime != nil {
+			ed.markAppDirty(appStats.AppId)
+		}
+	}
+}
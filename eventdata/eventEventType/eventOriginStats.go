@@ -53,3 +53,18 @@ func (os *EventOriginStats) FindEventDetailStats(msg *events.Envelope) *EventDet
 	}
 	return eventDetailStats
 }
+
+// Add the counts of the same origin from another ingest partition
+func (os *EventOriginStats) Merge(other *EventOriginStats) {
+	for key, otherDetailStats := range other.EventDetailStatsMap {
+		eventDetailStats := os.EventDetailStatsMap[key]
+		if eventDetailStats == nil {
+			os.EventDetailStatsMap[key] = otherDetailStats
+			continue
+		}
+		eventDetailStats.EventCount = eventDetailStats.EventCount + otherDetailStats.EventCount
+		if otherDetailStats.LastEventTime.After(eventDetailStats.LastEventTime) {
+			eventDetailStats.LastEventTime = otherDetailStats.LastEventTime
+		}
+	}
+}
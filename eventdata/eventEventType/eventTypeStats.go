@@ -47,3 +47,15 @@ func (ets *EventTypeStats) FindEventOriginStats(origin string) *EventOriginStats
 	}
 	return originStats
 }
+
+// Add the counts of the same event type from another ingest partition
+func (ets *EventTypeStats) Merge(other *EventTypeStats) {
+	for origin, otherOriginStats := range other.EventOriginStatsMap {
+		originStats := ets.EventOriginStatsMap[origin]
+		if originStats == nil {
+			ets.EventOriginStatsMap[origin] = otherOriginStats
+		} else {
+			originStats.Merge(otherOriginStats)
+		}
+	}
+}
@@ -29,7 +29,7 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 )
 
-func (ed *EventData) httpStartStopEvent(msg *events.Envelope) {
+func (ed *EventData) httpStartStopEvent(msg *events.Envelope, httpScope httpStatsScope) {
 
 	appUUID := msg.GetHttpStartStop().GetApplicationId()
 	instId := msg.GetHttpStartStop().GetInstanceId()
@@ -39,10 +39,14 @@ func (ed *EventData) httpStartStopEvent(msg *events.Envelope) {
 	switch {
 	case peerType == events.PeerType_Client:
 
-		if ed.EnableRouteTracking {
+		if ed.EnableRouteTracking && httpScope != httpStatsApp {
 			ed.handleRouteStats(msg)
 		}
 
+		if httpScope == httpStatsRoute {
+			return
+		}
+
 		if appUUID != nil && instId != "" {
 			ed.httpStartStopEventForApp(msg)
 		} else if appUUID == nil && instId != "" {
@@ -337,14 +341,15 @@ func (ed *EventData) GetAppRouteStats(uri string, domain string, host string, po
 
 	domain = strings.ToLower(domain)
 	host = strings.ToLower(host)
+	ed.markDomainDirty(domain)
 
 	domainStats := ed.DomainMap[domain]
 	if domainStats == nil {
 		toplog.Debug("domainStats not found. It will be dynamically added for uri:[%v] domain:[%v] host:[%v] port:[%v] path:[%v]",
 			uri, domain, host, port, path)
 		if len(ed.DomainMap) > config.MaxDomainBucket {
-			toplog.Warn("domainStats map at max size. The entry will NOT be added")
-			return nil
+			toplog.Debug("domainStats map at max size. Counted in the OTHER domain uri:[%v]", uri)
+			return ed.otherAppRouteStats(eventRoute.OTHER, appId)
 		}
 		domainGuid := util.Pseudo_uuid()
 		domainStats = eventRoute.NewDomainStats(domainGuid)
@@ -360,12 +365,12 @@ func (ed *EventData) GetAppRouteStats(uri string, domain string, host string, po
 		} else {
 			toplog.Debug("hostStats not found. It will be dynamically added for uri:[%v] domain:[%v] host:[%v] port:[%v] path:[%v]",
 				uri, domain, host, port, path)
-			if len(domainStats.HostStatsMap) > config.MaxHostBucket {
-				toplog.Warn("hostStats map at max size. The entry will NOT be added")
-				return nil
+			if len(domainStats.HostStatsMap) > config.MaxHostBucket && !ed.evictLeastRecentHosts(domain, domainStats) {
+				toplog.Debug("hostStats map at max size. Counted in the OTHER host uri:[%v]", uri)
+				return ed.otherAppRouteStats(domain, appId)
 			}
 			// dynamically add new hosts/routes that we don't have pre-registered
-			hostStats = eventRoute.NewHostStats(host)
+			hostStats = eventRoute.NewDynamicHostStats(host)
 			domainStats.HostStatsMap[host] = hostStats
 		}
 	}
@@ -375,7 +380,7 @@ func (ed *EventData) GetAppRouteStats(uri string, domain string, host string, po
 		toplog.Debug("routeStats not found. It will be dynamically added for uri:[%v] domain:[%v] host:[%v] port:[%v] path:[%v]",
 			uri, domain, host, port, path)
 		// dynamically add root path
-		routeStats = ed.eventProcessor.addInternalRoute(domain, host, "", 0)
+		routeStats = ed.eventProcessor.addInternalRoute(ed, domain, host, "", 0)
 		if routeStats == nil {
 			return nil
 		}
@@ -423,10 +428,8 @@ func (ed *EventData) updateRouteStats(domain string, host string, port string, p
 
 	httpMethodStats.HttpStatusCode[httpEvent.GetStatusCode()] = httpMethodStats.HttpStatusCode[httpEvent.GetStatusCode()] + 1
 
-	userAgentCount := appRouteStats.UserAgentMap[httpEvent.GetUserAgent()]
-	if userAgentCount > 0 || (len(appRouteStats.UserAgentMap) < config.MaxUserAgentBucket) {
-		appRouteStats.UserAgentMap[httpEvent.GetUserAgent()] = userAgentCount + 1
-	}
+	evictedUserAgents := appRouteStats.CountUserAgent(httpEvent.GetUserAgent(), msgTime, config.MaxUserAgentBucket)
+	ed.memory.evictedUserAgents = ed.memory.evictedUserAgents + uint64(evictedUserAgents)
 
 	httpMethodStats.RequestCount = httpMethodStats.RequestCount + 1
 
@@ -0,0 +1,281 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventdata
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// Ingest pipeline:
+//
+//   nozzle --> nozzle queue --> dispatcher --> partition queue --> partition worker
+//
+// Each nozzle puts its envelopes on its own bounded queue and never waits -- if the
+// queue is full the envelope is dropped and counted.  A dispatcher per nozzle queue
+// hands each envelope to the partition that owns the app (or VM) the envelope is
+// about.  Each partition is an EventData with its own lock that is only updated by
+// its worker so partitions are processed concurrently.  The partitions are merged
+// when a snapshot is taken for display (see eventMerge.go).
+//
+// The partition of an envelope only depends on fields of the envelope so it never
+// changes as metadata is loaded.  An HttpStartStop envelope of an app request is
+// handed to two partitions: the one that owns the app updates the app stats and the
+// one that owns the domain of the request updates the route stats.  This way each
+// domain (and all its routes) is owned by a single partition.
+
+const (
+	INGEST_QUEUE_NOZZLE    = "NOZZLE"
+	INGEST_QUEUE_PARTITION = "PARTITION"
+)
+
+// Queue depth and counts of an ingest queue
+type IngestQueueStats struct {
+	Name      string
+	QueueType string
+	// Nozzle instanceId or partition number
+	QueueId   int
+	Depth     int
+	Capacity  int
+	Processed uint64
+	Dropped   uint64
+}
+
+func (qs *IngestQueueStats) Id() string {
+	return qs.Name
+}
+
+func (qs *IngestQueueStats) FullPercent() float64 {
+	if qs.Capacity == 0 {
+		return 0
+	}
+	return float64(qs.Depth) / float64(qs.Capacity) * 100
+}
+
+// Which stats of an HttpStartStop envelope a partition updates
+type httpStatsScope int
+
+const (
+	httpStatsAll httpStatsScope = iota
+	httpStatsApp
+	httpStatsRoute
+)
+
+var ipAddressRegexp = regexp.MustCompile(`^(?:[0-9]{1,3}\.){3}[0-9]{1,3}$`)
+
+type ingestEnvelope struct {
+	instanceId int
+	msg        *events.Envelope
+	httpScope  httpStatsScope
+}
+
+type ingestQueue struct {
+	name      string
+	queueType string
+	queueId   int
+	queue     chan *ingestEnvelope
+	processed uint64
+	dropped   uint64
+}
+
+func newIngestQueue(name string, queueType string, queueId int, size int) *ingestQueue {
+	return &ingestQueue{
+		name:      name,
+		queueType: queueType,
+		queueId:   queueId,
+		queue:     make(chan *ingestEnvelope, size),
+	}
+}
+
+func (iq *ingestQueue) stats() *IngestQueueStats {
+	return &IngestQueueStats{
+		Name:      iq.name,
+		QueueType: iq.queueType,
+		QueueId:   iq.queueId,
+		Depth:     len(iq.queue),
+		Capacity:  cap(iq.queue),
+		Processed: atomic.LoadUint64(&iq.processed),
+		Dropped:   atomic.LoadUint64(&iq.dropped),
+	}
+}
+
+// One partition per CPU (up to MaxIngestPartitions)
+func ingestPartitionCount() int {
+	count := runtime.NumCPU()
+	if count > config.MaxIngestPartitions {
+		count = config.MaxIngestPartitions
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+func (ep *EventProcessor) startPartitionWorkers() {
+	for i := range ep.partitions {
+		go ep.partitionWorker(ep.partitions[i], ep.partitionQueues[i])
+	}
+}
+
+func (ep *EventProcessor) partitionWorker(partition *EventData, partitionQueue *ingestQueue) {
+	for item := range partitionQueue.queue {
+		partition.process(item.instanceId, item.msg, item.httpScope)
+		atomic.AddUint64(&partitionQueue.processed, 1)
+	}
+}
+
+// Put the envelope on the queue of the nozzle it was received from.  Never blocks
+// the nozzle -- the envelope is dropped if the queue is full.
+func (ep *EventProcessor) enqueue(instanceId int, msg *events.Envelope) {
+	nozzleQueue := ep.getNozzleQueue(instanceId)
+	select {
+	case nozzleQueue.queue <- &ingestEnvelope{instanceId: instanceId, msg: msg}:
+	default:
+		if atomic.AddUint64(&nozzleQueue.dropped, 1) == 1 {
+			toplog.Warn("Nozzle #%v - Ingest queue is full, envelopes are being dropped (cf top is not keeping up)", instanceId)
+		}
+	}
+}
+
+func (ep *EventProcessor) getNozzleQueue(instanceId int) *ingestQueue {
+	ep.nozzleQueueMapLock.RLock()
+	nozzleQueue := ep.nozzleQueueMap[instanceId]
+	ep.nozzleQueueMapLock.RUnlock()
+	if nozzleQueue != nil {
+		return nozzleQueue
+	}
+
+	ep.nozzleQueueMapLock.Lock()
+	defer ep.nozzleQueueMapLock.Unlock()
+	nozzleQueue = ep.nozzleQueueMap[instanceId]
+	if nozzleQueue == nil {
+		nozzleQueue = newIngestQueue(fmt.Sprintf("Nozzle #%v", instanceId), INGEST_QUEUE_NOZZLE, instanceId, config.IngestNozzleQueueSize)
+		ep.nozzleQueueMap[instanceId] = nozzleQueue
+		go ep.dispatch(nozzleQueue)
+	}
+	return nozzleQueue
+}
+
+// Hand the envelopes of a nozzle queue to the partition that owns them.  Blocks when
+// the partition queue is full which in turn fills the nozzle queue.
+func (ep *EventProcessor) dispatch(nozzleQueue *ingestQueue) {
+	for item := range nozzleQueue.queue {
+		ep.forEachPartition(item.msg, func(partitionIndex int, httpScope httpStatsScope) {
+			ep.partitionQueues[partitionIndex].queue <- &ingestEnvelope{instanceId: item.instanceId, msg: item.msg, httpScope: httpScope}
+		})
+		atomic.AddUint64(&nozzleQueue.processed, 1)
+	}
+}
+
+// Call fn with each partition that updates stats of the envelope
+func (ep *EventProcessor) forEachPartition(msg *events.Envelope, fn func(partitionIndex int, httpScope httpStatsScope)) {
+	partitionIndex := ep.partitionIndexByKey(ep.partitionKey(msg))
+	if msg.GetEventType() != events.Envelope_HttpStartStop || msg.GetHttpStartStop().GetApplicationId() == nil {
+		fn(partitionIndex, httpStatsAll)
+		return
+	}
+	routePartitionIndex := ep.partitionIndexByKey(routePartitionKey(msg.GetHttpStartStop().GetUri()))
+	if routePartitionIndex == partitionIndex {
+		fn(partitionIndex, httpStatsAll)
+		return
+	}
+	fn(partitionIndex, httpStatsApp)
+	fn(routePartitionIndex, httpStatsRoute)
+}
+
+func (ep *EventProcessor) partitionIndexByKey(key string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(len(ep.partitions)))
+}
+
+// Key of the partition that owns the stats an envelope updates.  Envelopes about
+// an app are partitioned by app guid, all others by the VM (cell, router, etc)
+// they were sent from.
+func (ep *EventProcessor) partitionKey(msg *events.Envelope) string {
+	switch msg.GetEventType() {
+	case events.Envelope_HttpStartStop:
+		httpEvent := msg.GetHttpStartStop()
+		if appUUID := httpEvent.GetApplicationId(); appUUID != nil {
+			return formatUUID(appUUID)
+		}
+		// Requests not sent to an app (e.g., API calls) only update route stats
+		return routePartitionKey(httpEvent.GetUri())
+	case events.Envelope_ContainerMetric:
+		// The applicationId of a container metric is the process guid.  For other than
+		// the web process this is not the app guid so the stats of such an app are kept
+		// by two partitions and are merged for display (see mergeAppStats)
+		return msg.GetContainerMetric().GetApplicationId()
+	case events.Envelope_LogMessage:
+		if appId := msg.GetLogMessage().GetAppId(); appId != "" {
+			return appId
+		}
+	}
+	// Host metrics are not always sent with the VM IP so use the BOSH instance if we have it
+	if msg.GetIndex() != "" {
+		return msg.GetDeployment() + "|" + msg.GetJob() + "|" + msg.GetIndex()
+	}
+	return msg.GetIp()
+}
+
+// Key of the partition that owns the route stats of a request uri: the domain of the
+// uri the same as it is parsed by handleRouteStats
+func routePartitionKey(uri string) string {
+	if i := strings.Index(uri, "://"); i >= 0 {
+		uri = uri[i+3:]
+	}
+	if i := strings.IndexAny(uri, "/:"); i >= 0 {
+		uri = uri[:i]
+	}
+	return domainPartitionKey(domainOfHost(uri))
+}
+
+// Domain of a host name:  everything after the first label.  IP addresses have no domain.
+func domainOfHost(hostName string) string {
+	if ipAddressRegexp.MatchString(hostName) {
+		return ""
+	}
+	if i := strings.Index(hostName, "."); i >= 0 {
+		return hostName[i+1:]
+	}
+	return ""
+}
+
+func domainPartitionKey(domain string) string {
+	return "domain|" + strings.ToLower(domain)
+}
+
+// Depth and counts of all nozzle and partition queues
+func (ep *EventProcessor) GetIngestQueueStats() []*IngestQueueStats {
+	queueStats := make([]*IngestQueueStats, 0)
+	ep.nozzleQueueMapLock.RLock()
+	for _, nozzleQueue := range ep.nozzleQueueMap {
+		queueStats = append(queueStats, nozzleQueue.stats())
+	}
+	ep.nozzleQueueMapLock.RUnlock()
+	for _, partitionQueue := range ep.partitionQueues {
+		queueStats = append(queueStats, partitionQueue.stats())
+	}
+	return queueStats
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventLog
+
+import "sync"
+
+// Bounded ring buffer of the most recent log lines of an application
+type LogBuffer struct {
+	mu      sync.Mutex
+	lines   []*LogLine
+	next    int
+	full    bool
+	lastSeq int64
+}
+
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{lines: make([]*LogLine, size)}
+}
+
+// Add a line to the buffer replacing the oldest line if the buffer is full
+func (lb *LogBuffer) Add(line *LogLine) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.lastSeq++
+	line.Seq = lb.lastSeq
+	lb.lines[lb.next] = line
+	lb.next++
+	if lb.next == len(lb.lines) {
+		lb.next = 0
+		lb.full = true
+	}
+}
+
+// Get a copy of the buffered lines, oldest first
+func (lb *LogBuffer) GetLines() []*LogLine {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	if !lb.full {
+		lines := make([]*LogLine, lb.next)
+		copy(lines, lb.lines[:lb.next])
+		return lines
+	}
+	lines := make([]*LogLine, 0, len(lb.lines))
+	lines = append(lines, lb.lines[lb.next:]...)
+	lines = append(lines, lb.lines[:lb.next]...)
+	return lines
+}
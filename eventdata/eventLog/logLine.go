@@ -0,0 +1,42 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eventLog
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+)
+
+// A single log line (LogMessage envelope) of an application
+type LogLine struct {
+	// Sequence number within the log buffer -- used to keep lines in arrival order
+	Seq            int64
+	Timestamp      time.Time
+	SourceType     string
+	SourceInstance string
+	MessageType    events.LogMessage_MessageType
+	Message        string
+}
+
+func NewLogLine(logMessage *events.LogMessage) *LogLine {
+	return &LogLine{
+		Timestamp:      time.Unix(0, logMessage.GetTimestamp()),
+		SourceType:     logMessage.GetSourceType(),
+		SourceInstance: logMessage.GetSourceInstance(),
+		MessageType:    logMessage.GetMessageType(),
+		Message:        string(logMessage.GetMessage()),
+	}
+}
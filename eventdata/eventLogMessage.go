@@ -16,6 +16,7 @@
 package eventdata
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -24,34 +25,43 @@ import (
 	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/process"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 )
 
+var (
+	regexStagingBuildpack  = regexp.MustCompile(`----->\s+(.*[Bb]uildpack.*)`)
+	regexStagingExitStatus = regexp.MustCompile(`Exit status (-?[0-9]+)`)
+)
+
 func (ed *EventData) logMessageEvent(msg *events.Envelope) {
 
 	logMessage := msg.GetLogMessage()
 	appId := logMessage.GetAppId()
 	appStats := ed.getAppStats(appId)
 	sourceType := logMessage.GetSourceType()
+	ed.eventProcessor.bufferLogMessage(appId, logMessage)
 	switch {
 	case sourceType == "CELL" || sourceType == "CELL/SSHD" || sourceType == "SSH":
 		// The Diego cell emits CELL logs when it starts or stops the app. These actions implement the
 		// desired state requested by the user. The Diego cell also emits messages when an app crashes.
 		// PCF 1.10 has "CELL" for non-app related logging: e.g. "Container became healthy"
 		ed.logCellMsg(msg, logMessage, appStats)
-		fallthrough
 	case sourceType == "APP":
 		fallthrough
 	case strings.HasPrefix(sourceType, "APP/PROC"):
 		// PCF 1.6 - 1.9 used "APP" but 1.10 changed to:
 		//	 	"APP/PROC/WEB/0" or "APP/PROC/WEB"  AND   (the /0 seems to have been removed in later versions of 1.10)
 		// 		"APP/TASK/f7e79060/0" or "APP/TASK/6dd774cd"
-		// A TASK stdout/stderr output should NOT be attributed to instance 0 of real app.  We let this drop to
-		// default statement to count TASK output as general log messages.
-		// FUTURE: create seperate counters for tasks
+		// A TASK stdout/stderr output should NOT be attributed to instance 0 of real app.  TASK output
+		// is counted seperately by the "APP/TASK" case below.
+		//
+		// Apps with multiple processes log as "APP/PROC/WORKER", "APP/PROC/CLOCK", etc.  CELL messages do not
+		// include the process type in the source type so they are attributed by logCellMsg.
 		instNum, err := strconv.Atoi(*logMessage.SourceInstance)
 		if err == nil {
-			containerStats := ed.getContainerStats(appStats, instNum)
+			containerStats := ed.getProcessContainerStats(appStats, processTypeFromSourceType(sourceType), instNum)
 			switch *logMessage.MessageType {
 			case events.LogMessage_OUT:
 				containerStats.OutCount++
@@ -59,6 +69,8 @@ func (ed *EventData) logMessageEvent(msg *events.Envelope) {
 				containerStats.ErrCount++
 			}
 		}
+	case strings.HasPrefix(sourceType, "APP/TASK"):
+		ed.logTaskMsg(sourceType, logMessage, appStats)
 	case sourceType == "API":
 		// This is our notification that the state of an application may have changed
 		// e.g., App was marked as STARTED or STOPPED (by a user) or
@@ -73,7 +85,7 @@ func (ed *EventData) logMessageEvent(msg *events.Envelope) {
 	case sourceType == "HEALTH":
 		// Ignore health check messages (TODO: Check sourceType of "crashed" messages)
 	case sourceType == "STG":
-		ed.logStgCall(msg)
+		ed.logStgCall(msg, appStats)
 		fallthrough
 	default:
 		// Non-container log -- staging logs, router logs, etc
@@ -93,7 +105,16 @@ func (ed *EventData) logCellMsg(msg *events.Envelope, logMessage *events.LogMess
 		return
 	}
 
-	containerStats := ed.getContainerStats(appStats, instNum)
+	mdMgr := ed.eventProcessor.GetMetadataManager()
+	processType := ed.cellMsgProcessType(msg, appStats.AppId)
+	if processType == "" {
+		// Can't tell which process (web, worker, etc) this container belongs to but
+		// the app instances have still changed
+		mdMgr.RequestRefreshAppInstancesMetadata(appStats.AppId)
+		return
+	}
+
+	containerStats := ed.getProcessContainerStats(appStats, processType, instNum)
 	msgTime := time.Unix(0, logMessage.GetTimestamp())
 
 	msgBytes := logMessage.GetMessage()
@@ -117,10 +138,12 @@ func (ed *EventData) logCellMsg(msg *events.Envelope, logMessage *events.LogMess
 		containerStats.LastUpdateTime = nil
 		containerStats.LastMetricUpdateTime = nil
 
-		// Locate the traffic stats for this app index
-		for appInstId, containerTraffic := range appStats.ContainerTrafficMap {
-			if int(containerTraffic.InstanceIndex) == instNum {
-				delete(appStats.ContainerTrafficMap, appInstId)
+		// Locate the traffic stats for this app index (only the web process receives traffic)
+		if processType == process.WebProcessType {
+			for appInstId, containerTraffic := range appStats.ContainerTrafficMap {
+				if int(containerTraffic.InstanceIndex) == instNum {
+					delete(appStats.ContainerTrafficMap, appInstId)
+				}
 			}
 		}
 
@@ -141,6 +164,14 @@ func (ed *EventData) logCellMsg(msg *events.Envelope, logMessage *events.LogMess
 		containerStats.CellHealthyMsgTime = &msgTime
 	}
 
+	// Count after the switch so a "Creating" message is included in the new container's counts
+	switch *logMessage.MessageType {
+	case events.LogMessage_OUT:
+		containerStats.OutCount++
+	case events.LogMessage_ERR:
+		containerStats.ErrCount++
+	}
+
 	// TODO: Issue -- with handling all messagees the issue is we get some "destroy" message from the prior
 	// container after the new container starts logging create message beause the destroy is done async
 	// How can we ensure we only capture "new" container message and ignore the old container?
@@ -162,16 +193,136 @@ func (ed *EventData) logCellMsg(msg *events.Envelope, logMessage *events.LogMess
 		containerStats.LastUpdateTime = &msgTime
 	}
 
+	if processType == process.WebProcessType {
+		// Lifecycle history is kept by instance index of the web process
+		ed.recordLifecycleEvent(msg, appStats, instNum, &msgTime, msgText)
+	}
+
 	//toplog.Info("**** RequestRefreshAppInstancesMetadata for appId %v", appStats.AppId)
-	ed.eventProcessor.GetMetadataManager().RequestRefreshAppInstancesMetadata(appStats.AppId)
+	mdMgr.RequestRefreshAppInstancesMetadata(appStats.AppId)
+
+}
+
+// Find the process type (web, worker, etc) of the container that a CELL log message is about.
+// Newer cells tag the envelope with the process type and guid.  Without tags the message is
+// attributed to the web process unless a non-web process also has instances.  Returns empty
+// string if unknown.
+func (ed *EventData) cellMsgProcessType(msg *events.Envelope, appId string) string {
+	tags := msg.GetTags()
+	if processType := tags["process_type"]; processType != "" {
+		return strings.ToLower(processType)
+	}
+
+	mdMgr := ed.eventProcessor.GetMetadataManager()
+	if processGuid := tags["process_id"]; processGuid != "" {
+		if processGuid == appId {
+			return process.WebProcessType
+		}
+		processMetadata := mdMgr.GetProcessMdManager().FindProcess(processGuid)
+		if processMetadata == nil {
+			mdMgr.RequestRefreshProcessMetadata(appId)
+			return ""
+		}
+		return processMetadata.Type
+	}
 
+	for _, processMetadata := range mdMgr.GetProcessMdManager().FindByApp(appId) {
+		if !processMetadata.IsWeb() && processMetadata.Instances > 0 {
+			return ""
+		}
+	}
+	return process.WebProcessType
+}
+
+// Record the CELL message in the container lifecycle event history if it is
+// one of the lifecycle events we track
+func (ed *EventData) recordLifecycleEvent(msg *events.Envelope, appStats *eventApp.AppStats, instNum int, msgTime *time.Time, msgText string) {
+
+	var eventType eventApp.LifecycleEventType
+	switch {
+	case strings.Contains(msgText, "reating"): // Creating/creating
+		eventType = eventApp.LIFECYCLE_CREATING
+	case strings.Contains(msgText, "created"):
+		eventType = eventApp.LIFECYCLE_CREATED
+	case strings.Contains(msgText, "unhealthy"):
+		return
+	case strings.Contains(msgText, "healthy"):
+		eventType = eventApp.LIFECYCLE_HEALTHY
+	case strings.Contains(msgText, "vacuat") || strings.Contains(msgText, "requesting replacement"):
+		// Older cells log "evacuating", newer cells "requesting replacement for instance"
+		eventType = eventApp.LIFECYCLE_EVACUATED
+	case strings.Contains(msgText, "destroying"):
+		eventType = eventApp.LIFECYCLE_DESTROYING
+	default:
+		return
+	}
+	event := eventApp.NewContainerLifecycleEvent(instNum, eventType, msgTime, msg.GetIp(), msgText)
+	appStats.AddLifecycleEvent(event)
+}
+
+// Get the lower case process type from a source type of "APP/PROC/<TYPE>/<index>" or "APP/PROC/<TYPE>".
+// Returns empty string if source type does not contain a process type
+func processTypeFromSourceType(sourceType string) string {
+	sourceTypeParts := strings.Split(sourceType, "/")
+	if len(sourceTypeParts) < 3 || sourceTypeParts[0] != "APP" || sourceTypeParts[1] != "PROC" {
+		return ""
+	}
+	return strings.ToLower(sourceTypeParts[2])
+}
+
+// Task log message -- source type format: "APP/TASK/<task name>/<index>" or "APP/TASK/<task name>"
+func (ed *EventData) logTaskMsg(sourceType string, logMessage *events.LogMessage, appStats *eventApp.AppStats) {
+	sourceTypeParts := strings.Split(sourceType, "/")
+	if len(sourceTypeParts) < 3 || sourceTypeParts[2] == "" {
+		return
+	}
+	taskName := sourceTypeParts[2]
+	msgTime := time.Unix(0, logMessage.GetTimestamp())
+
+	taskStats := ed.getTaskStats(appStats, taskName, msgTime)
+	if taskStats.FirstLogTime == nil {
+		taskStats.FirstLogTime = &msgTime
+	}
+	if taskStats.TaskGuid == "" {
+		// Task not yet in metadata -- get the task guid, state, memory, etc from cloud controller
+		ed.eventProcessor.GetMetadataManager().RequestRefreshTaskMetadata()
+	}
+	taskStats.LastLogTime = &msgTime
+
+	switch *logMessage.MessageType {
+	case events.LogMessage_OUT:
+		taskStats.OutCount++
+	case events.LogMessage_ERR:
+		taskStats.ErrCount++
+	}
 }
 
 // Staging log message
-func (ed *EventData) logStgCall(msg *events.Envelope) {
+func (ed *EventData) logStgCall(msg *events.Envelope, appStats *eventApp.AppStats) {
 	logMessage := msg.GetLogMessage()
 	appId := logMessage.GetAppId()
 	logText := string(logMessage.GetMessage())
+	msgTime := time.Unix(0, logMessage.GetTimestamp())
+
+	stagingStats := appStats.CurrentStaging(msgTime)
+	if stagingStats == nil {
+		// First staging output -- a new build has started
+		stagingStats = eventApp.NewStagingStats(&msgTime)
+		appStats.AddStaging(stagingStats)
+	}
+	stagingStats.LastLogTime = &msgTime
+	switch *logMessage.MessageType {
+	case events.LogMessage_OUT:
+		stagingStats.OutCount++
+	case events.LogMessage_ERR:
+		stagingStats.ErrCount++
+	}
+	if stagingStats.Buildpack == "" {
+		stagingStats.Buildpack = parseStagingBuildpack(logText)
+	}
+	if exitStatus := regexStagingExitStatus.FindStringSubmatch(logText); exitStatus != nil {
+		stagingStats.ExitStatus = exitStatus[1]
+	}
 
 	// We only care about when a "successfully destroyed container" staging event occures.
 	// NOTE: Since PCF 2.x uses lower case "sucessfully" vs PCF 1.x uses uppercase, we just
@@ -181,6 +332,7 @@ func (ed *EventData) logStgCall(msg *events.Envelope) {
 	if !strings.Contains(logText, "uccessfully destroyed container") {
 		return
 	}
+	stagingStats.EndTime = &msgTime
 
 	appMetadata := ed.eventProcessor.GetMetadataManager().GetAppMdManager().FindItem(appId)
 	toplog.Info("STG event occured and complete for app:%v name:%v msg: %v", appId, appMetadata.Name, logText)
@@ -188,6 +340,16 @@ func (ed *EventData) logStgCall(msg *events.Envelope) {
 
 }
 
+// Buildpack from staging output such as "-----> Java Buildpack v4.16" or
+// "-----> Nodejs Buildpack version 1.6.28".  Returns empty string if not found
+func parseStagingBuildpack(logText string) string {
+	parsedData := regexStagingBuildpack.FindStringSubmatch(logText)
+	if parsedData == nil {
+		return ""
+	}
+	return strings.TrimSpace(parsedData[1])
+}
+
 func (ed *EventData) logApiCall(msg *events.Envelope) {
 
 	logMessage := msg.GetLogMessage()
@@ -199,6 +361,10 @@ func (ed *EventData) logApiCall(msg *events.Envelope) {
 	toplog.Debug("API event occured for app:%v name:%v msg: %v", appId, appMetadata.Name, logText)
 	ed.eventProcessor.GetMetadataManager().RequestLoadOfItem(common.APP, appId)
 	ed.eventProcessor.GetMetadataManager().RequestRefreshAppInstancesMetadata(appId)
+	ed.eventProcessor.GetMetadataManager().RequestRefreshProcessMetadata(appId)
+
+	msgTime := time.Unix(0, logMessage.GetTimestamp())
+	ed.detectApiDeployment(appStats, logText, &msgTime)
 
 	if !strings.HasPrefix(logText, "App instance exited") {
 		return
@@ -234,6 +400,12 @@ func (ed *EventData) logApiCall(msg *events.Envelope) {
 	if reasonField == nil {
 		return
 	}
+	if versionField := fields["version"]; versionField != nil {
+		if version, ok := versionField.Data().(string); ok {
+			ed.detectVersionChange(appStats, version, &msgTime)
+		}
+	}
+
 	reason := reasonField.Data().(string)
 	toplog.Debug("API app event event occured for app:%v name:%v reason: %v", appId, appMetadata.Name, reason)
 	if reason == "CRASHED" {
@@ -257,7 +429,15 @@ func (ed *EventData) logApiCall(msg *events.Envelope) {
 		if crashTimestampField != nil {
 			timestamp64 := crashTimestampField.Data().(float64)
 			timestamp := time.Unix(0, int64(timestamp64))
-			appStats.AddCrashInfo(instNum, &timestamp, exitDescription)
+			// The crashed container's cell is the last cell the container was seen running on
+			cellIp := ""
+			if instNum < len(appStats.ContainerArray) && appStats.ContainerArray[instNum] != nil {
+				cellIp = appStats.ContainerArray[instNum].Ip
+			}
+			appStats.AddCrashInfo(instNum, &timestamp, exitDescription, cellIp)
+			crashDescription := crashData.CleanupExitDescription(exitDescription)
+			event := eventApp.NewContainerLifecycleEvent(instNum, eventApp.LIFECYCLE_CRASHED, &timestamp, cellIp, crashDescription)
+			appStats.AddLifecycleEvent(event)
 		}
 		toplog.Info("CRASH of app %v exit desc: %v", appMetadata.Name, exitDescription)
 
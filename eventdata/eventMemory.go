@@ -0,0 +1,382 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventdata
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// Approximate memory used by each stats entry including its map entry and typical
+// child data.  These don't need to be exact, only in proportion to each other.
+const (
+	appStatsBytes        = 2048
+	containerStatsBytes  = 1024
+	appHistoryBytes      = 256
+	cellStatsBytes       = 2048
+	componentStatsBytes  = 1024
+	hostStatsBytes       = 512
+	routeStatsBytes      = 256
+	appRouteStatsBytes   = 256
+	httpMethodStatsBytes = 512
+	userAgentEntryBytes  = 64
+)
+
+// Approximate memory used by the live event stats (all ingest partitions) and the
+// number of entries evicted to stay within config.MaxStatsMemoryMB.  Snapshots taken
+// for display are not included.
+type MemoryStats struct {
+	CheckTime  time.Time
+	LimitBytes int64
+
+	AppBytes       int64
+	RouteBytes     int64
+	UserAgentBytes int64
+	// Cells and platform components
+	PlatformBytes int64
+
+	AppCount       int
+	HostCount      int
+	RouteCount     int
+	UserAgentCount int
+
+	EvictedApps       uint64
+	EvictedHosts      uint64
+	EvictedRoutes     uint64
+	EvictedUserAgents uint64
+}
+
+func (ms *MemoryStats) TotalBytes() int64 {
+	return ms.AppBytes + ms.RouteBytes + ms.UserAgentBytes + ms.PlatformBytes
+}
+
+func (ms *MemoryStats) add(other *MemoryStats) {
+	ms.AppBytes = ms.AppBytes + other.AppBytes
+	ms.RouteBytes = ms.RouteBytes + other.RouteBytes
+	ms.UserAgentBytes = ms.UserAgentBytes + other.UserAgentBytes
+	ms.PlatformBytes = ms.PlatformBytes + other.PlatformBytes
+	ms.AppCount = ms.AppCount + other.AppCount
+	ms.HostCount = ms.HostCount + other.HostCount
+	ms.RouteCount = ms.RouteCount + other.RouteCount
+	ms.UserAgentCount = ms.UserAgentCount + other.UserAgentCount
+	ms.EvictedApps = ms.EvictedApps + other.EvictedApps
+	ms.EvictedHosts = ms.EvictedHosts + other.EvictedHosts
+	ms.EvictedRoutes = ms.EvictedRoutes + other.EvictedRoutes
+	ms.EvictedUserAgents = ms.EvictedUserAgents + other.EvictedUserAgents
+}
+
+// Activity and eviction counts of a partition.  Caller must hold ed.mu
+type memoryTracker struct {
+	// Key: appId.  Time of the first snapshot after the app last changed.
+	appLastActive map[string]time.Time
+
+	evictedApps       uint64
+	evictedHosts      uint64
+	evictedRoutes     uint64
+	evictedUserAgents uint64
+}
+
+func newMemoryTracker() *memoryTracker {
+	return &memoryTracker{appLastActive: make(map[string]time.Time)}
+}
+
+type memoryGovernor struct {
+	mu          sync.Mutex
+	memoryStats *MemoryStats
+}
+
+func (ep *EventProcessor) startMemoryGovernor() {
+	go ep.memoryGovernorThread()
+}
+
+func (ep *EventProcessor) memoryGovernorThread() {
+	for {
+		time.Sleep(config.StatsMemoryCheckSeconds * time.Second)
+		ep.checkMemory(time.Now())
+	}
+}
+
+// Returns nil until memory has been checked the first time
+func (ep *EventProcessor) GetMemoryStats() *MemoryStats {
+	ep.memoryGovernor.mu.Lock()
+	defer ep.memoryGovernor.mu.Unlock()
+	return ep.memoryGovernor.memoryStats
+}
+
+// Each partition gets an equal share of the memory limit
+func (ep *EventProcessor) checkMemory(now time.Time) {
+
+	limitBytes := int64(config.MaxStatsMemoryMB) * 1024 * 1024
+	partitionLimitBytes := limitBytes / int64(len(ep.partitions))
+
+	// Only apps not in the metadata (e.g., never loaded) are evicted.  If the
+	// metadata hasn't loaded yet no apps are evicted.
+	knownAppMap := make(map[string]bool)
+	for _, appMd := range ep.metadataManager.GetAppMdManager().AllApps() {
+		knownAppMap[appMd.Guid] = true
+	}
+
+	memoryStats := &MemoryStats{CheckTime: now, LimitBytes: limitBytes}
+	for _, partition := range ep.partitions {
+		partition.mu.Lock()
+		partitionStats := partition.estimateMemory()
+		if partitionStats.TotalBytes() > partitionLimitBytes {
+			targetBytes := partitionLimitBytes * config.StatsMemoryTargetPercent / 100
+			partition.evictToTarget(partitionStats, targetBytes, knownAppMap)
+			partitionStats = partition.estimateMemory()
+		}
+		partition.mu.Unlock()
+		memoryStats.add(partitionStats)
+	}
+
+	ep.memoryGovernor.mu.Lock()
+	ep.memoryGovernor.memoryStats = memoryStats
+	ep.memoryGovernor.mu.Unlock()
+}
+
+// Caller must hold ed.mu
+func (ed *EventData) estimateMemory() *MemoryStats {
+
+	memoryStats := &MemoryStats{
+		AppCount:          len(ed.AppMap),
+		EvictedApps:       ed.memory.evictedApps,
+		EvictedHosts:      ed.memory.evictedHosts,
+		EvictedRoutes:     ed.memory.evictedRoutes,
+		EvictedUserAgents: ed.memory.evictedUserAgents,
+	}
+	for _, appStats := range ed.AppMap {
+		memoryStats.AppBytes = memoryStats.AppBytes + estimateAppMemory(appStats)
+	}
+	for _, domainStats := range ed.DomainMap {
+		memoryStats.HostCount = memoryStats.HostCount + len(domainStats.HostStatsMap)
+		for _, hostStats := range domainStats.HostStatsMap {
+			routeBytes, userAgentBytes, routeCount, userAgentCount := estimateHostMemory(hostStats)
+			memoryStats.RouteBytes = memoryStats.RouteBytes + routeBytes
+			memoryStats.UserAgentBytes = memoryStats.UserAgentBytes + userAgentBytes
+			memoryStats.RouteCount = memoryStats.RouteCount + routeCount
+			memoryStats.UserAgentCount = memoryStats.UserAgentCount + userAgentCount
+		}
+	}
+	memoryStats.PlatformBytes = int64(len(ed.CellMap)*cellStatsBytes + len(ed.ComponentMap)*componentStatsBytes)
+	return memoryStats
+}
+
+func estimateAppMemory(appStats *eventApp.AppStats) int64 {
+	containerCount := len(appStats.ContainerArray)
+	for _, containerArray := range appStats.ProcessContainerMap {
+		containerCount = containerCount + len(containerArray)
+	}
+	historyCount := len(appStats.ContainerCrashInfo) + len(appStats.LifecycleEvents) +
+		len(appStats.TaskMap) + len(appStats.StagingArray) + len(appStats.Deployments)
+	return int64(appStatsBytes + containerCount*containerStatsBytes + historyCount*appHistoryBytes)
+}
+
+func estimateHostMemory(hostStats *eventRoute.HostStats) (routeBytes, userAgentBytes int64, routeCount, userAgentCount int) {
+	routeBytes = hostStatsBytes
+	addRoute := func(routeStats *eventRoute.RouteStats) {
+		routeCount++
+		routeBytes = routeBytes + routeStatsBytes
+		for _, appRouteStats := range routeStats.AppRouteStatsMap {
+			routeBytes = routeBytes + int64(appRouteStatsBytes+len(appRouteStats.HttpMethodStatsMap)*httpMethodStatsBytes)
+			for userAgent := range appRouteStats.UserAgentMap {
+				userAgentCount++
+				userAgentBytes = userAgentBytes + int64(userAgentEntryBytes+len(userAgent))
+			}
+		}
+	}
+	for _, routeStats := range hostStats.RouteStatsMap {
+		addRoute(routeStats)
+	}
+	for _, routeStats := range hostStats.TcpRouteStatsMap {
+		addRoute(routeStats)
+	}
+	return routeBytes, userAgentBytes, routeCount, userAgentCount
+}
+
+type hostEvictCandidate struct {
+	domain     string
+	hostName   string
+	lastAccess time.Time
+}
+
+// Least recently active first
+type hostEvictCandidateSlice []*hostEvictCandidate
+
+func (s hostEvictCandidateSlice) Len() int           { return len(s) }
+func (s hostEvictCandidateSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s hostEvictCandidateSlice) Less(i, j int) bool { return s[i].lastAccess.Before(s[j].lastAccess) }
+
+type appEvictCandidate struct {
+	appId      string
+	lastActive time.Time
+}
+
+// Least recently active first
+type appEvictCandidateSlice []*appEvictCandidate
+
+func (s appEvictCandidateSlice) Len() int           { return len(s) }
+func (s appEvictCandidateSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s appEvictCandidateSlice) Less(i, j int) bool { return s[i].lastActive.Before(s[j].lastActive) }
+
+// Evict the least recently active dynamically added hosts and then the least recently
+// active apps that are not in the metadata until the estimate is at targetBytes.
+// Caller must hold ed.mu
+func (ed *EventData) evictToTarget(memoryStats *MemoryStats, targetBytes int64, knownAppMap map[string]bool) {
+
+	totalBytes := memoryStats.TotalBytes()
+
+	hostCandidates := make(hostEvictCandidateSlice, 0)
+	for domain, domainStats := range ed.DomainMap {
+		for hostName, hostStats := range domainStats.HostStatsMap {
+			if hostStats.IsDynamic() {
+				hostCandidates = append(hostCandidates, &hostEvictCandidate{domain, hostName, hostStats.LastAccess()})
+			}
+		}
+	}
+	sort.Sort(hostCandidates)
+	hostsEvicted := 0
+	for _, candidate := range hostCandidates {
+		if totalBytes <= targetBytes {
+			break
+		}
+		domainStats := ed.DomainMap[candidate.domain]
+		routeBytes, userAgentBytes, _, _ := estimateHostMemory(domainStats.HostStatsMap[candidate.hostName])
+		ed.evictHost(candidate.domain, domainStats, candidate.hostName)
+		totalBytes = totalBytes - routeBytes - userAgentBytes
+		hostsEvicted++
+	}
+
+	// Forget apps that have been removed from the map (deleted)
+	for appId := range ed.memory.appLastActive {
+		if ed.AppMap[appId] == nil {
+			delete(ed.memory.appLastActive, appId)
+		}
+	}
+	appsEvicted := 0
+	if totalBytes > targetBytes && len(knownAppMap) > 0 {
+		appCandidates := make(appEvictCandidateSlice, 0)
+		for appId := range ed.AppMap {
+			if !knownAppMap[appId] && !ed.snapshot.dirtyAppMap[appId] {
+				appCandidates = append(appCandidates, &appEvictCandidate{appId, ed.memory.appLastActive[appId]})
+			}
+		}
+		sort.Sort(appCandidates)
+		for _, candidate := range appCandidates {
+			if totalBytes <= targetBytes {
+				break
+			}
+			totalBytes = totalBytes - estimateAppMemory(ed.AppMap[candidate.appId])
+			delete(ed.AppMap, candidate.appId)
+			delete(ed.memory.appLastActive, candidate.appId)
+			ed.memory.evictedApps++
+			appsEvicted++
+		}
+	}
+
+	toplog.Info("Stats memory over limit. Evicted %v hosts and %v apps", hostsEvicted, appsEvicted)
+}
+
+// Evict the HostEvictPercent least recently active dynamically added hosts of a
+// domain.  Returns false if the domain has no dynamically added hosts.
+// Caller must hold ed.mu
+func (ed *EventData) evictLeastRecentHosts(domain string, domainStats *eventRoute.DomainStats) bool {
+
+	hostCandidates := make(hostEvictCandidateSlice, 0)
+	for hostName, hostStats := range domainStats.HostStatsMap {
+		if hostStats.IsDynamic() {
+			hostCandidates = append(hostCandidates, &hostEvictCandidate{domain, hostName, hostStats.LastAccess()})
+		}
+	}
+	if len(hostCandidates) == 0 {
+		return false
+	}
+	sort.Sort(hostCandidates)
+	evictCount := len(hostCandidates) * config.HostEvictPercent / 100
+	if evictCount == 0 {
+		evictCount = 1
+	}
+	for _, candidate := range hostCandidates[:evictCount] {
+		ed.evictHost(domain, domainStats, candidate.hostName)
+	}
+	toplog.Info("Domain %v at max of %v hosts. Evicted %v least recently active hosts", domain, config.MaxHostBucket, evictCount)
+	return true
+}
+
+// Fold the routes of a host into the OTHER host of its domain and remove the host.
+// Caller must hold ed.mu
+func (ed *EventData) evictHost(domain string, domainStats *eventRoute.DomainStats, hostName string) {
+	hostStats := domainStats.HostStatsMap[hostName]
+	otherRouteStats := ed.otherRouteStats(domain, domainStats)
+	for _, routeStats := range hostStats.RouteStatsMap {
+		otherRouteStats.AddCounts(routeStats, config.MaxUserAgentBucket)
+		ed.memory.evictedRoutes++
+	}
+	for _, routeStats := range hostStats.TcpRouteStatsMap {
+		otherRouteStats.AddCounts(routeStats, config.MaxUserAgentBucket)
+		ed.memory.evictedRoutes++
+	}
+	delete(domainStats.HostStatsMap, hostName)
+	ed.memory.evictedHosts++
+	ed.markDomainDirty(domain)
+}
+
+// The route requests are counted in when their host (or domain) has been evicted or
+// could not be added.  The OTHER host is upper case so it can't match a real host
+// name (host names are lower cased).
+// Caller must hold ed.mu
+func (ed *EventData) otherRouteStats(domain string, domainStats *eventRoute.DomainStats) *eventRoute.RouteStats {
+	hostStats := domainStats.HostStatsMap[eventRoute.OTHER]
+	if hostStats == nil {
+		hostStats = eventRoute.NewHostStats(eventRoute.OTHER)
+		domainStats.HostStatsMap[eventRoute.OTHER] = hostStats
+	}
+	routeStats := hostStats.RouteStatsMap[""]
+	if routeStats == nil {
+		mdMgr := ed.eventProcessor.GetMetadataManager()
+		domainMd := mdMgr.GetDomainFinder().FindDomainMetadataByName(domain)
+		if domainMd == nil {
+			domainMd = mdMgr.GetDomainPrivateMdManager().AddDomainMetadata(domain)
+		}
+		route := mdMgr.GetRouteMdManager().CreateInternalGeneratedRoute(eventRoute.OTHER, "", domainMd.Guid, 0)
+		routeStats = hostStats.AddPath("", route.Guid, true)
+	}
+	return routeStats
+}
+
+// The app route of the OTHER host of a domain.  The domain is added if needed
+// (used for the OTHER domain).
+// Caller must hold ed.mu
+func (ed *EventData) otherAppRouteStats(domain string, appId string) *eventRoute.AppRouteStats {
+	ed.markDomainDirty(domain)
+	domainStats := ed.DomainMap[domain]
+	if domainStats == nil {
+		domainStats = eventRoute.NewDomainStats(domain)
+		ed.DomainMap[domain] = domainStats
+	}
+	routeStats := ed.otherRouteStats(domain, domainStats)
+	appRouteStats := routeStats.FindAppRouteStats(appId)
+	if appRouteStats == nil {
+		appRouteStats = eventRoute.NewAppRouteStats(appId)
+		routeStats.AppRouteStatsMap[appId] = appRouteStats
+	}
+	return appRouteStats
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventdata
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventData memory", func() {
+	const testDomain = "apps.example.com"
+
+	var (
+		ed  *EventData
+		now time.Time
+	)
+
+	// Add a host with a single route that has requestCount GET requests from appId
+	addHost := func(hostName string, dynamic bool, lastAccess time.Time, appId string, requestCount int64) {
+		domainStats := ed.DomainMap[testDomain]
+		if domainStats == nil {
+			domainStats = eventRoute.NewDomainStats(testDomain)
+			ed.DomainMap[testDomain] = domainStats
+		}
+		hostStats := eventRoute.NewHostStats(hostName)
+		if dynamic {
+			hostStats = eventRoute.NewDynamicHostStats(hostName)
+		}
+		domainStats.HostStatsMap[hostName] = hostStats
+		appRouteStats := eventRoute.NewAppRouteStats(appId)
+		httpMethodStats := eventRoute.NewHttpMethodStats(events.Method_GET)
+		httpMethodStats.LastAccess = lastAccess
+		httpMethodStats.RequestCount = requestCount
+		appRouteStats.HttpMethodStatsMap[events.Method_GET] = httpMethodStats
+		hostStats.AddPath("", hostName+"-route", true).AppRouteStatsMap[appId] = appRouteStats
+	}
+
+	// Requests of appId counted in the OTHER host of the test domain
+	otherRequestCount := func(appId string) int64 {
+		hostStats := ed.DomainMap[testDomain].HostStatsMap[eventRoute.OTHER]
+		if hostStats == nil || hostStats.RouteStatsMap[""] == nil {
+			return 0
+		}
+		appRouteStats := hostStats.RouteStatsMap[""].FindAppRouteStats(appId)
+		if appRouteStats == nil {
+			return 0
+		}
+		return appRouteStats.HttpMethodStatsMap[events.Method_GET].RequestCount
+	}
+
+	BeforeEach(func() {
+		ed = NewEventData(&sync.Mutex{}, newBenchEventProcessor())
+		now = time.Now()
+	})
+
+	Describe("estimateAppMemory", func() {
+		It("counts an empty app", func() {
+			Expect(estimateAppMemory(eventApp.NewAppStats("app"))).To(BeEquivalentTo(appStatsBytes))
+		})
+		It("counts the containers of all processes", func() {
+			appStats := &eventApp.AppStats{
+				ContainerArray:      make([]*eventApp.ContainerStats, 2),
+				ProcessContainerMap: map[string][]*eventApp.ContainerStats{"worker": make([]*eventApp.ContainerStats, 3)},
+			}
+			Expect(estimateAppMemory(appStats)).To(BeEquivalentTo(appStatsBytes + 5*containerStatsBytes))
+		})
+		It("counts the history entries", func() {
+			appStats := &eventApp.AppStats{
+				LifecycleEvents: make([]*eventApp.ContainerLifecycleEvent, 4),
+				TaskMap:         map[string]*eventApp.TaskStats{"task": nil},
+				Deployments:     make([]*eventApp.DeploymentStats, 1),
+			}
+			Expect(estimateAppMemory(appStats)).To(BeEquivalentTo(appStatsBytes + 6*appHistoryBytes))
+		})
+	})
+
+	Describe("evictToTarget", func() {
+		hostBytes := int64(hostStatsBytes + routeStatsBytes + appRouteStatsBytes + httpMethodStatsBytes)
+
+		var knownAppMap map[string]bool
+
+		evict := func(freeBytes int64) {
+			memoryStats := ed.estimateMemory()
+			ed.evictToTarget(memoryStats, memoryStats.TotalBytes()-freeBytes, knownAppMap)
+		}
+
+		BeforeEach(func() {
+			addHost("seeded", false, now.Add(-time.Hour), "app1", 1)
+			addHost("old", true, now.Add(-time.Minute), "app1", 2)
+			addHost("new", true, now, "app1", 3)
+			for _, appId := range []string{"app1", "app2", "app3"} {
+				ed.AppMap[appId] = eventApp.NewAppStats(appId)
+				ed.memory.appLastActive[appId] = now
+			}
+			ed.memory.appLastActive["app2"] = now.Add(-time.Minute)
+			ed.snapshot = newSnapshotTracker()
+			knownAppMap = map[string]bool{"app1": true}
+		})
+
+		It("evicts nothing when under the target", func() {
+			evict(0)
+			Expect(ed.DomainMap[testDomain].HostStatsMap).To(HaveLen(3))
+			Expect(ed.AppMap).To(HaveLen(3))
+		})
+
+		It("folds the least recently active dynamic host into OTHER first", func() {
+			evict(1)
+			hosts := ed.DomainMap[testDomain].HostStatsMap
+			Expect(hosts).To(HaveLen(3))
+			Expect(hosts).To(HaveKey("seeded"))
+			Expect(hosts).To(HaveKey("new"))
+			Expect(hosts).To(HaveKey(eventRoute.OTHER))
+			Expect(otherRequestCount("app1")).To(BeEquivalentTo(2))
+			Expect(ed.memory.evictedHosts).To(BeEquivalentTo(1))
+			Expect(ed.AppMap).To(HaveLen(3))
+		})
+
+		Context("when evicting hosts is not enough", func() {
+			It("evicts the least recently active app that is no longer known", func() {
+				evict(2*hostBytes + 1)
+				hosts := ed.DomainMap[testDomain].HostStatsMap
+				Expect(hosts).To(HaveLen(2))
+				Expect(hosts).To(HaveKey("seeded"))
+				Expect(otherRequestCount("app1")).To(BeEquivalentTo(5))
+				Expect(ed.memory.evictedHosts).To(BeEquivalentTo(2))
+				Expect(ed.AppMap).To(HaveLen(2))
+				Expect(ed.AppMap).NotTo(HaveKey("app2"))
+				Expect(ed.memory.evictedApps).To(BeEquivalentTo(1))
+			})
+			It("does not evict apps before the app metadata is loaded", func() {
+				knownAppMap = map[string]bool{}
+				evict(2*hostBytes + 1)
+				Expect(ed.AppMap).To(HaveLen(3))
+				Expect(ed.memory.evictedApps).To(BeZero())
+			})
+			It("does not evict apps changed since the last snapshot", func() {
+				ed.markAppDirty("app2")
+				evict(2*hostBytes + 1)
+				Expect(ed.AppMap).To(HaveKey("app2"))
+				Expect(ed.AppMap).NotTo(HaveKey("app3"))
+				Expect(ed.memory.evictedApps).To(BeEquivalentTo(1))
+			})
+		})
+	})
+
+	Describe("evictLeastRecentHosts", func() {
+		addHosts := func(seeded int, dynamic int) *eventRoute.DomainStats {
+			for i := 0; i < seeded; i++ {
+				addHost(fmt.Sprintf("seeded%v", i), false, now, "app1", 1)
+			}
+			for i := 0; i < dynamic; i++ {
+				addHost(fmt.Sprintf("dynamic%v", i), true, now.Add(time.Duration(i)*time.Second), "app1", 1)
+			}
+			return ed.DomainMap[testDomain]
+		}
+
+		It("does not evict seeded hosts", func() {
+			domainStats := addHosts(3, 0)
+			Expect(ed.evictLeastRecentHosts(testDomain, domainStats)).To(BeFalse())
+			Expect(domainStats.HostStatsMap).To(HaveLen(3))
+		})
+		It("evicts at least one dynamic host", func() {
+			domainStats := addHosts(1, 3)
+			Expect(ed.evictLeastRecentHosts(testDomain, domainStats)).To(BeTrue())
+			Expect(domainStats.HostStatsMap).To(HaveLen(1 + 2 + 1))
+			Expect(domainStats.HostStatsMap).NotTo(HaveKey("dynamic0"))
+		})
+		It("evicts HostEvictPercent of the dynamic hosts", func() {
+			domainStats := addHosts(0, 30)
+			Expect(ed.evictLeastRecentHosts(testDomain, domainStats)).To(BeTrue())
+			Expect(domainStats.HostStatsMap).To(HaveLen(30 - 3 + 1))
+			Expect(domainStats.HostStatsMap).NotTo(HaveKey("dynamic0"))
+		})
+	})
+
+	Describe("CountUserAgent", func() {
+		var appRouteStats *eventRoute.AppRouteStats
+
+		count := func(maxUserAgents int, userAgents ...string) int {
+			evicted := 0
+			for i, userAgent := range userAgents {
+				evicted = evicted + appRouteStats.CountUserAgent(userAgent, now.Add(time.Duration(i)*time.Second), maxUserAgents)
+			}
+			return evicted
+		}
+
+		BeforeEach(func() {
+			appRouteStats = eventRoute.NewAppRouteStats("app1")
+		})
+
+		It("counts each user agent when under the max", func() {
+			Expect(count(3, "a", "b", "a")).To(BeZero())
+			Expect(appRouteStats.UserAgentMap).To(HaveLen(2))
+			Expect(appRouteStats.UserAgentMap).NotTo(HaveKey(eventRoute.OTHER))
+		})
+		It("folds the least recent user agents into OTHER, which takes one of the entries", func() {
+			Expect(count(2, "a", "b", "c")).To(Equal(2))
+			Expect(appRouteStats.UserAgentMap).To(HaveLen(2))
+			Expect(appRouteStats.UserAgentMap[eventRoute.OTHER]).To(BeEquivalentTo(2))
+		})
+		It("never evicts OTHER", func() {
+			Expect(count(2, "a", "b", "c", "d")).To(Equal(3))
+			Expect(appRouteStats.UserAgentMap).To(HaveLen(2))
+			Expect(appRouteStats.UserAgentMap[eventRoute.OTHER]).To(BeEquivalentTo(3))
+		})
+	})
+})
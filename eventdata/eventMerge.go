@@ -0,0 +1,153 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventdata
+
+import (
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventComponent"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventEventType"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
+)
+
+// Merge the snapshots of the ingest partitions into a single snapshot for display.
+// Most apps, cells, components and domains are owned by a single partition and the
+// snapshot copy is used as is.  The few that are updated by more than one partition
+// (e.g., the container metrics of a worker process are partitioned by process guid)
+// are merged into a new copy with their counters added together.  Event type counts
+// are always added together.  Caller must hold ep.mu
+func (ep *EventProcessor) mergeSnapshots(snapshots []*EventData) *EventData {
+
+	merged := snapshots[0]
+	if len(snapshots) > 1 {
+		merged = &EventData{
+			StatsTime:      snapshots[len(snapshots)-1].StatsTime,
+			AppMap:         make(map[string]*eventApp.AppStats),
+			CellMap:        make(map[string]*eventCell.CellStats),
+			DomainMap:      make(map[string]*eventRoute.DomainStats),
+			EventTypeMap:   make(map[events.Envelope_EventType]*eventEventType.EventTypeStats),
+			ComponentMap:   make(map[string]*eventComponent.ComponentStats),
+			eventProcessor: ep,
+		}
+
+		appStatsListMap := make(map[string][]*eventApp.AppStats)
+		cellStatsListMap := make(map[string][]*eventCell.CellStats)
+		componentStatsListMap := make(map[string][]*eventComponent.ComponentStats)
+		domainStatsListMap := make(map[string][]*eventRoute.DomainStats)
+		for _, snapshot := range snapshots {
+			merged.EnableRouteTracking = merged.EnableRouteTracking || snapshot.EnableRouteTracking
+			merged.TotalEvents = merged.TotalEvents + snapshot.TotalEvents
+			for appId, appStats := range snapshot.AppMap {
+				appStatsListMap[appId] = append(appStatsListMap[appId], appStats)
+			}
+			for cellIp, cellStats := range snapshot.CellMap {
+				cellStatsListMap[cellIp] = append(cellStatsListMap[cellIp], cellStats)
+			}
+			for key, componentStats := range snapshot.ComponentMap {
+				componentStatsListMap[key] = append(componentStatsListMap[key], componentStats)
+			}
+			// The event type stats of a snapshot are always a new copy so they can be updated
+			for eventType, eventTypeStats := range snapshot.EventTypeMap {
+				mergedEventTypeStats := merged.EventTypeMap[eventType]
+				if mergedEventTypeStats == nil {
+					merged.EventTypeMap[eventType] = eventTypeStats
+				} else {
+					mergedEventTypeStats.Merge(eventTypeStats)
+				}
+			}
+			for domain, domainStats := range snapshot.DomainMap {
+				domainStatsListMap[domain] = append(domainStatsListMap[domain], domainStats)
+			}
+		}
+
+		for appId, appStatsList := range appStatsListMap {
+			merged.AppMap[appId] = eventApp.MergeAppStats(appStatsList)
+		}
+		for cellIp, cellStatsList := range cellStatsListMap {
+			merged.CellMap[cellIp] = eventCell.MergeCellStats(cellStatsList)
+		}
+		for key, componentStatsList := range componentStatsListMap {
+			merged.ComponentMap[key] = eventComponent.MergeComponentStats(componentStatsList)
+		}
+		for domain, domainStatsList := range domainStatsListMap {
+			merged.DomainMap[domain] = eventRoute.MergeDomainStats(domainStatsList, config.MaxUserAgentBucket)
+		}
+	}
+
+	ep.assignCells(merged)
+	return merged
+}
+
+// Stack group and isolation segment of a cell found from an app container running on it
+type cellAssignment struct {
+	stackGroupId         string
+	isolationSegmentGuid string
+}
+
+// A cell and the apps running on it are usually in different partitions so the stack
+// group and isolation segment of a cell are assigned in the merged snapshot.  The
+// assignment is remembered so the apps are only searched for cells that are not
+// assigned yet.  The cells of a snapshot are always a new copy so they can be updated.
+// Caller must hold ep.mu
+func (ep *EventProcessor) assignCells(merged *EventData) {
+
+	unassigned := false
+	for cellIp, cellStats := range merged.CellMap {
+		if assignment := ep.cellAssignmentMap[cellIp]; assignment != nil {
+			cellStats.StackGroupId = assignment.stackGroupId
+			cellStats.IsolationSegmentGuid = assignment.isolationSegmentGuid
+		} else {
+			unassigned = true
+		}
+	}
+	if !unassigned {
+		return
+	}
+
+	// Key: container IP  Value: an app with a container on that cell
+	cellAppMap := make(map[string]string)
+	for _, appStats := range merged.AppMap {
+		for _, containerStats := range appStats.ContainerArray {
+			if containerStats != nil && containerStats.Ip != "" {
+				cellAppMap[containerStats.Ip] = appStats.AppId
+			}
+		}
+	}
+
+	mdMgr := ep.GetMetadataManager()
+	for cellIp, cellStats := range merged.CellMap {
+		appId := cellAppMap[cellIp]
+		if ep.cellAssignmentMap[cellIp] != nil || appId == "" {
+			continue
+		}
+		appMetadata := mdMgr.GetAppMdManager().FindItem(appId)
+		stackGroup := mdMgr.GetStackMdManager().FindStackGroupByStackGuid(appMetadata.StackGuid)
+		// stackGroup can be nil if the metadata hasn't been loaded yet.
+		if stackGroup == nil {
+			continue
+		}
+		spaceMetadata := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
+		assignment := &cellAssignment{
+			stackGroupId:         stackGroup.Guid,
+			isolationSegmentGuid: mdMgr.FindIsoSegBySpace(spaceMetadata).GetGuid(),
+		}
+		ep.cellAssignmentMap[cellIp] = assignment
+		cellStats.StackGroupId = assignment.stackGroupId
+		cellStats.IsolationSegmentGuid = assignment.isolationSegmentGuid
+	}
+}
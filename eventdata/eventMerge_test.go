@@ -0,0 +1,192 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventdata
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// Event processor with the given number of partitions (not started)
+func newTestPartitionedEventProcessor(partitionCount int) *EventProcessor {
+	ep := newBenchEventProcessor()
+	ep.partitions = make([]*EventData, partitionCount)
+	for i := range ep.partitions {
+		ep.partitions[i] = NewEventData(&sync.Mutex{}, ep)
+	}
+	return ep
+}
+
+var _ = Describe("EventProcessor partitions", func() {
+	appUUID := &events.UUID{Low: proto.Uint64(1), High: proto.Uint64(0)}
+	appId := formatUUID(appUUID)
+
+	httpStartStop := func(uri string, applicationId *events.UUID) *events.Envelope {
+		return &events.Envelope{EventType: events.Envelope_HttpStartStop.Enum(),
+			HttpStartStop: &events.HttpStartStop{ApplicationId: applicationId, Uri: proto.String(uri)}}
+	}
+
+	DescribeTable("partitionKey",
+		func(msg *events.Envelope, wantKey string) {
+			Expect(newTestPartitionedEventProcessor(4).partitionKey(msg)).To(Equal(wantKey))
+		},
+		Entry("container metric by process guid",
+			&events.Envelope{EventType: events.Envelope_ContainerMetric.Enum(),
+				ContainerMetric: &events.ContainerMetric{ApplicationId: proto.String("process-guid")}},
+			"process-guid"),
+		Entry("log message by app guid",
+			&events.Envelope{EventType: events.Envelope_LogMessage.Enum(), Index: proto.String("0"),
+				LogMessage: &events.LogMessage{AppId: proto.String(appId)}},
+			appId),
+		Entry("app request by app guid", httpStartStop("http://app1.apps.example.com/", appUUID), appId),
+		Entry("request without app by domain", httpStartStop("https://API.sys.example.com:443/v2/apps", nil), "domain|sys.example.com"),
+		Entry("request to an IP address", httpStartStop("10.0.0.1:8080/health", nil), "domain|"),
+		Entry("VM metric by BOSH instance",
+			&events.Envelope{EventType: events.Envelope_ValueMetric.Enum(), Ip: proto.String("10.0.0.2"),
+				Deployment: proto.String("cf"), Job: proto.String("diego_cell"), Index: proto.String("abc")},
+			"cf|diego_cell|abc"),
+		Entry("VM metric by IP",
+			&events.Envelope{EventType: events.Envelope_ValueMetric.Enum(), Ip: proto.String("10.0.0.2")},
+			"10.0.0.2"),
+	)
+
+	Describe("forEachPartition", func() {
+		var (
+			ep          *EventProcessor
+			appIndex    int
+			domain      string
+			domainIndex int
+		)
+
+		partitionScopes := func(msg *events.Envelope) map[int]httpStatsScope {
+			scopes := make(map[int]httpStatsScope)
+			ep.forEachPartition(msg, func(partitionIndex int, httpScope httpStatsScope) {
+				scopes[partitionIndex] = httpScope
+			})
+			return scopes
+		}
+
+		BeforeEach(func() {
+			ep = newTestPartitionedEventProcessor(64)
+			// A domain that is owned by another partition than the app
+			appIndex = ep.partitionIndexByKey(appId)
+			domain = ""
+			for i := 0; domain == "" || ep.partitionIndexByKey(domainPartitionKey(domain)) == appIndex; i++ {
+				domain = fmt.Sprintf("d%v.example.com", i)
+			}
+			domainIndex = ep.partitionIndexByKey(domainPartitionKey(domain))
+		})
+
+		It("splits an app request between the app and the domain partition", func() {
+			Expect(partitionScopes(httpStartStop("http://myapp."+domain+"/", appUUID))).To(Equal(
+				map[int]httpStatsScope{appIndex: httpStatsApp, domainIndex: httpStatsRoute}))
+		})
+		It("hands a request without an app to the domain partition only", func() {
+			Expect(partitionScopes(httpStartStop("http://myapp."+domain+"/", nil))).To(Equal(
+				map[int]httpStatsScope{domainIndex: httpStatsAll}))
+		})
+	})
+})
+
+var _ = Describe("Partition merge", func() {
+
+	Describe("MergeAppStats", func() {
+		metricTime := time.Now()
+		olderTime := metricTime.Add(-time.Minute)
+
+		workerContainer := func(index int, outCount int64, updateTime *time.Time) *eventApp.ContainerStats {
+			containerStats := eventApp.NewProcessContainerStats("worker", index)
+			containerStats.OutCount = outCount
+			containerStats.LastUpdateTime = updateTime
+			containerStats.LastMetricUpdateTime = updateTime
+			containerStats.ContainerMetric = &events.ContainerMetric{InstanceIndex: proto.Int32(int32(index))}
+			return containerStats
+		}
+
+		It("uses the stats of a single partition as is", func() {
+			merged := eventApp.MergeAppStats([]*eventApp.AppStats{
+				{AppId: "app", NonContainerStdout: 3,
+					ProcessContainerMap: map[string][]*eventApp.ContainerStats{"worker": {workerContainer(0, 5, &metricTime)}}},
+			})
+			Expect(merged.NonContainerStdout).To(BeEquivalentTo(3))
+			Expect(merged.ProcessContainerMap["worker"]).To(HaveLen(1))
+			Expect(merged.ProcessContainerMap["worker"][0].OutCount).To(BeEquivalentTo(5))
+		})
+
+		It("adds the counters of the partitions without modifying them", func() {
+			first := workerContainer(0, 5, &olderTime)
+			merged := eventApp.MergeAppStats([]*eventApp.AppStats{
+				{AppId: "app", NonContainerStdout: 3,
+					ProcessContainerMap: map[string][]*eventApp.ContainerStats{"worker": {first}}},
+				{AppId: "app", NonContainerStdout: 4,
+					ProcessContainerMap: map[string][]*eventApp.ContainerStats{"worker": {workerContainer(0, 2, &metricTime), workerContainer(1, 1, &metricTime)}}},
+			})
+			Expect(merged.NonContainerStdout).To(BeEquivalentTo(7))
+			containers := merged.ProcessContainerMap["worker"]
+			Expect(containers).To(HaveLen(2))
+			Expect(containers[0].OutCount).To(BeEquivalentTo(7))
+			Expect(*containers[0].LastMetricUpdateTime).To(BeTemporally("==", metricTime))
+			Expect(first.OutCount).To(BeEquivalentTo(5))
+		})
+	})
+
+	Describe("MergeDomainStats", func() {
+		domainStats := func(appId string, requestCount int64) *eventRoute.DomainStats {
+			domainStats := eventRoute.NewDomainStats(eventRoute.OTHER)
+			hostStats := eventRoute.NewHostStats(eventRoute.OTHER)
+			domainStats.HostStatsMap[eventRoute.OTHER] = hostStats
+			appRouteStats := eventRoute.NewAppRouteStats(appId)
+			httpMethodStats := eventRoute.NewHttpMethodStats(events.Method_GET)
+			httpMethodStats.RequestCount = requestCount
+			appRouteStats.HttpMethodStatsMap[events.Method_GET] = httpMethodStats
+			hostStats.AddPath("", "route", true).AppRouteStatsMap[appId] = appRouteStats
+			return domainStats
+		}
+
+		requestCount := func(domainStats *eventRoute.DomainStats, appId string) int64 {
+			appRouteStats := domainStats.HostStatsMap[eventRoute.OTHER].RouteStatsMap[""].FindAppRouteStats(appId)
+			if appRouteStats == nil {
+				return 0
+			}
+			return appRouteStats.HttpMethodStatsMap[events.Method_GET].RequestCount
+		}
+
+		It("uses the stats of a single partition as is", func() {
+			merged := eventRoute.MergeDomainStats([]*eventRoute.DomainStats{domainStats("app1", 2)}, 10)
+			Expect(requestCount(merged, "app1")).To(BeEquivalentTo(2))
+		})
+		It("adds the requests of the same app without modifying the partitions", func() {
+			first := domainStats("app1", 2)
+			merged := eventRoute.MergeDomainStats([]*eventRoute.DomainStats{first, domainStats("app1", 3)}, 10)
+			Expect(requestCount(merged, "app1")).To(BeEquivalentTo(5))
+			Expect(requestCount(first, "app1")).To(BeEquivalentTo(2))
+		})
+		It("keeps the requests of different apps", func() {
+			merged := eventRoute.MergeDomainStats([]*eventRoute.DomainStats{domainStats("app1", 2), domainStats("app2", 3)}, 10)
+			Expect(requestCount(merged, "app1")).To(BeEquivalentTo(2))
+			Expect(requestCount(merged, "app2")).To(BeEquivalentTo(3))
+		})
+	})
+})
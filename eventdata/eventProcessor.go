@@ -16,6 +16,7 @@
 package eventdata
 
 import (
+	"fmt"
 	"regexp"
 	"sync"
 	"time"
@@ -25,7 +26,9 @@ import (
 	"strings"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventLog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/domain"
@@ -34,9 +37,13 @@ import (
 )
 
 type EventProcessor struct {
-	eventCount         int64
-	mu                 *sync.Mutex
-	currentEventData   *EventData
+	eventCount int64
+	// Serializes taking snapshots (UpdateData), seeding and clearing the stats.  The
+	// live event data of each partition is guarded by the lock of the partition.
+	mu *sync.Mutex
+	// Live event data is partitioned (see eventIngest.go)
+	partitions         []*EventData
+	partitionQueues    []*ingestQueue
 	displayedEventData *EventData
 	cliConnection      plugin.CliConnection
 	privileged         bool
@@ -46,25 +53,50 @@ type EventProcessor struct {
 
 	eventRateCounterMap     map[events.Envelope_EventType]*util.RateCounter
 	eventRateCounterMapLock sync.Mutex
+
+	// Recent log lines of monitored apps.  Kept outside of EventData so they
+	// are not copied on every display snapshot
+	appLogBufferMap     map[string]*eventLog.LogBuffer
+	appLogBufferMapLock sync.Mutex
+
+	// Key: nozzle instanceId
+	nozzleQueueMap     map[int]*ingestQueue
+	nozzleQueueMapLock sync.RWMutex
+
+	// Keeps the live stats within config.MaxStatsMemoryMB (see eventMemory.go)
+	memoryGovernor memoryGovernor
+
+	// Key: cell IP.  Guarded by mu (see assignCells)
+	cellAssignmentMap map[string]*cellAssignment
 }
 
 func NewEventProcessor(cliConnection plugin.CliConnection, privileged bool, statusMsg chan string) *EventProcessor {
 
-	mu := &sync.Mutex{}
-
 	metadataManager := metadata.NewGlobalManager(cliConnection, statusMsg)
 
 	ep := &EventProcessor{
-		mu:                  mu,
+		mu:                  &sync.Mutex{},
 		cliConnection:       cliConnection,
 		privileged:          privileged,
 		metadataManager:     metadataManager,
 		eventRateCounterMap: make(map[events.Envelope_EventType]*util.RateCounter),
+		appLogBufferMap:     make(map[string]*eventLog.LogBuffer),
+		nozzleQueueMap:      make(map[int]*ingestQueue),
 		statusMsg:           statusMsg,
+		cellAssignmentMap:   make(map[string]*cellAssignment),
 	}
 
-	ep.currentEventData = NewEventData(mu, ep)
-	ep.displayedEventData = NewEventData(mu, ep)
+	partitionCount := ingestPartitionCount()
+	ep.partitions = make([]*EventData, partitionCount)
+	ep.partitionQueues = make([]*ingestQueue, partitionCount)
+	for i := 0; i < partitionCount; i++ {
+		ep.partitions[i] = NewEventData(&sync.Mutex{}, ep)
+		ep.partitionQueues[i] = newIngestQueue(fmt.Sprintf("Partition #%v", i), INGEST_QUEUE_PARTITION, i, config.IngestPartitionQueueSize)
+	}
+	ep.startPartitionWorkers()
+	ep.startMemoryGovernor()
+
+	ep.displayedEventData = NewEventData(&sync.Mutex{}, ep)
 	ep.eventRateHistory = NewEventRateHistory(ep)
 	ep.eventRateHistory.start()
 	return ep
@@ -82,17 +114,13 @@ func (ep *EventProcessor) Process(instanceId int, msg *events.Envelope) {
 	}
 	ep.eventRateCounterMapLock.Unlock()
 	eventCounter.Incr()
-	ep.currentEventData.Process(instanceId, msg)
+	ep.enqueue(instanceId, msg)
 }
 
 func (ep *EventProcessor) GetCliConnection() plugin.CliConnection {
 	return ep.cliConnection
 }
 
-func (ep *EventProcessor) GetCurrentEventData() *EventData {
-	return ep.currentEventData
-}
-
 func (ep *EventProcessor) GetDisplayedEventData() *EventData {
 	return ep.displayedEventData
 }
@@ -105,12 +133,61 @@ func (ep *EventProcessor) GetMetadataManager() *metadata.GlobalManager {
 	return ep.metadataManager
 }
 
+// Get the recent log lines buffer of an app.  Returns nil if no lines have been
+// buffered (only apps that are monitored have their log lines buffered).
+func (ep *EventProcessor) GetAppLogBuffer(appId string) *eventLog.LogBuffer {
+	ep.appLogBufferMapLock.Lock()
+	defer ep.appLogBufferMapLock.Unlock()
+	return ep.appLogBufferMap[appId]
+}
+
+func (ep *EventProcessor) bufferLogMessage(appId string, logMessage *events.LogMessage) {
+	if !ep.metadataManager.IsMaybeMonitorAppDetails(appId) {
+		// Most apps are not monitored -- skip the locks.  Buffers of apps that
+		// are no longer monitored are released by releaseLogBuffers
+		return
+	}
+	ep.appLogBufferMapLock.Lock()
+	defer ep.appLogBufferMapLock.Unlock()
+	logBuffer := ep.appLogBufferMap[appId]
+	if !ep.metadataManager.IsMonitorAppDetails(appId) {
+		if logBuffer != nil {
+			// App is no longer monitored -- release the buffer
+			delete(ep.appLogBufferMap, appId)
+		}
+		return
+	}
+	if logBuffer == nil {
+		logBuffer = eventLog.NewLogBuffer(config.MaxAppLogLineHistory)
+		ep.appLogBufferMap[appId] = logBuffer
+	}
+	logBuffer.Add(eventLog.NewLogLine(logMessage))
+}
+
+// Release the log buffers of apps that are no longer monitored
+func (ep *EventProcessor) releaseLogBuffers() {
+	ep.appLogBufferMapLock.Lock()
+	defer ep.appLogBufferMapLock.Unlock()
+	for appId := range ep.appLogBufferMap {
+		if !ep.metadataManager.IsMonitorAppDetails(appId) {
+			delete(ep.appLogBufferMap, appId)
+		}
+	}
+}
+
 func (ep *EventProcessor) UpdateData() {
 
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
 	//toplog.Info("Current ep: %v", ep.currentEventData.eventProcessor)
 
-	eventDataCopy := ep.currentEventData.Clone()
-	ep.displayedEventData = eventDataCopy
+	snapshots := make([]*EventData, len(ep.partitions))
+	for i, partition := range ep.partitions {
+		snapshots[i] = partition.Clone()
+	}
+	ep.displayedEventData = ep.mergeSnapshots(snapshots)
+	ep.releaseLogBuffers()
 
 	//toplog.Info("Display ep: %v", eventDataCopy.eventProcessor)
 
@@ -145,6 +222,11 @@ func (ep *EventProcessor) SeedStatsFromMetadata() {
 	ep.mu.Lock()
 	defer ep.mu.Unlock()
 
+	// Apps are seeded in the partition that owns the app and routes in the partition
+	// that owns the domain so every partition is locked while seeding
+	for _, partition := range ep.partitions {
+		partition.mu.Lock()
+	}
 	ep.seedAppMap()
 	ep.seedDomainShared()
 	ep.seedDomainPrivate()
@@ -152,15 +234,25 @@ func (ep *EventProcessor) SeedStatsFromMetadata() {
 	if ep.privileged {
 		ep.seedSpecialRouteData()
 	}
+	for _, partition := range ep.partitions {
+		partition.EnableRouteTracking = true
+		partition.mu.Unlock()
+	}
+}
 
-	ep.currentEventData.EnableRouteTracking = true
+func (ep *EventProcessor) appPartition(appId string) *EventData {
+	return ep.partitions[ep.partitionIndexByKey(appId)]
+}
+
+func (ep *EventProcessor) domainPartition(domain string) *EventData {
+	return ep.partitions[ep.partitionIndexByKey(domainPartitionKey(domain))]
 }
 
 func (ep *EventProcessor) seedAppMap() {
 
-	currentStatsMap := ep.currentEventData.AppMap
 	for _, app := range ep.metadataManager.GetAppMdManager().AllApps() {
 		appId := app.Guid
+		currentStatsMap := ep.appPartition(appId).AppMap
 		appStats := currentStatsMap[appId]
 		if appStats == nil {
 			// New app we haven't seen yet
@@ -179,8 +271,8 @@ func (ep *EventProcessor) seedDomainPrivate() {
 }
 
 func (ep *EventProcessor) seedDomain(domains []*domain.DomainMetadata) {
-	currentStatsMap := ep.currentEventData.DomainMap
 	for _, domain := range domains {
+		currentStatsMap := ep.domainPartition(domain.Name).DomainMap
 		domainStats := currentStatsMap[domain.Name]
 		if domainStats == nil {
 			// New domain we haven't seen yet
@@ -194,7 +286,7 @@ func (ep *EventProcessor) seedRouteData() {
 
 	for _, route := range ep.GetMetadataManager().GetRouteMdManager().GetAll() {
 		domainMd := ep.GetMetadataManager().GetDomainFinder().FindDomainMetadata(route.DomainGuid)
-		ep.addRoute(domainMd.Name, route.Host, route.Path, route.Port, route.Guid)
+		ep.addRoute(ep.domainPartition(domainMd.Name), domainMd.Name, route.Host, route.Path, route.Port, route.Guid)
 	}
 }
 
@@ -202,6 +294,7 @@ func (ep *EventProcessor) seedSpecialRouteData() {
 
 	// Seed special host names
 	apiDomain, apiHost := ep.getAPIHostAndDomain()
+	partition := ep.domainPartition(apiDomain)
 
 	// Register all documented APIs from https://apidocs.cloudfoundry.org/249/
 	// TODO: Add a way to dynamically add level-n paths as seen during runtime
@@ -245,17 +338,17 @@ func (ep *EventProcessor) seedSpecialRouteData() {
 	}
 
 	for _, registerPath := range registerApiPaths {
-		ep.addInternalRoute(apiDomain, apiHost, registerPath, 0)
+		ep.addInternalRoute(partition, apiDomain, apiHost, registerPath, 0)
 	}
 
-	ep.addInternalRoute(apiDomain, "uaa", "", 0)
-	ep.addInternalRoute(apiDomain, "uaa", "/oauth/token", 0)
-	ep.addInternalRoute(apiDomain, "doppler", "", 0)
-	ep.addInternalRoute(apiDomain, "doppler", "/apps", 0)
+	ep.addInternalRoute(partition, apiDomain, "uaa", "", 0)
+	ep.addInternalRoute(partition, apiDomain, "uaa", "/oauth/token", 0)
+	ep.addInternalRoute(partition, apiDomain, "doppler", "", 0)
+	ep.addInternalRoute(partition, apiDomain, "doppler", "/apps", 0)
 
 }
 
-func (ep *EventProcessor) addInternalRoute(domainName string, hostName string, pathName string, port int) *eventRoute.RouteStats {
+func (ep *EventProcessor) addInternalRoute(partition *EventData, domainName string, hostName string, pathName string, port int) *eventRoute.RouteStats {
 	domainItem := ep.GetMetadataManager().GetDomainFinder().FindDomainMetadataByName(domainName)
 	if domainItem == nil {
 		domainItem = ep.GetMetadataManager().GetDomainPrivateMdManager().AddDomainMetadata(domainName)
@@ -264,15 +357,16 @@ func (ep *EventProcessor) addInternalRoute(domainName string, hostName string, p
 		//return nil
 	}
 	route := ep.GetMetadataManager().GetRouteMdManager().CreateInternalGeneratedRoute(hostName, pathName, domainItem.Guid, port)
-	return ep.addRoute(domainName, hostName, pathName, port, route.Guid)
+	return ep.addRoute(partition, domainName, hostName, pathName, port, route.Guid)
 }
 
-func (ep *EventProcessor) addRoute(domain string, host string, path string, port int, routeGuid string) *eventRoute.RouteStats {
+func (ep *EventProcessor) addRoute(partition *EventData, domain string, host string, path string, port int, routeGuid string) *eventRoute.RouteStats {
 
 	domain = strings.ToLower(domain)
 	host = strings.ToLower(host)
 
-	currentDomainStatsMap := ep.currentEventData.DomainMap
+	partition.markDomainDirty(domain)
+	currentDomainStatsMap := partition.DomainMap
 	domainStats := currentDomainStatsMap[domain]
 	if domainStats == nil {
 		// New domain we haven't seen yet
@@ -314,7 +408,12 @@ func (ep *EventProcessor) getAPIHostAndDomain() (domain, host string) {
 
 func (ep *EventProcessor) ClearStats() error {
 	toplog.Info("EventProcessor>ClearStats")
-	ep.currentEventData.Clear()
+	for _, partition := range ep.partitions {
+		partition.Clear()
+	}
+	ep.mu.Lock()
+	ep.cellAssignmentMap = make(map[string]*cellAssignment)
+	ep.mu.Unlock()
 	ep.UpdateData()
 	ep.SeedStatsFromMetadata()
 	return nil
@@ -15,7 +15,11 @@
 
 package eventRoute
 
-import "github.com/cloudfoundry/sonde-go/events"
+import (
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+)
 
 // Used as an overflow key when too many values are in map
 // E.g., if too many values in UserAgent map, use OTHER bucket
@@ -31,6 +35,8 @@ type AppRouteStats struct {
 
 	// Good idea??
 	UserAgentMap map[string]int64
+	// Only tracked in the live stats (not copied to snapshots)
+	userAgentLastAccess map[string]time.Time
 }
 
 func NewAppRouteStats(appId string) *AppRouteStats {
@@ -44,3 +50,74 @@ func NewAppRouteStats(appId string) *AppRouteStats {
 func (ars *AppRouteStats) FindHttpMethodStats(httpMethod events.Method) *HttpMethodStats {
 	return ars.HttpMethodStatsMap[httpMethod]
 }
+
+// Count a request from userAgent.  If the map already holds maxUserAgents the least
+// recently seen user agents are folded into OTHER.  Returns the number evicted.
+func (ars *AppRouteStats) CountUserAgent(userAgent string, accessTime time.Time, maxUserAgents int) int {
+	evicted := 0
+	if _, ok := ars.UserAgentMap[userAgent]; !ok {
+		for len(ars.UserAgentMap) >= maxUserAgents && ars.evictLeastRecentUserAgent() {
+			evicted++
+		}
+	}
+	ars.UserAgentMap[userAgent] = ars.UserAgentMap[userAgent] + 1
+	if ars.userAgentLastAccess == nil {
+		ars.userAgentLastAccess = make(map[string]time.Time)
+	}
+	ars.userAgentLastAccess[userAgent] = accessTime
+	return evicted
+}
+
+func (ars *AppRouteStats) evictLeastRecentUserAgent() bool {
+	evictUserAgent := ""
+	var evictLastAccess time.Time
+	found := false
+	for userAgent := range ars.UserAgentMap {
+		if userAgent == OTHER {
+			continue
+		}
+		lastAccess := ars.userAgentLastAccess[userAgent]
+		if !found || lastAccess.Before(evictLastAccess) {
+			evictUserAgent = userAgent
+			evictLastAccess = lastAccess
+			found = true
+		}
+	}
+	if !found {
+		return false
+	}
+	ars.UserAgentMap[OTHER] = ars.UserAgentMap[OTHER] + ars.UserAgentMap[evictUserAgent]
+	delete(ars.UserAgentMap, evictUserAgent)
+	delete(ars.userAgentLastAccess, evictUserAgent)
+	return true
+}
+
+// Add the request counts of other (an evicted route) to this app route.  User agents
+// that don't fit within maxUserAgents are counted as OTHER.
+func (ars *AppRouteStats) AddCounts(other *AppRouteStats, maxUserAgents int) {
+	for method, otherHttpMethodStats := range other.HttpMethodStatsMap {
+		httpMethodStats := ars.HttpMethodStatsMap[method]
+		if httpMethodStats == nil {
+			httpMethodStats = NewHttpMethodStats(method)
+			ars.HttpMethodStatsMap[method] = httpMethodStats
+		}
+		httpMethodStats.AddCounts(otherHttpMethodStats)
+	}
+	for userAgent, count := range other.UserAgentMap {
+		if _, ok := ars.UserAgentMap[userAgent]; !ok && len(ars.UserAgentMap) >= maxUserAgents {
+			userAgent = OTHER
+		}
+		ars.UserAgentMap[userAgent] = ars.UserAgentMap[userAgent] + count
+	}
+}
+
+// Most recent request of any http method
+func (ars *AppRouteStats) LastAccess() time.Time {
+	var lastAccess time.Time
+	for _, httpMethodStats := range ars.HttpMethodStatsMap {
+		if lastAccess.Before(httpMethodStats.LastAccess) {
+			lastAccess = httpMethodStats.LastAccess
+		}
+	}
+	return lastAccess
+}
@@ -34,3 +34,24 @@ func NewDomainStats(domainId string) *DomainStats {
 func (ds *DomainStats) Id() string {
 	return ds.DomainId
 }
+
+// New domain stats with the hosts and routes of all the given stats of the same domain
+// (from different ingest partitions).  A domain is owned by one partition so this is
+// only needed for the OTHER domain.  The given stats are not modified.
+func MergeDomainStats(domainStatsList []*DomainStats, maxUserAgents int) *DomainStats {
+	if len(domainStatsList) == 1 {
+		return domainStatsList[0]
+	}
+	merged := NewDomainStats(domainStatsList[0].DomainId)
+	for _, domainStats := range domainStatsList {
+		for host, hostStats := range domainStats.HostStatsMap {
+			mergedHostStats := merged.HostStatsMap[host]
+			if mergedHostStats == nil {
+				mergedHostStats = NewHostStats(host)
+				merged.HostStatsMap[host] = mergedHostStats
+			}
+			mergedHostStats.addRoutes(hostStats, maxUserAgents)
+		}
+	}
+	return merged
+}
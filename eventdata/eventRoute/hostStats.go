@@ -18,6 +18,7 @@ package eventRoute
 import (
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 )
@@ -40,6 +41,10 @@ type HostStats struct {
 
 	// index of paths where the best match is first (longest path first)
 	routeIndex []string
+
+	// Added because a request was seen (not seeded from route metadata).  Only
+	// dynamically added hosts are evicted when memory is short.
+	dynamic bool
 }
 
 func NewHostStats(hostName string) *HostStats {
@@ -50,6 +55,32 @@ func NewHostStats(hostName string) *HostStats {
 	return stats
 }
 
+func NewDynamicHostStats(hostName string) *HostStats {
+	stats := NewHostStats(hostName)
+	stats.dynamic = true
+	return stats
+}
+
+func (hs *HostStats) IsDynamic() bool {
+	return hs.dynamic
+}
+
+// Most recent request to any route of this host
+func (hs *HostStats) LastAccess() time.Time {
+	var lastAccess time.Time
+	for _, routeStats := range hs.RouteStatsMap {
+		if routeLastAccess := routeStats.LastAccess(); lastAccess.Before(routeLastAccess) {
+			lastAccess = routeLastAccess
+		}
+	}
+	for _, routeStats := range hs.TcpRouteStatsMap {
+		if routeLastAccess := routeStats.LastAccess(); lastAccess.Before(routeLastAccess) {
+			lastAccess = routeLastAccess
+		}
+	}
+	return lastAccess
+}
+
 func (hs *HostStats) AddPort(port int, routeId string, ignoreOnExists bool) *RouteStats {
 	routeStats := hs.TcpRouteStatsMap[port]
 	if routeStats == nil {
@@ -88,6 +119,16 @@ func (hs *HostStats) AddPathDynamic(fullPath string, routeId string) *RouteStats
 	return rs
 }
 
+// Add the routes (and the app stats of each route) of another host stats
+func (hs *HostStats) addRoutes(other *HostStats, maxUserAgents int) {
+	for path, routeStats := range other.RouteStatsMap {
+		hs.AddPath(path, routeStats.RouteId, true).addAppRouteStats(routeStats, maxUserAgents)
+	}
+	for port, routeStats := range other.TcpRouteStatsMap {
+		hs.AddPort(port, routeStats.RouteId, true).addAppRouteStats(routeStats, maxUserAgents)
+	}
+}
+
 // Build index of paths where the best match is first
 func (hs *HostStats) rebuildPathIndex() {
 
@@ -64,3 +64,20 @@ func NewHttpMethodStats(httpMethod events.Method) *HttpMethodStats {
 	stats.Forwarder = make(map[string]int64)
 	return stats
 }
+
+// Add the counts of other to this one.  The response time averages are not
+// combined, they only reflect the requests counted directly.
+func (hms *HttpMethodStats) AddCounts(other *HttpMethodStats) {
+	if hms.LastAccess.Before(other.LastAccess) {
+		hms.LastAccess = other.LastAccess
+	}
+	hms.RequestCount = hms.RequestCount + other.RequestCount
+	for statusCode, count := range other.HttpStatusCode {
+		hms.HttpStatusCode[statusCode] = hms.HttpStatusCode[statusCode] + count
+	}
+	for forwarder, count := range other.Forwarder {
+		hms.Forwarder[forwarder] = hms.Forwarder[forwarder] + count
+	}
+	hms.ResponseContentLength = hms.ResponseContentLength + other.ResponseContentLength
+	hms.RequestContentLength = hms.RequestContentLength + other.RequestContentLength
+}
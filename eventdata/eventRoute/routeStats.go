@@ -15,6 +15,8 @@
 
 package eventRoute
 
+import "time"
+
 type RouteSlice []*RouteStats
 
 type RouteStats struct {
@@ -38,3 +40,42 @@ func (rs *RouteStats) Id() string {
 func (rs *RouteStats) FindAppRouteStats(appId string) *AppRouteStats {
 	return rs.AppRouteStatsMap[appId]
 }
+
+// Add the app stats of another route stats (of a different ingest partition).  App
+// stats in both are combined into a new AppRouteStats; other is not modified.
+func (rs *RouteStats) addAppRouteStats(other *RouteStats, maxUserAgents int) {
+	for appId, otherAppRouteStats := range other.AppRouteStatsMap {
+		appRouteStats := rs.AppRouteStatsMap[appId]
+		if appRouteStats == nil {
+			rs.AppRouteStatsMap[appId] = otherAppRouteStats
+			continue
+		}
+		mergedAppRouteStats := NewAppRouteStats(appId)
+		mergedAppRouteStats.AddCounts(appRouteStats, maxUserAgents)
+		mergedAppRouteStats.AddCounts(otherAppRouteStats, maxUserAgents)
+		rs.AppRouteStatsMap[appId] = mergedAppRouteStats
+	}
+}
+
+// Add the request counts of other (an evicted route) to this route
+func (rs *RouteStats) AddCounts(other *RouteStats, maxUserAgents int) {
+	for appId, otherAppRouteStats := range other.AppRouteStatsMap {
+		appRouteStats := rs.AppRouteStatsMap[appId]
+		if appRouteStats == nil {
+			appRouteStats = NewAppRouteStats(appId)
+			rs.AppRouteStatsMap[appId] = appRouteStats
+		}
+		appRouteStats.AddCounts(otherAppRouteStats, maxUserAgents)
+	}
+}
+
+// Most recent request to any app on this route
+func (rs *RouteStats) LastAccess() time.Time {
+	var lastAccess time.Time
+	for _, appRouteStats := range rs.AppRouteStatsMap {
+		if appLastAccess := appRouteStats.LastAccess(); lastAccess.Before(appLastAccess) {
+			lastAccess = appLastAccess
+		}
+	}
+	return lastAccess
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventdata
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventComponent"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventEventType"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
+	"github.com/mohae/deepcopy"
+)
+
+// Tracks which apps and domains have changed since the last snapshot was taken
+// so that a snapshot only needs to deep copy the changed entities.  Unchanged
+// entities reuse the copy that was made for the previous snapshot.
+//
+// This is safe because a copy is never modified once it is part of a snapshot.
+// Clone refreshes the time based values (rates, stale containers) on the live
+// data and marks the app dirty when they change so a new copy is made.
+type snapshotTracker struct {
+	// Key: appId
+	dirtyAppMap map[string]bool
+	// Key: domain name (lower case)
+	dirtyDomainMap map[string]bool
+	lastSnapshot   *EventData
+}
+
+func newSnapshotTracker() *snapshotTracker {
+	return &snapshotTracker{
+		dirtyAppMap:    make(map[string]bool),
+		dirtyDomainMap: make(map[string]bool),
+	}
+}
+
+// Caller must hold ed.mu
+func (ed *EventData) markAppDirty(appId string) {
+	ed.snapshot.dirtyAppMap[appId] = true
+}
+
+// Caller must hold ed.mu
+func (ed *EventData) markDomainDirty(domain string) {
+	ed.snapshot.dirtyDomainMap[strings.ToLower(domain)] = true
+}
+
+// Copy the event data reusing the entities of the last snapshot that have not
+// changed.  The cell, component and event type maps are bounded by the size of
+// the platform (not the number of apps) so they are always copied.
+// Caller must hold ed.mu
+func (ed *EventData) snapshotCopy() *EventData {
+
+	lastSnapshot := ed.snapshot.lastSnapshot
+
+	clone := &EventData{
+		AppMap:              make(map[string]*eventApp.AppStats, len(ed.AppMap)),
+		CellMap:             deepcopy.Copy(ed.CellMap).(map[string]*eventCell.CellStats),
+		DomainMap:           make(map[string]*eventRoute.DomainStats, len(ed.DomainMap)),
+		EventTypeMap:        deepcopy.Copy(ed.EventTypeMap).(map[events.Envelope_EventType]*eventEventType.EventTypeStats),
+		ComponentMap:        deepcopy.Copy(ed.ComponentMap).(map[string]*eventComponent.ComponentStats),
+		AppRouteStatsMap:    deepcopy.Copy(ed.AppRouteStatsMap).(map[string]*eventRoute.AppRouteStats),
+		EnableRouteTracking: ed.EnableRouteTracking,
+		TotalEvents:         ed.TotalEvents,
+	}
+
+	for appId, appStats := range ed.AppMap {
+		var clonedAppStats *eventApp.AppStats
+		if lastSnapshot != nil && !ed.snapshot.dirtyAppMap[appId] {
+			clonedAppStats = lastSnapshot.AppMap[appId]
+		}
+		if clonedAppStats == nil {
+			clonedAppStats = deepcopy.Copy(appStats).(*eventApp.AppStats)
+		}
+		clone.AppMap[appId] = clonedAppStats
+	}
+
+	for domain, domainStats := range ed.DomainMap {
+		var clonedDomainStats *eventRoute.DomainStats
+		// Domain keys are lower case except for the OTHER domain
+		if lastSnapshot != nil && !ed.snapshot.dirtyDomainMap[strings.ToLower(domain)] {
+			clonedDomainStats = lastSnapshot.DomainMap[domain]
+		}
+		if clonedDomainStats == nil {
+			clonedDomainStats = deepcopy.Copy(domainStats).(*eventRoute.DomainStats)
+		}
+		clone.DomainMap[domain] = clonedDomainStats
+	}
+
+	return clone
+}
+
+// Remember the snapshot just taken and start tracking changes from here.
+// Caller must hold ed.mu
+func (ed *EventData) snapshotTaken(clone *EventData) {
+	for appId := range ed.snapshot.dirtyAppMap {
+		ed.memory.appLastActive[appId] = clone.StatsTime
+	}
+	ed.snapshot.lastSnapshot = clone
+	ed.snapshot.dirtyAppMap = make(map[string]bool)
+	ed.snapshot.dirtyDomainMap = make(map[string]bool)
+}
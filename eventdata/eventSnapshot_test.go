@@ -0,0 +1,336 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventdata
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/cli/plugin/pluginfakes"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/gogo/protobuf/proto"
+	"github.com/mohae/deepcopy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// Size of the synthetic foundation used by the snapshot benchmarks
+const (
+	benchAppCount       = 2000
+	benchInstanceCount  = 4
+	benchCellCount      = 50
+	benchActiveAppCount = benchAppCount / 10
+)
+
+func newBenchEventProcessor() *EventProcessor {
+	fakeCliConnection := &pluginfakes.FakeCliConnection{}
+	fakeCliConnection.ApiEndpointReturns("https://api.sys.example.com", nil)
+	ep := NewEventProcessor(fakeCliConnection, false, make(chan string, 1000))
+	for _, partition := range ep.partitions {
+		partition.EnableRouteTracking = true
+	}
+	return ep
+}
+
+// Process the envelope on the calling goroutine (bypassing the ingest queues)
+func processNow(ep *EventProcessor, msg *events.Envelope) {
+	ep.forEachPartition(msg, func(partitionIndex int, httpScope httpStatsScope) {
+		ep.partitions[partitionIndex].process(0, msg, httpScope)
+	})
+}
+
+// Generate one round of firehose traffic for apps [firstApp, lastApp): a container
+// metric, an HTTP request and a log line for every app instance
+func syntheticFirehose(firstApp int, lastApp int) []*events.Envelope {
+	now := time.Now().UnixNano()
+	envelopes := make([]*events.Envelope, 0, (lastApp-firstApp)*benchInstanceCount*3)
+	for appNum := firstApp; appNum < lastApp; appNum++ {
+		appUUID := &events.UUID{Low: proto.Uint64(uint64(appNum + 1)), High: proto.Uint64(0)}
+		appId := formatUUID(appUUID)
+		for instNum := 0; instNum < benchInstanceCount; instNum++ {
+			cellIp := fmt.Sprintf("10.0.0.%v", (appNum+instNum)%benchCellCount+1)
+			envelopes = append(envelopes, &events.Envelope{
+				Origin:    proto.String("rep"),
+				EventType: events.Envelope_ContainerMetric.Enum(),
+				Timestamp: proto.Int64(now),
+				Ip:        proto.String(cellIp),
+				ContainerMetric: &events.ContainerMetric{
+					ApplicationId:    proto.String(appId),
+					InstanceIndex:    proto.Int32(int32(instNum)),
+					CpuPercentage:    proto.Float64(12.5),
+					MemoryBytes:      proto.Uint64(256 * 1024 * 1024),
+					DiskBytes:        proto.Uint64(128 * 1024 * 1024),
+					MemoryBytesQuota: proto.Uint64(1024 * 1024 * 1024),
+					DiskBytesQuota:   proto.Uint64(1024 * 1024 * 1024),
+				},
+			})
+			envelopes = append(envelopes, &events.Envelope{
+				Origin:    proto.String("gorouter"),
+				EventType: events.Envelope_HttpStartStop.Enum(),
+				Timestamp: proto.Int64(now),
+				HttpStartStop: &events.HttpStartStop{
+					StartTimestamp: proto.Int64(now - int64(20*time.Millisecond)),
+					StopTimestamp:  proto.Int64(now),
+					PeerType:       events.PeerType_Client.Enum(),
+					Method:         events.Method_GET.Enum(),
+					Uri:            proto.String(fmt.Sprintf("http://app%v.apps.example.com/", appNum)),
+					UserAgent:      proto.String("bench"),
+					StatusCode:     proto.Int32(200),
+					ContentLength:  proto.Int64(512),
+					ApplicationId:  appUUID,
+					InstanceIndex:  proto.Int32(int32(instNum)),
+					InstanceId:     proto.String(fmt.Sprintf("%v-%v", appId, instNum)),
+				},
+			})
+			envelopes = append(envelopes, &events.Envelope{
+				Origin:    proto.String("rep"),
+				EventType: events.Envelope_LogMessage.Enum(),
+				Timestamp: proto.Int64(now),
+				LogMessage: &events.LogMessage{
+					Message:        []byte("GET / 200"),
+					MessageType:    events.LogMessage_OUT.Enum(),
+					Timestamp:      proto.Int64(now),
+					AppId:          proto.String(appId),
+					SourceType:     proto.String("APP/PROC/WEB"),
+					SourceInstance: proto.String(fmt.Sprintf("%v", instNum)),
+				},
+			})
+		}
+	}
+	return envelopes
+}
+
+// Event processor with every app of the synthetic foundation seen at least once
+// and an initial snapshot taken
+func newPopulatedBenchEventProcessor() *EventProcessor {
+	ep := newBenchEventProcessor()
+	for _, msg := range syntheticFirehose(0, benchAppCount) {
+		processNow(ep, msg)
+	}
+	ep.UpdateData()
+	return ep
+}
+
+// Ingest rate of a single partition (events/sec) with no snapshots being taken
+func BenchmarkEventDataProcess(b *testing.B) {
+	ep := newPopulatedBenchEventProcessor()
+	envelopes := syntheticFirehose(0, benchAppCount)
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		processNow(ep, envelopes[i%len(envelopes)])
+	}
+	b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "events/sec")
+}
+
+// Snapshot latency when a tenth of the apps have changed since the last snapshot
+func BenchmarkEventDataClone(b *testing.B) {
+	ep := newPopulatedBenchEventProcessor()
+	envelopes := syntheticFirehose(0, benchActiveAppCount)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		for _, msg := range envelopes {
+			processNow(ep, msg)
+		}
+		b.StartTimer()
+		ep.UpdateData()
+	}
+}
+
+// Snapshot latency of copying the whole event data tree (the previous Clone
+// implementation).  Baseline for BenchmarkEventDataClone
+func BenchmarkEventDataFullCopy(b *testing.B) {
+	ep := newPopulatedBenchEventProcessor()
+	envelopes := syntheticFirehose(0, benchActiveAppCount)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		for _, msg := range envelopes {
+			processNow(ep, msg)
+		}
+		b.StartTimer()
+		for _, ed := range ep.partitions {
+			ed.mu.Lock()
+			deepcopy.Copy(ed)
+			ed.mu.Unlock()
+		}
+	}
+}
+
+// Ingest rate through the ingest pipeline (nozzle queues and partitions) with
+// benchNozzleCount nozzles sending at the same time
+func BenchmarkEventDataIngestPipeline(b *testing.B) {
+	const benchNozzleCount = 4
+	ep := newPopulatedBenchEventProcessor()
+	envelopes := syntheticFirehose(0, benchAppCount)
+
+	b.ResetTimer()
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	for nozzle := 0; nozzle < benchNozzleCount; nozzle++ {
+		wg.Add(1)
+		go func(instanceId int) {
+			defer wg.Done()
+			for i := instanceId; i < b.N; i = i + benchNozzleCount {
+				ep.Process(instanceId, envelopes[i%len(envelopes)])
+			}
+		}(nozzle)
+	}
+	wg.Wait()
+
+	// Wait for the partitions to process everything that was not dropped
+	for {
+		processed, dropped := uint64(0), uint64(0)
+		for _, queueStats := range ep.GetIngestQueueStats() {
+			if queueStats.QueueType == INGEST_QUEUE_PARTITION {
+				processed = processed + queueStats.Processed
+			} else {
+				dropped = dropped + queueStats.Dropped
+			}
+		}
+		if processed+dropped >= uint64(b.N) {
+			elapsed := time.Since(start)
+			b.ReportMetric(float64(processed)/elapsed.Seconds(), "events/sec")
+			b.ReportMetric(float64(dropped), "dropped")
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Ingest rate of a nozzle and the snapshot latency while both run at the same
+// time.  b.N is the number of events ingested; snapshots are taken back to back
+// until all the events have been processed
+func BenchmarkEventDataIngestWithSnapshots(b *testing.B) {
+	ep := newPopulatedBenchEventProcessor()
+	envelopes := syntheticFirehose(0, benchAppCount)
+
+	var snapshotCount int64
+	var snapshotNanos int64
+	stop := make(chan bool)
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				start := time.Now()
+				ep.UpdateData()
+				atomic.AddInt64(&snapshotNanos, int64(time.Since(start)))
+				atomic.AddInt64(&snapshotCount, 1)
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		processNow(ep, envelopes[i%len(envelopes)])
+	}
+	elapsed := time.Since(start)
+	close(stop)
+	wg.Wait()
+	b.ReportMetric(float64(b.N)/elapsed.Seconds(), "events/sec")
+	if count := atomic.LoadInt64(&snapshotCount); count > 0 {
+		b.ReportMetric(float64(atomic.LoadInt64(&snapshotNanos))/float64(count)/float64(time.Millisecond), "ms/snapshot")
+	}
+}
+
+// Container metric envelopes of the synthetic firehose for apps [firstApp, lastApp)
+func syntheticContainerMetrics(firstApp int, lastApp int) []*events.Envelope {
+	envelopes := make([]*events.Envelope, 0)
+	for _, msg := range syntheticFirehose(firstApp, lastApp) {
+		if msg.GetEventType() == events.Envelope_ContainerMetric {
+			envelopes = append(envelopes, msg)
+		}
+	}
+	return envelopes
+}
+
+func syntheticAppId(appNum int) string {
+	return formatUUID(&events.UUID{Low: proto.Uint64(uint64(appNum + 1)), High: proto.Uint64(0)})
+}
+
+var _ = Describe("EventData Clone", func() {
+	var (
+		ed    *EventData
+		first *EventData
+	)
+
+	// App 0 instance 0 of the snapshot
+	firstContainer := func(snapshot *EventData) *eventApp.ContainerStats {
+		return snapshot.AppMap[syntheticAppId(0)].ContainerArray[0]
+	}
+
+	setContainerAge := func(seconds int) {
+		lastUpdateTime := time.Now().Add(-time.Duration(seconds) * time.Second)
+		firstContainer(ed).LastUpdateTime = &lastUpdateTime
+	}
+
+	BeforeEach(func() {
+		ed = NewEventData(&sync.Mutex{}, newBenchEventProcessor())
+		for _, msg := range syntheticContainerMetrics(0, 2) {
+			ed.Process(0, msg)
+		}
+		first = ed.Clone()
+	})
+
+	AfterEach(func() {
+		// The previous snapshot must never be modified
+		Expect(firstContainer(first)).NotTo(BeNil())
+		Expect(firstContainer(first).ContainerMetric).NotTo(BeNil())
+	})
+
+	It("reuses the apps that have not changed", func() {
+		second := ed.Clone()
+		Expect(second.AppMap[syntheticAppId(0)]).To(BeIdenticalTo(first.AppMap[syntheticAppId(0)]))
+		Expect(second.AppMap[syntheticAppId(1)]).To(BeIdenticalTo(first.AppMap[syntheticAppId(1)]))
+	})
+
+	It("copies an app again when it has changed", func() {
+		for _, msg := range syntheticContainerMetrics(1, 2) {
+			ed.Process(0, msg)
+		}
+		second := ed.Clone()
+		Expect(second.AppMap[syntheticAppId(0)]).To(BeIdenticalTo(first.AppMap[syntheticAppId(0)]))
+		Expect(second.AppMap[syntheticAppId(1)]).NotTo(BeIdenticalTo(first.AppMap[syntheticAppId(1)]))
+	})
+
+	It("removes a stale container metric", func() {
+		setContainerAge(config.StaleContainerSeconds + 1)
+		second := ed.Clone()
+		Expect(second.AppMap[syntheticAppId(0)]).NotTo(BeIdenticalTo(first.AppMap[syntheticAppId(0)]))
+		Expect(firstContainer(second)).NotTo(BeNil())
+		Expect(firstContainer(second).ContainerMetric).To(BeNil())
+	})
+
+	It("removes a dead container", func() {
+		setContainerAge(config.DeadContainerSeconds + 1)
+		second := ed.Clone()
+		Expect(second.AppMap[syntheticAppId(0)]).NotTo(BeIdenticalTo(first.AppMap[syntheticAppId(0)]))
+		Expect(firstContainer(second)).To(BeNil())
+		Expect(second.AppMap[syntheticAppId(1)]).To(BeIdenticalTo(first.AppMap[syntheticAppId(1)]))
+	})
+})
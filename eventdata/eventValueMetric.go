@@ -15,14 +15,17 @@
 
 package eventdata
 
-import "github.com/cloudfoundry/sonde-go/events"
+import (
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+)
 
 func (ed *EventData) valueMetricEvent(msg *events.Envelope) {
 
-	// Can we assume that all rep orgins are cflinuxfs2 diego cells? Might be a bad idea
-	// 12/28/2017 - Added garden-linux to support PCF 2.0 small footprint
-	// Can't use "diego_cell" in job name as isolation segment job name could be custom job name
-	if msg.GetOrigin() == "rep" || msg.GetOrigin() == "garden-linux" {
+	valueMetric := msg.GetValueMetric()
+	metric, found := eventCell.FindCellMetric(msg.GetOrigin(), valueMetric.GetName())
+
+	if eventCell.IsCellOrigin(msg.GetOrigin()) {
 		ip := msg.GetIp()
 		cellStats := ed.getCellStats(ip)
 
@@ -31,30 +34,39 @@ func (ed *EventData) valueMetricEvent(msg *events.Envelope) {
 
 		cellStats.JobIndex = msg.GetIndex()
 
-		valueMetric := msg.GetValueMetric()
-		value := ed.getMetricValue(valueMetric)
-		name := valueMetric.GetName()
-		switch name {
-		case "numCPUS":
-			// ISSUE: numCPUS - PCF 2.6 no longer sending metric "numCPUS"
-			//cellStats.NumOfCpus = int(value)
-		case "CapacityTotalMemory":
-			cellStats.CapacityMemoryTotal = int64(value)
-		case "CapacityRemainingMemory":
-			cellStats.CapacityMemoryRemaining = int64(value)
-		case "CapacityTotalDisk":
-			cellStats.CapacityDiskTotal = int64(value)
-		case "CapacityRemainingDisk":
-			cellStats.CapacityDiskRemaining = int64(value)
-		case "CapacityTotalContainers":
-			cellStats.CapacityTotalContainers = int(value)
-		case "CapacityRemainingContainers":
-			cellStats.CapacityRemainingContainers = int(value)
-		case "ContainerCount":
-			cellStats.ContainerCount = int(value)
+		if found {
+			cellStats.SetMetric(metric, ed.getMetricValue(valueMetric))
 		}
+		return
 	}
 
+	if found && metric.IsHostMetric() {
+		// Host metrics are sent for every VM -- only keep the ones for known cells
+		cellStats := ed.findCellStatsForVM(msg)
+		if cellStats != nil {
+			cellStats.SetMetric(metric, ed.getMetricValue(valueMetric))
+		}
+	}
+
+}
+
+// Find the cell a metric was sent from.  Host metrics forwarded from the BOSH
+// director do not always include the VM IP so fall back to the BOSH instance.
+func (ed *EventData) findCellStatsForVM(msg *events.Envelope) *eventCell.CellStats {
+	if cellStats := ed.CellMap[msg.GetIp()]; cellStats != nil {
+		return cellStats
+	}
+	if msg.GetIndex() == "" {
+		return nil
+	}
+	for _, cellStats := range ed.CellMap {
+		if cellStats.DeploymentName == msg.GetDeployment() &&
+			cellStats.JobName == msg.GetJob() &&
+			cellStats.JobIndex == msg.GetIndex() {
+			return cellStats
+		}
+	}
+	return nil
 }
 
 func (ed *EventData) getMetricValue(valueMetric *events.ValueMetric) float64 {
@@ -16,6 +16,7 @@
 package eventrouting
 
 import (
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -27,12 +28,20 @@ type EventRouter struct {
 	eventCount uint64
 	startTime  time.Time
 	processor  *eventdata.EventProcessor
+
+	// Key: nozzle instanceId
+	nozzleTrackerMap     map[int]*nozzleTracker
+	nozzleTrackerMapLock sync.RWMutex
+
+	// Only set when the nozzle count can be changed (firehose)
+	nozzleController NozzleController
 }
 
 func NewEventRouter(processor *eventdata.EventProcessor) *EventRouter {
 	return &EventRouter{
-		processor: processor,
-		startTime: time.Now(),
+		processor:        processor,
+		startTime:        time.Now(),
+		nozzleTrackerMap: make(map[int]*nozzleTracker),
 	}
 }
 
@@ -56,5 +65,6 @@ func (er *EventRouter) Clear() {
 
 func (er *EventRouter) Route(instanceId int, msg *events.Envelope) {
 	atomic.AddUint64(&er.eventCount, 1)
+	er.trackMessage(instanceId, msg)
 	er.processor.Process(instanceId, msg)
 }
@@ -0,0 +1,234 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventrouting
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+)
+
+// Health of a nozzle (firehose or app stream connection)
+type NozzleStats struct {
+	InstanceId int
+	// Only set for app stream nozzles (non-privileged user)
+	AppGuid string
+
+	Connected      bool
+	ConnectTime    *time.Time
+	ReconnectCount int
+
+	MessageCount    uint64
+	MessageRate     float64
+	LastMessageTime *time.Time
+
+	ErrorCount    int
+	LastError     string
+	LastErrorTime *time.Time
+	// Disconnected by doppler because the nozzle couldn't keep up (ClosePolicyViolation)
+	SlowConsumerCount int
+
+	// Envelopes dropped by doppler (TruncatingBuffer.DroppedMessages) and by the
+	// ingest queue of this nozzle
+	DopplerDropped   uint64
+	IngestDropped    uint64
+	LastDataLossTime *time.Time
+}
+
+func (ns *NozzleStats) Id() string {
+	return strconv.Itoa(ns.InstanceId)
+}
+
+// True if the nozzle lost data within NozzleDataLossAlertSeconds
+func (ns *NozzleStats) IsDataLoss(now time.Time) bool {
+	return ns.LastDataLossTime != nil &&
+		now.Sub(*ns.LastDataLossTime) < config.NozzleDataLossAlertSeconds*time.Second
+}
+
+// Changes the number of firehose nozzles at runtime.  App stream nozzles (non-privileged
+// user) are one per monitored app so they can not be scaled.
+type NozzleController interface {
+	GetNozzleCount() int
+	// Manually changing the count turns off automatic scaling
+	SetNozzleCount(count int)
+	IsAutoScale() bool
+	SetAutoScale(autoScale bool)
+	// Bounds used by automatic scaling
+	GetScaleBounds() (min int, max int)
+}
+
+type nozzleTracker struct {
+	mu    sync.Mutex
+	stats NozzleStats
+	// Updated on every envelope so kept outside of the lock
+	messageCount    uint64
+	lastMessageTime int64
+
+	rateSampleTime  time.Time
+	rateSampleCount uint64
+}
+
+func (er *EventRouter) getNozzleTracker(instanceId int) *nozzleTracker {
+	er.nozzleTrackerMapLock.RLock()
+	tracker := er.nozzleTrackerMap[instanceId]
+	er.nozzleTrackerMapLock.RUnlock()
+	if tracker != nil {
+		return tracker
+	}
+
+	er.nozzleTrackerMapLock.Lock()
+	defer er.nozzleTrackerMapLock.Unlock()
+	tracker = er.nozzleTrackerMap[instanceId]
+	if tracker == nil {
+		tracker = &nozzleTracker{rateSampleTime: time.Now()}
+		tracker.stats.InstanceId = instanceId
+		er.nozzleTrackerMap[instanceId] = tracker
+	}
+	return tracker
+}
+
+func (er *EventRouter) trackMessage(instanceId int, msg *events.Envelope) {
+	tracker := er.getNozzleTracker(instanceId)
+	atomic.AddUint64(&tracker.messageCount, 1)
+	atomic.StoreInt64(&tracker.lastMessageTime, time.Now().UnixNano())
+
+	if eventdata.IsDroppedMessagesEvent(msg) {
+		now := time.Now()
+		tracker.mu.Lock()
+		tracker.stats.DopplerDropped = tracker.stats.DopplerDropped + msg.GetCounterEvent().GetDelta()
+		tracker.stats.LastDataLossTime = &now
+		tracker.mu.Unlock()
+	}
+}
+
+// Called when a nozzle connection has been opened
+func (er *EventRouter) NozzleConnected(instanceId int, appGuid string) {
+	tracker := er.getNozzleTracker(instanceId)
+	now := time.Now()
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if tracker.stats.ConnectTime != nil {
+		tracker.stats.ReconnectCount++
+	}
+	tracker.stats.AppGuid = appGuid
+	tracker.stats.Connected = true
+	tracker.stats.ConnectTime = &now
+}
+
+// Called when a nozzle connection has been closed
+func (er *EventRouter) NozzleDisconnected(instanceId int) {
+	tracker := er.getNozzleTracker(instanceId)
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.stats.Connected = false
+}
+
+// Called when a nozzle has been stopped by scaling down.  Its stats are discarded.
+func (er *EventRouter) NozzleRemoved(instanceId int) {
+	er.nozzleTrackerMapLock.Lock()
+	defer er.nozzleTrackerMapLock.Unlock()
+	delete(er.nozzleTrackerMap, instanceId)
+}
+
+func (er *EventRouter) SetNozzleController(nozzleController NozzleController) {
+	er.nozzleController = nozzleController
+}
+
+// Returns nil if the nozzle count can not be changed
+func (er *EventRouter) GetNozzleController() NozzleController {
+	return er.nozzleController
+}
+
+// Called when a nozzle fails to connect or its connection fails.  slowConsumer
+// is true if doppler closed the connection because the nozzle couldn't keep up.
+func (er *EventRouter) NozzleError(instanceId int, err error, slowConsumer bool) {
+	tracker := er.getNozzleTracker(instanceId)
+	now := time.Now()
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.stats.ErrorCount++
+	if err != nil {
+		tracker.stats.LastError = err.Error()
+	}
+	tracker.stats.LastErrorTime = &now
+	if slowConsumer {
+		tracker.stats.SlowConsumerCount++
+		tracker.stats.LastDataLossTime = &now
+	}
+}
+
+// Current stats of all nozzles
+func (er *EventRouter) GetNozzleStats() []*NozzleStats {
+
+	now := time.Now()
+	ingestDroppedMap := make(map[int]uint64)
+	for _, queueStats := range er.processor.GetIngestQueueStats() {
+		if queueStats.QueueType == eventdata.INGEST_QUEUE_NOZZLE {
+			ingestDroppedMap[queueStats.QueueId] = queueStats.Dropped
+		}
+	}
+
+	er.nozzleTrackerMapLock.RLock()
+	defer er.nozzleTrackerMapLock.RUnlock()
+	nozzleStatsList := make([]*NozzleStats, 0, len(er.nozzleTrackerMap))
+	for instanceId, tracker := range er.nozzleTrackerMap {
+		nozzleStatsList = append(nozzleStatsList, tracker.sample(now, ingestDroppedMap[instanceId]))
+	}
+	return nozzleStatsList
+}
+
+// Number of nozzles that lost data within NozzleDataLossAlertSeconds
+func (er *EventRouter) GetDataLossNozzleCount() int {
+	now := time.Now()
+	count := 0
+	for _, nozzleStats := range er.GetNozzleStats() {
+		if nozzleStats.IsDataLoss(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// Copy of the nozzle stats.  The message rate is recalculated at most once a second.
+func (tracker *nozzleTracker) sample(now time.Time, ingestDropped uint64) *NozzleStats {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	messageCount := atomic.LoadUint64(&tracker.messageCount)
+	elapsed := now.Sub(tracker.rateSampleTime)
+	if elapsed >= time.Second {
+		tracker.stats.MessageRate = float64(messageCount-tracker.rateSampleCount) / elapsed.Seconds()
+		tracker.rateSampleTime = now
+		tracker.rateSampleCount = messageCount
+	}
+	tracker.stats.MessageCount = messageCount
+	if lastMessageTime := atomic.LoadInt64(&tracker.lastMessageTime); lastMessageTime > 0 {
+		lastMessage := time.Unix(0, lastMessageTime)
+		tracker.stats.LastMessageTime = &lastMessage
+	}
+	if ingestDropped > tracker.stats.IngestDropped {
+		tracker.stats.IngestDropped = ingestDropped
+		tracker.stats.LastDataLossTime = &now
+	}
+
+	nozzleStats := tracker.stats
+	return &nozzleStats
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace"
 	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/top"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/simonleung8/flags"
@@ -55,7 +56,12 @@ func (c *TopCmd) GetMetadata() plugin.PluginMetadata {
 					Options: map[string]string{
 						"no-top-check": "-ntc, do not check if there are other instances of top running on this OS",
 						"cygwin":       "-c, force run under cygwin (Use this to run: 'cmd /c start cf top -cygwin' )",
-						"nozzles":      "-n, specify the number of nozzle instances (default: 2)",
+						"nozzles":      "-n, specify the initial number of nozzle instances (default: 2)",
+						"nozzles-min":  "-nmin, minimum number of nozzle instances when automatically scaling (default: 1)",
+						"nozzles-max":  "-nmax, maximum number of nozzle instances when automatically scaling (default: 10)",
+						"quota-warn":   "-qw, percent of an org/space quota limit to display a warning (default: 80)",
+						"quota-alert":  "-qa, percent of an org/space quota limit to display an alert (default: 95)",
+						"cell-metrics": "-cm, JSON file of additional cell metric name mappings (origin, name, metric)",
 						"debug":        "-d, enable debugging",
 					},
 				},
@@ -94,14 +100,31 @@ func (c *TopCmd) Run(cliConnection plugin.CliConnection, args []string) {
 		return
 	}
 
-	if options.Nozzles > 10 {
-		c.ui.Failed("Can not specify more then 10 nozzle instances")
+	if options.NozzlesMax > config.MaxNozzleCount {
+		c.ui.Failed("Can not specify more then %v nozzle instances", config.MaxNozzleCount)
 		return
 	}
-	if options.Nozzles < 1 {
+	if options.NozzlesMin < 1 {
 		c.ui.Failed("Can not specify less then 1 nozzle instance")
 		return
 	}
+	if options.NozzlesMin > options.NozzlesMax {
+		c.ui.Failed("Nozzles min can not be greater then nozzles max")
+		return
+	}
+	if options.Nozzles < options.NozzlesMin || options.Nozzles > options.NozzlesMax {
+		c.ui.Failed("Number of nozzles must be between nozzles min and max")
+		return
+	}
+	if options.QuotaWarnPercent < 1 || options.QuotaWarnPercent > 100 ||
+		options.QuotaAlertPercent < 1 || options.QuotaAlertPercent > 100 {
+		c.ui.Failed("Quota warn and alert percent must be between 1 and 100")
+		return
+	}
+	if options.QuotaWarnPercent > options.QuotaAlertPercent {
+		c.ui.Failed("Quota warn percent can not be greater then quota alert percent")
+		return
+	}
 
 	// TODO: THis is for testing only
 	/*
@@ -172,12 +195,22 @@ func (c *TopCmd) buildClientOptions(args []string) *top.ClientOptions {
 	var noTopCheck bool
 	var cygwin bool
 	var nozzles int
+	var nozzlesMin int
+	var nozzlesMax int
+	var quotaWarnPercent int
+	var quotaAlertPercent int
+	var cellMetricsFile string
 
 	fc := flags.New()
 	fc.NewBoolFlag("debug", "d", "used for debugging")
 	fc.NewBoolFlag("no-top-check", "ntc", "Do not check if there are other instances of top running")
 	fc.NewBoolFlag("cygwin", "c", "force run under cygwin (Use this to run: 'cmd /c start cf top -cygwin' )")
 	fc.NewIntFlagWithDefault("nozzles", "n", "number of nozzles", 2)
+	fc.NewIntFlagWithDefault("nozzles-min", "nmin", "minimum number of nozzles", config.NozzleMinCount)
+	fc.NewIntFlagWithDefault("nozzles-max", "nmax", "maximum number of nozzles", config.NozzleMaxCount)
+	fc.NewIntFlagWithDefault("quota-warn", "qw", "percent of quota limit to display a warning", config.QuotaWarnPercent)
+	fc.NewIntFlagWithDefault("quota-alert", "qa", "percent of quota limit to display an alert", config.QuotaAlertPercent)
+	fc.NewStringFlag("cell-metrics", "cm", "JSON file of additional cell metric name mappings")
 	//fc.NewStringFlag("filter", "f", "specify message filter such as LogMessage, ValueMetric, CounterEvent, HttpStartStop")
 	err := fc.Parse(args[1:]...)
 
@@ -196,6 +229,22 @@ func (c *TopCmd) buildClientOptions(args []string) *top.ClientOptions {
 	}
 
 	nozzles = fc.Int("nozzles")
+	nozzlesMin = fc.Int("nozzles-min")
+	nozzlesMax = fc.Int("nozzles-max")
+	// Keep the default initial count within the scaling bounds
+	if !fc.IsSet("nozzles") {
+		if nozzles < nozzlesMin {
+			nozzles = nozzlesMin
+		}
+		if nozzles > nozzlesMax {
+			nozzles = nozzlesMax
+		}
+	}
+	quotaWarnPercent = fc.Int("quota-warn")
+	quotaAlertPercent = fc.Int("quota-alert")
+	if fc.IsSet("cell-metrics") {
+		cellMetricsFile = fc.String("cell-metrics")
+	}
 
 	/*
 		if fc.IsSet("filter") {
@@ -207,5 +256,12 @@ func (c *TopCmd) buildClientOptions(args []string) *top.ClientOptions {
 		NoTopCheck: noTopCheck,
 		Cygwin:     cygwin,
 		Nozzles:    nozzles,
+		NozzlesMin: nozzlesMin,
+		NozzlesMax: nozzlesMax,
+
+		QuotaWarnPercent:  quotaWarnPercent,
+		QuotaAlertPercent: quotaAlertPercent,
+
+		CellMetricsFile: cellMetricsFile,
 	}
 }
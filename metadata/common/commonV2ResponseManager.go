@@ -180,7 +180,7 @@ func (commonV2ResponseMgr *CommonV2ResponseManager) GetNextUrl(response IRespons
 		nextUrl, _ = GetStringValueByFieldName(response, "NextUrl")
 	} else {
 
-		// NOTE: Since we only have 1 v3 api (iso segs) we override this method
+		// NOTE: Since we only have a few v3 apis (iso segs, tasks, processes) we override this method
 		// but need to thing about generic handling in fugure
 		log.Panicln("STOP -- using wrong GetNextUrl method for v3 API")
 
@@ -239,7 +239,12 @@ func (commonV2ResponseMgr *CommonV2ResponseManager) GetMetadataFromUrl(url strin
 	}
 
 	// Configure the number of records per API call that we get
-	url += "?" + pageParamVar + "=" + strconv.Itoa(resultsPerVPage)
+	if strings.Contains(url, "?") {
+		url += "&"
+	} else {
+		url += "?"
+	}
+	url += pageParamVar + "=" + strconv.Itoa(resultsPerVPage)
 
 	toplog.Debug("URL: %v", url)
 	handleRequest := func(outputBytes []byte) (data interface{}, nextUrl string, err error) {
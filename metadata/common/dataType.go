@@ -18,33 +18,39 @@ package common
 type DataType string
 
 const (
-	APP            DataType = "APP"
-	APP_INST                = "APP_INST"
-	APP_STATS               = "APP_STATS"
-	SPACE                   = "SPACE"
-	ORG                     = "ORG"
-	DOMAIN_PRIVATE          = "DOMAIN_PRIVATE"
-	DOMAIN_SHARED           = "DOMAIN_SHARED"
-	ISO_SEG                 = "ISO_SEG"
-	ORG_QUOTA               = "ORG_QUOTA"
-	SPACE_QUOTA             = "SPACE_QUOTA"
-	ROUTE                   = "ROUTE"
-	STACK                   = "STACK"
-	EVENTS_CRASH            = "EVENTS_CRASH"
+	APP              DataType = "APP"
+	APP_INST                  = "APP_INST"
+	APP_STATS                 = "APP_STATS"
+	SPACE                     = "SPACE"
+	ORG                       = "ORG"
+	DOMAIN_PRIVATE            = "DOMAIN_PRIVATE"
+	DOMAIN_SHARED             = "DOMAIN_SHARED"
+	ISO_SEG                   = "ISO_SEG"
+	ORG_QUOTA                 = "ORG_QUOTA"
+	SPACE_QUOTA               = "SPACE_QUOTA"
+	ROUTE                     = "ROUTE"
+	STACK                     = "STACK"
+	EVENTS_CRASH              = "EVENTS_CRASH"
+	TASK                      = "TASK"
+	PROCESS                   = "PROCESS"
+	SERVICE_INSTANCE          = "SERVICE_INSTANCE"
 )
 
 var DataTypeDisplay = map[DataType]string{
-	APP:            "Application",
-	APP_INST:       "Application Instance",
-	APP_STATS:      "Application Stat",
-	SPACE:          "Space",
-	ORG:            "Organization",
-	DOMAIN_PRIVATE: "Private Domain",
-	DOMAIN_SHARED:  "Shared Domain",
-	ISO_SEG:        "Isolation Segment",
-	ORG_QUOTA:      "Organization Quota",
-	SPACE_QUOTA:    "Space Quota",
-	ROUTE:          "Route",
-	STACK:          "Stack",
-	EVENTS_CRASH:   "Event Crash",
+	APP:              "Application",
+	APP_INST:         "Application Instance",
+	APP_STATS:        "Application Stat",
+	SPACE:            "Space",
+	ORG:              "Organization",
+	DOMAIN_PRIVATE:   "Private Domain",
+	DOMAIN_SHARED:    "Shared Domain",
+	ISO_SEG:          "Isolation Segment",
+	ORG_QUOTA:        "Organization Quota",
+	SPACE_QUOTA:      "Space Quota",
+	ROUTE:            "Route",
+	STACK:            "Stack",
+	EVENTS_CRASH:     "Event Crash",
+	TASK:             "Task",
+	PROCESS:          "Process",
+	SERVICE_INSTANCE: "Service Instance",
 }
@@ -31,6 +31,8 @@ type ContainerCrashInfo struct {
 	ContainerIndex  int
 	CrashTime       *time.Time
 	ExitDescription string
+	// IP of the cell the container was running on -- only known for crashes captured live
+	CellIp string
 }
 
 func NewContainerCrashInfo(containerIndex int, crashTime *time.Time, exitDescription string) *ContainerCrashInfo {
@@ -115,14 +115,14 @@ func LoadCrashDataCache(cliConnection plugin.CliConnection) {
 	}
 	crashDataMetadataCache = data
 
-	crashDataByAppId = make(map[string][]*ContainerCrashInfo)
+	crashInfoByAppId := make(map[string][]*ContainerCrashInfo)
 
 	layout := "2006-01-02T15:04:05Z"
 	for _, crashData := range data {
-		crashInfoList := crashDataByAppId[crashData.Actor]
+		crashInfoList := crashInfoByAppId[crashData.Actor]
 		if crashInfoList == nil {
 			crashInfoList = make([]*ContainerCrashInfo, 0)
-			crashDataByAppId[crashData.Actor] = crashInfoList
+			crashInfoByAppId[crashData.Actor] = crashInfoList
 		}
 		crashTimestamp, err := time.Parse(layout, crashData.Timestamp)
 		if err != nil {
@@ -131,8 +131,15 @@ func LoadCrashDataCache(cliConnection plugin.CliConnection) {
 		instanceIndex := crashData.Metadata.Index
 		exitDescription := crashData.Metadata.Exit_description
 		crashInfo := NewContainerCrashInfo(instanceIndex, &crashTimestamp, exitDescription)
-		crashDataByAppId[crashData.Actor] = append(crashDataByAppId[crashData.Actor], crashInfo)
+		crashInfoByAppId[crashData.Actor] = append(crashInfoByAppId[crashData.Actor], crashInfo)
 	}
+	// Add crashes older then 24 hours from the local crash history
+	mergeCrashHistory(cliConnection, crashInfoByAppId)
+	for _, crashInfoList := range crashInfoByAppId {
+		sort.Sort(ContainerCrashInfoSlice(crashInfoList))
+	}
+	crashDataByAppId = crashInfoByAppId
+
 	now := time.Now()
 	cacheTime = &now
 }
@@ -0,0 +1,224 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashData
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+var regexFileNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+var (
+	crashHistoryLock sync.Mutex
+	// API endpoint of the targeted foundation and the full path of its crash history file
+	crashHistoryApiEndpoint string
+	crashHistoryFilename    string
+	// Map: [crashHistoryKey] = crash record
+	crashHistoryMap   map[string]*crashHistoryRecord
+	crashHistoryDirty bool
+
+	crashHistorySaverOnce sync.Once
+)
+
+type crashHistoryRecord struct {
+	AppId           string    `json:"appId"`
+	ContainerIndex  int       `json:"index"`
+	CrashTime       time.Time `json:"crashTime"`
+	ExitDescription string    `json:"exitDescription"`
+	CellIp          string    `json:"cellIp,omitempty"`
+}
+
+type crashHistoryFile struct {
+	ApiEndpoint string                `json:"apiEndpoint"`
+	Crashes     []*crashHistoryRecord `json:"crashes"`
+}
+
+func newCrashHistoryRecord(appId string, crashInfo *ContainerCrashInfo) *crashHistoryRecord {
+	return &crashHistoryRecord{
+		AppId:           appId,
+		ContainerIndex:  crashInfo.ContainerIndex,
+		CrashTime:       *crashInfo.CrashTime,
+		ExitDescription: crashInfo.ExitDescription,
+		CellIp:          crashInfo.CellIp,
+	}
+}
+
+func (r *crashHistoryRecord) key() string {
+	return crashHistoryKey(r.AppId, r.ContainerIndex, &r.CrashTime)
+}
+
+func (r *crashHistoryRecord) toContainerCrashInfo() *ContainerCrashInfo {
+	crashTime := r.CrashTime
+	crashInfo := &ContainerCrashInfo{ContainerIndex: r.ContainerIndex, CrashTime: &crashTime,
+		ExitDescription: r.ExitDescription, CellIp: r.CellIp}
+	return crashInfo
+}
+
+// Crash events from the cloud controller only have a timestamp to the second
+// so the key uses second resolution to match the same crash captured live
+func crashHistoryKey(appId string, containerIndex int, crashTime *time.Time) string {
+	return fmt.Sprintf("%v|%v|%v", appId, containerIndex, crashTime.Unix())
+}
+
+// Record a crash captured live from the firehose in the local crash history.  The
+// history is written by the saver started with StartCrashHistorySaver (and at exit)
+// so the caller (ingest worker) never waits on the file write.
+func RecordCrash(appId string, crashInfo *ContainerCrashInfo) {
+	if crashInfo == nil || crashInfo.CrashTime == nil {
+		return
+	}
+	crashHistoryLock.Lock()
+	defer crashHistoryLock.Unlock()
+	if crashHistoryMap == nil {
+		// Crash history not loaded yet -- record it in memory, merged when loaded
+		crashHistoryMap = make(map[string]*crashHistoryRecord)
+	}
+	record := newCrashHistoryRecord(appId, crashInfo)
+	crashHistoryMap[record.key()] = record
+	crashHistoryDirty = true
+}
+
+// Start writing any unsaved crash history every CrashHistorySaveSeconds
+func StartCrashHistorySaver() {
+	crashHistorySaverOnce.Do(func() {
+		ticker := time.NewTicker(config.CrashHistorySaveSeconds * time.Second)
+		go func() {
+			for range ticker.C {
+				SaveCrashHistory()
+			}
+		}()
+	})
+}
+
+// Write any unsaved crash history to the local store
+func SaveCrashHistory() {
+	crashHistoryLock.Lock()
+	defer crashHistoryLock.Unlock()
+	if crashHistoryDirty && crashHistoryFilename != "" {
+		saveCrashHistory()
+	}
+}
+
+// Merge the crash events loaded from the cloud controller with the locally
+// persisted crash history.  Persisted crashes that occurred before top was started
+// and are not already in crashInfoByAppId are added to crashInfoByAppId.
+func mergeCrashHistory(cliConnection plugin.CliConnection, crashInfoByAppId map[string][]*ContainerCrashInfo) {
+	crashHistoryLock.Lock()
+	defer crashHistoryLock.Unlock()
+
+	if crashHistoryFilename == "" {
+		crashHistoryFilename = getCrashHistoryFilename(cliConnection)
+		loadCrashHistory()
+	}
+
+	loadedKeys := make(map[string]bool)
+	for appId, crashInfoList := range crashInfoByAppId {
+		for _, crashInfo := range crashInfoList {
+			key := crashHistoryKey(appId, crashInfo.ContainerIndex, crashInfo.CrashTime)
+			loadedKeys[key] = true
+			if crashHistoryMap[key] == nil {
+				crashHistoryMap[key] = newCrashHistoryRecord(appId, crashInfo)
+				crashHistoryDirty = true
+			}
+		}
+	}
+
+	retentionTime := time.Now().Add(-config.CrashHistoryRetentionDays * 24 * time.Hour)
+	for key, record := range crashHistoryMap {
+		if record.CrashTime.Before(retentionTime) {
+			delete(crashHistoryMap, key)
+			crashHistoryDirty = true
+			continue
+		}
+		// Crashes after top was started are counted from the live capture (AppStats)
+		if loadedKeys[key] || (LoadEventsUntilTime != nil && !record.CrashTime.Before(*LoadEventsUntilTime)) {
+			continue
+		}
+		crashInfoByAppId[record.AppId] = append(crashInfoByAppId[record.AppId], record.toContainerCrashInfo())
+	}
+
+	if crashHistoryDirty {
+		saveCrashHistory()
+	}
+}
+
+func getCrashHistoryFilename(cliConnection plugin.CliConnection) string {
+	crashHistoryApiEndpoint = util.GetApiEndpointNoProtocol(cliConnection)
+	filename := fmt.Sprintf("crashHistory_%v.json", regexFileNameInvalidChars.ReplaceAllString(crashHistoryApiEndpoint, "_"))
+	return filepath.Join(util.GetTopConfigDir(), filename)
+}
+
+// Caller must hold crashHistoryLock
+func loadCrashHistory() {
+	if crashHistoryMap == nil {
+		crashHistoryMap = make(map[string]*crashHistoryRecord)
+	}
+	data, err := ioutil.ReadFile(crashHistoryFilename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			toplog.Warn("Unable to read crash history file %v: %v", crashHistoryFilename, err)
+		}
+		return
+	}
+	historyFile := &crashHistoryFile{}
+	if err := json.Unmarshal(data, historyFile); err != nil {
+		toplog.Warn("Unable to parse crash history file %v: %v", crashHistoryFilename, err)
+		return
+	}
+	for _, record := range historyFile.Crashes {
+		key := record.key()
+		// Keep any record captured live this session (may have cell IP)
+		if crashHistoryMap[key] == nil {
+			crashHistoryMap[key] = record
+		}
+	}
+	toplog.Info("Crash history loaded %v crashes from %v", len(historyFile.Crashes), crashHistoryFilename)
+}
+
+// Caller must hold crashHistoryLock
+func saveCrashHistory() {
+	historyFile := &crashHistoryFile{}
+	historyFile.ApiEndpoint = crashHistoryApiEndpoint
+	historyFile.Crashes = make([]*crashHistoryRecord, 0, len(crashHistoryMap))
+	for _, record := range crashHistoryMap {
+		historyFile.Crashes = append(historyFile.Crashes, record)
+	}
+	data, err := json.Marshal(historyFile)
+	if err != nil {
+		toplog.Warn("Unable to marshal crash history: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(crashHistoryFilename), 0700); err != nil {
+		toplog.Warn("Unable to create crash history directory: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(crashHistoryFilename, data, 0600); err != nil {
+		toplog.Warn("Unable to write crash history file %v: %v", crashHistoryFilename, err)
+		return
+	}
+	crashHistoryDirty = false
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crashData
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/plugin/pluginfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CrashHistoryStore", func() {
+	var (
+		cliConnection *pluginfakes.FakeCliConnection
+		cfHomeDir     string
+		savedCfHome   string
+		now           time.Time
+	)
+
+	crash := func(containerIndex int, crashTime time.Time) *ContainerCrashInfo {
+		return &ContainerCrashInfo{ContainerIndex: containerIndex, CrashTime: &crashTime, ExitDescription: "Exited with status 1"}
+	}
+
+	// Crash history file of a prior session
+	writeCrashHistory := func(crashInfoByAppId map[string][]*ContainerCrashInfo) {
+		crashHistoryFilename = getCrashHistoryFilename(cliConnection)
+		crashHistoryMap = make(map[string]*crashHistoryRecord)
+		for appId, crashInfoList := range crashInfoByAppId {
+			for _, crashInfo := range crashInfoList {
+				record := newCrashHistoryRecord(appId, crashInfo)
+				crashHistoryMap[record.key()] = record
+			}
+		}
+		saveCrashHistory()
+		crashHistoryFilename = ""
+		crashHistoryMap = nil
+	}
+
+	readCrashHistory := func() map[string]*crashHistoryRecord {
+		crashHistoryMap = nil
+		loadCrashHistory()
+		return crashHistoryMap
+	}
+
+	BeforeEach(func() {
+		var err error
+		cfHomeDir, err = ioutil.TempDir("", "crashHistory")
+		Expect(err).NotTo(HaveOccurred())
+		savedCfHome = os.Getenv("CF_HOME")
+		os.Setenv("CF_HOME", cfHomeDir)
+
+		cliConnection = &pluginfakes.FakeCliConnection{}
+		cliConnection.ApiEndpointReturns("https://api.sys.example.com", nil)
+
+		crashHistoryFilename = ""
+		crashHistoryMap = nil
+		crashHistoryDirty = false
+		now = time.Now().Truncate(time.Second)
+	})
+
+	AfterEach(func() {
+		os.Setenv("CF_HOME", savedCfHome)
+		os.RemoveAll(cfHomeDir)
+	})
+
+	Describe("crashHistoryKey", func() {
+		crashTime := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+		key := crashHistoryKey("app1", 0, &crashTime)
+
+		It("matches a crash time in the same second", func() {
+			sameSecond := crashTime.Add(500 * time.Millisecond)
+			Expect(crashHistoryKey("app1", 0, &sameSecond)).To(Equal(key))
+		})
+		It("does not match the next second", func() {
+			nextSecond := crashTime.Add(time.Second)
+			Expect(crashHistoryKey("app1", 0, &nextSecond)).NotTo(Equal(key))
+		})
+		It("does not match another container or app", func() {
+			Expect(crashHistoryKey("app1", 1, &crashTime)).NotTo(Equal(key))
+			Expect(crashHistoryKey("app2", 0, &crashTime)).NotTo(Equal(key))
+		})
+	})
+
+	Describe("mergeCrashHistory", func() {
+		var (
+			savedLoadEventsUntilTime *time.Time
+			startTime                time.Time
+			hourAgo                  time.Time
+			dayAgo                   time.Time
+			loaded                   map[string][]*ContainerCrashInfo
+		)
+
+		BeforeEach(func() {
+			savedLoadEventsUntilTime = LoadEventsUntilTime
+			startTime = now.Add(-time.Minute)
+			LoadEventsUntilTime = &startTime
+			hourAgo = now.Add(-time.Hour)
+			dayAgo = now.Add(-24 * time.Hour)
+			loaded = map[string][]*ContainerCrashInfo{"app1": {crash(1, hourAgo)}}
+		})
+
+		AfterEach(func() {
+			LoadEventsUntilTime = savedLoadEventsUntilTime
+		})
+
+		Context("when there is no crash history file", func() {
+			It("saves the loaded crashes", func() {
+				mergeCrashHistory(cliConnection, loaded)
+				Expect(loaded["app1"]).To(HaveLen(1))
+				Expect(readCrashHistory()).To(HaveLen(1))
+			})
+		})
+
+		Context("when a persisted crash is older than the loaded crash events", func() {
+			BeforeEach(func() {
+				writeCrashHistory(map[string][]*ContainerCrashInfo{"app1": {crash(0, dayAgo)}})
+			})
+			It("adds the persisted crash", func() {
+				mergeCrashHistory(cliConnection, loaded)
+				Expect(loaded["app1"]).To(HaveLen(2))
+				Expect(readCrashHistory()).To(HaveLen(2))
+			})
+		})
+
+		Context("when a persisted crash was also loaded from the cloud controller", func() {
+			BeforeEach(func() {
+				writeCrashHistory(map[string][]*ContainerCrashInfo{"app1": {crash(1, hourAgo.Add(200*time.Millisecond))}})
+			})
+			It("is only counted once", func() {
+				mergeCrashHistory(cliConnection, loaded)
+				Expect(loaded["app1"]).To(HaveLen(1))
+				Expect(readCrashHistory()).To(HaveLen(1))
+			})
+		})
+
+		Context("when a persisted crash is past the retention period", func() {
+			BeforeEach(func() {
+				expired := now.Add(-(30*24 + 1) * time.Hour)
+				writeCrashHistory(map[string][]*ContainerCrashInfo{"app2": {crash(0, expired), crash(0, dayAgo)}})
+			})
+			It("removes the crash", func() {
+				mergeCrashHistory(cliConnection, loaded)
+				Expect(loaded["app2"]).To(HaveLen(1))
+				Expect(readCrashHistory()).To(HaveLen(2))
+			})
+		})
+
+		Context("when a persisted crash occurred after top was started", func() {
+			BeforeEach(func() {
+				writeCrashHistory(map[string][]*ContainerCrashInfo{"app2": {crash(0, now)}})
+			})
+			It("is left to the live capture", func() {
+				mergeCrashHistory(cliConnection, loaded)
+				Expect(loaded["app2"]).To(BeEmpty())
+				Expect(readCrashHistory()).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("RecordCrash", func() {
+		BeforeEach(func() {
+			mergeCrashHistory(cliConnection, map[string][]*ContainerCrashInfo{})
+		})
+
+		It("saves crashes captured live with their cell", func() {
+			crashInfo := crash(2, now)
+			crashInfo.CellIp = "10.0.0.5"
+			RecordCrash("app1", crashInfo)
+			SaveCrashHistory()
+
+			records := readCrashHistory()
+			Expect(records).To(HaveLen(1))
+			for _, record := range records {
+				Expect(record.AppId).To(Equal("app1"))
+				Expect(record.ContainerIndex).To(Equal(2))
+				Expect(record.CellIp).To(Equal("10.0.0.5"))
+			}
+		})
+
+		It("is not written until saved", func() {
+			RecordCrash("app1", crash(2, now))
+			Expect(readCrashHistory()).To(BeEmpty())
+		})
+
+		It("ignores crashes without a crash time", func() {
+			RecordCrash("app1", &ContainerCrashInfo{ContainerIndex: 3})
+			SaveCrashHistory()
+			Expect(readCrashHistory()).To(BeEmpty())
+		})
+	})
+})
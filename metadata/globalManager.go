@@ -17,6 +17,7 @@ package metadata
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
@@ -28,10 +29,13 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/orgQuota"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/process"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/route"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/serviceInstance"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/spaceQuota"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/stack"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/task"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 
 	"code.cloudfoundry.org/cli/plugin"
@@ -51,6 +55,9 @@ type GlobalManager struct {
 	domainPrivateMdMgr *domain.DomainPrivateMetadataManager
 	domainFinder       *domain.DomainFinder
 	routeMdMgr         *route.RouteMetadataManager
+	taskMdMgr          *task.TaskMetadataManager
+	processMdMgr       *process.ProcessMetadataManager
+	serviceInstMdMgr   *serviceInstance.ServiceInstanceMetadataManager
 
 	cliConnection plugin.CliConnection
 
@@ -59,6 +66,9 @@ type GlobalManager struct {
 	// If app detail hasn't been viewed for awhile, it will be removed from list
 	monitoredAppDetails     map[string]*time.Time
 	monitoredAppDetailsLock sync.Mutex
+	// Read only copy of the monitoredAppDetails keys (map[string]bool) that is replaced
+	// on each change so the per message checks do not need monitoredAppDetailsLock
+	monitoredAppIds atomic.Value
 
 	statusMsg chan string
 
@@ -90,14 +100,20 @@ func NewGlobalManager(conn plugin.CliConnection, statusMsg chan string) *GlobalM
 
 	mgr.routeMdMgr = route.NewRouteMetadataManager(mgr)
 
+	mgr.taskMdMgr = task.NewTaskMetadataManager(mgr)
+	mgr.processMdMgr = process.NewProcessMetadataManager(mgr)
+	mgr.serviceInstMdMgr = serviceInstance.NewServiceInstanceMetadataManager(mgr)
+
 	mgr.cliConnection = conn
 
 	mgr.monitoredAppDetails = make(map[string]*time.Time)
+	mgr.monitoredAppIds.Store(make(map[string]bool))
 
 	// Set set the time of event data end date/time here so we don't end up loading
 	// events after we've already started counting them from the firehose.
 	now := time.Now()
 	crashData.LoadEventsUntilTime = &now
+	crashData.StartCrashHistorySaver()
 
 	return mgr
 }
@@ -157,6 +173,18 @@ func (mgr *GlobalManager) GetRouteMdManager() *route.RouteMetadataManager {
 	return mgr.routeMdMgr
 }
 
+func (mgr *GlobalManager) GetTaskMdManager() *task.TaskMetadataManager {
+	return mgr.taskMdMgr
+}
+
+func (mgr *GlobalManager) GetProcessMdManager() *process.ProcessMetadataManager {
+	return mgr.processMdMgr
+}
+
+func (mgr *GlobalManager) GetServiceInstanceMdManager() *serviceInstance.ServiceInstanceMetadataManager {
+	return mgr.serviceInstMdMgr
+}
+
 func (mgr *GlobalManager) GetCliConnection() plugin.CliConnection {
 	return mgr.cliConnection
 }
@@ -174,6 +202,7 @@ func (mgr *GlobalManager) LoadMetadata() {
 	mgr.isoSegMdMgr.LoadAllItems()
 	mgr.stackMdMgr.LoadAllItems()
 	mgr.appMdMgr.LoadAllItems()
+	mgr.processMdMgr.LoadAllItems()
 
 	//time.Sleep(time.Second * 60)
 
@@ -181,9 +210,11 @@ func (mgr *GlobalManager) LoadMetadata() {
 	mgr.orgMdMgr.LoadAllItems()
 
 	mgr.routeMdMgr.LoadAllItems()
+	mgr.serviceInstMdMgr.LoadAllItems()
 
 	mgr.domainSharedMdMgr.LoadAllItems()
 	mgr.domainPrivateMdMgr.LoadAllItems()
+	mgr.taskMdMgr.LoadAllItems()
 	crashData.LoadCrashDataCache(mgr.cliConnection)
 
 	mgr.loadMetadataInProgress = false
@@ -207,11 +238,65 @@ func (mgr *GlobalManager) RequestLoadOfAll(dataType common.DataType) {
 	mgr.loadHandler.RequestLoadOfAll(dataType, 0*time.Second)
 }
 
+// Request a reload of the task metadata changed since the last load if it hasn't been
+// loaded recently.  Task state changes (e.g., RUNNING to SUCCEEDED) are not visible on the firehose
+func (mgr *GlobalManager) RequestRefreshTaskMetadata() {
+	lastFullLoadTime := mgr.taskMdMgr.LastFullLoadTime()
+	if lastFullLoadTime != nil && time.Since(*lastFullLoadTime) < (time.Second*config.TaskMetadataRefreshSeconds) {
+		return
+	}
+	mgr.loadHandler.RequestLoadOfAll(common.TASK, 0*time.Second)
+}
+
+// Request a reload of all the processes (web, worker, clock, etc) of the given app.  This
+// also finds process types that have been added to the app since the metadata was loaded.
+func (mgr *GlobalManager) RequestRefreshProcessMetadata(appId string) {
+	mgr.loadHandler.RequestLoadOfItem(common.PROCESS, appId, 0*time.Second)
+}
+
+// Get the desired number of containers of all the process types (web, worker, etc) of the given app
+func (mgr *GlobalManager) FindDesiredContainers(appMetadata *app.AppMetadata) int {
+	desiredContainers := int(appMetadata.Instances)
+	for _, processMetadata := range mgr.processMdMgr.FindByApp(appMetadata.Guid) {
+		if !processMetadata.IsWeb() {
+			desiredContainers += int(processMetadata.Instances)
+		}
+	}
+	return desiredContainers
+}
+
+// Get the memory and disk reserved by a single container of the given process type
+func (mgr *GlobalManager) FindContainerReservation(appMetadata *app.AppMetadata, processType string) (memoryMB float64, diskQuotaMB float64) {
+	if processType != "" && processType != process.WebProcessType {
+		processMetadata := mgr.processMdMgr.FindByAppAndType(appMetadata.Guid, processType)
+		if processMetadata != nil {
+			return processMetadata.MemoryMB, processMetadata.DiskQuotaMB
+		}
+	}
+	return appMetadata.MemoryMB, appMetadata.DiskQuotaMB
+}
+
 // Indicate that we should actively monitor app details (container updates) for given appId
 func (mgr *GlobalManager) MonitorAppDetails(appId string, lastViewed *time.Time) {
 	mgr.monitoredAppDetailsLock.Lock()
 	defer mgr.monitoredAppDetailsLock.Unlock()
 	mgr.monitoredAppDetails[appId] = lastViewed
+	mgr.publishMonitoredAppIds()
+}
+
+// Replace the read only set of monitored appIds.  Caller must hold monitoredAppDetailsLock
+func (mgr *GlobalManager) publishMonitoredAppIds() {
+	monitoredAppIds := make(map[string]bool, len(mgr.monitoredAppDetails))
+	for appId := range mgr.monitoredAppDetails {
+		monitoredAppIds[appId] = true
+	}
+	mgr.monitoredAppIds.Store(monitoredAppIds)
+}
+
+// Lock free check if appId may be monitored.  Unlike IsMonitorAppDetails the TTL
+// is not checked so a true result must be confirmed with IsMonitorAppDetails.
+func (mgr *GlobalManager) IsMaybeMonitorAppDetails(appId string) bool {
+	return mgr.monitoredAppIds.Load().(map[string]bool)[appId]
 }
 
 func (mgr *GlobalManager) IsMonitorAppDetails(appId string) bool {
@@ -233,6 +318,7 @@ func (mgr *GlobalManager) IsMonitorAppDetails(appId string) bool {
 		//appInstances.ClearAppInstancesMetadata(appId)
 		mgr.appInstMdMgr.DeleteItem(appId)
 		delete(mgr.monitoredAppDetails, appId)
+		mgr.publishMonitoredAppIds()
 		return false
 	}
 	return true
@@ -47,6 +47,15 @@ type IsolationSegment struct {
 	Name string `json:"name"`
 }
 
+// Response of /v3/isolation_segments/:guid/relationships/organizations
+type IsolationSegmentOrgsResponse struct {
+	Data []IsolationSegmentOrg `json:"data"`
+}
+
+type IsolationSegmentOrg struct {
+	Guid string `json:"guid"`
+}
+
 var (
 	DefaultIsolationSegment = NewIsolationSegmentMetadata(IsolationSegment{EntityCommon: common.EntityCommon{Guid: DefaultIsolationSegmentGuid}, Name: "default"})
 	UnknownIsolationSegment = NewIsolationSegmentMetadata(IsolationSegment{EntityCommon: common.EntityCommon{Guid: UnknownIsolationSegmentGuid}, Name: UnknownIsolationSegmentName})
@@ -57,6 +66,14 @@ func GetDefault() *IsolationSegmentMetadata {
 	return SharedIsolationSegment
 }
 
+// Resolve the default isolation segment guid to the guid of the shared segment
+func ResolveGuid(guid string) string {
+	if guid == DefaultIsolationSegmentGuid && SharedIsolationSegment != nil {
+		return SharedIsolationSegment.Guid
+	}
+	return guid
+}
+
 /*
 func All() []*IsolationSegment {
 	return isolationSegmentMetadataCache
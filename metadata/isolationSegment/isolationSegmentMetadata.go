@@ -20,6 +20,8 @@ import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
 type IsolationSegmentMetadata struct {
 	*common.Metadata
 	*IsolationSegment
+	// Guids of the orgs entitled to use this isolation segment
+	EntitledOrgGuids []string
 }
 
 func NewIsolationSegmentMetadata(isoSeg IsolationSegment) *IsolationSegmentMetadata {
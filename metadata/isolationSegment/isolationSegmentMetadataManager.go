@@ -16,6 +16,7 @@
 package isolationSegment
 
 import (
+	"encoding/json"
 	"net/url"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
@@ -66,6 +67,8 @@ func (mdMgr *IsolationSegmentMetadataManager) LoadAllItems() {
 		SharedIsolationSegment = NewIsolationSegmentMetadata(IsolationSegment{EntityCommon: common.EntityCommon{Guid: DefaultIsolationSegmentGuid}, Name: SharedIsolationSegmentName})
 	}
 
+	mdMgr.loadEntitledOrgs()
+
 	toplog.Debug("*** isoseg total map size: %v", len(mdMgr.MetadataMap))
 	// for _, metadata := range mdMgr.MetadataMap {
 	// 	isoSeg := metadata.(*IsolationSegmentMetadata)
@@ -73,6 +76,29 @@ func (mdMgr *IsolationSegmentMetadataManager) LoadAllItems() {
 	// }
 }
 
+// Load the orgs entitled to each isolation segment
+func (mdMgr *IsolationSegmentMetadataManager) loadEntitledOrgs() {
+	for _, isoSeg := range mdMgr.GetAll() {
+		url := mdMgr.GetUrl() + "/" + isoSeg.Guid + "/relationships/organizations"
+		output, err := common.CallAPI(mdMgr.GetMdGlobalManager().GetCliConnection(), url)
+		if err != nil {
+			toplog.Warn("*** %v error: %v", url, err.Error())
+			continue
+		}
+		var response IsolationSegmentOrgsResponse
+		err = json.Unmarshal([]byte(output), &response)
+		if err != nil {
+			toplog.Warn("*** %v unmarshal parsing output: %v", url, output)
+			continue
+		}
+		orgGuids := make([]string, 0, len(response.Data))
+		for _, org := range response.Data {
+			orgGuids = append(orgGuids, org.Guid)
+		}
+		isoSeg.EntitledOrgGuids = orgGuids
+	}
+}
+
 func (mdMgr *IsolationSegmentMetadataManager) findMetadataByName(name string) *IsolationSegmentMetadata {
 	if name == "" {
 		return nil
@@ -42,4 +42,6 @@ type OrgQuota struct {
 	TrialDbAllowed          bool   `json:"trial_db_allowed"`
 	InstanceMemoryLimit     int    `json:"instance_memory_limit"`
 	AppInstanceLimit        int    `json:"app_instance_limit"`
+	AppTaskLimit            int    `json:"app_task_limit"`
+	TotalReservedRoutePorts int    `json:"total_reserved_route_ports"`
 }
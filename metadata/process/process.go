@@ -0,0 +1,61 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+
+// Process type of the process that receives HTTP traffic.  The web process guid is the
+// same as the app guid and is the only process type known to the /v2 app API.
+const WebProcessType = "web"
+
+type ProcessResponse struct {
+	Pagination common.Pagination `json:"pagination"`
+	Resources  []Process         `json:"resources"`
+}
+
+type Process struct {
+	common.EntityCommon
+	//Guid string `json:"guid"`
+	Type        string  `json:"type"`
+	Command     string  `json:"command"`
+	Instances   float64 `json:"instances"`
+	MemoryMB    float64 `json:"memory_in_mb"`
+	DiskQuotaMB float64 `json:"disk_in_mb"`
+
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+
+	// Newer cloud controllers return the app guid as a relationship,
+	// older ones only return a link to the app
+	Relationships ProcessRelationships `json:"relationships"`
+	Links         ProcessLinks         `json:"links"`
+}
+
+type ProcessRelationships struct {
+	App ProcessRelationship `json:"app"`
+}
+
+type ProcessRelationship struct {
+	Data ProcessRelationshipData `json:"data"`
+}
+
+type ProcessRelationshipData struct {
+	Guid string `json:"guid"`
+}
+
+type ProcessLinks struct {
+	App common.Link `json:"app"`
+}
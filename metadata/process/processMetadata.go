@@ -0,0 +1,60 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+)
+
+type ProcessMetadata struct {
+	*common.Metadata
+	*Process
+
+	AppGuid string
+}
+
+func NewProcessMetadata(process Process) *ProcessMetadata {
+	processMetadata := &ProcessMetadata{}
+	processMetadata.Metadata = common.NewMetadata()
+	processMetadata.Process = &process
+	processMetadata.AppGuid = findAppGuid(&process)
+	return processMetadata
+}
+
+func NewProcessMetadataById(guid string) *ProcessMetadata {
+	return NewProcessMetadata(Process{EntityCommon: common.EntityCommon{Guid: guid}})
+}
+
+// Processes do not have a name, the type (web, worker, etc) is unique within an app
+func (metadataItem *ProcessMetadata) GetName() string {
+	return metadataItem.Type
+}
+
+func (metadataItem *ProcessMetadata) IsWeb() bool {
+	return metadataItem.Type == WebProcessType
+}
+
+func findAppGuid(process *Process) string {
+	appGuid := process.Relationships.App.Data.Guid
+	if appGuid == "" && process.Links.App.Href != "" {
+		// Link format: https://api.example.org/v3/apps/<GUID>
+		href := process.Links.App.Href
+		appGuid = href[strings.LastIndex(href, "/")+1:]
+	}
+	return appGuid
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+type ProcessMetadataManager struct {
+	*common.CommonV2ResponseManager
+}
+
+func NewProcessMetadataManager(mdGlobalManager common.MdGlobalManagerInterface) *ProcessMetadataManager {
+	url := "/v3/processes"
+	mdMgr := &ProcessMetadataManager{}
+	mdMgr.CommonV2ResponseManager = common.NewCommonV2ResponseManager(mdGlobalManager, common.PROCESS, url, mdMgr, false)
+	// Replace the default handler so a load request of an app loads all of its processes
+	common.RegisterMetadataHandler(common.PROCESS, mdMgr)
+	return mdMgr
+}
+
+// Load requests are keyed by app guid (which is also the guid of the app's web process)
+func (mdMgr *ProcessMetadataManager) MetadataLoadMethod(guid string) error {
+	if guid == common.ALL {
+		return mdMgr.LoadAllItems()
+	}
+	return mdMgr.LoadAppProcesses(guid)
+}
+
+// Load all processes (web, worker, etc) of the given app.  This finds process types
+// added to the app since the last full load and removes those no longer defined.
+func (mdMgr *ProcessMetadataManager) LoadAppProcesses(appId string) error {
+	start := time.Now()
+	query := url.Values{}
+	query.Set("app_guids", appId)
+	_, err := mdMgr.GetMetadataFromUrl(mdMgr.GetUrl() + "?" + query.Encode())
+	if err != nil {
+		toplog.Warn("*** process metadata error for app %v: %v", appId, err.Error())
+		return err
+	}
+	for _, processMetadata := range mdMgr.FindByApp(appId) {
+		cacheTime := processMetadata.GetCacheTime()
+		if cacheTime != nil && cacheTime.Before(start) {
+			mdMgr.DeleteItem(processMetadata.Guid)
+		}
+	}
+	return nil
+}
+
+func (mdMgr *ProcessMetadataManager) FindItem(guid string) *ProcessMetadata {
+	return mdMgr.FindItemInternal(guid, false, true).(*ProcessMetadata)
+}
+
+// Find process by guid. Returns nil if process is not in the metadata cache
+func (mdMgr *ProcessMetadataManager) FindProcess(guid string) *ProcessMetadata {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	metadata := mdMgr.MetadataMap[guid]
+	if metadata == nil {
+		return nil
+	}
+	return metadata.(*ProcessMetadata)
+}
+
+func (mdMgr *ProcessMetadataManager) GetAll() []*ProcessMetadata {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	metadataArray := []*ProcessMetadata{}
+	for _, metadata := range mdMgr.MetadataMap {
+		metadataArray = append(metadataArray, metadata.(*ProcessMetadata))
+	}
+	return metadataArray
+}
+
+// Find all processes (web, worker, etc) that belong to the given app
+func (mdMgr *ProcessMetadataManager) FindByApp(appId string) []*ProcessMetadata {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	metadataArray := []*ProcessMetadata{}
+	for _, metadata := range mdMgr.MetadataMap {
+		processMetadata := metadata.(*ProcessMetadata)
+		if processMetadata.AppGuid == appId {
+			metadataArray = append(metadataArray, processMetadata)
+		}
+	}
+	return metadataArray
+}
+
+// Find the process of the given type that belongs to the given app.  Returns nil if not found
+func (mdMgr *ProcessMetadataManager) FindByAppAndType(appId string, processType string) *ProcessMetadata {
+	for _, processMetadata := range mdMgr.FindByApp(appId) {
+		if processMetadata.Type == processType {
+			return processMetadata
+		}
+	}
+	return nil
+}
+
+func (mdMgr *ProcessMetadataManager) NewItemById(guid string) common.IMetadata {
+	return NewProcessMetadataById(guid)
+}
+
+func (mdMgr *ProcessMetadataManager) CreateResponseObject() common.IResponse {
+	return &ProcessResponse{}
+}
+
+func (mdMgr *ProcessMetadataManager) CreateResourceObject() common.IResource {
+	return &Process{}
+}
+
+func (mdMgr *ProcessMetadataManager) CreateMetadataEntityObject(guid string) common.IMetadata {
+	return NewProcessMetadataById(guid)
+}
+
+func (mdMgr *ProcessMetadataManager) ProcessResponse(response common.IResponse, metadataArray []common.IMetadata) []common.IMetadata {
+	resp := response.(*ProcessResponse)
+	for _, item := range resp.Resources {
+		itemMd := mdMgr.ProcessResource(&item)
+		metadataArray = append(metadataArray, itemMd)
+	}
+	return metadataArray
+}
+
+func (mdMgr *ProcessMetadataManager) ProcessResource(resource common.IResource) common.IMetadata {
+	resourceType := resource.(*Process)
+	metadata := NewProcessMetadata(*resourceType)
+	return metadata
+}
+
+func (mdMgr *ProcessMetadataManager) GetNextUrl(response common.IResponse) string {
+	processResponse := response.(*ProcessResponse)
+	href := processResponse.Pagination.Next.Href
+	if href != "" {
+		// The v3 API returns the full URL (including hostname), we just want the URI (path)
+		url, _ := url.Parse(href)
+		nextUrl := url.RequestURI()
+		return nextUrl
+	} else {
+		return ""
+	}
+}
@@ -17,6 +17,7 @@ package route
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
@@ -26,6 +27,8 @@ import (
 type RouteMetadataManager struct {
 	*common.CommonV2ResponseManager
 	internalRoutesMetadataCache []*RouteMetadata
+	// Internal routes are created by the ingest partitions concurrently
+	internalRoutesMutex sync.Mutex
 	// Key: routeId, value: list of AppId
 	appsForRouteCache map[string][]string
 }
@@ -39,6 +42,14 @@ func NewRouteMetadataManager(mdGlobalManager common.MdGlobalManagerInterface) *R
 }
 
 func (mdMgr *RouteMetadataManager) CreateInternalGeneratedRoute(hostName string, pathName string, domainGuid string, port int) *RouteMetadata {
+	mdMgr.internalRoutesMutex.Lock()
+	defer mdMgr.internalRoutesMutex.Unlock()
+	// Reuse the route if another partition already created it
+	for _, routeMd := range mdMgr.internalRoutesMetadataCache {
+		if routeMd.Host == hostName && routeMd.Path == pathName && routeMd.DomainGuid == domainGuid && routeMd.Port == port {
+			return routeMd
+		}
+	}
 	route := &Route{
 		EntityCommon:      common.EntityCommon{Guid: util.Pseudo_uuid()},
 		Host:              hostName,
@@ -55,6 +66,8 @@ func (mdMgr *RouteMetadataManager) CreateInternalGeneratedRoute(hostName string,
 func (mdMgr *RouteMetadataManager) FindItem(guid string) *RouteMetadata {
 	foundRouteMd := mdMgr.FindItemInternal(guid, false, false)
 	if foundRouteMd == nil {
+		mdMgr.internalRoutesMutex.Lock()
+		defer mdMgr.internalRoutesMutex.Unlock()
 		for _, route := range mdMgr.internalRoutesMetadataCache {
 			if route.Guid == guid {
 				return route
@@ -0,0 +1,39 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serviceInstance
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+
+type ServiceInstanceResponse struct {
+	Count     int                       `json:"total_results"`
+	Pages     int                       `json:"total_pages"`
+	NextUrl   string                    `json:"next_url"`
+	Resources []ServiceInstanceResource `json:"resources"`
+}
+
+type ServiceInstanceResource struct {
+	Meta   common.Meta     `json:"metadata"`
+	Entity ServiceInstance `json:"entity"`
+}
+
+// Managed service instance.  NOTE: User provided service instances are not
+// counted against the org/space total_services quota so are not loaded.
+type ServiceInstance struct {
+	common.EntityCommon
+	Name            string `json:"name"`
+	SpaceGuid       string `json:"space_guid"`
+	ServicePlanGuid string `json:"service_plan_guid"`
+	Type            string `json:"type"`
+}
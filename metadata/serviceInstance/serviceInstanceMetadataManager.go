@@ -0,0 +1,83 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serviceInstance
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+
+type ServiceInstanceMetadataManager struct {
+	*common.CommonV2ResponseManager
+}
+
+func NewServiceInstanceMetadataManager(mdGlobalManager common.MdGlobalManagerInterface) *ServiceInstanceMetadataManager {
+	url := "/v2/service_instances"
+	mdMgr := &ServiceInstanceMetadataManager{}
+	mdMgr.CommonV2ResponseManager = common.NewCommonV2ResponseManager(mdGlobalManager, common.SERVICE_INSTANCE, url, mdMgr, false)
+	return mdMgr
+}
+
+func (mdMgr *ServiceInstanceMetadataManager) FindItem(guid string) *ServiceInstanceMetadata {
+	return mdMgr.FindItemInternal(guid, false, true).(*ServiceInstanceMetadata)
+}
+
+func (mdMgr *ServiceInstanceMetadataManager) GetAll() []*ServiceInstanceMetadata {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	metadataArray := []*ServiceInstanceMetadata{}
+	for _, metadata := range mdMgr.MetadataMap {
+		metadataArray = append(metadataArray, metadata.(*ServiceInstanceMetadata))
+	}
+	return metadataArray
+}
+
+// Count of service instances by space guid
+func (mdMgr *ServiceInstanceMetadataManager) CountBySpace() map[string]int {
+	countMap := make(map[string]int)
+	for _, serviceInstanceMetadata := range mdMgr.GetAll() {
+		countMap[serviceInstanceMetadata.SpaceGuid]++
+	}
+	return countMap
+}
+
+func (mdMgr *ServiceInstanceMetadataManager) NewItemById(guid string) common.IMetadata {
+	return NewServiceInstanceMetadataById(guid)
+}
+
+func (mdMgr *ServiceInstanceMetadataManager) CreateResponseObject() common.IResponse {
+	return &ServiceInstanceResponse{}
+}
+
+func (mdMgr *ServiceInstanceMetadataManager) CreateResourceObject() common.IResource {
+	return &ServiceInstanceResource{}
+}
+
+func (mdMgr *ServiceInstanceMetadataManager) CreateMetadataEntityObject(guid string) common.IMetadata {
+	return NewServiceInstanceMetadataById(guid)
+}
+
+func (mdMgr *ServiceInstanceMetadataManager) ProcessResponse(response common.IResponse, metadataArray []common.IMetadata) []common.IMetadata {
+	resp := response.(*ServiceInstanceResponse)
+	for _, item := range resp.Resources {
+		itemMd := mdMgr.ProcessResource(&item)
+		metadataArray = append(metadataArray, itemMd)
+	}
+	return metadataArray
+}
+
+func (mdMgr *ServiceInstanceMetadataManager) ProcessResource(resource common.IResource) common.IMetadata {
+	resourceType := resource.(*ServiceInstanceResource)
+	resourceType.Entity.Guid = resourceType.Meta.Guid
+	metadata := NewServiceInstanceMetadata(resourceType.Entity)
+	return metadata
+}
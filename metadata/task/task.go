@@ -0,0 +1,73 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+
+const (
+	StatePending   = "PENDING"
+	StateRunning   = "RUNNING"
+	StateSucceeded = "SUCCEEDED"
+	StateCanceling = "CANCELING"
+	StateFailed    = "FAILED"
+)
+
+type TaskResponse struct {
+	Pagination common.Pagination `json:"pagination"`
+	Resources  []Task            `json:"resources"`
+}
+
+type Task struct {
+	common.EntityCommon
+	//Guid string `json:"guid"`
+	Name        string  `json:"name"`
+	SequenceId  int     `json:"sequence_id"`
+	State       string  `json:"state"`
+	MemoryMB    float64 `json:"memory_in_mb"`
+	DiskQuotaMB float64 `json:"disk_in_mb"`
+	DropletGuid string  `json:"droplet_guid"`
+
+	Result TaskResult `json:"result"`
+
+	// "created_at": "2016-05-04T17:00:41Z",
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+
+	// Newer cloud controllers return the app guid as a relationship,
+	// older ones only return a link to the app
+	Relationships TaskRelationships `json:"relationships"`
+	Links         TaskLinks         `json:"links"`
+}
+
+type TaskResult struct {
+	FailureReason string `json:"failure_reason"`
+}
+
+type TaskRelationships struct {
+	App TaskRelationship `json:"app"`
+}
+
+type TaskRelationship struct {
+	Data TaskRelationshipData `json:"data"`
+}
+
+type TaskRelationshipData struct {
+	Guid string `json:"guid"`
+}
+
+type TaskLinks struct {
+	App common.Link `json:"app"`
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+)
+
+const timestampLayout = "2006-01-02T15:04:05Z"
+
+type TaskMetadata struct {
+	*common.Metadata
+	*Task
+
+	AppGuid     string
+	CreatedTime *time.Time
+	UpdatedTime *time.Time
+}
+
+func NewTaskMetadata(task Task) *TaskMetadata {
+	taskMetadata := &TaskMetadata{}
+	taskMetadata.Metadata = common.NewMetadata()
+	taskMetadata.Task = &task
+	taskMetadata.AppGuid = findAppGuid(&task)
+	taskMetadata.CreatedTime = parseTimestamp(task.CreatedAt)
+	taskMetadata.UpdatedTime = parseTimestamp(task.UpdatedAt)
+	return taskMetadata
+}
+
+func NewTaskMetadataById(guid string) *TaskMetadata {
+	return NewTaskMetadata(Task{EntityCommon: common.EntityCommon{Guid: guid}, Name: guid})
+}
+
+func (metadataItem *TaskMetadata) GetName() string {
+	return metadataItem.Name
+}
+
+// A task is complete once it has reached a terminal state (SUCCEEDED or FAILED)
+func (metadataItem *TaskMetadata) IsComplete() bool {
+	return metadataItem.State == StateSucceeded || metadataItem.State == StateFailed
+}
+
+func findAppGuid(task *Task) string {
+	appGuid := task.Relationships.App.Data.Guid
+	if appGuid == "" && task.Links.App.Href != "" {
+		// Link format: https://api.example.org/v3/apps/<GUID>
+		href := task.Links.App.Href
+		appGuid = href[strings.LastIndex(href, "/")+1:]
+	}
+	return appGuid
+}
+
+func parseTimestamp(timestamp string) *time.Time {
+	if timestamp == "" {
+		return nil
+	}
+	parsedTime, err := time.Parse(timestampLayout, timestamp)
+	if err != nil {
+		return nil
+	}
+	return &parsedTime
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// Allow for clock skew between this host and cloud controller when asking
+// for tasks updated since the last load
+const updatedSinceSkew = time.Second * 10
+
+type TaskMetadataManager struct {
+	*common.CommonV2ResponseManager
+
+	lastFullLoadTime     *time.Time
+	lastFullLoadTimeLock sync.Mutex
+
+	// Tasks keyed by appGuid/name so log messages can find their task without
+	// scanning all tasks.  Guarded by MetadataMapMutex and rebuilt after each load.
+	appNameIndex map[string][]*TaskMetadata
+}
+
+func NewTaskMetadataManager(mdGlobalManager common.MdGlobalManagerInterface) *TaskMetadataManager {
+	url := "/v3/tasks"
+	mdMgr := &TaskMetadataManager{}
+	mdMgr.appNameIndex = make(map[string][]*TaskMetadata)
+	mdMgr.CommonV2ResponseManager = common.NewCommonV2ResponseManager(mdGlobalManager, common.TASK, url, mdMgr, false)
+	// Replace the default handler so a load request of ALL does an incremental refresh
+	common.RegisterMetadataHandler(common.TASK, mdMgr)
+	return mdMgr
+}
+
+func (mdMgr *TaskMetadataManager) MetadataLoadMethod(guid string) error {
+	if guid == common.ALL {
+		return mdMgr.RefreshItems()
+	}
+	err := mdMgr.LoadItem(guid)
+	mdMgr.rebuildIndex()
+	return err
+}
+
+// Load the active (pending, running or canceling) tasks.  The full task history of a
+// foundation can be very large so completed tasks are only loaded by RefreshItems when
+// they change state after we have started.
+func (mdMgr *TaskMetadataManager) LoadAllItems() error {
+	start := time.Now()
+	query := url.Values{}
+	query.Set("states", StatePending+","+StateRunning+","+StateCanceling)
+	_, err := mdMgr.GetMetadataFromUrl(mdMgr.GetUrl() + "?" + query.Encode())
+	if err != nil {
+		toplog.Warn("*** task metadata error: %v", err.Error())
+		return err
+	}
+
+	// Remove anything not reloaded (tasks that completed while we were not watching)
+	mdMgr.MetadataMapMutex.Lock()
+	for guid, metadataItem := range mdMgr.MetadataMap {
+		cacheTime := metadataItem.GetCacheTime()
+		if cacheTime != nil && cacheTime.Before(start) {
+			delete(mdMgr.MetadataMap, guid)
+		}
+	}
+	mdMgr.MetadataMapMutex.Unlock()
+	mdMgr.rebuildIndex()
+
+	mdMgr.setLastFullLoadTime(&start)
+	return nil
+}
+
+// Load only the tasks that have been created or changed state since the last load.
+// Falls back to loading the active tasks if we have never loaded or if cloud
+// controller does not support the updated_ats filter.
+func (mdMgr *TaskMetadataManager) RefreshItems() error {
+	lastFullLoadTime := mdMgr.LastFullLoadTime()
+	if lastFullLoadTime == nil {
+		return mdMgr.LoadAllItems()
+	}
+	start := time.Now()
+	query := url.Values{}
+	query.Set("updated_ats[gte]", lastFullLoadTime.Add(-updatedSinceSkew).UTC().Format(timestampLayout))
+	_, err := mdMgr.GetMetadataFromUrl(mdMgr.GetUrl() + "?" + query.Encode())
+	if err != nil {
+		toplog.Info("Task metadata incremental load failed, loading active tasks: %v", err.Error())
+		return mdMgr.LoadAllItems()
+	}
+	mdMgr.pruneCompleted(start)
+	mdMgr.rebuildIndex()
+	mdMgr.setLastFullLoadTime(&start)
+	return nil
+}
+
+// Remove tasks that completed more then TaskRetentionSeconds ago.  The incremental
+// load only adds tasks so without this the cache grows for the life of top.
+func (mdMgr *TaskMetadataManager) pruneCompleted(now time.Time) {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	for guid, metadata := range mdMgr.MetadataMap {
+		if mdMgr.isExpired(metadata.(*TaskMetadata), now) {
+			delete(mdMgr.MetadataMap, guid)
+		}
+	}
+}
+
+func (mdMgr *TaskMetadataManager) isExpired(taskMetadata *TaskMetadata, now time.Time) bool {
+	return taskMetadata.IsComplete() && taskMetadata.UpdatedTime != nil &&
+		now.Sub(*taskMetadata.UpdatedTime) > config.TaskRetentionSeconds*time.Second
+}
+
+// Rebuild the appGuid/name index from the loaded tasks
+func (mdMgr *TaskMetadataManager) rebuildIndex() {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	appNameIndex := make(map[string][]*TaskMetadata)
+	for _, metadata := range mdMgr.MetadataMap {
+		taskMetadata := metadata.(*TaskMetadata)
+		key := appNameKey(taskMetadata.AppGuid, taskMetadata.Name)
+		appNameIndex[key] = append(appNameIndex[key], taskMetadata)
+	}
+	mdMgr.appNameIndex = appNameIndex
+}
+
+func appNameKey(appId string, taskName string) string {
+	return appId + "/" + taskName
+}
+
+func (mdMgr *TaskMetadataManager) FindItem(guid string) *TaskMetadata {
+	return mdMgr.FindItemInternal(guid, false, true).(*TaskMetadata)
+}
+
+// Find a loaded task.  Unlike FindItem an empty task is not created.  Returns nil if not loaded
+func (mdMgr *TaskMetadataManager) FindLoadedItem(guid string) *TaskMetadata {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	metadata := mdMgr.MetadataMap[guid]
+	if metadata == nil {
+		return nil
+	}
+	return metadata.(*TaskMetadata)
+}
+
+func (mdMgr *TaskMetadataManager) GetAll() []*TaskMetadata {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	metadataArray := []*TaskMetadata{}
+	for _, metadata := range mdMgr.MetadataMap {
+		metadataArray = append(metadataArray, metadata.(*TaskMetadata))
+	}
+	return metadataArray
+}
+
+// Find all tasks that belong to the given app
+func (mdMgr *TaskMetadataManager) FindByApp(appId string) []*TaskMetadata {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	metadataArray := []*TaskMetadata{}
+	for _, metadata := range mdMgr.MetadataMap {
+		taskMetadata := metadata.(*TaskMetadata)
+		if taskMetadata.AppGuid == appId {
+			metadataArray = append(metadataArray, taskMetadata)
+		}
+	}
+	return metadataArray
+}
+
+// Find the task with the given name that was running at the given time.  Task names
+// can be reused so this is the most recently created task with that name that
+// was created at or before msgTime.  Returns nil if not found.
+func (mdMgr *TaskMetadataManager) FindByAppAndName(appId string, taskName string, msgTime time.Time) *TaskMetadata {
+	mdMgr.MetadataMapMutex.Lock()
+	defer mdMgr.MetadataMapMutex.Unlock()
+	var found *TaskMetadata
+	for _, taskMetadata := range mdMgr.appNameIndex[appNameKey(appId, taskName)] {
+		if taskMetadata.CreatedTime == nil || taskMetadata.CreatedTime.After(msgTime) {
+			continue
+		}
+		if found == nil || taskMetadata.CreatedTime.After(*found.CreatedTime) {
+			found = taskMetadata
+		}
+	}
+	return found
+}
+
+// Time the last successful load of tasks started or nil if never loaded
+func (mdMgr *TaskMetadataManager) LastFullLoadTime() *time.Time {
+	mdMgr.lastFullLoadTimeLock.Lock()
+	defer mdMgr.lastFullLoadTimeLock.Unlock()
+	return mdMgr.lastFullLoadTime
+}
+
+func (mdMgr *TaskMetadataManager) setLastFullLoadTime(loadTime *time.Time) {
+	mdMgr.lastFullLoadTimeLock.Lock()
+	defer mdMgr.lastFullLoadTimeLock.Unlock()
+	mdMgr.lastFullLoadTime = loadTime
+}
+
+func (mdMgr *TaskMetadataManager) NewItemById(guid string) common.IMetadata {
+	return NewTaskMetadataById(guid)
+}
+
+func (mdMgr *TaskMetadataManager) CreateResponseObject() common.IResponse {
+	return &TaskResponse{}
+}
+
+func (mdMgr *TaskMetadataManager) CreateResourceObject() common.IResource {
+	return &Task{}
+}
+
+func (mdMgr *TaskMetadataManager) CreateMetadataEntityObject(guid string) common.IMetadata {
+	return NewTaskMetadataById(guid)
+}
+
+func (mdMgr *TaskMetadataManager) ProcessResponse(response common.IResponse, metadataArray []common.IMetadata) []common.IMetadata {
+	resp := response.(*TaskResponse)
+	for _, item := range resp.Resources {
+		itemMd := mdMgr.ProcessResource(&item)
+		metadataArray = append(metadataArray, itemMd)
+	}
+	return metadataArray
+}
+
+func (mdMgr *TaskMetadataManager) ProcessResource(resource common.IResource) common.IMetadata {
+	resourceType := resource.(*Task)
+	metadata := NewTaskMetadata(*resourceType)
+	return metadata
+}
+
+func (mdMgr *TaskMetadataManager) GetNextUrl(response common.IResponse) string {
+	taskResponse := response.(*TaskResponse)
+	href := taskResponse.Pagination.Next.Href
+	if href != "" {
+		// The v3 API returns the full URL (including hostname), we just want the URI (path)
+		url, _ := url.Parse(href)
+		nextUrl := url.RequestURI()
+		return nextUrl
+	} else {
+		return ""
+	}
+}
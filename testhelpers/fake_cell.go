@@ -0,0 +1,36 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testhelpers
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+// A cflinuxfs3 cell that reports the given capacity with all of it remaining
+func NewFakeCell(ip string, isolationSegmentGuid string, memoryGB int64, diskGB int64, containers int) *eventCell.CellStats {
+	return &eventCell.CellStats{
+		Ip:                          ip,
+		IsolationSegmentGuid:        isolationSegmentGuid,
+		StackGroupId:                "cflinuxfs3",
+		CapacityMemoryTotal:         memoryGB * util.GIGABYTE,
+		CapacityMemoryRemaining:     memoryGB * util.GIGABYTE,
+		CapacityDiskTotal:           diskGB * util.GIGABYTE,
+		CapacityDiskRemaining:       diskGB * util.GIGABYTE,
+		CapacityTotalContainers:     containers,
+		CapacityRemainingContainers: containers,
+	}
+}
@@ -31,6 +31,8 @@ import (
 	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/gorilla/websocket"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui"
@@ -57,6 +59,14 @@ type ClientOptions struct {
 	NoTopCheck bool
 	Cygwin     bool
 	Nozzles    int
+	NozzlesMin int
+	NozzlesMax int
+
+	QuotaWarnPercent  int
+	QuotaAlertPercent int
+
+	// Optional JSON file of cell metric mappings (see eventCell.LoadCellMetricMappings)
+	CellMetricsFile string
 }
 
 // NewClient instantiating the top client
@@ -91,6 +101,17 @@ func (c *Client) Start() {
 	}
 
 	toplog.SetDebugEnabled(c.options.Debug)
+	config.QuotaWarnPercent = c.options.QuotaWarnPercent
+	config.QuotaAlertPercent = c.options.QuotaAlertPercent
+	config.NozzleMinCount = c.options.NozzlesMin
+	config.NozzleMaxCount = c.options.NozzlesMax
+
+	if c.options.CellMetricsFile != "" {
+		if err := eventCell.LoadCellMetricMappings(c.options.CellMetricsFile); err != nil {
+			c.ui.Failed(err.Error())
+			return
+		}
+	}
 
 	conn := c.cliConnection
 
@@ -151,9 +172,9 @@ func (c *Client) setupFirehoseConnections(privileged bool) (map[string]bool, err
 	if privileged {
 		toplog.Info("Running with doppler.firehose privileges - opening %v nozzles", c.options.Nozzles)
 		subscriptionID := "TopPlugin_" + util.Pseudo_uuid()
-		for i := 0; i < c.options.Nozzles; i++ {
-			go c.createAndKeepAliveNozzle(subscriptionID, "", i)
-		}
+		nozzleScaler := NewNozzleScaler(c, subscriptionID, c.options.Nozzles)
+		c.router.SetNozzleController(nozzleScaler)
+		nozzleScaler.Start()
 		toplog.Info("Starting %v firehose nozzle instances", c.options.Nozzles)
 		return nil, nil
 	}
@@ -192,7 +213,7 @@ func (c *Client) setupFirehoseConnections(privileged bool) (map[string]bool, err
 			break
 		}
 		toplog.Info("Starting app nozzle #%v instance for App %s", i, application.Name)
-		go c.createAndKeepAliveNozzle("", application.Guid, i)
+		go c.createAndKeepAliveNozzle("", application.Guid, i, nil)
 		monitoredAppGuids[application.Guid] = true
 		// TODO: Need to come up with a way for user to specify (or select on UI) which apps will be monitored
 		// if the max is reached -- does't make sense to just choose the first n apps returned from the API
@@ -201,7 +222,9 @@ func (c *Client) setupFirehoseConnections(privileged bool) (map[string]bool, err
 	return monitoredAppGuids, nil
 }
 
-func (c *Client) createAndKeepAliveNozzle(subscriptionID, appGUID string, instanceID int) error {
+// createAndKeepAliveNozzle runs a nozzle until stop is closed.  A nil stop channel
+// runs the nozzle for the life of top.
+func (c *Client) createAndKeepAliveNozzle(subscriptionID, appGUID string, instanceID int, stop <-chan struct{}) error {
 	minRetrySeconds := (2 * time.Second)
 
 	for {
@@ -209,11 +232,21 @@ func (c *Client) createAndKeepAliveNozzle(subscriptionID, appGUID string, instan
 		startTime := time.Now()
 		var err error
 		if len(subscriptionID) > 0 {
-			err = c.createNozzle(subscriptionID, instanceID)
+			err = c.createNozzle(subscriptionID, instanceID, stop)
 		} else {
-			err = c.createAppNozzle(appGUID, instanceID)
+			err = c.createAppNozzle(appGUID, instanceID, stop)
+		}
+		if isStopped(stop) {
+			toplog.Info("Nozzle #%v - Stopped", instanceID)
+			break
 		}
 		if err != nil {
+			// Stream errors have already been recorded by handleError
+			recorded := false
+			if streamErr, ok := err.(recordedStreamError); ok {
+				err = streamErr.error
+				recorded = true
+			}
 			errMsg := err.Error()
 			notAuthorized := strings.Contains(errMsg, "authorized")
 			if websocket.IsCloseError(err, websocket.CloseNormalClosure) || notAuthorized {
@@ -226,6 +259,9 @@ func (c *Client) createAndKeepAliveNozzle(subscriptionID, appGUID string, instan
 				break
 			}
 			toplog.Warn("Nozzle #%v - error: %v", instanceID, err)
+			if !recorded {
+				c.router.NozzleError(instanceID, err, false)
+			}
 			/*
 				authorizationExpired := strings.Contains(errMsg, "auth request failed")
 				if authorizationExpired {
@@ -237,14 +273,32 @@ func (c *Client) createAndKeepAliveNozzle(subscriptionID, appGUID string, instan
 		lastRetry := time.Now().Sub(startTime)
 		if lastRetry < minRetrySeconds {
 			toplog.Info("Nozzle #%v - Nozzle instance restart too fast, delaying for %v", instanceID, minRetrySeconds)
-			time.Sleep(minRetrySeconds)
+			select {
+			case <-stop:
+			case <-time.After(minRetrySeconds):
+			}
 		}
 	}
 	return nil
 }
 
+// recordedStreamError wraps a stream error that handleError has already
+// reported to the router so it is not counted a second time
+type recordedStreamError struct {
+	error
+}
+
+func isStopped(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
 // createNozzle uses 'firehose' API - one instance will get all firehose events
-func (c *Client) createNozzle(subscriptionID string, instanceID int) error {
+func (c *Client) createNozzle(subscriptionID string, instanceID int, stop <-chan struct{}) error {
 	// Delay each nozzle instance creation by 1 second
 	// We do this so we don't have a flood of API request
 	time.Sleep(time.Duration(instanceID) * time.Second)
@@ -275,19 +329,21 @@ func (c *Client) createNozzle(subscriptionID string, instanceID int) error {
 	defer dopplerConnection.Close()
 
 	toplog.Info("Nozzle #%v - Started", instanceID)
+	c.router.NozzleConnected(instanceID, "")
+	defer c.router.NozzleDisconnected(instanceID)
 
-	eventError := c.routeEvents(instanceID, messages, errors)
+	eventError := c.routeEvents(instanceID, messages, errors, stop)
 	if eventError != nil {
 		msg := eventError.Error()
 		if strings.Contains(msg, "Invalid authorization") {
-			return eventError
+			return recordedStreamError{eventError}
 		}
 	}
 	return nil
 }
 
 // createAppNozzle uses'Stream' API - must be called once per app we are monitoring
-func (c *Client) createAppNozzle(appGUID string, instanceID int) error {
+func (c *Client) createAppNozzle(appGUID string, instanceID int, stop <-chan struct{}) error {
 
 	// Delay each nozzle instance creation by 100 milliseconds
 	// We do this so we don't have a flood of API requests
@@ -322,20 +378,24 @@ func (c *Client) createAppNozzle(appGUID string, instanceID int) error {
 	defer dopplerConnection.Close()
 
 	toplog.Info("Nozzle #%v for %s - Started", instanceID, appGUID)
+	c.router.NozzleConnected(instanceID, appGUID)
+	defer c.router.NozzleDisconnected(instanceID)
 
-	eventError := c.routeEvents(instanceID, messages, errors)
+	eventError := c.routeEvents(instanceID, messages, errors, stop)
 	if eventError != nil {
 		msg := eventError.Error()
 		if strings.Contains(msg, "Invalid authorization") {
-			return eventError
+			return recordedStreamError{eventError}
 		}
 	}
 	return nil
 }
 
-func (c *Client) routeEvents(instanceID int, messages <-chan *events.Envelope, errors <-chan error) error {
+func (c *Client) routeEvents(instanceID int, messages <-chan *events.Envelope, errors <-chan error, stop <-chan struct{}) error {
 	for {
 		select {
+		case <-stop:
+			return nil
 		case envelope := <-messages:
 			c.router.Route(instanceID, envelope)
 		case err := <-errors:
@@ -349,6 +409,7 @@ func (c *Client) routeEvents(instanceID int, messages <-chan *events.Envelope, e
 
 func (c *Client) handleError(instanceID int, err error) {
 
+	slowConsumer := false
 	switch {
 	case websocket.IsCloseError(err, websocket.CloseNormalClosure):
 		msg := fmt.Sprintf("Nozzle #%v - Normal Websocket Closure: %v", instanceID, err)
@@ -356,10 +417,12 @@ func (c *Client) handleError(instanceID int, err error) {
 	case websocket.IsCloseError(err, websocket.ClosePolicyViolation):
 		msg := fmt.Sprintf("Nozzle #%v - Disconnected because nozzle couldn't keep up (CloseError): %v", instanceID, err)
 		toplog.Error(msg)
+		slowConsumer = true
 	default:
 		msg := fmt.Sprintf("Nozzle #%v - Error reading firehose: %v", instanceID, err)
 		toplog.Error(msg)
 	}
+	c.router.NozzleError(instanceID, err, slowConsumer)
 }
 
 func (c *Client) shouldExitTop() bool {
@@ -0,0 +1,201 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// NozzleScaler opens and closes firehose nozzles sharing one subscription ID.  Doppler
+// spreads the firehose across all connections with the same subscription ID so adding
+// nozzles increases the rate top can receive envelopes.
+type NozzleScaler struct {
+	client         *Client
+	subscriptionID string
+
+	mu      sync.Mutex
+	nozzles []*nozzleInstance
+	// Done channel of nozzles being stopped.  Key: instanceID
+	stopping  map[int]chan struct{}
+	autoScale bool
+
+	lastScaleTime      time.Time
+	lastDopplerDropped uint64
+	lastSlowConsumer   int
+	lowRateStartTime   *time.Time
+}
+
+type nozzleInstance struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewNozzleScaler(client *Client, subscriptionID string, count int) *NozzleScaler {
+	ns := &NozzleScaler{
+		client:         client,
+		subscriptionID: subscriptionID,
+		stopping:       make(map[int]chan struct{}),
+		autoScale:      config.NozzleMinCount < config.NozzleMaxCount,
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.scaleTo(time.Now(), count)
+	return ns
+}
+
+// Start the automatic scaling thread
+func (ns *NozzleScaler) Start() {
+	go ns.scaleThread()
+}
+
+func (ns *NozzleScaler) GetNozzleCount() int {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	return len(ns.nozzles)
+}
+
+func (ns *NozzleScaler) SetNozzleCount(count int) {
+	if count < 1 {
+		count = 1
+	}
+	if count > config.MaxNozzleCount {
+		count = config.MaxNozzleCount
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if ns.autoScale {
+		toplog.Info("Nozzle scaling - automatic scaling turned off by manual change")
+		ns.autoScale = false
+	}
+	if count != len(ns.nozzles) {
+		toplog.Info("Nozzle scaling - manually changed from %v to %v nozzles", len(ns.nozzles), count)
+		ns.scaleTo(time.Now(), count)
+	}
+}
+
+func (ns *NozzleScaler) IsAutoScale() bool {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	return ns.autoScale
+}
+
+// Automatic scaling can not be turned on if min and max nozzles are the same
+func (ns *NozzleScaler) SetAutoScale(autoScale bool) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.autoScale = autoScale && config.NozzleMinCount < config.NozzleMaxCount
+	ns.lowRateStartTime = nil
+}
+
+func (ns *NozzleScaler) GetScaleBounds() (int, int) {
+	return config.NozzleMinCount, config.NozzleMaxCount
+}
+
+func (ns *NozzleScaler) scaleThread() {
+	for {
+		time.Sleep(config.NozzleScaleCheckSeconds * time.Second)
+		ns.checkScale(time.Now())
+	}
+}
+
+func (ns *NozzleScaler) checkScale(now time.Time) {
+
+	// Stats of stopped nozzles are discarded so the totals can go down
+	dopplerDropped := uint64(0)
+	slowConsumer := 0
+	rate := float64(0)
+	for _, nozzleStats := range ns.client.router.GetNozzleStats() {
+		dopplerDropped = dopplerDropped + nozzleStats.DopplerDropped
+		slowConsumer = slowConsumer + nozzleStats.SlowConsumerCount
+		rate = rate + nozzleStats.MessageRate
+	}
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	// Envelopes dropped by the ingest queues are not counted -- that is top itself
+	// not keeping up and more nozzles would make it worse
+	dataLoss := dopplerDropped > ns.lastDopplerDropped || slowConsumer > ns.lastSlowConsumer
+	ns.lastDopplerDropped = dopplerDropped
+	ns.lastSlowConsumer = slowConsumer
+
+	if !ns.autoScale || now.Sub(ns.lastScaleTime) < config.NozzleScaleCooldownSeconds*time.Second {
+		return
+	}
+
+	count := len(ns.nozzles)
+	switch {
+	case count < config.NozzleMinCount:
+		toplog.Info("Nozzle scaling - increasing from %v to minimum of %v nozzles", count, config.NozzleMinCount)
+		ns.scaleTo(now, config.NozzleMinCount)
+	case count > config.NozzleMaxCount:
+		toplog.Info("Nozzle scaling - decreasing from %v to maximum of %v nozzles", count, config.NozzleMaxCount)
+		ns.scaleTo(now, config.NozzleMaxCount)
+	case dataLoss:
+		if count < config.NozzleMaxCount {
+			toplog.Info("Nozzle scaling - doppler dropped messages, increasing from %v to %v nozzles", count, count+1)
+			ns.scaleTo(now, count+1)
+		}
+	case count > config.NozzleMinCount && rate/float64(count-1) < config.NozzleScaleDownRate:
+		if ns.lowRateStartTime == nil {
+			ns.lowRateStartTime = &now
+		} else if now.Sub(*ns.lowRateStartTime) >= config.NozzleScaleDownSeconds*time.Second {
+			toplog.Info("Nozzle scaling - low envelope rate (%.0f/sec), decreasing from %v to %v nozzles", rate, count, count-1)
+			ns.scaleTo(now, count-1)
+		}
+	default:
+		ns.lowRateStartTime = nil
+	}
+}
+
+// Start or stop nozzles so count are running.  The highest instanceIDs are
+// stopped first so running nozzles are always numbered 0 to count-1.
+// Must be called with the lock held.
+func (ns *NozzleScaler) scaleTo(now time.Time, count int) {
+	for len(ns.nozzles) < count {
+		instanceID := len(ns.nozzles)
+		nozzle := &nozzleInstance{stop: make(chan struct{}), done: make(chan struct{})}
+		// A stopped nozzle with the same instanceID may still be closing its connection
+		previousDone := ns.stopping[instanceID]
+		delete(ns.stopping, instanceID)
+		go ns.runNozzle(instanceID, nozzle, previousDone)
+		ns.nozzles = append(ns.nozzles, nozzle)
+	}
+	for len(ns.nozzles) > count {
+		instanceID := len(ns.nozzles) - 1
+		nozzle := ns.nozzles[instanceID]
+		close(nozzle.stop)
+		ns.stopping[instanceID] = nozzle.done
+		ns.nozzles = ns.nozzles[:instanceID]
+	}
+	ns.lastScaleTime = now
+	ns.lowRateStartTime = nil
+}
+
+func (ns *NozzleScaler) runNozzle(instanceID int, nozzle *nozzleInstance, previousDone chan struct{}) {
+	defer close(nozzle.done)
+	if previousDone != nil {
+		<-previousDone
+	}
+	ns.client.createAndKeepAliveNozzle(ns.subscriptionID, "", instanceID, nozzle.stop)
+	if isStopped(nozzle.stop) {
+		ns.client.router.NozzleRemoved(instanceID)
+	}
+}
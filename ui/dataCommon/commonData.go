@@ -23,6 +23,7 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/process"
 )
 
 type CommonData struct {
@@ -41,6 +42,16 @@ type CommonData struct {
 	appsNotInDesiredState int
 	totalCrash1hCount     int
 	totalCrash24hCount    int
+
+	// Quota usage by org guid and by space guid
+	orgQuotaUsageMap   map[string]*QuotaUsage
+	spaceQuotaUsageMap map[string]*QuotaUsage
+	quotaHistoryMap    map[string]*quotaHistory
+	// Count of orgs/spaces at or above the warn (but below alert) and alert thresholds
+	quotaWarnCount  int
+	quotaAlertCount int
+	// Count of orgs/spaces forecast to reach a quota limit soon
+	quotaForecastCount int
 }
 
 // TODO:  Create a common data struct -- which needs access to masterUI
@@ -76,6 +87,31 @@ func (cd *CommonData) TotalCrash24hCount() int {
 	return cd.totalCrash24hCount
 }
 
+func (cd *CommonData) GetOrgQuotaUsageMap() map[string]*QuotaUsage {
+	return cd.orgQuotaUsageMap
+}
+
+func (cd *CommonData) GetSpaceQuotaUsageMap() map[string]*QuotaUsage {
+	return cd.spaceQuotaUsageMap
+}
+
+func (cd *CommonData) QuotaWarnCount() int {
+	return cd.quotaWarnCount
+}
+
+func (cd *CommonData) QuotaAlertCount() int {
+	return cd.quotaAlertCount
+}
+
+func (cd *CommonData) QuotaForecastCount() int {
+	return cd.quotaForecastCount
+}
+
+// Nozzle health is live (not from the displayed snapshot)
+func (cd *CommonData) DataLossNozzleCount() int {
+	return cd.router.GetDataLossNozzleCount()
+}
+
 func (cd *CommonData) SetMonitoredAppGuids(monitoredAppGuids map[string]bool) {
 	cd.monitoredAppGuids = monitoredAppGuids
 }
@@ -145,14 +181,30 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 		totalDiskUsed := int64(0)
 		totalReportingContainers := 0
 
+		displayAppStats.ProcessDesiredContainers = make(map[string]int)
+		displayAppStats.ProcessReportingContainers = make(map[string]int)
 		if appMetadata.State == "STARTED" {
-			displayAppStats.DesiredContainers = int(appMetadata.Instances)
+			displayAppStats.ProcessDesiredContainers[process.WebProcessType] = int(appMetadata.Instances)
+			for _, processMetadata := range mdMgr.GetProcessMdManager().FindByApp(appId) {
+				if !processMetadata.IsWeb() && processMetadata.Instances > 0 {
+					displayAppStats.ProcessDesiredContainers[processMetadata.Type] = int(processMetadata.Instances)
+				}
+			}
+			for _, desiredContainers := range displayAppStats.ProcessDesiredContainers {
+				displayAppStats.DesiredContainers += desiredContainers
+			}
 		}
 
 		stack := mdMgr.GetStackMdManager().FindItem(appMetadata.StackGuid)
 		displayAppStats.StackId = appMetadata.StackGuid
 		displayAppStats.StackName = stack.Name
 
+		// Use the buildpack given by the developer, otherwise the one detected during staging
+		displayAppStats.BuildpackName = appMetadata.Buildpack
+		if displayAppStats.BuildpackName == "" {
+			displayAppStats.BuildpackName = appMetadata.DetectedBuildpack
+		}
+
 		isoSeg := mdMgr.FindIsoSegBySpace(spaceMetadata)
 		displayAppStats.IsolationSegmentGuid = isoSeg.Guid
 		displayAppStats.IsolationSegmentName = isoSeg.Name
@@ -165,6 +217,12 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 		crash24hCount := crashData.FindCountSinceByApp(appId, -24*time.Hour)
 		crash24hCount = crash24hCount + appStats.Crash24hCount()
 
+		// Crash count in last 7 and 30 days (/v2/events and local crash history)
+		crash7dCount := crashData.FindCountSinceByApp(appId, -7*24*time.Hour)
+		crash7dCount = crash7dCount + appStats.CrashCountSince(-7*24*time.Hour)
+		crash30dCount := crashData.FindCountSinceByApp(appId, -30*24*time.Hour)
+		crash30dCount = crash30dCount + appStats.CrashCountSince(-30*24*time.Hour)
+
 		for _, containerTraffic := range appStats.ContainerTrafficMap {
 			for _, httpStatusCodeMap := range containerTraffic.HttpInfoMap {
 				for statusCode, httpCountInfo := range httpStatusCodeMap {
@@ -185,10 +243,11 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 			}
 		}
 
-		for _, cs := range appStats.ContainerArray {
+		for _, cs := range appStats.AllContainers() {
 			if cs != nil {
+				// App instance metadata (/v2/apps/:guid/instances) only covers the web process
 				appInsts := mdMgr.GetAppInstMdManager().FindItem(appId)
-				if appInsts != nil {
+				if appInsts != nil && cs.ProcessType == process.WebProcessType {
 
 					// If we have app instance metadata, lets check if the app is in a good state
 					appInst := appInsts.Data[strconv.Itoa(cs.ContainerIndex)]
@@ -198,6 +257,7 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 				}
 				if cs.ContainerMetric != nil {
 					totalReportingContainers++
+					displayAppStats.ProcessReportingContainers[cs.ProcessType]++
 
 					totalCpuPercentage = totalCpuPercentage + *cs.ContainerMetric.CpuPercentage
 					totalMemoryUsed = totalMemoryUsed + int64(*cs.ContainerMetric.MemoryBytes)
@@ -214,7 +274,7 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 		if displayAppStats.Monitored && appMetadata.State == "STARTED" && appMetadata.PackageState == "STAGED" {
 			cacheTime := appMetadata.GetCacheTime()
 			startedDuration := now.Sub(*cacheTime)
-			if startedDuration > (config.AppNotInDesiredStateWaitTimeSeconds*time.Second) && totalReportingContainers < displayAppStats.DesiredContainers {
+			if startedDuration > (config.AppNotInDesiredStateWaitTimeSeconds*time.Second) && !isProcessesInDesiredState(displayAppStats) {
 				appsNotInDesiredState = appsNotInDesiredState + 1
 				displayAppStats.AppNotInDesiredState = true
 			}
@@ -235,6 +295,8 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 		displayAppStats.TotalReportingContainers = totalReportingContainers
 		displayAppStats.Crash1hCount = crash1hCount
 		displayAppStats.Crash24hCount = crash24hCount
+		displayAppStats.Crash7dCount = crash7dCount
+		displayAppStats.Crash30dCount = crash30dCount
 		totalCrash1hCount = totalCrash1hCount + crash1hCount
 		totalCrash24hCount = totalCrash24hCount + crash24hCount
 		/*
@@ -255,5 +317,16 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 	cd.appsNotInDesiredState = appsNotInDesiredState
 	cd.totalCrash1hCount = totalCrash1hCount
 	cd.totalCrash24hCount = totalCrash24hCount
+	cd.postProcessQuotaData(mdMgr, time.Now())
 	return displayStatsMap
 }
+
+// Check that every process type of the app has at least the desired number of reporting containers
+func isProcessesInDesiredState(displayAppStats *DisplayAppStats) bool {
+	for processType, desiredContainers := range displayAppStats.ProcessDesiredContainers {
+		if displayAppStats.ProcessReportingContainers[processType] < desiredContainers {
+			return false
+		}
+	}
+	return true
+}
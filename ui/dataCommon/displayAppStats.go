@@ -35,6 +35,7 @@ type DisplayAppStats struct {
 	StackName            string
 	IsolationSegmentGuid string
 	IsolationSegmentName string
+	BuildpackName        string
 
 	// If the app is in the pending delete from cache, then its been deleted
 	// but we keep it around for a n seconds to show newly deleted app on the UI
@@ -48,6 +49,11 @@ type DisplayAppStats struct {
 
 	AppNotInDesiredState bool
 
+	// Desired and reporting container counts by process type (web, worker, etc).
+	// DesiredContainers and TotalReportingContainers are the totals across all processes
+	ProcessDesiredContainers   map[string]int
+	ProcessReportingContainers map[string]int
+
 	//TotalTraffic *eventdata.TrafficStats
 
 	TotalCpuPercentage float64
@@ -59,6 +65,8 @@ type DisplayAppStats struct {
 	TotalLogStderr           int64
 	Crash1hCount             int
 	Crash24hCount            int
+	Crash7dCount             int
+	Crash30dCount            int
 	LastCrashTime            *time.Time
 
 	// Summerize HTTP response codes
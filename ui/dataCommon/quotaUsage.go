@@ -0,0 +1,378 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dataCommon
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/task"
+)
+
+type QuotaDimension string
+
+const (
+	QUOTA_MEMORY          QuotaDimension = "MEMORY"
+	QUOTA_INSTANCE_MEMORY                = "INSTANCE_MEMORY"
+	QUOTA_INSTANCES                      = "INSTANCES"
+	QUOTA_ROUTES                         = "ROUTES"
+	QUOTA_SERVICES                       = "SERVICES"
+	QUOTA_APP_TASKS                      = "APP_TASKS"
+)
+
+var QuotaDimensions = []QuotaDimension{
+	QUOTA_MEMORY,
+	QUOTA_INSTANCE_MEMORY,
+	QUOTA_INSTANCES,
+	QUOTA_ROUTES,
+	QUOTA_SERVICES,
+	QUOTA_APP_TASKS,
+}
+
+var QuotaDimensionDisplay = map[QuotaDimension]string{
+	QUOTA_MEMORY:          "Memory",
+	QUOTA_INSTANCE_MEMORY: "Instance Memory",
+	QUOTA_INSTANCES:       "App Instances",
+	QUOTA_ROUTES:          "Routes",
+	QUOTA_SERVICES:        "Service Instances",
+	QUOTA_APP_TASKS:       "App Tasks",
+}
+
+// Usage of a single quota dimension.  Memory values are in MB.
+type QuotaDimensionUsage struct {
+	Used float64
+	// A limit less then zero is unlimited
+	Limit          float64
+	PercentOfLimit float64
+	// Time when Used is forecast to reach Limit based on the reservation trend.
+	// nil if there is no limit or Used is not trending toward the limit
+	ForecastLimitTime *time.Time
+}
+
+func (du *QuotaDimensionUsage) IsLimited() bool {
+	return du.Limit >= 0
+}
+
+// Quota usage of an org or space
+type QuotaUsage struct {
+	Guid      string
+	QuotaName string
+	HasQuota  bool
+
+	Dimensions map[QuotaDimension]*QuotaDimensionUsage
+}
+
+func NewQuotaUsage(guid string) *QuotaUsage {
+	quotaUsage := &QuotaUsage{Guid: guid}
+	quotaUsage.Dimensions = make(map[QuotaDimension]*QuotaDimensionUsage)
+	for _, dimension := range QuotaDimensions {
+		quotaUsage.Dimensions[dimension] = &QuotaDimensionUsage{Limit: -1}
+	}
+	return quotaUsage
+}
+
+// The highest percent of limit across all quota dimensions
+func (qu *QuotaUsage) MaxPercentOfLimit() (QuotaDimension, float64) {
+	maxDimension := QUOTA_MEMORY
+	maxPercent := 0.0
+	for _, dimension := range QuotaDimensions {
+		dimensionUsage := qu.Dimensions[dimension]
+		if dimensionUsage.IsLimited() && dimensionUsage.PercentOfLimit > maxPercent {
+			maxDimension = dimension
+			maxPercent = dimensionUsage.PercentOfLimit
+		}
+	}
+	return maxDimension, maxPercent
+}
+
+// The earliest time any quota dimension is forecast to reach its limit.  Returns nil time if none.
+func (qu *QuotaUsage) EarliestForecastLimitTime() (QuotaDimension, *time.Time) {
+	var earliestDimension QuotaDimension
+	var earliestTime *time.Time
+	for _, dimension := range QuotaDimensions {
+		forecastTime := qu.Dimensions[dimension].ForecastLimitTime
+		if forecastTime != nil && (earliestTime == nil || forecastTime.Before(*earliestTime)) {
+			earliestDimension = dimension
+			earliestTime = forecastTime
+		}
+	}
+	return earliestDimension, earliestTime
+}
+
+func (qu *QuotaUsage) setLimit(dimension QuotaDimension, limit int) {
+	qu.Dimensions[dimension].Limit = float64(limit)
+}
+
+func (qu *QuotaUsage) addUsed(dimension QuotaDimension, used float64) {
+	qu.Dimensions[dimension].Used += used
+}
+
+func (qu *QuotaUsage) maxUsed(dimension QuotaDimension, used float64) {
+	if used > qu.Dimensions[dimension].Used {
+		qu.Dimensions[dimension].Used = used
+	}
+}
+
+func (qu *QuotaUsage) add(other *QuotaUsage) {
+	for _, dimension := range QuotaDimensions {
+		if dimension == QUOTA_INSTANCE_MEMORY {
+			qu.maxUsed(dimension, other.Dimensions[dimension].Used)
+		} else {
+			qu.addUsed(dimension, other.Dimensions[dimension].Used)
+		}
+	}
+}
+
+func (qu *QuotaUsage) calculatePercentOfLimit() {
+	for _, dimensionUsage := range qu.Dimensions {
+		switch {
+		case dimensionUsage.Limit > 0:
+			dimensionUsage.PercentOfLimit = (dimensionUsage.Used / dimensionUsage.Limit) * 100
+		case dimensionUsage.Limit == 0 && dimensionUsage.Used > 0:
+			dimensionUsage.PercentOfLimit = 100
+		}
+	}
+}
+
+type quotaSample struct {
+	sampleTime time.Time
+	used       float64
+}
+
+// History of reservation samples of an org or space used for forecasting
+type quotaHistory struct {
+	lastSampleTime time.Time
+	samples        map[QuotaDimension][]*quotaSample
+}
+
+func newQuotaHistory() *quotaHistory {
+	return &quotaHistory{samples: make(map[QuotaDimension][]*quotaSample)}
+}
+
+func (qh *quotaHistory) addSamples(now time.Time, quotaUsage *QuotaUsage) {
+	if now.Sub(qh.lastSampleTime) < time.Second*config.QuotaSampleSeconds {
+		return
+	}
+	qh.lastSampleTime = now
+	windowStart := now.Add(-1 * time.Second * config.QuotaForecastWindowSeconds)
+	for _, dimension := range QuotaDimensions {
+		samples := qh.samples[dimension]
+		// Drop samples that have aged out of the forecast window
+		for len(samples) > 0 && samples[0].sampleTime.Before(windowStart) {
+			samples = samples[1:]
+		}
+		qh.samples[dimension] = append(samples, &quotaSample{sampleTime: now, used: quotaUsage.Dimensions[dimension].Used})
+	}
+}
+
+// Forecast when each dimension will reach its limit using a least squares linear fit
+// of the reservation samples.  Instance memory is the size of the largest container
+// and not a total so it is not forecast.
+func (qh *quotaHistory) forecast(now time.Time, quotaUsage *QuotaUsage) {
+	for _, dimension := range QuotaDimensions {
+		dimensionUsage := quotaUsage.Dimensions[dimension]
+		dimensionUsage.ForecastLimitTime = nil
+		if dimension == QUOTA_INSTANCE_MEMORY || dimensionUsage.Limit <= 0 || dimensionUsage.Used >= dimensionUsage.Limit {
+			continue
+		}
+		samples := qh.samples[dimension]
+		if len(samples) < config.QuotaForecastMinSamples {
+			continue
+		}
+		slopePerSecond := trendSlope(samples)
+		if slopePerSecond <= 0 {
+			continue
+		}
+		secondsToLimit := (dimensionUsage.Limit - dimensionUsage.Used) / slopePerSecond
+		// Skip forecasts beyond the horizon (this also keeps the duration from overflowing)
+		if secondsToLimit > (time.Hour * 24 * config.QuotaForecastHorizonDays).Seconds() {
+			continue
+		}
+		forecastTime := now.Add(time.Duration(secondsToLimit * float64(time.Second)))
+		dimensionUsage.ForecastLimitTime = &forecastTime
+	}
+}
+
+// Least squares slope of used per second
+func trendSlope(samples []*quotaSample) float64 {
+	n := float64(len(samples))
+	startTime := samples[0].sampleTime
+	sumX, sumY, sumXY, sumXX := 0.0, 0.0, 0.0, 0.0
+	for _, sample := range samples {
+		x := sample.sampleTime.Sub(startTime).Seconds()
+		y := sample.used
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// Calculate the quota usage of all orgs and spaces.  Usage is based on cloud controller
+// metadata (reservations) and not on the firehose.
+func (cd *CommonData) postProcessQuotaData(mdMgr *metadata.GlobalManager, now time.Time) {
+
+	spaceQuotaUsageMap := make(map[string]*QuotaUsage)
+	orgQuotaUsageMap := make(map[string]*QuotaUsage)
+
+	spaceUsage := func(spaceGuid string) *QuotaUsage {
+		quotaUsage := spaceQuotaUsageMap[spaceGuid]
+		if quotaUsage == nil {
+			quotaUsage = NewQuotaUsage(spaceGuid)
+			spaceQuotaUsageMap[spaceGuid] = quotaUsage
+		}
+		return quotaUsage
+	}
+
+	for _, spaceMetadata := range mdMgr.GetSpaceMdManager().GetAll() {
+		spaceUsage(spaceMetadata.Guid)
+	}
+
+	// Started apps (all process types) count against the memory and instance quotas
+	processMdMgr := mdMgr.GetProcessMdManager()
+	appMdMgr := mdMgr.GetAppMdManager()
+	for _, appMetadata := range appMdMgr.AllApps() {
+		if appMetadata.State != "STARTED" {
+			continue
+		}
+		quotaUsage := spaceUsage(appMetadata.SpaceGuid)
+		quotaUsage.addUsed(QUOTA_MEMORY, appMetadata.MemoryMB*appMetadata.Instances)
+		quotaUsage.addUsed(QUOTA_INSTANCES, appMetadata.Instances)
+		quotaUsage.maxUsed(QUOTA_INSTANCE_MEMORY, appMetadata.MemoryMB)
+		for _, processMetadata := range processMdMgr.FindByApp(appMetadata.Guid) {
+			if !processMetadata.IsWeb() {
+				quotaUsage.addUsed(QUOTA_MEMORY, processMetadata.MemoryMB*processMetadata.Instances)
+				quotaUsage.addUsed(QUOTA_INSTANCES, processMetadata.Instances)
+				quotaUsage.maxUsed(QUOTA_INSTANCE_MEMORY, processMetadata.MemoryMB)
+			}
+		}
+	}
+
+	// Running tasks count against memory and app task quotas
+	for _, taskMetadata := range mdMgr.GetTaskMdManager().GetAll() {
+		if taskMetadata.State != task.StateRunning {
+			continue
+		}
+		appMetadata := appMdMgr.FindItem(taskMetadata.AppGuid)
+		if appMetadata.SpaceGuid == "" {
+			continue
+		}
+		quotaUsage := spaceUsage(appMetadata.SpaceGuid)
+		quotaUsage.addUsed(QUOTA_MEMORY, taskMetadata.MemoryMB)
+		quotaUsage.addUsed(QUOTA_APP_TASKS, 1)
+		quotaUsage.maxUsed(QUOTA_INSTANCE_MEMORY, taskMetadata.MemoryMB)
+	}
+
+	for _, routeMetadata := range mdMgr.GetRouteMdManager().GetAll() {
+		if routeMetadata.SpaceGuid != "" {
+			spaceUsage(routeMetadata.SpaceGuid).addUsed(QUOTA_ROUTES, 1)
+		}
+	}
+
+	for spaceGuid, serviceCount := range mdMgr.GetServiceInstanceMdManager().CountBySpace() {
+		spaceUsage(spaceGuid).addUsed(QUOTA_SERVICES, float64(serviceCount))
+	}
+
+	// Set space limits and roll space usage up to the org
+	spaceMdMgr := mdMgr.GetSpaceMdManager()
+	spaceQuotaMdMgr := mdMgr.GetSpaceQuotaMdManager()
+	for spaceGuid, quotaUsage := range spaceQuotaUsageMap {
+		spaceMetadata := spaceMdMgr.FindItem(spaceGuid)
+		if spaceMetadata.QuotaGuid != "" {
+			spaceQuotaMd := spaceQuotaMdMgr.FindItem(spaceMetadata.QuotaGuid)
+			quotaUsage.HasQuota = true
+			quotaUsage.QuotaName = spaceQuotaMd.Name
+			quotaUsage.setLimit(QUOTA_MEMORY, spaceQuotaMd.MemoryLimit)
+			quotaUsage.setLimit(QUOTA_INSTANCE_MEMORY, spaceQuotaMd.InstanceMemoryLimit)
+			quotaUsage.setLimit(QUOTA_INSTANCES, spaceQuotaMd.AppInstanceLimit)
+			quotaUsage.setLimit(QUOTA_ROUTES, spaceQuotaMd.TotalRoutes)
+			quotaUsage.setLimit(QUOTA_SERVICES, spaceQuotaMd.TotalServices)
+			quotaUsage.setLimit(QUOTA_APP_TASKS, spaceQuotaMd.AppTaskLimit)
+		}
+		if spaceMetadata.OrgGuid == "" {
+			continue
+		}
+		orgQuotaUsage := orgQuotaUsageMap[spaceMetadata.OrgGuid]
+		if orgQuotaUsage == nil {
+			orgQuotaUsage = NewQuotaUsage(spaceMetadata.OrgGuid)
+			orgQuotaUsageMap[spaceMetadata.OrgGuid] = orgQuotaUsage
+		}
+		orgQuotaUsage.add(quotaUsage)
+	}
+
+	orgMdMgr := mdMgr.GetOrgMdManager()
+	orgQuotaMdMgr := mdMgr.GetOrgQuotaMdManager()
+	for orgGuid, quotaUsage := range orgQuotaUsageMap {
+		orgMetadata := orgMdMgr.FindItem(orgGuid)
+		if orgMetadata.QuotaGuid != "" {
+			orgQuotaMd := orgQuotaMdMgr.FindItem(orgMetadata.QuotaGuid)
+			quotaUsage.HasQuota = true
+			quotaUsage.QuotaName = orgQuotaMd.Name
+			quotaUsage.setLimit(QUOTA_MEMORY, orgQuotaMd.MemoryLimit)
+			quotaUsage.setLimit(QUOTA_INSTANCE_MEMORY, orgQuotaMd.InstanceMemoryLimit)
+			quotaUsage.setLimit(QUOTA_INSTANCES, orgQuotaMd.AppInstanceLimit)
+			quotaUsage.setLimit(QUOTA_ROUTES, orgQuotaMd.TotalRoutes)
+			quotaUsage.setLimit(QUOTA_SERVICES, orgQuotaMd.TotalServices)
+			quotaUsage.setLimit(QUOTA_APP_TASKS, orgQuotaMd.AppTaskLimit)
+		}
+	}
+
+	quotaWarnCount := 0
+	quotaAlertCount := 0
+	quotaForecastCount := 0
+	forecastWarnTime := now.Add(time.Hour * config.QuotaForecastWarnHours)
+	quotaHistoryMap := make(map[string]*quotaHistory)
+	for _, quotaUsageMap := range []map[string]*QuotaUsage{orgQuotaUsageMap, spaceQuotaUsageMap} {
+		for guid, quotaUsage := range quotaUsageMap {
+			quotaUsage.calculatePercentOfLimit()
+
+			history := cd.quotaHistoryMap[guid]
+			if history == nil {
+				history = newQuotaHistory()
+			}
+			quotaHistoryMap[guid] = history
+			history.addSamples(now, quotaUsage)
+			history.forecast(now, quotaUsage)
+
+			if !quotaUsage.HasQuota {
+				continue
+			}
+			_, maxPercent := quotaUsage.MaxPercentOfLimit()
+			switch {
+			case maxPercent >= float64(config.QuotaAlertPercent):
+				quotaAlertCount++
+			case maxPercent >= float64(config.QuotaWarnPercent):
+				quotaWarnCount++
+			}
+			_, forecastTime := quotaUsage.EarliestForecastLimitTime()
+			if forecastTime != nil && forecastTime.Before(forecastWarnTime) {
+				quotaForecastCount++
+			}
+		}
+	}
+
+	cd.quotaHistoryMap = quotaHistoryMap
+	cd.orgQuotaUsageMap = orgQuotaUsageMap
+	cd.spaceQuotaUsageMap = spaceQuotaUsageMap
+	cd.quotaWarnCount = quotaWarnCount
+	cd.quotaAlertCount = quotaAlertCount
+	cd.quotaForecastCount = quotaForecastCount
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataCommon
+
+import (
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QuotaHistory", func() {
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-4 * time.Minute)
+
+	// Samples taken every minute starting at start with the given used values
+	minuteSamples := func(used ...float64) []*quotaSample {
+		samples := make([]*quotaSample, len(used))
+		for i, u := range used {
+			samples[i] = &quotaSample{sampleTime: start.Add(time.Duration(i) * time.Minute), used: u}
+		}
+		return samples
+	}
+
+	Describe("trendSlope", func() {
+		It("is zero for a single sample", func() {
+			Expect(trendSlope(minuteSamples(100))).To(BeZero())
+		})
+		It("is zero when usage is flat", func() {
+			Expect(trendSlope(minuteSamples(100, 100, 100, 100))).To(BeZero())
+		})
+		It("is the usage change per second", func() {
+			Expect(trendSlope(minuteSamples(0, 60, 120, 180, 240))).To(BeNumerically("~", 1, 1e-9))
+			Expect(trendSlope(minuteSamples(480, 360, 240, 120))).To(BeNumerically("~", -2, 1e-9))
+		})
+		It("fits a line through noisy samples", func() {
+			Expect(trendSlope(minuteSamples(0, 90, 90, 180, 240))).To(BeNumerically("~", 0.95, 1e-9))
+		})
+		It("is zero when all samples have the same time", func() {
+			samples := []*quotaSample{{sampleTime: start, used: 10}, {sampleTime: start, used: 20}}
+			Expect(trendSlope(samples)).To(BeZero())
+		})
+	})
+
+	Describe("forecast", func() {
+		forecast := func(dimension QuotaDimension, limit float64, samples []*quotaSample) *time.Time {
+			quotaUsage := NewQuotaUsage("guid")
+			dimensionUsage := quotaUsage.Dimensions[dimension]
+			dimensionUsage.Limit = limit
+			dimensionUsage.Used = samples[len(samples)-1].used
+
+			history := newQuotaHistory()
+			history.samples[dimension] = samples
+			history.forecast(now, quotaUsage)
+			return dimensionUsage.ForecastLimitTime
+		}
+
+		DescribeTable("forecasts when a rising usage reaches the limit",
+			func(dimension QuotaDimension, limit float64, samples []*quotaSample, want time.Duration) {
+				forecastTime := forecast(dimension, limit, samples)
+				Expect(forecastTime).NotTo(BeNil())
+				Expect(forecastTime.Sub(now)).To(Equal(want))
+			},
+			Entry("memory", QuotaDimension(QUOTA_MEMORY), 1240.0, minuteSamples(0, 60, 120, 180, 240), 1000*time.Second),
+			Entry("routes", QuotaDimension(QUOTA_ROUTES), 10.0, minuteSamples(1, 2, 3, 4, 5), 5*time.Minute),
+		)
+
+		DescribeTable("does not forecast",
+			func(dimension QuotaDimension, limit float64, samples []*quotaSample) {
+				Expect(forecast(dimension, limit, samples)).To(BeNil())
+			},
+			Entry("flat usage", QuotaDimension(QUOTA_MEMORY), 1024.0, minuteSamples(512, 512, 512, 512, 512)),
+			Entry("falling usage", QuotaDimension(QUOTA_MEMORY), 1024.0, minuteSamples(900, 800, 700, 600, 500)),
+			Entry("unlimited quota", QuotaDimension(QUOTA_MEMORY), -1.0, minuteSamples(0, 60, 120, 180, 240)),
+			Entry("zero limit", QuotaDimension(QUOTA_MEMORY), 0.0, minuteSamples(0, 60, 120, 180, 240)),
+			Entry("usage at the limit", QuotaDimension(QUOTA_MEMORY), 240.0, minuteSamples(0, 60, 120, 180, 240)),
+			Entry("too few samples", QuotaDimension(QUOTA_MEMORY), 1024.0, minuteSamples(0, 60)),
+			Entry("instance memory", QuotaDimension(QUOTA_INSTANCE_MEMORY), 1024.0, minuteSamples(0, 60, 120, 180, 240)),
+			Entry("limit beyond the horizon", QuotaDimension(QUOTA_MEMORY), 1e12, minuteSamples(0, 0, 0, 0, 1)),
+			Entry("time that would overflow a duration", QuotaDimension(QUOTA_MEMORY), math.MaxFloat64, minuteSamples(0, 1, 2, 3, 4)),
+		)
+	})
+})
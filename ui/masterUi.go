@@ -31,6 +31,7 @@ import (
 	"github.com/cloudfoundry/cli/plugin"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
@@ -41,11 +42,21 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/capacityPlanView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/noisyNeighborView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/crashViews/crashAnalyticsView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/deploymentViews/deploymentView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/eventRateHistoryView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/eventViews/eventView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/headerView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/ingestQueueView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/isolationSegmentView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/nozzleView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/orgSpaceViews/orgView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/platformHealthView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/routeViews/routeView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/searchView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/stagingViews/stagingView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/taskViews/taskView"
 	"github.com/jroimartin/gocui"
 )
 
@@ -200,6 +211,7 @@ func (mui *MasterUI) initGui(monitoredAppGuids map[string]bool) {
 		m := merry.Details(err)
 		log.Panicln(m)
 	}
+	crashData.SaveCrashHistory()
 
 }
 
@@ -246,6 +258,9 @@ func (mui *MasterUI) addTopLevelDataViewKeybindings(g *gocui.Gui, viewName strin
 	if err := g.SetKeybinding(viewName, 'd', gocui.ModNone, mui.selectDisplayAction); err != nil {
 		log.Panicln(err)
 	}
+	if err := g.SetKeybinding(viewName, '/', gocui.ModNone, mui.searchAction); err != nil {
+		log.Panicln(err)
+	}
 	return nil
 }
 
@@ -429,12 +444,21 @@ func (mui *MasterUI) selectDisplayAction(g *gocui.Gui, v *gocui.View) error {
 	menuItems = append(menuItems, uiCommon.NewMenuItem("orgListView", "Org Stats"))
 	if mui.privileged {
 		menuItems = append(menuItems, uiCommon.NewMenuItem("cellListView", "Cell Stats"))
+		menuItems = append(menuItems, uiCommon.NewMenuItem("isolationSegmentListView", "Isolation Segment Stats"))
+		menuItems = append(menuItems, uiCommon.NewMenuItem("noisyNeighborView", "Noisy Neighbors"))
 	}
 	menuItems = append(menuItems, uiCommon.NewMenuItem("routeListView", "Route Stats"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("taskListView", "Task Stats"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("stagingListView", "Staging"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("deploymentListView", "Deployments"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("crashAnalyticsView", "Crash Analytics"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("eventRateHistoryListView", "Event Rate History"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("eventListView", "Event Stats"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("platformHealthView", "Platform Health"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("ingestQueueView", "Ingest Queues"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("nozzleView", "Nozzles"))
 	if mui.privileged {
-		menuItems = append(menuItems, uiCommon.NewMenuItem("capacityPlanView", "Capacity Plan (memory)"))
+		menuItems = append(menuItems, uiCommon.NewMenuItem("capacityPlanView", "Capacity Plan"))
 	}
 	menuItems = append(menuItems, uiCommon.NewMenuItem("aboutView", "About Top"))
 
@@ -465,13 +489,31 @@ func (mui *MasterUI) createAndOpenView(g *gocui.Gui, viewName string) error {
 	var dataView masterUIInterface.UpdatableView
 	switch viewName {
 	case "appListView":
-		dataView = appView.NewAppListView(mui, nil, "appListView", mui.helpTextTipsViewSize, ep, "")
+		dataView = appView.NewAppListView(mui, nil, "appListView", mui.helpTextTipsViewSize, ep, "", "")
 	case "orgListView":
 		dataView = orgView.NewOrgListView(mui, "orgListView", mui.helpTextTipsViewSize, ep)
 	case "cellListView":
-		dataView = cellView.NewCellListView(mui, "cellListView", mui.helpTextTipsViewSize, ep)
+		dataView = cellView.NewCellListView(mui, nil, "cellListView", mui.helpTextTipsViewSize, ep, "")
+	case "isolationSegmentListView":
+		dataView = isolationSegmentView.NewIsolationSegmentListView(mui, "isolationSegmentListView", mui.helpTextTipsViewSize, ep)
 	case "routeListView":
 		dataView = routeView.NewRouteListView(mui, "routeListView", mui.helpTextTipsViewSize, ep)
+	case "taskListView":
+		dataView = taskView.NewTaskListView(mui, "taskListView", mui.helpTextTipsViewSize, ep)
+	case "deploymentListView":
+		dataView = deploymentView.NewDeploymentListView(mui, nil, "deploymentListView", mui.helpTextTipsViewSize, ep, "")
+	case "stagingListView":
+		dataView = stagingView.NewStagingListView(mui, "stagingListView", mui.helpTextTipsViewSize, ep)
+	case "noisyNeighborView":
+		dataView = noisyNeighborView.NewNoisyNeighborView(mui, "noisyNeighborView", mui.helpTextTipsViewSize, ep)
+	case "platformHealthView":
+		dataView = platformHealthView.NewPlatformHealthView(mui, "platformHealthView", mui.helpTextTipsViewSize, ep)
+	case "ingestQueueView":
+		dataView = ingestQueueView.NewIngestQueueView(mui, "ingestQueueView", mui.helpTextTipsViewSize, ep)
+	case "nozzleView":
+		dataView = nozzleView.NewNozzleView(mui, "nozzleView", mui.helpTextTipsViewSize, ep, mui.router)
+	case "crashAnalyticsView":
+		dataView = crashAnalyticsView.NewCrashAnalyticsView(mui, "crashAnalyticsView", mui.helpTextTipsViewSize, ep)
 	case "eventListView":
 		dataView = eventView.NewEventListView(mui, "eventListView", mui.helpTextTipsViewSize, ep)
 	case "capacityPlanView":
@@ -529,6 +571,42 @@ func (mui *MasterUI) editUpdateInterval(g *gocui.Gui, v *gocui.View) error {
 	return intervalWidget.Init(g)
 }
 
+func (mui *MasterUI) searchAction(g *gocui.Gui, v *gocui.View) error {
+
+	labelText := "Search:"
+	maxLength := 60
+	titleText := "Search apps, routes, spaces, orgs and cells"
+	helpText := "no help"
+
+	valueText := ""
+	if searchMgr := mui.layoutManager.GetManagerByViewName("searchView"); searchMgr != nil {
+		valueText = searchMgr.(*searchView.SearchView).GetQuery()
+	}
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		if strings.TrimSpace(inputValue) == "" {
+			return nil
+		}
+		if err := w.(*uiCommon.InputDialogWidget).CloseWidget(g, v); err != nil {
+			return err
+		}
+		if searchMgr := mui.layoutManager.GetManagerByViewName("searchView"); searchMgr != nil {
+			searchMgr.(*searchView.SearchView).SetQuery(inputValue)
+			return mui.createAndOpenView(g, "searchView")
+		}
+		dataView := searchView.NewSearchView(mui, "searchView", mui.helpTextTipsViewSize, mui.router.GetProcessor(), inputValue)
+		mui.OpenView(g, dataView)
+		mui.addTopLevelDataViewKeybindings(g, dataView.Name())
+		return nil
+	}
+
+	searchWidget := uiCommon.NewInputDialogWidget(mui,
+		"searchWidget", 60, 6, labelText, maxLength, titleText, helpText,
+		valueText, applyCallbackFunc)
+
+	return searchWidget.Init(g)
+}
+
 func (mui *MasterUI) clearStats(g *gocui.Gui, v *gocui.View) error {
 	mui.router.Clear()
 	mui.updateDisplay(g)
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package uiCommon
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/jroimartin/gocui"
+)
+
+type EditAggregateView struct {
+	*EditColumnViewAbs
+
+	oldAggregateTypes      map[string]AggregateType
+	oldHideAggregateFooter bool
+}
+
+func NewEditAggregateView(masterUI masterUIInterface.MasterUIInterface, name string, listWidget *ListWidget) *EditAggregateView {
+	w := &EditAggregateView{EditColumnViewAbs: NewEditColumnViewAbs(masterUI, name, listWidget)}
+	w.width = 55
+	w.height = 12
+	w.title = "Edit Totals"
+
+	w.refreshDisplayCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.refreshDisplayCallback(g, v)
+	}
+
+	w.initialLayoutCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.initialLayoutCallback(g, v)
+	}
+
+	w.cancelActionCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.cancelActionCallback(g, v)
+	}
+
+	// Save old aggregates for cancel
+	w.oldAggregateTypes = make(map[string]AggregateType)
+	for columnId, aggregateType := range listWidget.aggregateTypes {
+		w.oldAggregateTypes[columnId] = aggregateType
+	}
+	w.oldHideAggregateFooter = listWidget.hideAggregateFooter
+
+	return w
+}
+
+func (w *EditAggregateView) initialLayoutCallback(g *gocui.Gui, v *gocui.View) error {
+	if err := g.SetKeybinding(w.name, gocui.KeySpace, gocui.ModNone, w.keySpaceAction); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(w.name, 'T', gocui.ModNone, w.toggleFooterAction); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *EditAggregateView) refreshDisplayCallback(g *gocui.Gui, v *gocui.View) error {
+
+	v.Clear()
+	fmt.Fprintln(v, " ")
+	col := w.getSelectedColumn()
+	fmt.Fprintf(v, " Column name: %v\n", col.label)
+	if col.columnType == NUMERIC {
+		fmt.Fprintf(v, " Total: %v\n\n", w.listWidget.GetAggregateType(col.id))
+	} else {
+		fmt.Fprintf(v, " Total: only numeric columns can be totaled\n\n")
+	}
+	footer := "shown"
+	if w.listWidget.hideAggregateFooter {
+		footer = "hidden"
+	}
+	fmt.Fprintf(v, " Totals footer is %v\n\n", footer)
+
+	fmt.Fprintln(v, " RIGHT or LEFT arrow - highlight column")
+	fmt.Fprintln(v, " SPACE - change total (SUM, AVG, MIN, MAX, NONE)")
+	fmt.Fprintln(v, " 'T' - show / hide totals footer")
+	fmt.Fprintln(v, " ENTER - apply, ESC to cancel")
+
+	return nil
+}
+
+func (w *EditAggregateView) getSelectedColumn() *ListColumn {
+	return w.listWidget.columnMap[w.listWidget.selectedColumnId]
+}
+
+func (w *EditAggregateView) keySpaceAction(g *gocui.Gui, v *gocui.View) error {
+	col := w.getSelectedColumn()
+	if col.columnType != NUMERIC {
+		return nil
+	}
+	w.listWidget.SetAggregateType(col.id, w.listWidget.GetAggregateType(col.id).next())
+	return w.RefreshDisplay(g)
+}
+
+func (w *EditAggregateView) toggleFooterAction(g *gocui.Gui, v *gocui.View) error {
+	w.listWidget.setHideAggregateFooter(!w.listWidget.hideAggregateFooter)
+	return w.RefreshDisplay(g)
+}
+
+func (w *EditAggregateView) cancelActionCallback(g *gocui.Gui, v *gocui.View) error {
+	w.listWidget.aggregateTypes = w.oldAggregateTypes
+	savedAggregateTypes[w.listWidget.name] = w.oldAggregateTypes
+	w.listWidget.setHideAggregateFooter(w.oldHideAggregateFooter)
+	return nil
+}
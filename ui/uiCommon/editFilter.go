@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/Knetic/govaluate"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
@@ -28,21 +29,31 @@ import (
 	"github.com/jroimartin/gocui"
 )
 
+type editFilterMode int
+
+const (
+	editFilterNone editFilterMode = iota
+	editFilterColumn
+	editFilterExpression
+	editFilterSaveName
+)
+
 type EditFilterView struct {
 	*EditColumnViewAbs
 
 	labelWidget managerUI.Manager
 	inputWidget managerUI.Manager
 
-	oldFilterColumnMap map[string]*FilterColumn
+	oldFilterColumnMap  map[string]*FilterColumn
+	oldFilterExpression *FilterExpression
 
-	editField bool
+	editMode editFilterMode
 }
 
 func NewEditFilterView(masterUI masterUIInterface.MasterUIInterface, name string, listWidget *ListWidget) *EditFilterView {
 	w := &EditFilterView{EditColumnViewAbs: NewEditColumnViewAbs(masterUI, name, listWidget)}
-	w.width = 55
-	w.height = 14
+	w.width = 64
+	w.height = 18
 	w.title = "Edit Filter"
 
 	w.refreshDisplayCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
@@ -67,6 +78,7 @@ func NewEditFilterView(masterUI masterUIInterface.MasterUIInterface, name string
 		cloneFilter := &FilterColumn{filterText: filter.filterText}
 		w.oldFilterColumnMap[columnId] = cloneFilter
 	}
+	w.oldFilterExpression = listWidget.filterExpression
 
 	return w
 }
@@ -80,6 +92,15 @@ func (w *EditFilterView) initialLayoutCallback(g *gocui.Gui, v *gocui.View) erro
 	if err := g.SetKeybinding(w.name, 'c', gocui.ModNone, w.clearFilterAction); err != nil {
 		return err
 	}
+	if err := g.SetKeybinding(w.name, 'e', gocui.ModNone, w.editExpressionAction); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(w.name, 's', gocui.ModNone, w.saveNamedFilterAction); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(w.name, 'l', gocui.ModNone, w.loadNamedFilterAction); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -96,10 +117,17 @@ func (w *EditFilterView) refreshDisplayCallback(g *gocui.Gui, v *gocui.View) err
 		filterText = filter.filterText
 	}
 
+	expressionText := "--none--"
+	if w.listWidget.filterExpression != nil {
+		expressionText = w.listWidget.filterExpression.Text()
+	}
+
 	fmt.Fprintf(v, " Column name: %v\n", col.label)
-	fmt.Fprintf(v, " Filter: %v\n\n", filterText)
+	fmt.Fprintf(v, " Filter: %v\n", filterText)
+	fmt.Fprintf(v, " Expression: %v\n\n", expressionText)
 
-	if w.editField {
+	switch w.editMode {
+	case editFilterColumn:
 		switch col.columnType {
 		case ALPHANUMERIC:
 			fmt.Fprintf(v, "\n\n RegEx examples:\n")
@@ -110,16 +138,26 @@ func (w *EditFilterView) refreshDisplayCallback(g *gocui.Gui, v *gocui.View) err
 			fmt.Fprintf(v, " Greater then: >0.15\n")
 			fmt.Fprintf(v, " Equals: ==2   Note the double equals\n")
 		case TIMESTAMP:
-			fmt.Fprintf(v, "\n\n Timestamp filtering not supported\n")
-			fmt.Fprintf(v, "\n\n at this time.\n")
+			fmt.Fprintf(v, "\n\n Expression examples:\n")
+			fmt.Fprintf(v, " Within last 10 minutes: < 10m\n")
+			fmt.Fprintf(v, " Older then 1 day: > 1d\n")
+			fmt.Fprintf(v, " After a time: > \"2017-06-01 13:00\"\n")
 		}
-	} else {
+	case editFilterExpression:
+		fmt.Fprintf(v, "\n\n Expression examples:\n")
+		fmt.Fprintf(v, " ORG =~ \"^prod\" AND CPU_PERCENT > 50\n")
+		fmt.Fprintf(v, " NOT (STATE == RUNNING) OR LAST_ACCESS < 10m\n")
+		fmt.Fprintf(v, " Operators: == != < <= > >= =~ (regex) !~\n")
+		fmt.Fprintf(v, " Durations: 30s 10m 2h 1d  Times: \"2017-06-01 13:00\"\n")
+	case editFilterSaveName:
+		fmt.Fprintf(v, "\n\n Save the expression under a name for this view.\n")
+		fmt.Fprintf(v, " Saving an empty expression removes the name.\n")
+	default:
 		fmt.Fprintln(v, " RIGHT or LEFT arrow - highlight column")
-		if col.columnType != TIMESTAMP {
-			fmt.Fprintln(v, " SPACE - select column to edit filter")
-		} else {
-			fmt.Fprintln(v, " NOTE: Filters on Timestamp fields not supported ")
-		}
+		fmt.Fprintln(v, " SPACE - select column to edit filter")
+		fmt.Fprintln(v, " 'e' - edit expression across columns")
+		fmt.Fprintln(v, " 's' - save expression as named filter")
+		fmt.Fprintln(v, " 'l' - load named filter")
 		fmt.Fprintln(v, " ENTER - apply filter, ESC to cancel")
 		fmt.Fprintln(v, " 'c' - clear all filters")
 	}
@@ -143,17 +181,12 @@ func (w *EditFilterView) applyValueCallback(g *gocui.Gui, v *gocui.View, mgr man
 	case NUMERIC:
 		inputValue = w.adjustExpression(inputValue)
 		err = w.applyNumericFilter(g, v, mgr, inputValue)
+	case TIMESTAMP:
+		err = w.applyTimestampFilter(g, v, mgr, inputValue)
 	}
 
 	if err != nil {
-		parentView, err2 := g.View(w.name)
-		if err2 != nil {
-			return err2
-		}
-		fmt.Fprintf(parentView, "%v", util.BRIGHT_RED)
-		fmt.Fprintf(parentView, "\r Error: %v", err)
-		fmt.Fprintf(parentView, "%v", util.CLEAR)
-		return nil
+		return w.showError(g, err)
 	}
 	selectedColId := w.listWidget.selectedColumnId
 	filter := &FilterColumn{filterText: inputValue}
@@ -164,6 +197,17 @@ func (w *EditFilterView) applyValueCallback(g *gocui.Gui, v *gocui.View, mgr man
 	return nil
 }
 
+func (w *EditFilterView) showError(g *gocui.Gui, err error) error {
+	parentView, err2 := g.View(w.name)
+	if err2 != nil {
+		return err2
+	}
+	fmt.Fprintf(parentView, "%v", util.BRIGHT_RED)
+	fmt.Fprintf(parentView, "\r Error: %v", err)
+	fmt.Fprintf(parentView, "%v", util.CLEAR)
+	return nil
+}
+
 func (w *EditFilterView) closeInputWidget(g *gocui.Gui) error {
 	g.Cursor = false
 	if err := w.masterUI.CloseView(w.labelWidget); err != nil {
@@ -172,7 +216,7 @@ func (w *EditFilterView) closeInputWidget(g *gocui.Gui) error {
 	if err := w.masterUI.CloseView(w.inputWidget); err != nil {
 		return err
 	}
-	w.editField = false
+	w.editMode = editFilterNone
 	return w.RefreshDisplay(g)
 }
 
@@ -217,6 +261,15 @@ func (w *EditFilterView) adjustExpression(value string) string {
 	return value
 }
 
+func (w *EditFilterView) applyTimestampFilter(g *gocui.Gui, v *gocui.View, mgr managerUI.Manager, inputValue string) error {
+	if inputValue == "" {
+		return nil
+	}
+	col := w.getSelectedColumn()
+	_, err := NewFilterExpression(col.id+" "+inputValue, []*ListColumn{col})
+	return err
+}
+
 func (w *EditFilterView) applyAlphaFilter(g *gocui.Gui, v *gocui.View, mgr managerUI.Manager, inputValue string) error {
 	_, err := regexp.Compile(inputValue)
 	if err != nil {
@@ -227,6 +280,7 @@ func (w *EditFilterView) applyAlphaFilter(g *gocui.Gui, v *gocui.View, mgr manag
 
 func (w *EditFilterView) clearFilterAction(g *gocui.Gui, v *gocui.View) error {
 	w.listWidget.filterColumnMap = make(map[string]*FilterColumn)
+	w.listWidget.filterExpression = nil
 	w.applyFilterAndRefresh(g, v)
 	return nil
 }
@@ -242,31 +296,28 @@ func (w *EditFilterView) keySpaceAction(g *gocui.Gui, v *gocui.View) error {
 
 	selectedColId := w.listWidget.selectedColumnId
 
-	col := w.listWidget.columnMap[selectedColId]
-	if col.columnType == TIMESTAMP {
-		// TODO: Timestamp filtering not supported yet
-		return nil
-	}
-
 	filter := w.listWidget.filterColumnMap[selectedColId]
 	filterText := ""
 	if filter != nil {
 		filterText = filter.filterText
 	}
 
-	labelText := "Filter:"
-	maxLength := 30
-	valueText := filterText
-	topMargin := 4
+	return w.openInputWidget(g, editFilterColumn, "Filter:", 30, 30, filterText, w.applyValueCallback)
+}
+
+func (w *EditFilterView) openInputWidget(g *gocui.Gui, editMode editFilterMode,
+	labelText string, width, maxLength int, valueText string, applyCallbackFunc applyCallbackFunc) error {
+
+	topMargin := 5
 
 	w.labelWidget = NewLabel(w, "label", 1, topMargin, labelText)
 	cancelCallbackFunc := func(g *gocui.Gui, v *gocui.View) error {
 		return w.closeInputWidget(g)
 	}
 
-	w.inputWidget = NewInput(w, "input", len(labelText)+2, topMargin, maxLength+2,
+	w.inputWidget = NewInput(w, "input", len(labelText)+2, topMargin, width+2,
 		maxLength, valueText,
-		w.applyValueCallback,
+		applyCallbackFunc,
 		cancelCallbackFunc)
 
 	w.masterUI.LayoutManager().Add(w.labelWidget)
@@ -275,13 +326,77 @@ func (w *EditFilterView) keySpaceAction(g *gocui.Gui, v *gocui.View) error {
 	w.labelWidget.Layout(g)
 	w.inputWidget.Layout(g)
 	w.masterUI.SetCurrentViewOnTop(g)
-	w.editField = true
+	w.editMode = editMode
 
 	w.RefreshDisplay(g)
 
 	return nil
 }
 
+func (w *EditFilterView) editExpressionAction(g *gocui.Gui, v *gocui.View) error {
+	expressionText := ""
+	if w.listWidget.filterExpression != nil {
+		expressionText = w.listWidget.filterExpression.Text()
+	}
+	return w.openInputWidget(g, editFilterExpression, "Expression:", w.width-16, 250, expressionText, w.applyExpressionCallback)
+}
+
+func (w *EditFilterView) applyExpressionCallback(g *gocui.Gui, v *gocui.View, mgr managerUI.Manager, inputValue string) error {
+	var filterExpression *FilterExpression
+	if strings.TrimSpace(inputValue) != "" {
+		var err error
+		filterExpression, err = NewFilterExpression(inputValue, w.listWidget.columns)
+		if err != nil {
+			return w.showError(g, err)
+		}
+	}
+	w.listWidget.filterExpression = filterExpression
+
+	w.closeInputWidget(g)
+	w.applyFilterAndRefresh(g, v)
+	return nil
+}
+
+func (w *EditFilterView) saveNamedFilterAction(g *gocui.Gui, v *gocui.View) error {
+	return w.openInputWidget(g, editFilterSaveName, "Name:", 30, 30, "", w.applySaveNamedFilterCallback)
+}
+
+func (w *EditFilterView) applySaveNamedFilterCallback(g *gocui.Gui, v *gocui.View, mgr managerUI.Manager, inputValue string) error {
+	name := strings.TrimSpace(inputValue)
+	if name == "" {
+		return w.showError(g, errors.New("Name is required"))
+	}
+	expressionText := ""
+	if w.listWidget.filterExpression != nil {
+		expressionText = w.listWidget.filterExpression.Text()
+	}
+	SaveNamedFilter(w.listWidget.name, name, expressionText)
+	return w.closeInputWidget(g)
+}
+
+func (w *EditFilterView) loadNamedFilterAction(g *gocui.Gui, v *gocui.View) error {
+	names := GetNamedFilterNames(w.listWidget.name)
+	if len(names) == 0 {
+		return w.showError(g, errors.New("No named filters saved for this view"))
+	}
+	menuItems := make([]*MenuItem, 0, len(names))
+	for _, name := range names {
+		menuItems = append(menuItems, NewMenuItem(name, name))
+	}
+	selectFilterView := NewSelectMenuWidget(w.masterUI, w.name+".selectNamedFilterView", "Load Named Filter", menuItems, w.selectNamedFilterCallback)
+	w.masterUI.LayoutManager().Add(selectFilterView)
+	return w.masterUI.SetCurrentViewOnTop(g)
+}
+
+func (w *EditFilterView) selectNamedFilterCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
+	filterExpression, err := NewFilterExpression(GetNamedFilter(w.listWidget.name, menuId), w.listWidget.columns)
+	if err != nil {
+		return w.showError(g, err)
+	}
+	w.listWidget.filterExpression = filterExpression
+	return w.applyFilterAndRefresh(g, v)
+}
+
 func (w *EditFilterView) applyActionCallback(g *gocui.Gui, v *gocui.View) error {
 	w.listWidget.SaveFilters()
 	return nil
@@ -289,5 +404,6 @@ func (w *EditFilterView) applyActionCallback(g *gocui.Gui, v *gocui.View) error
 
 func (w *EditFilterView) cancelActionCallback(g *gocui.Gui, v *gocui.View) error {
 	w.listWidget.filterColumnMap = w.oldFilterColumnMap
+	w.listWidget.filterExpression = w.oldFilterExpression
 	return nil
 }
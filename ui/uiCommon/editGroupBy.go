@@ -0,0 +1,118 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package uiCommon
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/jroimartin/gocui"
+)
+
+type EditGroupByView struct {
+	*EditColumnViewAbs
+
+	oldGroupByColumnId string
+	oldExpandedGroups  map[string]bool
+}
+
+func NewEditGroupByView(masterUI masterUIInterface.MasterUIInterface, name string, listWidget *ListWidget) *EditGroupByView {
+	w := &EditGroupByView{EditColumnViewAbs: NewEditColumnViewAbs(masterUI, name, listWidget)}
+	w.width = 55
+	w.height = 11
+	w.title = "Edit Group By"
+
+	w.refreshDisplayCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.refreshDisplayCallback(g, v)
+	}
+
+	w.initialLayoutCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.initialLayoutCallback(g, v)
+	}
+
+	w.cancelActionCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.cancelActionCallback(g, v)
+	}
+
+	// Save old group by for cancel
+	w.oldGroupByColumnId = listWidget.groupByColumnId
+	w.oldExpandedGroups = listWidget.expandedGroups
+
+	return w
+}
+
+func (w *EditGroupByView) initialLayoutCallback(g *gocui.Gui, v *gocui.View) error {
+	if err := g.SetKeybinding(w.name, gocui.KeySpace, gocui.ModNone, w.keySpaceAction); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(w.name, 'c', gocui.ModNone, w.clearGroupByAction); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *EditGroupByView) refreshDisplayCallback(g *gocui.Gui, v *gocui.View) error {
+
+	v.Clear()
+	fmt.Fprintln(v, " ")
+	col := w.getSelectedColumn()
+	fmt.Fprintf(v, " Column name: %v\n", col.label)
+	groupBy := "--none--"
+	if groupColumn := w.listWidget.groupByColumn(); groupColumn != nil {
+		groupBy = groupColumn.label
+	}
+	fmt.Fprintf(v, " Group by: %v\n\n", groupBy)
+
+	fmt.Fprintln(v, " RIGHT or LEFT arrow - highlight column")
+	fmt.Fprintln(v, " SPACE - group by column (again to ungroup)")
+	fmt.Fprintln(v, " 'c' - clear group by")
+	fmt.Fprintln(v, " ENTER - apply, ESC to cancel")
+
+	return nil
+}
+
+func (w *EditGroupByView) getSelectedColumn() *ListColumn {
+	return w.listWidget.columnMap[w.listWidget.selectedColumnId]
+}
+
+func (w *EditGroupByView) keySpaceAction(g *gocui.Gui, v *gocui.View) error {
+	col := w.getSelectedColumn()
+	if col.columnType == TIMESTAMP {
+		// Timestamps are (nearly) unique per row so grouping is not useful
+		return nil
+	}
+	if col.id == w.listWidget.groupByColumnId {
+		return w.applyGroupBy(g, "")
+	}
+	return w.applyGroupBy(g, col.id)
+}
+
+func (w *EditGroupByView) clearGroupByAction(g *gocui.Gui, v *gocui.View) error {
+	return w.applyGroupBy(g, "")
+}
+
+func (w *EditGroupByView) applyGroupBy(g *gocui.Gui, columnId string) error {
+	w.listWidget.SetGroupByColumnId(columnId)
+	w.listWidget.FilterAndSortData()
+	w.listWidget.displayRowIndexOffset = 0
+	return w.RefreshDisplay(g)
+}
+
+func (w *EditGroupByView) cancelActionCallback(g *gocui.Gui, v *gocui.View) error {
+	w.listWidget.SetGroupByColumnId(w.oldGroupByColumnId)
+	w.listWidget.expandedGroups = w.oldExpandedGroups
+	w.listWidget.FilterAndSortData()
+	return nil
+}
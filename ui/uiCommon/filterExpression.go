@@ -0,0 +1,507 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package uiCommon
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// A filter expression is a boolean expression across columns, e.g.:
+//
+//	ORG =~ "^prod" AND CPU_PERCENT > 50 AND NOT (LAST_ACCESS > 10m)
+//
+// Comparisons are always <COLUMN> <operator> <value>.  Operators are
+// ==, =, !=, <, <=, >, >=, =~ (regex match) and !~ (regex not match).
+// Comparisons are combined with AND, OR and NOT (or &&, || and !) and
+// grouped with parentheses.
+//
+// ALPHANUMERIC columns compare case insensitive, NUMERIC columns compare as
+// numbers.  TIMESTAMP columns compared to a duration (e.g., 90s, 10m, 2h, 1d)
+// compare the age of the timestamp, compared to a date/time (e.g.,
+// "2017-06-01 13:00" or "13:00" for today) compare the timestamp itself.
+type FilterExpression struct {
+	text string
+	root filterNode
+}
+
+type filterNode interface {
+	match(data IData, now time.Time) bool
+}
+
+type filterAndNode struct {
+	left, right filterNode
+}
+
+func (n *filterAndNode) match(data IData, now time.Time) bool {
+	return n.left.match(data, now) && n.right.match(data, now)
+}
+
+type filterOrNode struct {
+	left, right filterNode
+}
+
+func (n *filterOrNode) match(data IData, now time.Time) bool {
+	return n.left.match(data, now) || n.right.match(data, now)
+}
+
+type filterNotNode struct {
+	node filterNode
+}
+
+func (n *filterNotNode) match(data IData, now time.Time) bool {
+	return !n.node.match(data, now)
+}
+
+type filterCompareNode struct {
+	column *ListColumn
+	op     string
+
+	textValue   string
+	regexValue  *regexp.Regexp
+	numberValue float64
+	isNumber    bool
+	ageValue    time.Duration
+	isAge       bool
+	timeValue   time.Time
+	// timeValue only holds a time of day which is resolved to the day of the match
+	isTimeOfDay bool
+}
+
+func (n *filterCompareNode) match(data IData, now time.Time) bool {
+	rawValue := n.column.rawValueFunc(data)
+	switch n.column.columnType {
+	case NUMERIC:
+		value, err := strconv.ParseFloat(strings.TrimSpace(rawValue), 64)
+		if err != nil {
+			return false
+		}
+		return compareFloat(value, n.op, n.numberValue)
+	case TIMESTAMP:
+		timestamp, ok := parseRawTimestamp(rawValue)
+		if !ok {
+			return false
+		}
+		if n.isAge {
+			return compareFloat(float64(now.Sub(timestamp)), n.op, float64(n.ageValue))
+		}
+		timeValue := n.timeValue
+		if n.isTimeOfDay {
+			timeValue = timeOfDay(timeValue, now)
+		}
+		return compareFloat(float64(timestamp.UnixNano()), n.op, float64(timeValue.UnixNano()))
+	default:
+		switch n.op {
+		case "=~":
+			return n.regexValue.MatchString(rawValue)
+		case "!~":
+			return !n.regexValue.MatchString(rawValue)
+		}
+		// Alphanumeric column holding numbers (e.g., IDX) compare as numbers
+		if n.isNumber {
+			if value, err := strconv.ParseFloat(strings.TrimSpace(rawValue), 64); err == nil {
+				return compareFloat(value, n.op, n.numberValue)
+			}
+		}
+		return compareString(strings.ToLower(rawValue), n.op, n.textValue)
+	}
+}
+
+func compareFloat(value float64, op string, compareValue float64) bool {
+	switch op {
+	case "==":
+		return value == compareValue
+	case "!=":
+		return value != compareValue
+	case "<":
+		return value < compareValue
+	case "<=":
+		return value <= compareValue
+	case ">":
+		return value > compareValue
+	case ">=":
+		return value >= compareValue
+	}
+	return false
+}
+
+func compareString(value string, op string, compareValue string) bool {
+	switch op {
+	case "==":
+		return value == compareValue
+	case "!=":
+		return value != compareValue
+	case "<":
+		return value < compareValue
+	case "<=":
+		return value <= compareValue
+	case ">":
+		return value > compareValue
+	case ">=":
+		return value >= compareValue
+	}
+	return false
+}
+
+// Timestamp raw values are either the default time.Time format or unix nanoseconds
+func parseRawTimestamp(rawValue string) (time.Time, bool) {
+	rawValue = strings.TrimSpace(rawValue)
+	if rawValue == "" {
+		return time.Time{}, false
+	}
+	if nanos, err := strconv.ParseInt(rawValue, 10, 64); err == nil {
+		if nanos == 0 {
+			return time.Time{}, false
+		}
+		return time.Unix(0, nanos), true
+	}
+	// Strip monotonic clock reading (e.g., " m=+12.345")
+	if index := strings.Index(rawValue, " m="); index > 0 {
+		rawValue = rawValue[:index]
+	}
+	timestamp, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", rawValue)
+	if err != nil || timestamp.IsZero() {
+		return time.Time{}, false
+	}
+	return timestamp, true
+}
+
+var filterTimeFormats = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"01-02-2006 15:04:05",
+	"01-02-2006 15:04",
+	"01-02-2006",
+}
+
+var filterTimeOfDayFormats = []string{
+	"15:04:05",
+	"15:04",
+}
+
+// Date/time values are local time.  A time of day only value (isTimeOfDay) is
+// resolved to a date with timeOfDay when it is compared.
+func parseFilterTime(value string) (t time.Time, isTimeOfDay bool, ok bool) {
+	for _, format := range filterTimeFormats {
+		if t, err := time.ParseInLocation(format, value, time.Local); err == nil {
+			return t, false, true
+		}
+	}
+	for _, format := range filterTimeOfDayFormats {
+		if t, err := time.ParseInLocation(format, value, time.Local); err == nil {
+			return t, true, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// The time of day of t on the (local) day of now
+func timeOfDay(t time.Time, now time.Time) time.Time {
+	year, month, day := now.Local().Date()
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+}
+
+var regexDays = regexp.MustCompile(`^(\d+(\.\d+)?)d(.*)$`)
+
+// Same as time.ParseDuration but also allows a days suffix (e.g., 1d12h)
+func parseFilterDuration(value string) (time.Duration, bool) {
+	days := 0.0
+	if match := regexDays.FindStringSubmatch(value); match != nil {
+		days, _ = strconv.ParseFloat(match[1], 64)
+		value = match[3]
+		if value == "" {
+			value = "0s"
+		}
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false
+	}
+	return duration + time.Duration(days*float64(24*time.Hour)), true
+}
+
+const (
+	filterTokenWord = iota
+	filterTokenString
+	filterTokenOperator
+	filterTokenOpenParen
+	filterTokenCloseParen
+)
+
+type filterToken struct {
+	tokenType int
+	text      string
+}
+
+func isFilterOperatorChar(c rune) bool {
+	return strings.ContainsRune("=!<>~&|", c)
+}
+
+func tokenizeFilterExpression(text string) ([]*filterToken, error) {
+	tokens := make([]*filterToken, 0)
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, &filterToken{filterTokenOpenParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, &filterToken{filterTokenCloseParen, ")"})
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != c {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("Missing closing quote")
+			}
+			value := strings.Replace(string(runes[i+1:end]), `\`+string(c), string(c), -1)
+			tokens = append(tokens, &filterToken{filterTokenString, value})
+			i = end + 1
+		case isFilterOperatorChar(c):
+			op := ""
+			if i+1 < len(runes) {
+				switch twoChars := string(runes[i : i+2]); twoChars {
+				case "==", "!=", "<=", ">=", "=~", "!~", "&&", "||":
+					op = twoChars
+				}
+			}
+			if op == "" {
+				switch c {
+				case '=':
+					op = "=="
+				case '<', '>', '!':
+					op = string(c)
+				default:
+					return nil, fmt.Errorf("Invalid operator: %v", string(c))
+				}
+				i++
+			} else {
+				i += 2
+			}
+			tokens = append(tokens, &filterToken{filterTokenOperator, op})
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !isFilterOperatorChar(runes[end]) &&
+				runes[end] != '(' && runes[end] != ')' && runes[end] != '"' && runes[end] != '\'' {
+				end++
+			}
+			tokens = append(tokens, &filterToken{filterTokenWord, string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens  []*filterToken
+	pos     int
+	columns []*ListColumn
+}
+
+// Parse a filter expression.  Column names are matched (case insensitive)
+// to the id or label of the given columns.
+func NewFilterExpression(text string, columns []*ListColumn) (*FilterExpression, error) {
+	tokens, err := tokenizeFilterExpression(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("Filter expression is empty")
+	}
+	parser := &filterParser{tokens: tokens, columns: columns}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token != nil {
+		return nil, fmt.Errorf("Unexpected '%v'", token.text)
+	}
+	return &FilterExpression{text: text, root: root}, nil
+}
+
+func (fe *FilterExpression) Text() string {
+	return fe.text
+}
+
+func (fe *FilterExpression) Match(data IData, now time.Time) bool {
+	return fe.root.match(data, now)
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *filterParser) next() *filterToken {
+	token := p.peek()
+	if token != nil {
+		p.pos++
+	}
+	return token
+}
+
+func (p *filterParser) isKeyword(token *filterToken, keyword string, operator string) bool {
+	if token == nil {
+		return false
+	}
+	return (token.tokenType == filterTokenWord && strings.EqualFold(token.text, keyword)) ||
+		(token.tokenType == filterTokenOperator && token.text == operator)
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(p.peek(), "OR", "||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterOrNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(p.peek(), "AND", "&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterAndNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (filterNode, error) {
+	if p.isKeyword(p.peek(), "NOT", "!") {
+		p.next()
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNotNode{node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	token := p.next()
+	if token == nil {
+		return nil, fmt.Errorf("Unexpected end of expression")
+	}
+	if token.tokenType == filterTokenOpenParen {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closeToken := p.next(); closeToken == nil || closeToken.tokenType != filterTokenCloseParen {
+			return nil, fmt.Errorf("Missing closing parenthesis")
+		}
+		return node, nil
+	}
+	if token.tokenType != filterTokenWord {
+		return nil, fmt.Errorf("Expected column name but found '%v'", token.text)
+	}
+	column := p.findColumn(token.text)
+	if column == nil {
+		return nil, fmt.Errorf("Unknown column: %v", token.text)
+	}
+	opToken := p.next()
+	if opToken == nil || opToken.tokenType != filterTokenOperator || opToken.text == "&&" || opToken.text == "||" || opToken.text == "!" {
+		return nil, fmt.Errorf("Expected comparison operator after %v", token.text)
+	}
+	valueToken := p.next()
+	if valueToken == nil || (valueToken.tokenType != filterTokenWord && valueToken.tokenType != filterTokenString) {
+		return nil, fmt.Errorf("Expected value after %v %v", token.text, opToken.text)
+	}
+	return p.newCompareNode(column, opToken.text, valueToken.text)
+}
+
+func (p *filterParser) findColumn(name string) *ListColumn {
+	for _, column := range p.columns {
+		if strings.EqualFold(column.id, name) || strings.EqualFold(column.label, name) {
+			return column
+		}
+	}
+	return nil
+}
+
+func (p *filterParser) newCompareNode(column *ListColumn, op string, value string) (filterNode, error) {
+	node := &filterCompareNode{column: column, op: op}
+	isRegexOp := op == "=~" || op == "!~"
+	switch column.columnType {
+	case NUMERIC:
+		if isRegexOp {
+			return nil, fmt.Errorf("Operator %v not supported on numeric column %v", op, column.id)
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Column %v is numeric, '%v' is not a number", column.id, value)
+		}
+		node.numberValue = number
+	case TIMESTAMP:
+		if isRegexOp {
+			return nil, fmt.Errorf("Operator %v not supported on timestamp column %v", op, column.id)
+		}
+		if duration, ok := parseFilterDuration(value); ok {
+			node.ageValue = duration
+			node.isAge = true
+		} else if timestamp, isTimeOfDay, ok := parseFilterTime(value); ok {
+			node.timeValue = timestamp
+			node.isTimeOfDay = isTimeOfDay
+		} else {
+			return nil, fmt.Errorf("Column %v is a timestamp, '%v' is not a duration (e.g., 10m) or date/time (e.g., \"2017-06-01 13:00\")", column.id, value)
+		}
+	default:
+		if isRegexOp {
+			// make regex case insenstive
+			regex, err := regexp.Compile("(?i)" + value)
+			if err != nil {
+				return nil, err
+			}
+			node.regexValue = regex
+		} else {
+			node.textValue = strings.ToLower(value)
+			if number, err := strconv.ParseFloat(value, 64); err == nil && op != "==" && op != "!=" {
+				node.numberValue = number
+				node.isNumber = true
+			}
+		}
+	}
+	return node, nil
+}
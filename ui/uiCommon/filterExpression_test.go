@@ -0,0 +1,132 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FilterExpression", func() {
+	var (
+		columns []*ListColumn
+		rows    []IData
+		now     time.Time
+	)
+
+	row := func(org string, idx string, cpu string, lastAccess time.Time) fakeRow {
+		return fakeRow{"ID": org, "ORG": org, "IDX": idx, "CPU_PERCENT": cpu, "LAST_ACCESS": fmt.Sprintf("%v", lastAccess)}
+	}
+
+	// Ids of the rows the expression matches
+	matching := func(text string) []string {
+		expression, err := NewFilterExpression(text, columns)
+		Expect(err).NotTo(HaveOccurred())
+		ids := make([]string, 0)
+		for _, data := range rows {
+			if expression.Match(data, now) {
+				ids = append(ids, data.Id())
+			}
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		cpuColumn := newFakeColumn("CPU_PERCENT", NUMERIC)
+		cpuColumn.label = "CPU%"
+		columns = []*ListColumn{
+			newFakeColumn("ORG", ALPHANUMERIC),
+			newFakeColumn("IDX", ALPHANUMERIC),
+			cpuColumn,
+			newFakeColumn("LAST_ACCESS", TIMESTAMP),
+		}
+		now = time.Date(2017, 6, 1, 15, 0, 0, 0, time.Local)
+		rows = []IData{
+			row("prod-a", "2", "60", now.Add(-time.Minute)),
+			row("prod-b", "10", "10", now.Add(-3*time.Hour)),
+			row("dev", "1", "90", now.Add(-48*time.Hour)),
+			row("never", "0", "0", time.Time{}),
+		}
+	})
+
+	DescribeTable("Match",
+		func(text string, want ...string) {
+			Expect(matching(text)).To(Equal(want))
+		},
+		Entry("regex", `ORG =~ "^PROD"`, "prod-a", "prod-b"),
+		Entry("negated regex", `ORG !~ prod`, "dev", "never"),
+		Entry("== ignores case", `org == DEV`, "dev"),
+		Entry("= is an alias of ==", `ORG = dev`, "dev"),
+		Entry("!=", `ORG != dev`, "prod-a", "prod-b", "never"),
+		Entry("numeric column", `CPU_PERCENT >= 60`, "prod-a", "dev"),
+		Entry("column label", `CPU% < 60`, "prod-b", "never"),
+		Entry("numbers in an alphanumeric column", `IDX > 1`, "prod-a", "prod-b"),
+		Entry("AND before OR", `ORG = prod-a OR ORG = dev AND CPU_PERCENT > 95`, "prod-a"),
+		Entry("&& before ||", `ORG = dev || CPU_PERCENT < 20 && IDX = 10`, "prod-b", "dev"),
+		Entry("parentheses", `(ORG = dev OR ORG = prod-a) AND CPU_PERCENT > 50`, "prod-a", "dev"),
+		Entry("NOT", `NOT ORG =~ prod`, "dev", "never"),
+		Entry("!", `!(ORG =~ prod) && CPU_PERCENT > 0`, "dev"),
+		Entry("duration", `LAST_ACCESS < 10m`, "prod-a"),
+		Entry("duration in days", `LAST_ACCESS > 1d2h`, "dev"),
+		Entry("date", `LAST_ACCESS >= "2017-06-01"`, "prod-a", "prod-b"),
+		Entry("date and time", `LAST_ACCESS < "2017-06-01 13:00"`, "prod-b", "dev"),
+		Entry("time of day", `LAST_ACCESS > "13:00"`, "prod-a"),
+	)
+
+	Context("when the value is a time of day", func() {
+		var expression *FilterExpression
+
+		BeforeEach(func() {
+			var err error
+			expression, err = NewFilterExpression(`LAST_ACCESS > "13:00"`, columns)
+			Expect(err).NotTo(HaveOccurred())
+			rows = []IData{row("org", "0", "0", time.Date(2017, 6, 1, 14, 0, 0, 0, time.Local))}
+		})
+
+		It("is resolved to the day of the match", func() {
+			Expect(expression.Match(rows[0], time.Date(2017, 6, 1, 15, 0, 0, 0, time.Local))).To(BeTrue())
+			Expect(expression.Match(rows[0], time.Date(2017, 6, 2, 15, 0, 0, 0, time.Local))).To(BeFalse())
+			Expect(expression.Match(rows[0], time.Date(2017, 5, 31, 15, 0, 0, 0, time.Local))).To(BeTrue())
+		})
+	})
+
+	DescribeTable("NewFilterExpression errors",
+		func(text string, wantError string) {
+			_, err := NewFilterExpression(text, columns)
+			Expect(err).To(MatchError(wantError))
+		},
+		Entry("empty", ``, "Filter expression is empty"),
+		Entry("unterminated string", `ORG = "dev`, "Missing closing quote"),
+		Entry("invalid operator", `ORG ~ dev`, "Invalid operator: ~"),
+		Entry("unclosed parenthesis", `(ORG = dev`, "Missing closing parenthesis"),
+		Entry("extra parenthesis", `ORG = dev)`, "Unexpected ')'"),
+		Entry("dangling AND", `ORG = dev AND`, "Unexpected end of expression"),
+		Entry("missing column", `= dev`, "Expected column name but found '=='"),
+		Entry("unknown column", `FOO = 1`, "Unknown column: FOO"),
+		Entry("missing operator", `ORG`, "Expected comparison operator after ORG"),
+		Entry("keyword instead of operator", `ORG AND`, "Expected comparison operator after ORG"),
+		Entry("missing value", `ORG =`, "Expected value after ORG =="),
+		Entry("not a number", `CPU_PERCENT > abc`, "Column CPU_PERCENT is numeric, 'abc' is not a number"),
+		Entry("regex on a numeric column", `CPU_PERCENT =~ 5`, "Operator =~ not supported on numeric column CPU_PERCENT"),
+		Entry("regex on a timestamp column", `LAST_ACCESS =~ 5`, "Operator =~ not supported on timestamp column LAST_ACCESS"),
+		Entry("not a time", `LAST_ACCESS > soon`,
+			`Column LAST_ACCESS is a timestamp, 'soon' is not a duration (e.g., 10m) or date/time (e.g., "2017-06-01 13:00")`),
+	)
+})
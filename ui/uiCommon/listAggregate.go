@@ -0,0 +1,235 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package uiCommon
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+// Aggregate of a NUMERIC column shown in the list footer
+type AggregateType int
+
+const (
+	AGGREGATE_NONE AggregateType = iota
+	AGGREGATE_SUM
+	AGGREGATE_AVG
+	AGGREGATE_MIN
+	AGGREGATE_MAX
+)
+
+var aggregateTypeNames = map[AggregateType]string{
+	AGGREGATE_NONE: "NONE",
+	AGGREGATE_SUM:  "SUM",
+	AGGREGATE_AVG:  "AVG",
+	AGGREGATE_MIN:  "MIN",
+	AGGREGATE_MAX:  "MAX",
+}
+
+func (t AggregateType) String() string {
+	return aggregateTypeNames[t]
+}
+
+// Next aggregate type when cycling through the types (SUM, AVG, MIN, MAX, NONE)
+func (t AggregateType) next() AggregateType {
+	if t == AGGREGATE_MAX {
+		return AGGREGATE_NONE
+	}
+	return t + 1
+}
+
+type getAggregateDisplayFunc func(value float64) string
+
+var (
+	// savedAggregateTypes map key = viewName, columnId
+	savedAggregateTypes map[string]map[string]AggregateType
+	// savedHideAggregateFooter map key = viewName
+	savedHideAggregateFooter map[string]bool
+)
+
+func init() {
+	savedAggregateTypes = make(map[string]map[string]AggregateType)
+	savedHideAggregateFooter = make(map[string]bool)
+}
+
+// Set the aggregate shown in the footer for this column.  NUMERIC columns
+// default to SUM, use AVG for percentages and NONE for ids or ports.
+func (c *ListColumn) SetDefaultAggregate(aggregateType AggregateType) *ListColumn {
+	c.defaultAggregateType = aggregateType
+	return c
+}
+
+// Set how the aggregate value is displayed, default is a plain number
+func (c *ListColumn) SetAggregateDisplayFunc(aggregateDisplayFunc getAggregateDisplayFunc) *ListColumn {
+	c.aggregateDisplayFunc = aggregateDisplayFunc
+	return c
+}
+
+func defaultAggregateType(columnType ColumnType) AggregateType {
+	if columnType == NUMERIC {
+		return AGGREGATE_SUM
+	}
+	return AGGREGATE_NONE
+}
+
+// Aggregate display for columns whose raw value is bytes
+func AggregateByteSizeDisplay(value float64) string {
+	return util.ByteSize(value).StringWithPrecision(1)
+}
+
+func defaultAggregateDisplay(value float64) string {
+	if value == math.Trunc(value) && math.Abs(value) < 1e15 {
+		if value < 0 {
+			return strconv.FormatInt(int64(value), 10)
+		}
+		return util.Format(int64(value))
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+func (asUI *ListWidget) GetAggregateType(columnId string) AggregateType {
+	if aggregateType, ok := asUI.aggregateTypes[columnId]; ok {
+		return aggregateType
+	}
+	column := asUI.columnMap[columnId]
+	if column == nil {
+		return AGGREGATE_NONE
+	}
+	return column.defaultAggregateType
+}
+
+func (asUI *ListWidget) SetAggregateType(columnId string, aggregateType AggregateType) {
+	asUI.aggregateTypes[columnId] = aggregateType
+	savedAggregateTypes[asUI.name] = asUI.aggregateTypes
+}
+
+func (asUI *ListWidget) IsAggregateFooter() bool {
+	if asUI.hideAggregateFooter {
+		return false
+	}
+	for _, column := range asUI.columns {
+		if asUI.GetAggregateType(column.id) != AGGREGATE_NONE {
+			return true
+		}
+	}
+	return false
+}
+
+func (asUI *ListWidget) setHideAggregateFooter(hide bool) {
+	asUI.hideAggregateFooter = hide
+	savedHideAggregateFooter[asUI.name] = hide
+}
+
+func (asUI *ListWidget) toggleAggregateFooterAction(g *gocui.Gui, v *gocui.View) error {
+	asUI.setHideAggregateFooter(!asUI.hideAggregateFooter)
+	return asUI.RefreshDisplay(g)
+}
+
+func (asUI *ListWidget) editAggregateAction(g *gocui.Gui, v *gocui.View) error {
+	editViewName := asUI.name + ".editAggregateView"
+	asUI.selectColumnMode = true
+	if asUI.selectedColumnId == "" {
+		asUI.selectedColumnId = asUI.columns[0].id
+	}
+	editView := NewEditAggregateView(asUI.masterUI, editViewName, asUI)
+	asUI.masterUI.LayoutManager().Add(editView)
+	asUI.masterUI.SetCurrentViewOnTop(g)
+	asUI.masterUI.SetEditColumnMode(g, true)
+	return asUI.RefreshDisplay(g)
+}
+
+// Aggregate the column over the given (filtered) rows.  Rows with a value
+// that is not a number are skipped.  Returns false if no rows had a value.
+func aggregateColumn(column *ListColumn, aggregateType AggregateType, listData []IData) (float64, bool) {
+	result := 0.0
+	count := 0
+	for _, data := range listData {
+		value, err := strconv.ParseFloat(strings.TrimSpace(column.rawValueFunc(data)), 64)
+		if err != nil || math.IsNaN(value) {
+			continue
+		}
+		switch {
+		case count == 0 && (aggregateType == AGGREGATE_MIN || aggregateType == AGGREGATE_MAX):
+			result = value
+		case aggregateType == AGGREGATE_MIN:
+			result = math.Min(result, value)
+		case aggregateType == AGGREGATE_MAX:
+			result = math.Max(result, value)
+		default:
+			result = result + value
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	if aggregateType == AGGREGATE_AVG {
+		result = result / float64(count)
+	}
+	return result, true
+}
+
+func (asUI *ListWidget) writeAggregateFooter(g *gocui.Gui, v *gocui.View) {
+
+	lastColumnCanDisplay := asUI.lastColumnCanDisplay(g, asUI.displayColIndexOffset)
+
+	fmt.Fprint(v, normalHeaderColor)
+
+	for colIndex, column := range asUI.columns {
+		if colIndex > lastColumnCanDisplay {
+			break
+		}
+		if colIndex >= LOCK_COLUMNS && colIndex < asUI.displayColIndexOffset+LOCK_COLUMNS {
+			continue
+		}
+		displayValue := asUI.aggregateDisplayValue(column, asUI.filteredListData)
+		if colIndex == 0 {
+			displayValue = fmt.Sprintf("TOTAL (%v rows)", len(asUI.filteredListData))
+		}
+		writeAggregateCell(v, column, displayValue)
+	}
+	fmt.Fprint(v, util.CLEAR)
+}
+
+// Display value of the column's aggregate over the rows, blank if the
+// column is not aggregated
+func (asUI *ListWidget) aggregateDisplayValue(column *ListColumn, listData []IData) string {
+	aggregateType := asUI.GetAggregateType(column.id)
+	if column.columnType != NUMERIC || aggregateType == AGGREGATE_NONE {
+		return ""
+	}
+	value, ok := aggregateColumn(column, aggregateType, listData)
+	if !ok {
+		return ""
+	}
+	aggregateDisplayFunc := column.aggregateDisplayFunc
+	if aggregateDisplayFunc == nil {
+		aggregateDisplayFunc = defaultAggregateDisplay
+	}
+	return aggregateDisplayFunc(value)
+}
+
+func writeAggregateCell(v *gocui.View, column *ListColumn, displayValue string) {
+	if column.columnType == NUMERIC {
+		fmt.Fprintf(v, "%v ", util.FormatDisplayDataRight(displayValue, column.size))
+	} else {
+		fmt.Fprintf(v, "%v ", util.FormatDisplayDataLeft(displayValue, column.size))
+	}
+}
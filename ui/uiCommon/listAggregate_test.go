@@ -0,0 +1,175 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListWidget aggregates", func() {
+
+	// Rows with the given raw values in the VALUE column
+	valueRows := func(values ...string) []IData {
+		rows := make([]IData, len(values))
+		for i, value := range values {
+			rows[i] = fakeRow{"VALUE": value}
+		}
+		return rows
+	}
+
+	Describe("aggregateColumn", func() {
+		column := newFakeColumn("VALUE", NUMERIC)
+		rows := valueRows("1", " 2.5 ", "", "abc", "-4", "NaN")
+
+		DescribeTable("skips values that are not numbers",
+			func(aggregateType AggregateType, want float64) {
+				value, ok := aggregateColumn(column, aggregateType, rows)
+				Expect(ok).To(BeTrue())
+				Expect(value).To(BeNumerically("~", want, 1e-9))
+			},
+			Entry("SUM", AGGREGATE_SUM, -0.5),
+			Entry("AVG", AGGREGATE_AVG, -0.5/3),
+			Entry("MIN", AGGREGATE_MIN, -4.0),
+			Entry("MAX", AGGREGATE_MAX, 2.5),
+		)
+
+		It("starts MIN and MAX from the first value", func() {
+			value, _ := aggregateColumn(column, AGGREGATE_MIN, valueRows("5", "3", "7"))
+			Expect(value).To(Equal(3.0))
+			value, _ = aggregateColumn(column, AGGREGATE_MAX, valueRows("-5", "-3", "-7"))
+			Expect(value).To(Equal(-3.0))
+		})
+
+		It("has no value when no row is a number", func() {
+			_, ok := aggregateColumn(column, AGGREGATE_SUM, valueRows())
+			Expect(ok).To(BeFalse())
+			_, ok = aggregateColumn(column, AGGREGATE_AVG, valueRows("", "abc"))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	DescribeTable("defaultAggregateDisplay",
+		func(value float64, want string) {
+			Expect(defaultAggregateDisplay(value)).To(Equal(want))
+		},
+		Entry("zero", 0.0, "0"),
+		Entry("whole number", 1234567.0, "1,234,567"),
+		Entry("negative whole number", -1234.0, "-1234"),
+		Entry("fraction", 2.5, "2.50"),
+		Entry("too large for an int64", 1e16, "10000000000000000.00"),
+	)
+
+	It("cycles through the aggregate types", func() {
+		aggregateType := AGGREGATE_NONE
+		for _, want := range []AggregateType{AGGREGATE_SUM, AGGREGATE_AVG, AGGREGATE_MIN, AGGREGATE_MAX, AGGREGATE_NONE} {
+			aggregateType = aggregateType.next()
+			Expect(aggregateType).To(Equal(want))
+		}
+	})
+
+	Describe("aggregate type", func() {
+		var asUI *ListWidget
+
+		BeforeEach(func() {
+			columns := []*ListColumn{
+				newFakeColumn("VALUE", NUMERIC),
+				newFakeColumn("PORT", NUMERIC).SetDefaultAggregate(AGGREGATE_NONE),
+				newFakeColumn("TEXT", ALPHANUMERIC),
+			}
+			asUI = &ListWidget{
+				name:           "aggregateTest",
+				columns:        columns,
+				columnMap:      make(map[string]*ListColumn),
+				aggregateTypes: make(map[string]AggregateType),
+			}
+			for _, column := range columns {
+				asUI.columnMap[column.id] = column
+			}
+		})
+
+		It("defaults to SUM for numeric columns", func() {
+			Expect(asUI.GetAggregateType("VALUE")).To(Equal(AGGREGATE_SUM))
+			Expect(asUI.GetAggregateType("TEXT")).To(Equal(AGGREGATE_NONE))
+		})
+		It("uses the default aggregate of the column", func() {
+			Expect(asUI.GetAggregateType("PORT")).To(Equal(AGGREGATE_NONE))
+		})
+		It("uses the aggregate type set for the column", func() {
+			asUI.SetAggregateType("VALUE", AGGREGATE_MAX)
+			Expect(asUI.GetAggregateType("VALUE")).To(Equal(AGGREGATE_MAX))
+			Expect(savedAggregateTypes["aggregateTest"]).To(HaveKeyWithValue("VALUE", AGGREGATE_MAX))
+		})
+		It("shows the footer while a column is aggregated and it is not hidden", func() {
+			Expect(asUI.IsAggregateFooter()).To(BeTrue())
+			asUI.hideAggregateFooter = true
+			Expect(asUI.IsAggregateFooter()).To(BeFalse())
+			asUI.hideAggregateFooter = false
+			asUI.SetAggregateType("VALUE", AGGREGATE_NONE)
+			Expect(asUI.IsAggregateFooter()).To(BeFalse())
+		})
+	})
+
+	Describe("aggregateDisplayValue", func() {
+		var (
+			asUI *ListWidget
+			rows []IData
+		)
+
+		BeforeEach(func() {
+			columns := []*ListColumn{
+				newFakeColumn("VALUE", NUMERIC),
+				newFakeColumn("BYTES", NUMERIC).SetAggregateDisplayFunc(AggregateByteSizeDisplay),
+				newFakeColumn("PORT", NUMERIC).SetDefaultAggregate(AGGREGATE_NONE),
+				newFakeColumn("TEXT", ALPHANUMERIC),
+			}
+			asUI = &ListWidget{
+				columns:        columns,
+				columnMap:      make(map[string]*ListColumn),
+				aggregateTypes: make(map[string]AggregateType),
+			}
+			for _, column := range columns {
+				asUI.columnMap[column.id] = column
+			}
+			rows = []IData{
+				fakeRow{"VALUE": "1024", "BYTES": "1024", "PORT": "8080", "TEXT": "1"},
+				fakeRow{"VALUE": "2048", "BYTES": "2048", "PORT": "8080", "TEXT": "2"},
+				fakeRow{},
+			}
+		})
+
+		It("sums numeric columns by default", func() {
+			Expect(asUI.aggregateDisplayValue(asUI.columnMap["VALUE"], rows)).To(Equal("3,072"))
+		})
+		It("uses the aggregate type set for the column", func() {
+			asUI.aggregateTypes["VALUE"] = AGGREGATE_AVG
+			Expect(asUI.aggregateDisplayValue(asUI.columnMap["VALUE"], rows)).To(Equal("1,536"))
+			asUI.aggregateTypes["VALUE"] = AGGREGATE_NONE
+			Expect(asUI.aggregateDisplayValue(asUI.columnMap["VALUE"], rows)).To(BeEmpty())
+		})
+		It("uses the aggregate display func of the column", func() {
+			Expect(asUI.aggregateDisplayValue(asUI.columnMap["BYTES"], rows)).To(Equal("3.0KB"))
+		})
+		It("is blank for columns that are not aggregated", func() {
+			Expect(asUI.aggregateDisplayValue(asUI.columnMap["PORT"], rows)).To(BeEmpty())
+			Expect(asUI.aggregateDisplayValue(asUI.columnMap["TEXT"], rows)).To(BeEmpty())
+		})
+		It("is blank when no row has a value", func() {
+			Expect(asUI.aggregateDisplayValue(asUI.columnMap["VALUE"], rows[2:])).To(BeEmpty())
+		})
+	})
+})
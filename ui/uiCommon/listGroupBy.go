@@ -0,0 +1,223 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package uiCommon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+// Id prefix of group rows, can not collide with the id of a data row
+const groupRowIdPrefix = "\x00group:"
+
+const groupNoneValue = "(none)"
+
+var (
+	// savedGroupByColumnId map key = viewName
+	savedGroupByColumnId map[string]string
+)
+
+func init() {
+	savedGroupByColumnId = make(map[string]string)
+}
+
+// A group of rows that have the same value in the group by column
+type groupRow struct {
+	value string
+	rows  []IData
+	// Aggregate of the primary sort column, used to order the groups
+	sortValue    float64
+	hasSortValue bool
+}
+
+func (r *groupRow) Id() string {
+	return groupRowIdPrefix + r.value
+}
+
+func (r *groupRow) displayValue() string {
+	if r.value == "" {
+		return groupNoneValue
+	}
+	return r.value
+}
+
+func isGroupRowId(id string) bool {
+	return strings.HasPrefix(id, groupRowIdPrefix)
+}
+
+func (asUI *ListWidget) GetGroupByColumnId() string {
+	return asUI.groupByColumnId
+}
+
+// Group the rows by the given column, empty string to turn off grouping.
+// All groups start out collapsed.
+func (asUI *ListWidget) SetGroupByColumnId(columnId string) {
+	asUI.groupByColumnId = columnId
+	asUI.expandedGroups = make(map[string]bool)
+	savedGroupByColumnId[asUI.name] = columnId
+}
+
+func (asUI *ListWidget) groupByColumn() *ListColumn {
+	if asUI.groupByColumnId == "" {
+		return nil
+	}
+	return asUI.columnMap[asUI.groupByColumnId]
+}
+
+// Collapse the sorted rows into group rows.  Rows of expanded groups are
+// listed after their group row in sorted order.
+func (asUI *ListWidget) groupData(listData []IData) []IData {
+	groupColumn := asUI.groupByColumn()
+	if groupColumn == nil {
+		return listData
+	}
+
+	groupMap := make(map[string]*groupRow)
+	groups := make([]util.Sortable, 0)
+	for _, data := range listData {
+		value := strings.TrimSpace(groupColumn.rawValueFunc(data))
+		group := groupMap[value]
+		if group == nil {
+			group = &groupRow{value: value}
+			groupMap[value] = group
+			groups = append(groups, group)
+		}
+		group.rows = append(group.rows, data)
+	}
+	util.OrderedBy(asUI.groupSortFunctions(groups)).Sort(groups)
+
+	groupedList := make([]IData, 0, len(groups))
+	for _, g := range groups {
+		group := g.(*groupRow)
+		groupedList = append(groupedList, group)
+		if asUI.expandedGroups[group.value] {
+			groupedList = append(groupedList, group.rows...)
+		}
+	}
+	return groupedList
+}
+
+// Groups are ordered by the aggregate of the primary sort column when it is
+// numeric, otherwise (and for ties) by the group value.
+func (asUI *ListWidget) groupSortFunctions(groups []util.Sortable) []util.LessFunc {
+	sortFunctions := make([]util.LessFunc, 0)
+	reverseValueSort := false
+	if len(asUI.sortColumns) > 0 {
+		sortColumn := asUI.sortColumns[0]
+		column := asUI.columnMap[sortColumn.Id]
+		aggregateType := asUI.GetAggregateType(sortColumn.Id)
+		if column != nil && column.columnType == NUMERIC && aggregateType != AGGREGATE_NONE &&
+			column.id != asUI.groupByColumnId {
+			for _, g := range groups {
+				group := g.(*groupRow)
+				group.sortValue, group.hasSortValue = aggregateColumn(column, aggregateType, group.rows)
+			}
+			sortFunc := func(c1, c2 util.Sortable) bool {
+				g1 := c1.(*groupRow)
+				g2 := c2.(*groupRow)
+				if g1.hasSortValue != g2.hasSortValue {
+					return !g1.hasSortValue
+				}
+				return g1.sortValue < g2.sortValue
+			}
+			if sortColumn.ReverseSort {
+				sortFunc = util.Reverse(sortFunc)
+			}
+			sortFunctions = append(sortFunctions, sortFunc)
+		} else if column != nil && column.id == asUI.groupByColumnId {
+			reverseValueSort = sortColumn.ReverseSort
+		}
+	}
+	valueSortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*groupRow).value, c2.(*groupRow).value)
+	}
+	if reverseValueSort {
+		valueSortFunc = util.Reverse(valueSortFunc)
+	}
+	return append(sortFunctions, valueSortFunc)
+}
+
+func (asUI *ListWidget) highlightedGroupRow() *groupRow {
+	if !isGroupRowId(asUI.highlightKey) {
+		return nil
+	}
+	for _, data := range asUI.listData {
+		if data.Id() == asUI.highlightKey {
+			return data.(*groupRow)
+		}
+	}
+	return nil
+}
+
+// Expand or collapse the highlighted group
+func (asUI *ListWidget) toggleGroupAction(g *gocui.Gui, v *gocui.View) error {
+	group := asUI.highlightedGroupRow()
+	if group == nil {
+		return nil
+	}
+	asUI.expandedGroups[group.value] = !asUI.expandedGroups[group.value]
+	asUI.listData = asUI.groupData(asUI.filteredListData)
+	return asUI.RefreshDisplay(g)
+}
+
+func (asUI *ListWidget) editGroupByAction(g *gocui.Gui, v *gocui.View) error {
+	editViewName := asUI.name + ".editGroupByView"
+	asUI.selectColumnMode = true
+	if asUI.selectedColumnId == "" {
+		asUI.selectedColumnId = asUI.columns[0].id
+	}
+	editView := NewEditGroupByView(asUI.masterUI, editViewName, asUI)
+	asUI.masterUI.LayoutManager().Add(editView)
+	asUI.masterUI.SetCurrentViewOnTop(g)
+	asUI.masterUI.SetEditColumnMode(g, true)
+	return asUI.RefreshDisplay(g)
+}
+
+func (asUI *ListWidget) writeGroupRow(g *gocui.Gui, v *gocui.View, group *groupRow, isSelected bool) {
+
+	lastColumnCanDisplay := asUI.lastColumnCanDisplay(g, asUI.displayColIndexOffset)
+
+	if !isSelected {
+		fmt.Fprint(v, util.BRIGHT_WHITE)
+	}
+
+	for colIndex, column := range asUI.columns {
+		if colIndex > lastColumnCanDisplay {
+			break
+		}
+		if colIndex >= LOCK_COLUMNS && colIndex < asUI.displayColIndexOffset+LOCK_COLUMNS {
+			continue
+		}
+		displayValue := ""
+		switch {
+		case colIndex == 0:
+			expandIndicator := "+"
+			if asUI.expandedGroups[group.value] {
+				expandIndicator = "-"
+			}
+			displayValue = fmt.Sprintf("%v %v (%v)", expandIndicator, group.displayValue(), len(group.rows))
+		case column.id == asUI.groupByColumnId:
+			displayValue = group.displayValue()
+		default:
+			displayValue = asUI.aggregateDisplayValue(column, group.rows)
+		}
+		writeAggregateCell(v, column, displayValue)
+	}
+	fmt.Fprint(v, "\n")
+	fmt.Fprint(v, util.CLEAR)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListWidget group by", func() {
+	var (
+		asUI *ListWidget
+		rows []IData
+	)
+
+	// Ids of the grouped rows, group rows are shown as <value>
+	groupedIds := func() []string {
+		ids := make([]string, 0)
+		for _, data := range asUI.groupData(rows) {
+			if group, ok := data.(*groupRow); ok {
+				ids = append(ids, "<"+group.displayValue()+">")
+			} else {
+				ids = append(ids, data.Id())
+			}
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		columns := []*ListColumn{newFakeColumn("ORG", ALPHANUMERIC), newFakeColumn("MEM", NUMERIC)}
+		asUI = &ListWidget{
+			name:           "groupByTest",
+			columns:        columns,
+			columnMap:      map[string]*ListColumn{"ORG": columns[0], "MEM": columns[1]},
+			aggregateTypes: make(map[string]AggregateType),
+			expandedGroups: make(map[string]bool),
+		}
+		rows = []IData{
+			fakeRow{"ID": "r1", "ORG": "dev", "MEM": "100"},
+			fakeRow{"ID": "r2", "ORG": "prod", "MEM": "50"},
+			fakeRow{"ID": "r3", "ORG": "prod", "MEM": "70"},
+			fakeRow{"ID": "r4", "ORG": "", "MEM": "10"},
+			fakeRow{"ID": "r5", "ORG": "test", "MEM": ""},
+			fakeRow{"ID": "r6", "ORG": " dev ", "MEM": "5"},
+		}
+	})
+
+	Context("when not grouped", func() {
+		It("lists the rows as is", func() {
+			Expect(groupedIds()).To(Equal(rowIds(rows)))
+		})
+		It("ignores an unknown group by column", func() {
+			asUI.SetGroupByColumnId("FOO")
+			Expect(groupedIds()).To(Equal(rowIds(rows)))
+		})
+	})
+
+	Context("when grouped by a column", func() {
+		BeforeEach(func() {
+			asUI.SetGroupByColumnId("ORG")
+		})
+
+		It("collapses the rows into groups ordered by value", func() {
+			Expect(groupedIds()).To(Equal([]string{"<(none)>", "<dev>", "<prod>", "<test>"}))
+		})
+
+		It("lists the rows of expanded groups after their group", func() {
+			asUI.expandedGroups["dev"] = true
+			asUI.expandedGroups["test"] = true
+			Expect(groupedIds()).To(Equal([]string{"<(none)>", "<dev>", "r1", "r6", "<prod>", "<test>", "r5"}))
+		})
+
+		It("collapses all groups when the group by column is set again", func() {
+			asUI.expandedGroups["dev"] = true
+			asUI.SetGroupByColumnId("ORG")
+			Expect(asUI.expandedGroups).To(BeEmpty())
+			Expect(savedGroupByColumnId[asUI.name]).To(Equal("ORG"))
+		})
+
+		Context("and sorted by a numeric column", func() {
+			BeforeEach(func() {
+				asUI.sortColumns = []*SortColumn{{Id: "MEM"}}
+			})
+			It("orders the groups by the column sum with groups without a value first", func() {
+				Expect(groupedIds()).To(Equal([]string{"<test>", "<(none)>", "<dev>", "<prod>"}))
+			})
+			It("orders the groups by the aggregate type of the column", func() {
+				asUI.sortColumns[0].ReverseSort = true
+				asUI.aggregateTypes["MEM"] = AGGREGATE_MIN
+				Expect(groupedIds()).To(Equal([]string{"<prod>", "<(none)>", "<dev>", "<test>"}))
+			})
+			It("orders the groups by value when the column is not aggregated", func() {
+				asUI.aggregateTypes["MEM"] = AGGREGATE_NONE
+				Expect(groupedIds()).To(Equal([]string{"<(none)>", "<dev>", "<prod>", "<test>"}))
+			})
+		})
+
+		Context("and sorted in reverse by the group by column", func() {
+			BeforeEach(func() {
+				asUI.sortColumns = []*SortColumn{{Id: "ORG", ReverseSort: true}, {Id: "MEM"}}
+			})
+			It("orders the groups by value in reverse", func() {
+				Expect(groupedIds()).To(Equal([]string{"<test>", "<prod>", "<dev>", "<(none)>"}))
+			})
+		})
+	})
+
+	Context("when grouped by a numeric column", func() {
+		BeforeEach(func() {
+			asUI.SetGroupByColumnId("MEM")
+		})
+		It("orders the groups as text", func() {
+			Expect(groupedIds()).To(Equal([]string{"<(none)>", "<10>", "<100>", "<5>", "<50>", "<70>"}))
+		})
+	})
+
+	Describe("isGroupRowId", func() {
+		It("is true for group rows", func() {
+			Expect(isGroupRowId((&groupRow{value: "dev"}).Id())).To(BeTrue())
+			Expect(isGroupRowId((&groupRow{value: ""}).Id())).To(BeTrue())
+		})
+		It("is false for data rows", func() {
+			Expect(isGroupRowId("dev")).To(BeFalse())
+			Expect(isGroupRowId("group:dev")).To(BeFalse())
+		})
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"log"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/Knetic/govaluate"
 	"github.com/ansel1/merry"
@@ -101,6 +102,9 @@ type ListColumn struct {
 	displayFunc        getRowDisplayFunc
 	rawValueFunc       getRowRawValueFunc
 	attentionFunc      getRowAttentionFunc
+
+	defaultAggregateType AggregateType
+	aggregateDisplayFunc getAggregateDisplayFunc
 }
 
 const LOCK_COLUMNS = 1
@@ -126,6 +130,8 @@ type ListWidget struct {
 	columnOwner        IColumnOwner
 	listData           []IData
 	unfilteredListData []IData
+	// Filtered and sorted data rows (listData also has group rows when grouped)
+	filteredListData []IData
 
 	columns   []*ListColumn
 	columnMap map[string]*ListColumn
@@ -136,6 +142,17 @@ type ListWidget struct {
 	sortColumns []*SortColumn
 
 	filterColumnMap map[string]*FilterColumn
+	// Filter expression across columns (nil if none)
+	filterExpression *FilterExpression
+
+	// Aggregate type by column id (if changed from column default)
+	aggregateTypes      map[string]AggregateType
+	hideAggregateFooter bool
+
+	// Column the rows are grouped by (empty if not grouped)
+	groupByColumnId string
+	// Expanded groups by group value
+	expandedGroups map[string]bool
 }
 
 type SortColumn struct {
@@ -144,14 +161,16 @@ type SortColumn struct {
 }
 
 type FilterColumn struct {
-	filterText    string
-	compiledRegex *regexp.Regexp
+	filterText         string
+	compiledRegex      *regexp.Regexp
+	compiledExpression *FilterExpression
 }
 
 var (
-	normalHeaderColor    string
-	savedSortColumns     map[string][]*SortColumn
-	savedFilterColumnMap map[string]map[string]*FilterColumn
+	normalHeaderColor     string
+	savedSortColumns      map[string][]*SortColumn
+	savedFilterColumnMap  map[string]map[string]*FilterColumn
+	savedFilterExpression map[string]*FilterExpression
 )
 
 func init() {
@@ -165,6 +184,8 @@ func init() {
 	savedSortColumns = make(map[string][]*SortColumn)
 	// savedFilterColumnMap map key = viewName
 	savedFilterColumnMap = make(map[string]map[string]*FilterColumn)
+	// savedFilterExpression map key = viewName
+	savedFilterExpression = make(map[string]*FilterExpression)
 }
 
 func NewSortColumn(id string, reverseSort bool) *SortColumn {
@@ -193,6 +214,7 @@ func NewListColumn(
 		rawValueFunc:       rawValueFunc,
 		attentionFunc:      attentionFunc,
 	}
+	column.defaultAggregateType = defaultAggregateType(columnType)
 
 	return column
 }
@@ -208,6 +230,8 @@ func NewListWidget(masterUI masterUIInterface.MasterUIInterface, name string,
 		columns:         columns,
 		columnMap:       make(map[string]*ListColumn),
 		filterColumnMap: make(map[string]*FilterColumn),
+		aggregateTypes:  make(map[string]AggregateType),
+		expandedGroups:  make(map[string]bool),
 		columnOwner:     columnOwner,
 	}
 	for _, col := range columns {
@@ -224,6 +248,13 @@ func NewListWidget(masterUI masterUIInterface.MasterUIInterface, name string,
 	if savedFilterColumnMap != nil {
 		w.filterColumnMap = savedFilterColumnMap
 	}
+	w.filterExpression = savedFilterExpression[name]
+
+	if aggregateTypes := savedAggregateTypes[name]; aggregateTypes != nil {
+		w.aggregateTypes = aggregateTypes
+	}
+	w.hideAggregateFooter = savedHideAggregateFooter[name]
+	w.groupByColumnId = savedGroupByColumnId[name]
 
 	return w
 }
@@ -291,6 +322,22 @@ func (w *ListWidget) Layout(g *gocui.Gui) error {
 			log.Panicln(err)
 		}
 
+		if err := g.SetKeybinding(w.name, 'A', gocui.ModNone, w.editAggregateAction); err != nil {
+			log.Panicln(err)
+		}
+
+		if err := g.SetKeybinding(w.name, 'T', gocui.ModNone, w.toggleAggregateFooterAction); err != nil {
+			log.Panicln(err)
+		}
+
+		if err := g.SetKeybinding(w.name, 'G', gocui.ModNone, w.editGroupByAction); err != nil {
+			log.Panicln(err)
+		}
+
+		if err := g.SetKeybinding(w.name, gocui.KeyEnter, gocui.ModNone, w.toggleGroupAction); err != nil {
+			log.Panicln(err)
+		}
+
 		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone,
 			func(g *gocui.Gui, v *gocui.View) error {
 				w.highlightKey = ""
@@ -309,7 +356,11 @@ func (w *ListWidget) Layout(g *gocui.Gui) error {
 	return w.RefreshDisplay(g)
 }
 
+// Key of the highlighted data row, empty if nothing or a group row is highlighted
 func (asUI *ListWidget) HighlightKey() string {
+	if isGroupRowId(asUI.highlightKey) {
+		return ""
+	}
 	return asUI.highlightKey
 }
 
@@ -327,6 +378,14 @@ func (asUI *ListWidget) GetFilterColumnMap() map[string]*FilterColumn {
 	return asUI.filterColumnMap
 }
 
+func (asUI *ListWidget) GetFilterExpression() *FilterExpression {
+	return asUI.filterExpression
+}
+
+func (asUI *ListWidget) SetFilterExpression(filterExpression *FilterExpression) {
+	asUI.filterExpression = filterExpression
+}
+
 func (asUI *ListWidget) SetListData(listData []IData) {
 	asUI.unfilteredListData = listData
 	asUI.FilterAndSortData()
@@ -338,7 +397,8 @@ func (asUI *ListWidget) GetListData() []IData {
 
 func (asUI *ListWidget) FilterAndSortData() {
 	filteredData := asUI.filterData(asUI.unfilteredListData)
-	asUI.listData = asUI.sortData(filteredData)
+	asUI.filteredListData = asUI.sortData(filteredData)
+	asUI.listData = asUI.groupData(asUI.filteredListData)
 }
 
 func (asUI *ListWidget) sortData(listData []IData) []IData {
@@ -360,14 +420,22 @@ func (asUI *ListWidget) sortData(listData []IData) []IData {
 
 func (asUI *ListWidget) filterData(listData []IData) []IData {
 	filteredList := make([]IData, 0, len(listData))
+	now := time.Now()
 	for _, data := range listData {
-		if asUI.FilterRow(data) {
+		if asUI.FilterRow(data) && asUI.filterRowExpression(data, now) {
 			filteredList = append(filteredList, data)
 		}
 	}
 	return filteredList
 }
 
+func (asUI *ListWidget) filterRowExpression(data IData, now time.Time) bool {
+	if asUI.filterExpression == nil {
+		return true
+	}
+	return asUI.filterExpression.Match(data, now)
+}
+
 func (asUI *ListWidget) FilterRow(data IData) bool {
 	for _, column := range asUI.columns {
 		filter := asUI.filterColumnMap[column.id]
@@ -386,6 +454,8 @@ func (asUI *ListWidget) filterRow(data IData, column *ListColumn, filter *Filter
 		return asUI.filterRowAlpha(data, column, filter)
 	case NUMERIC:
 		return asUI.filterRowNumeric(data, column, filter)
+	case TIMESTAMP:
+		return asUI.filterRowTimestamp(data, column, filter)
 	}
 	return false
 }
@@ -436,8 +506,24 @@ func (asUI *ListWidget) filterRowAlpha(data IData, column *ListColumn, filter *F
 	return regex.MatchString(value)
 }
 
+// Timestamp column filters use the filter expression comparison (e.g., "< 10m")
+func (asUI *ListWidget) filterRowTimestamp(data IData, column *ListColumn, filter *FilterColumn) bool {
+	expression := filter.compiledExpression
+	if expression == nil {
+		compiledExpression, err := NewFilterExpression(column.id+" "+filter.filterText, []*ListColumn{column})
+		if err != nil {
+			// Better to error on the side of showing the row
+			return true
+		}
+		filter.compiledExpression = compiledExpression
+		expression = compiledExpression
+	}
+	return expression.Match(data, time.Now())
+}
+
 func (asUI *ListWidget) SaveFilters() {
 	savedFilterColumnMap[asUI.name] = asUI.filterColumnMap
+	savedFilterExpression[asUI.name] = asUI.filterExpression
 }
 
 func (asUI *ListWidget) SetSortColumns(sortColumns []*SortColumn) {
@@ -478,15 +564,17 @@ func (asUI *ListWidget) RefreshDisplay(g *gocui.Gui) error {
 	if err != nil {
 		return err
 	}
-	_, maxY := v.Size()
-	maxRows := maxY - 1
+	maxRows := asUI.rowsViewSize(v)
 
 	title := asUI.Title
-	displayListSize := len(asUI.listData)
+	displayListSize := len(asUI.filteredListData)
 	unfilteredListSize := len(asUI.unfilteredListData)
 	if displayListSize != unfilteredListSize {
 		title = fmt.Sprintf("%v (filter showing %v of %v)", title, displayListSize, unfilteredListSize)
 	}
+	if groupColumn := asUI.groupByColumn(); groupColumn != nil {
+		title = fmt.Sprintf("%v (grouped by %v)", title, groupColumn.label)
+	}
 	v.Title = title
 
 	v.Clear()
@@ -510,11 +598,21 @@ func (asUI *ListWidget) RefreshDisplay(g *gocui.Gui) error {
 		*/
 
 		// Loop through all rows
+		rowsWritten := 0
 		for i := 0; i < listSize && i < stopRowIndex; i++ {
 			if i < offset {
 				continue
 			}
 			asUI.writeRowData(g, v, i)
+			rowsWritten++
+		}
+
+		// Footer is always on the last line of the view
+		if listSize > 0 && asUI.IsAggregateFooter() {
+			for ; rowsWritten < maxRows; rowsWritten++ {
+				fmt.Fprint(v, "\n")
+			}
+			asUI.writeAggregateFooter(g, v)
 		}
 	} else {
 		if len(asUI.unfilteredListData) > 0 {
@@ -527,6 +625,16 @@ func (asUI *ListWidget) RefreshDisplay(g *gocui.Gui) error {
 	return nil
 }
 
+// Number of data rows that fit in the view (excludes header and footer)
+func (asUI *ListWidget) rowsViewSize(v *gocui.View) int {
+	_, viewY := v.Size()
+	viewSize := viewY - 1
+	if asUI.IsAggregateFooter() {
+		viewSize--
+	}
+	return viewSize
+}
+
 func (asUI *ListWidget) writeRowData(g *gocui.Gui, v *gocui.View, rowIndex int) {
 	rowData := asUI.listData[rowIndex]
 	isSelected := false
@@ -535,6 +643,11 @@ func (asUI *ListWidget) writeRowData(g *gocui.Gui, v *gocui.View, rowIndex int)
 		isSelected = true
 	}
 
+	if group, ok := rowData.(*groupRow); ok {
+		asUI.writeGroupRow(g, v, group, isSelected)
+		return
+	}
+
 	sortColumnId := ""
 	if len(asUI.sortColumns) > 0 {
 		sortColumnId = asUI.sortColumns[0].Id
@@ -797,8 +910,7 @@ func (asUI *ListWidget) arrowUp(g *gocui.Gui, v *gocui.View) error {
 	listSize := len(asUI.listData)
 	callbackFunc := func(g *gocui.Gui, v *gocui.View, rowIndex int, lastKey string) bool {
 		if rowIndex > 0 {
-			_, viewY := v.Size()
-			viewSize := viewY - 1
+			viewSize := asUI.rowsViewSize(v)
 			asUI.highlightKey = lastKey
 			offset := rowIndex - 1
 			if listSize > viewSize && offset > listSize-viewSize {
@@ -819,8 +931,7 @@ func (asUI *ListWidget) arrowDown(g *gocui.Gui, v *gocui.View) error {
 	listSize := len(asUI.listData)
 	callbackFunc := func(g *gocui.Gui, v *gocui.View, rowIndex int, lastKey string) bool {
 		if rowIndex+1 < listSize {
-			_, viewY := v.Size()
-			offset := (rowIndex + 2) - (viewY - 1)
+			offset := (rowIndex + 2) - asUI.rowsViewSize(v)
 			if offset > asUI.displayRowIndexOffset || rowIndex < asUI.displayRowIndexOffset {
 				asUI.displayRowIndexOffset = offset
 			}
@@ -865,8 +976,7 @@ func (asUI *ListWidget) moveHighlight(g *gocui.Gui, v *gocui.View, callback chan
 func (asUI *ListWidget) pageUpAction(g *gocui.Gui, v *gocui.View) error {
 	callbackFunc := func(g *gocui.Gui, v *gocui.View, rowIndex int, lastKey string) bool {
 		if rowIndex > 0 {
-			_, viewY := v.Size()
-			viewSize := viewY - 1
+			viewSize := asUI.rowsViewSize(v)
 			offset := 0
 			if rowIndex == asUI.displayRowIndexOffset {
 				offset = rowIndex - viewSize
@@ -894,8 +1004,7 @@ func (asUI *ListWidget) pageDownAction(g *gocui.Gui, v *gocui.View) error {
 	listSize := len(asUI.listData)
 	callbackFunc := func(g *gocui.Gui, v *gocui.View, rowIndex int, lastKey string) bool {
 		if rowIndex < listSize {
-			_, viewY := v.Size()
-			viewSize := viewY - 1
+			viewSize := asUI.rowsViewSize(v)
 			offset := 0
 			if rowIndex == (asUI.displayRowIndexOffset + viewSize - 1) {
 				offset = rowIndex + viewSize
@@ -0,0 +1,122 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package uiCommon
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+// Named filter expressions saved per view.  Saved to the local filter file
+// so they are available the next time top is run.
+var (
+	namedFiltersLock     sync.Mutex
+	namedFiltersFilename string
+	// Map: [viewName][filterName] = filter expression text
+	namedFilters map[string]map[string]string
+)
+
+type namedFiltersFile struct {
+	Views map[string]map[string]string `json:"views"`
+}
+
+// Get the sorted names of the filters saved for the given view
+func GetNamedFilterNames(viewName string) []string {
+	namedFiltersLock.Lock()
+	defer namedFiltersLock.Unlock()
+	loadNamedFilters()
+	names := make([]string, 0, len(namedFilters[viewName]))
+	for name := range namedFilters[viewName] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func GetNamedFilter(viewName, name string) string {
+	namedFiltersLock.Lock()
+	defer namedFiltersLock.Unlock()
+	loadNamedFilters()
+	return namedFilters[viewName][name]
+}
+
+// Save a named filter for the given view.  An empty expression removes the named filter.
+func SaveNamedFilter(viewName, name, expressionText string) {
+	namedFiltersLock.Lock()
+	defer namedFiltersLock.Unlock()
+	loadNamedFilters()
+	viewFilters := namedFilters[viewName]
+	if viewFilters == nil {
+		viewFilters = make(map[string]string)
+		namedFilters[viewName] = viewFilters
+	}
+	if expressionText == "" {
+		delete(viewFilters, name)
+	} else {
+		viewFilters[name] = expressionText
+	}
+	saveNamedFilters()
+}
+
+func getNamedFiltersFilename() string {
+	return filepath.Join(util.GetTopConfigDir(), "namedFilters.json")
+}
+
+// Caller must hold namedFiltersLock
+func loadNamedFilters() {
+	if namedFilters != nil {
+		return
+	}
+	namedFilters = make(map[string]map[string]string)
+	namedFiltersFilename = getNamedFiltersFilename()
+	data, err := ioutil.ReadFile(namedFiltersFilename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			toplog.Warn("Unable to read named filters file %v: %v", namedFiltersFilename, err)
+		}
+		return
+	}
+	filtersFile := &namedFiltersFile{}
+	if err := json.Unmarshal(data, filtersFile); err != nil {
+		toplog.Warn("Unable to parse named filters file %v: %v", namedFiltersFilename, err)
+		return
+	}
+	if filtersFile.Views != nil {
+		namedFilters = filtersFile.Views
+	}
+}
+
+// Caller must hold namedFiltersLock
+func saveNamedFilters() {
+	data, err := json.MarshalIndent(&namedFiltersFile{Views: namedFilters}, "", "  ")
+	if err != nil {
+		toplog.Warn("Unable to marshal named filters: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(namedFiltersFilename), 0700); err != nil {
+		toplog.Warn("Unable to create named filters directory: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(namedFiltersFilename, data, 0600); err != nil {
+		toplog.Warn("Unable to write named filters file %v: %v", namedFiltersFilename, err)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestUiCommon(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "UiCommon Suite")
+}
+
+// A list row that holds the raw value of each column by column id
+type fakeRow map[string]string
+
+func (r fakeRow) Id() string {
+	return r["ID"]
+}
+
+func newFakeColumn(id string, columnType ColumnType) *ListColumn {
+	rawValueFunc := func(data IData) string { return data.(fakeRow)[id] }
+	return NewListColumn(id, id, 10, columnType, columnType != NUMERIC, nil, false, nil, rawValueFunc, nil)
+}
+
+func rowIds(listData []IData) []string {
+	ids := make([]string, len(listData))
+	for i, data := range listData {
+		ids[i] = data.Id()
+	}
+	return ids
+}
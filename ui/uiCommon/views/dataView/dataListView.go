@@ -169,10 +169,6 @@ func (asUI *DataListView) initialize(g *gocui.Gui) {
 	}
 }
 
-func (asUI *DataListView) GetCurrentEventData() *eventdata.EventData {
-	return asUI.eventProcessor.GetCurrentEventData()
-}
-
 func (asUI *DataListView) GetDisplayedEventData() *eventdata.EventData {
 	return asUI.eventProcessor.GetDisplayedEventData()
 }
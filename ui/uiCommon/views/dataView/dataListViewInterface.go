@@ -37,7 +37,6 @@ type DataListViewInterface interface {
 	GetListWidget() *uiCommon.ListWidget
 	GetEventProcessor() *eventdata.EventProcessor
 	Layout(g *gocui.Gui) error
-	GetCurrentEventData() *eventdata.EventData
 	GetDisplayedEventData() *eventdata.EventData
 	RefreshDisplay(g *gocui.Gui) error
 	UpdateDisplay(g *gocui.Gui) error
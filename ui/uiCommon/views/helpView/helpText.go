@@ -47,6 +47,9 @@ const HelpTopLevelDataViewKeybindings = `
 **Display: **
 Press 'd' to show data view menu.
 
+**Search: **
+Press '/' to search apps, routes, spaces, orgs and cells by name.
+
 **Quit: **
 Press 'q' to quit application.
 `
@@ -68,7 +71,35 @@ sorting of any column.
 
 **Filter display: **
 Press 'f' to show the filter window which allows for filtering
-which rows should be displayed
+which rows should be displayed.  Press SPACE in the filter window
+to filter the highlighted column (regex for text columns, expression
+such as '>50' for numeric columns, '< 10m' for timestamp columns).
+
+Press 'e' in the filter window to enter an expression across columns:
+  ORG =~ "^prod" AND CPU_PERCENT > 50 AND LAST_ACCESS < 10m
+Comparisons are COLUMN operator value using ==, !=, <, <=, >, >=,
+=~ (regex match) or !~ (regex not match), combined with AND, OR, NOT
+and parentheses.  A timestamp column compared to a duration (30s,
+10m, 2h, 1d) compares the age, compared to a date/time (such as
+"2017-06-01 13:00" or "13:00" for today) compares the timestamp.
+
+Press 's' in the filter window to save the expression as a named
+filter for the current view and 'l' to load a named filter.  Named
+filters are saved in the .cf/top directory of the home directory.
+
+**Totals footer: **
+The last line of a list shows the total of each numeric column over
+the rows currently displayed (respects filters).  Press shift-T to
+show or hide the totals footer.  Press shift-A to choose the total
+of each column: SUM, AVG, MIN, MAX or NONE.
+
+**Group by:**
+Press shift-G to group the rows by a column (e.g., the stack,
+isolation segment, org or buildpack of the app list).  Each group
+is shown as a single row with the totals of its numeric columns.
+Highlight a group and press ENTER to expand or collapse the group.
+Groups are ordered by the total of the sort column when it is
+numeric, otherwise by the group value.
 
 **Scroll columns into view:**
 Press RIGHT or LEFT arrow to scroll the columns into view if the
@@ -30,6 +30,7 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
 
@@ -63,6 +64,7 @@ type TopView struct {
 	name           string
 	bottomMargin   int
 	pluginMetadata *plugin.PluginMetadata
+	eventProcessor *eventdata.EventProcessor
 
 	asciiArtTopLines []string
 
@@ -81,6 +83,7 @@ func NewTopView(masterUI masterUIInterface.MasterUIInterface,
 		name:             name,
 		bottomMargin:     bottomMargin,
 		pluginMetadata:   pluginMetadata,
+		eventProcessor:   eventProcessor,
 		asciiArtTopLines: asciiArtTopLines,
 	}
 }
@@ -161,7 +164,7 @@ func (asUI *TopView) RefreshDisplay(g *gocui.Gui) error {
 	//maxX, _ := v.Size()
 	//_, maxY := v.Size()
 	//maxRows := maxY - 1
-	asUI.textLines = 20
+	asUI.textLines = 24
 
 	currentVersion := asUI.getCurrentVersion()
 
@@ -178,6 +181,8 @@ func (asUI *TopView) RefreshDisplay(g *gocui.Gui) error {
 	fmt.Fprintf(v, " Company: %v\n", "http://www.ECSTeam.com")
 	fmt.Fprintf(v, " Program: %v\n", "http://github.com/ECSTeam/cloudfoundry-top-plugin")
 	fmt.Fprintln(v)
+	asUI.writeMemoryStats(v)
+	fmt.Fprintln(v)
 	fmt.Fprintf(v, " To report bugs, request enhancements or provide feedback visit:\n %v",
 		"http://github.com/ECSTeam/cloudfoundry-top-plugin/issues")
 	fmt.Fprintln(v)
@@ -188,6 +193,27 @@ func (asUI *TopView) RefreshDisplay(g *gocui.Gui) error {
 	return nil
 }
 
+func (asUI *TopView) writeMemoryStats(v *gocui.View) {
+	memoryStats := asUI.eventProcessor.GetMemoryStats()
+	if memoryStats == nil {
+		fmt.Fprintf(v, " Memory:  %v\n", "Checking...")
+		fmt.Fprintln(v)
+		return
+	}
+	fmt.Fprintf(v, " Memory:  %v of %v limit (apps: %v, routes: %v, user agents: %v, cells/components: %v)\n",
+		util.ByteSize(memoryStats.TotalBytes()).StringWithPrecision(1),
+		util.ByteSize(memoryStats.LimitBytes).StringWithPrecision(0),
+		util.ByteSize(memoryStats.AppBytes).StringWithPrecision(1),
+		util.ByteSize(memoryStats.RouteBytes).StringWithPrecision(1),
+		util.ByteSize(memoryStats.UserAgentBytes).StringWithPrecision(1),
+		util.ByteSize(memoryStats.PlatformBytes).StringWithPrecision(1))
+	fmt.Fprintf(v, " Evicted: %v hosts (%v routes), %v user agents, %v apps\n",
+		util.FormatUint64(memoryStats.EvictedHosts),
+		util.FormatUint64(memoryStats.EvictedRoutes),
+		util.FormatUint64(memoryStats.EvictedUserAgents),
+		util.FormatUint64(memoryStats.EvictedApps))
+}
+
 func (asUI *TopView) writeAsciiArt(g *gocui.Gui, v *gocui.View) {
 	maxX, _ := v.Size()
 	leftPaddingSize := (maxX / 2) - (26 / 2)
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
@@ -54,6 +55,8 @@ func (am *AlertManager) CheckForAlerts(g *gocui.Gui) error {
 	am.checkForAppsNotInDesiredState(g)
 	am.checkForErrorMsgDelta(g)
 	am.checkForCrashedApps(g)
+	am.checkForQuotaUsage(g)
+	am.checkForDataLoss(g)
 	return nil
 }
 
@@ -79,6 +82,40 @@ func (am *AlertManager) checkForCrashedApps(g *gocui.Gui) error {
 	return nil
 }
 
+func (am *AlertManager) checkForQuotaUsage(g *gocui.Gui) error {
+
+	if am.masterUI.GetDisplayPaused() {
+		return nil
+	}
+
+	am.showQuotaMessage(g, QUOTA_ALERT, am.commonData.QuotaAlertCount(), config.QuotaAlertPercent)
+	am.showQuotaMessage(g, QUOTA_WARN, am.commonData.QuotaWarnCount(), config.QuotaWarnPercent)
+	return am.showQuotaMessage(g, QUOTA_FORECAST, am.commonData.QuotaForecastCount(), config.QuotaForecastWarnHours)
+}
+
+func (am *AlertManager) showQuotaMessage(g *gocui.Gui, message *AlertMessage, count int, threshold int) error {
+	if count > 0 {
+		plural := ""
+		if count > 1 {
+			plural = "s"
+		}
+		return am.ShowMessage(g, message, count, plural, threshold)
+	}
+	return am.ClearUserMessage(g, message)
+}
+
+func (am *AlertManager) checkForDataLoss(g *gocui.Gui) error {
+	dataLossCount := am.commonData.DataLossNozzleCount()
+	if dataLossCount > 0 {
+		plural := ""
+		if dataLossCount > 1 {
+			plural = "s"
+		}
+		return am.ShowMessage(g, DATA_LOSS, dataLossCount, plural, config.NozzleDataLossAlertSeconds)
+	}
+	return am.ClearUserMessage(g, DATA_LOSS)
+}
+
 func (am *AlertManager) checkForAppsNotInDesiredState(g *gocui.Gui) error {
 
 	commonData := am.commonData
@@ -34,6 +34,10 @@ const (
 var MessageCatalog = make(map[string]*AlertMessage)
 var APPS_NOT_IN_DESIRED_STATE = NewAlertMessage("ANIDS", AlertType, "%v application%v not in desired state (DCR != RCR column)")
 var CONTAINER_CRASHES = NewAlertMessage("CRASH", WarnType, "%v container%v crashed (CRH column) in last 24 hours (%v in last hour)")
+var QUOTA_ALERT = NewAlertMessage("QUOTA", AlertType, "%v org/space quota%v at or above %v%% of a limit (Org List view)")
+var QUOTA_WARN = NewAlertMessage("QUOTAW", WarnType, "%v org/space quota%v at or above %v%% of a limit (Org List view)")
+var QUOTA_FORECAST = NewAlertMessage("QUOTAF", WarnType, "%v org/space quota%v forecast to reach a limit within %v hours (Q_FULL column)")
+var DATA_LOSS = NewAlertMessage("DLOSS", AlertType, "Data loss on %v nozzle%v in last %v seconds (Nozzles view)")
 var ErrorsSinceViewed = NewAlertMessage("ESV", AlertType, "%v monitoring errors. Data shown may be inaccurate. (shift-D to display)")
 var TestMessage = NewAlertMessage("TM", InfoType, "Test Message")
 
@@ -0,0 +1,140 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appCrashView
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+// Per-day crash histogram of an application over the crash history retention period
+type AppCrashHistogramView struct {
+	*dataView.DataListView
+	appId    string
+	appMdMgr *app.AppMetadataManager
+}
+
+func NewAppCrashHistogramView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appId string) *AppCrashHistogramView {
+
+	appMdMgr := eventProcessor.GetMetadataManager().GetAppMdManager()
+
+	asUI := &AppCrashHistogramView{appId: appId, appMdMgr: appMdMgr}
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("DAY", true),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string {
+		appMetadata := asUI.appMdMgr.FindItem(asUI.appId)
+		return fmt.Sprintf("App: %v - Crashes per Day (last %v days)", appMetadata.Name, config.CrashHistoryRetentionDays)
+	})
+
+	dataListView.HelpText = HistogramHelpText
+	dataListView.HelpTextTips = HistogramHelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *AppCrashHistogramView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppCrashHistogramView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppCrashHistogramView); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *AppCrashHistogramView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, ColumnCrashDay())
+	columns = append(columns, ColumnDayCrashCount())
+	columns = append(columns, ColumnCrashHistogram())
+	return columns
+}
+
+func (asUI *AppCrashHistogramView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := make([]uiCommon.IData, 0, len(displayDataList))
+	for _, d := range displayDataList {
+		listData = append(listData, d)
+	}
+	return listData
+}
+
+func (asUI *AppCrashHistogramView) postProcessData() []*DisplayCrashDay {
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	// One entry per day so days without crashes show in the histogram
+	days := config.CrashHistoryRetentionDays
+	crashDayMap := make(map[string]*DisplayCrashDay)
+	crashDayList := make([]*DisplayCrashDay, 0, days)
+	for i := 0; i < days; i++ {
+		crashDay := NewDisplayCrashDay(today.AddDate(0, 0, -i))
+		crashDayMap[crashDay.Id()] = crashDay
+		crashDayList = append(crashDayList, crashDay)
+	}
+
+	since := now.Sub(today.AddDate(0, 0, -(days - 1)))
+	crashInfoList := findCrashInfoSince(asUI.GetDisplayedEventData().AppMap, asUI.appId, -1*since)
+	for _, crashInfo := range crashInfoList {
+		crashDay := crashDayMap[crashInfo.CrashTime.Local().Format("20060102")]
+		if crashDay != nil {
+			crashDay.CrashCount++
+		}
+	}
+
+	maxCrashCount := 0
+	for _, crashDay := range crashDayList {
+		if crashDay.CrashCount > maxCrashCount {
+			maxCrashCount = crashDay.CrashCount
+		}
+	}
+	for _, crashDay := range crashDayList {
+		crashDay.MaxCrashCount = maxCrashCount
+	}
+	return crashDayList
+}
+
+func (asUI *AppCrashHistogramView) closeAppCrashHistogramView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
@@ -31,11 +31,16 @@ import (
 	"github.com/jroimartin/gocui"
 )
 
+// Crash list window sizes (in days) selectable in the crash view
+var crashWindowDays = []int{1, 7, 30}
+
 type AppCrashView struct {
 	*dataView.DataListView
 	appId         string
 	displayMenuId string
 	appMdMgr      *app.AppMetadataManager
+	// Index into crashWindowDays
+	crashWindowIndex int
 }
 
 func NewAppCrashView(masterUI masterUIInterface.MasterUIInterface,
@@ -61,7 +66,7 @@ func NewAppCrashView(masterUI masterUIInterface.MasterUIInterface,
 	dataListView.GetListData = asUI.GetListData
 
 	titleFunc := func() string {
-		return fmt.Sprintf("App: %v - Container CRASH List (last 24 hours)", asUI.getAppName())
+		return fmt.Sprintf("App: %v - Container CRASH List (last %v)", asUI.getAppName(), asUI.getCrashWindowLabel())
 	}
 	//dataListView.SetTitle(fmt.Sprintf("App: %v - Container CRASH List (last 24 hours)", asUI.getAppName()))
 	dataListView.SetTitle(titleFunc)
@@ -85,9 +90,39 @@ func (asUI *AppCrashView) initializeCallback(g *gocui.Gui, viewName string) erro
 	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
 		log.Panicln(err)
 	}
+	if err := g.SetKeybinding(viewName, 'w', gocui.ModNone, asUI.nextCrashWindowAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 'g', gocui.ModNone, asUI.openCrashHistogramAction); err != nil {
+		log.Panicln(err)
+	}
 	return nil
 }
 
+func (asUI *AppCrashView) nextCrashWindowAction(g *gocui.Gui, v *gocui.View) error {
+	asUI.crashWindowIndex = (asUI.crashWindowIndex + 1) % len(crashWindowDays)
+	return asUI.UpdateDisplay(g)
+}
+
+func (asUI *AppCrashView) openCrashHistogramAction(g *gocui.Gui, v *gocui.View) error {
+	_, bottomMargin := asUI.GetMargins()
+	view := NewAppCrashHistogramView(asUI.GetMasterUI(), asUI, "appCrashHistogramView",
+		bottomMargin, asUI.GetEventProcessor(), asUI.appId)
+	return asUI.GetMasterUI().OpenView(g, view)
+}
+
+func (asUI *AppCrashView) getCrashWindow() time.Duration {
+	return time.Duration(crashWindowDays[asUI.crashWindowIndex]) * 24 * time.Hour
+}
+
+func (asUI *AppCrashView) getCrashWindowLabel() string {
+	days := crashWindowDays[asUI.crashWindowIndex]
+	if days == 1 {
+		return "24 hours"
+	}
+	return fmt.Sprintf("%v days", days)
+}
+
 func (asUI *AppCrashView) enterAction(g *gocui.Gui, v *gocui.View) error {
 
 	highlightKey := asUI.GetListWidget().HighlightKey()
@@ -121,20 +156,23 @@ func (asUI *AppCrashView) postProcessData() []*DisplayContainerCrashInfo {
 
 	displayCrashInfoList := make([]*DisplayContainerCrashInfo, 0)
 
-	appMap := asUI.GetDisplayedEventData().AppMap
-	appId := asUI.appId
+	crashInfoList := findCrashInfoSince(asUI.GetDisplayedEventData().AppMap, asUI.appId, -1*asUI.getCrashWindow())
+	displayCrashInfoList = append(displayCrashInfoList, asUI.createDisplayContainerCrashInfo(crashInfoList)...)
+
+	return displayCrashInfoList
+}
+
+// All crashes of the app since the given duration.  Includes crashes from metadata
+// (/v2/events and local crash history) and crashes captured live since top started.
+func findCrashInfoSince(appMap map[string]*eventApp.AppStats, appId string, since time.Duration) []*crashData.ContainerCrashInfo {
 	appStats := appMap[appId]
 	if appStats == nil {
-		return displayCrashInfoList
+		return nil
 	}
-
-	crashInfoListFromMetadata := crashData.FindSinceByApp(appStats.AppId, -24*time.Hour)
-	displayCrashInfoList = append(displayCrashInfoList, asUI.createDisplayContainerCrashInfo(crashInfoListFromMetadata)...)
-
-	crashInfoListFromLiveCapture := appStats.CrashSince(-24 * time.Hour)
-	displayCrashInfoList = append(displayCrashInfoList, asUI.createDisplayContainerCrashInfo(crashInfoListFromLiveCapture)...)
-
-	return displayCrashInfoList
+	crashInfoList := make([]*crashData.ContainerCrashInfo, 0)
+	crashInfoList = append(crashInfoList, crashData.FindSinceByApp(appStats.AppId, since)...)
+	crashInfoList = append(crashInfoList, appStats.CrashSince(since)...)
+	return crashInfoList
 }
 
 func (asUI *AppCrashView) createDisplayContainerCrashInfo(crashInfoList []*crashData.ContainerCrashInfo) []*DisplayContainerCrashInfo {
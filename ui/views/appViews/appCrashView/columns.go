@@ -38,6 +38,7 @@ func ColumnContainerIndex() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("IDX", "IDX", defaultColSize,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
 	return c
 }
 
@@ -0,0 +1,97 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appCrashView
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+const histogramBarSize = 50
+
+func ColumnCrashDay() *uiCommon.ListColumn {
+	defaultColSize := 14
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashDay).Day.Before(c2.(*DisplayCrashDay).Day)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCrashDay)
+		return fmt.Sprintf("%-14v", stats.DayFormatted)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCrashDay)
+		return stats.Day.Format("2006-01-02")
+	}
+	c := uiCommon.NewListColumn("DAY", "DAY", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnDayCrashCount() *uiCommon.ListColumn {
+	defaultColSize := 7
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashDay).CrashCount < c2.(*DisplayCrashDay).CrashCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCrashDay)
+		return fmt.Sprintf("%7v", util.Format(int64(stats.CrashCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCrashDay)
+		return fmt.Sprintf("%v", stats.CrashCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		if data.(*DisplayCrashDay).CrashCount > 0 {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("CRASHES", "CRASHES", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func ColumnCrashHistogram() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashDay).CrashCount < c2.(*DisplayCrashDay).CrashCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCrashDay)
+		barSize := 0
+		if stats.MaxCrashCount > 0 && stats.CrashCount > 0 {
+			barSize = stats.CrashCount * histogramBarSize / stats.MaxCrashCount
+			if barSize == 0 {
+				barSize = 1
+			}
+		}
+		return fmt.Sprintf("%-50v", strings.Repeat("*", barSize))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCrashDay)
+		return fmt.Sprintf("%v", stats.CrashCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		if data.(*DisplayCrashDay).CrashCount > 0 {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("HISTOGRAM", "HISTOGRAM", histogramBarSize,
+		uiCommon.NUMERIC, true, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appCrashView
+
+import "time"
+
+// Number of crashes on a single (local time) day
+type DisplayCrashDay struct {
+	Day          time.Time
+	DayFormatted string
+	CrashCount   int
+	// Largest CrashCount of all days displayed -- used to scale histogram bar
+	MaxCrashCount int
+	key           string
+}
+
+func NewDisplayCrashDay(day time.Time) *DisplayCrashDay {
+	crashDay := &DisplayCrashDay{Day: day}
+	crashDay.DayFormatted = day.Format("01-02-2006 Mon")
+	crashDay.key = day.Format("20060102")
+	return crashDay
+}
+
+func (cd *DisplayCrashDay) Id() string {
+	return cd.key
+}
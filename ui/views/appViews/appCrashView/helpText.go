@@ -33,6 +33,11 @@ running in the container stops or exits unexpectedly.  Often an exit
 status code is recorded in the EXIT_DESCRIPTION which may help
 understand what happened.  
 
+Crashes older then 24 hours come from a local crash history
+that cf top keeps for each foundation (up to 30 days). Only
+crashes seen by a previous cf top session, or loaded from the
+cloud controller events while it was running, are in the history.
+
 Java exit status codes:
     137 = OutOfMemory error
     143 = SIGTERM (sometimes as a result of OutOfMemory error)
@@ -48,4 +53,32 @@ const HelpColumnsText = `
 `
 
 const HelpLocalViewKeybindings = `
+**Crash window: **
+Press 'w' to cycle the crash list between the last 24 hours,
+7 days and 30 days.
+
+**Crash histogram: **
+Press 'g' to display the number of crashes per day.
+`
+
+const HistogramHelpText = HistogramHelpOverviewText +
+	helpView.HelpHeaderText +
+	HistogramHelpColumnsText +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HistogramHelpOverviewText = `
+**App Crashes per Day View**
+
+Shows the number of container crashes of the application for
+each day (local timezone) of the last 30 days.  Crashes older
+then 24 hours come from the local crash history.
+`
+
+const HistogramHelpColumnsText = `
+**Crashes per Day Columns:**
+
+  DAY - Date of the day
+  CRASHES - Number of container crashes on the day
+  HISTOGRAM - Crash count bar scaled to the day with the most crashes
 `
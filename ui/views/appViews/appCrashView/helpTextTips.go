@@ -15,5 +15,8 @@
 
 package appCrashView
 
-const HelpTextTips = `**x**:exit view  **o**:order  **f**:filter  **h**:help  **UP**/**DOWN** arrow to highlight row
+const HelpTextTips = `**x**:exit view  **w**:crash window  **g**:crashes per day  **o**:order  **f**:filter  **h**:help
+**UP**/**DOWN** arrow to highlight row,  **LEFT**/**RIGHT** arrow to scroll columns`
+
+const HistogramHelpTextTips = `**x**:exit view  **o**:order  **f**:filter  **h**:help  **UP**/**DOWN** arrow to highlight row
 **LEFT**/**RIGHT** arrow to scroll columns`
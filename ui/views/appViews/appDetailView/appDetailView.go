@@ -24,6 +24,7 @@ import (
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
@@ -31,6 +32,10 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appCrashView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appHttpView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appLifecycleView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appLogView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appTaskView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/deploymentViews/deploymentView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
@@ -45,6 +50,8 @@ type AppDetailView struct {
 	Crash10mCount int
 	Crash1hCount  int
 	Crash24hCount int
+	Crash7dCount  int
+	Crash30dCount int
 	LastCrashInfo *crashData.ContainerCrashInfo
 }
 
@@ -120,6 +127,13 @@ func (asUI *AppDetailView) selectDisplayAction(g *gocui.Gui, v *gocui.View) erro
 	menuItems = append(menuItems, uiCommon.NewMenuItem("infoView", "App Info"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("crashInfoView", "View CRASH List"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("appHttpView", "HTTP Response Info"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("appTaskView", "View Task List"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("appLifecycleView", "View Container Lifecycle"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("deploymentListView", "View Deployments"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("appLogView", "View Log Tail"))
+	if asUI.GetListWidget().HighlightKey() != "" {
+		menuItems = append(menuItems, uiCommon.NewMenuItem("containerLogView", "View Log Tail (highlighted container)"))
+	}
 
 	windowTitle := fmt.Sprintf("Select App Detail View")
 	selectDisplayView := uiCommon.NewSelectMenuWidget(asUI.GetMasterUI(), "selectDisplayView", windowTitle, menuItems, asUI.selectDisplayCallback)
@@ -162,7 +176,7 @@ func (asUI *AppDetailView) createAndOpenView(g *gocui.Gui, viewName string) erro
 	switch viewName {
 	case "infoView":
 		infoWidgetName := "appInfoWidget"
-		view = NewAppInfoWidget(asUI.GetMasterUI(), asUI, infoWidgetName, 70, 20, asUI)
+		view = NewAppInfoWidget(asUI.GetMasterUI(), asUI, infoWidgetName, 70, 26, asUI)
 	case "crashInfoView":
 		_, bottomMargin := asUI.GetMargins()
 		view = appCrashView.NewAppCrashView(asUI.GetMasterUI(), asUI, "crashInfoView", bottomMargin,
@@ -173,6 +187,36 @@ func (asUI *AppDetailView) createAndOpenView(g *gocui.Gui, viewName string) erro
 		view = appHttpView.NewAppHttpView(asUI.GetMasterUI(), asUI, "appHttpView", bottomMargin,
 			asUI.GetEventProcessor(),
 			asUI.appId)
+	case "appTaskView":
+		_, bottomMargin := asUI.GetMargins()
+		view = appTaskView.NewAppTaskView(asUI.GetMasterUI(), asUI, "appTaskView", bottomMargin,
+			asUI.GetEventProcessor(),
+			asUI.appId)
+	case "appLifecycleView":
+		_, bottomMargin := asUI.GetMargins()
+		view = appLifecycleView.NewAppLifecycleView(asUI.GetMasterUI(), asUI, "appLifecycleView", bottomMargin,
+			asUI.GetEventProcessor(),
+			asUI.appId)
+	case "deploymentListView":
+		_, bottomMargin := asUI.GetMargins()
+		view = deploymentView.NewDeploymentListView(asUI.GetMasterUI(), asUI, "deploymentListView", bottomMargin,
+			asUI.GetEventProcessor(),
+			asUI.appId)
+	case "appLogView":
+		_, bottomMargin := asUI.GetMargins()
+		view = appLogView.NewAppLogView(asUI.GetMasterUI(), asUI, "appLogView", bottomMargin,
+			asUI.GetEventProcessor(),
+			asUI.appId, "")
+	case "containerLogView":
+		_, bottomMargin := asUI.GetMargins()
+		instanceFilter := ""
+		containerStats, ok := asUI.GetListWidget().HighlightData().(*DisplayContainerStats)
+		if ok {
+			instanceFilter = strconv.Itoa(containerStats.ContainerIndex)
+		}
+		view = appLogView.NewAppLogView(asUI.GetMasterUI(), asUI, "appLogView", bottomMargin,
+			asUI.GetEventProcessor(),
+			asUI.appId, instanceFilter)
 	default:
 		return errors.New("Unable to find view " + viewName)
 	}
@@ -182,6 +226,7 @@ func (asUI *AppDetailView) createAndOpenView(g *gocui.Gui, viewName string) erro
 func (asUI *AppDetailView) columnDefinitions() []*uiCommon.ListColumn {
 	columns := make([]*uiCommon.ListColumn, 0)
 	columns = append(columns, ColumnContainerIndex())
+	columns = append(columns, ColumnProcessType())
 
 	columns = append(columns, ColumnState())
 	columns = append(columns, ColumnStateDuration())
@@ -228,6 +273,7 @@ func (asUI *AppDetailView) postProcessData() []*DisplayContainerStats {
 
 	displayStatsArray := make([]*DisplayContainerStats, 0)
 	displayContainerStatsMap := make(map[int]*DisplayContainerStats)
+	processContainerStatsArray := make([]*DisplayContainerStats, 0)
 
 	now := time.Now().Truncate(time.Second)
 
@@ -303,38 +349,24 @@ func (asUI *AppDetailView) postProcessData() []*DisplayContainerStats {
 				displayContainerStats.ContainerStats = containerStats
 			}
 
-			displayContainerStats.AppName = appMetadata.Name
-			spaceMd := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
-			displayContainerStats.SpaceName = spaceMd.Name
-			orgMd := mdMgr.GetOrgMdManager().FindItem(spaceMd.OrgGuid)
-			displayContainerStats.OrgName = orgMd.Name
+			asUI.populateContainerStats(displayContainerStats, containerStats, appMetadata)
+		}
+	}
 
-			if containerStats.CellCreatedMsgTime != nil && containerStats.CellHealthyMsgTime != nil {
-				startupDuration := containerStats.CellHealthyMsgTime.Sub(*containerStats.CellCreatedMsgTime)
-				if startupDuration > 0 {
-					displayContainerStats.StartupDuration = &startupDuration
+	// Containers of non-web processes (worker, clock, etc) are not included in the app
+	// instance metadata so we only know about them from the firehose
+	for _, containerArray := range appStats.ProcessContainerMap {
+		for _, containerStats := range containerArray {
+			if containerStats != nil {
+				displayContainerStats := NewDisplayContainerStats(containerStats, appStats)
+				if containerStats.ContainerMetric != nil {
+					displayContainerStats.State = "RUNNING"
+				} else {
+					displayContainerStats.State = "UNKNOWN"
 				}
+				asUI.populateContainerStats(displayContainerStats, containerStats, appMetadata)
+				processContainerStatsArray = append(processContainerStatsArray, displayContainerStats)
 			}
-
-			if displayContainerStats.State == "DOWN" {
-				containerStats.ContainerMetric = nil
-				containerStats.Ip = ""
-			}
-
-			if containerStats.ContainerMetric != nil {
-				usedMemory := containerStats.ContainerMetric.GetMemoryBytes()
-				reservedMemory := uint64(appMetadata.MemoryMB) * util.MEGABYTE
-				freeMemory := reservedMemory - usedMemory
-				displayContainerStats.FreeMemory = freeMemory
-				displayContainerStats.ReservedMemory = reservedMemory
-
-				usedDisk := containerStats.ContainerMetric.GetDiskBytes()
-				reservedDisk := uint64(appMetadata.DiskQuotaMB) * util.MEGABYTE
-				freeDisk := reservedDisk - usedDisk
-				displayContainerStats.FreeDisk = freeDisk
-				displayContainerStats.ReservedDisk = reservedDisk
-			}
-
 		}
 	}
 
@@ -385,23 +417,27 @@ func (asUI *AppDetailView) postProcessData() []*DisplayContainerStats {
 
 		displayStatsArray = append(displayStatsArray, displayContainerStats)
 	}
+	displayStatsArray = append(displayStatsArray, processContainerStatsArray...)
 
 	displayAppStatsMap := asUI.GetMasterUI().GetCommonData().GetDisplayAppStatsMap()
 	displayAppStats := displayAppStatsMap[asUI.appId]
 
 	asUI.Crash1hCount = displayAppStats.Crash1hCount
 	asUI.Crash24hCount = displayAppStats.Crash24hCount
+	asUI.Crash7dCount = displayAppStats.Crash7dCount
+	asUI.Crash30dCount = displayAppStats.Crash30dCount
 
 	crash10mCount := crashData.FindCountSinceByApp(appStats.AppId, -10*time.Minute)
 	crash10mCount = crash10mCount + appStats.CrashCountSince(-10*time.Minute)
 	asUI.Crash10mCount = crash10mCount
 
-	if displayAppStats.Crash24hCount > 0 {
+	if displayAppStats.Crash30dCount > 0 {
 		// Lookup crash time from container stats
 		asUI.LastCrashInfo = asUI.FindLastCrash(appStats)
 		if asUI.LastCrashInfo == nil {
 			// If we don't find last crash in container stats, last crash must have occured
-			// before top was started.  Look for last crash time in metadata (/v2/event data)
+			// before top was started.  Look for last crash time in metadata (/v2/event data
+			// and local crash history)
 			asUI.LastCrashInfo = crashData.FindLastCrashByApp(appStats.AppId)
 		}
 	}
@@ -428,6 +464,44 @@ func (asUI *AppDetailView) FindLastCrash(appStats *eventApp.AppStats) *crashData
 	return nil
 }
 
+// Populate the app name, org/space and cpu/memory/disk info of a reporting container
+func (asUI *AppDetailView) populateContainerStats(displayContainerStats *DisplayContainerStats, containerStats *eventApp.ContainerStats, appMetadata *app.AppMetadata) {
+
+	mdMgr := asUI.GetMdGlobalMgr()
+	displayContainerStats.AppName = appMetadata.Name
+	spaceMd := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
+	displayContainerStats.SpaceName = spaceMd.Name
+	orgMd := mdMgr.GetOrgMdManager().FindItem(spaceMd.OrgGuid)
+	displayContainerStats.OrgName = orgMd.Name
+
+	if containerStats.CellCreatedMsgTime != nil && containerStats.CellHealthyMsgTime != nil {
+		startupDuration := containerStats.CellHealthyMsgTime.Sub(*containerStats.CellCreatedMsgTime)
+		if startupDuration > 0 {
+			displayContainerStats.StartupDuration = &startupDuration
+		}
+	}
+
+	if displayContainerStats.State == "DOWN" {
+		containerStats.ContainerMetric = nil
+		containerStats.Ip = ""
+	}
+
+	if containerStats.ContainerMetric != nil {
+		memoryMB, diskQuotaMB := mdMgr.FindContainerReservation(appMetadata, containerStats.ProcessType)
+		usedMemory := containerStats.ContainerMetric.GetMemoryBytes()
+		reservedMemory := uint64(memoryMB) * util.MEGABYTE
+		freeMemory := reservedMemory - usedMemory
+		displayContainerStats.FreeMemory = freeMemory
+		displayContainerStats.ReservedMemory = reservedMemory
+
+		usedDisk := containerStats.ContainerMetric.GetDiskBytes()
+		reservedDisk := uint64(diskQuotaMB) * util.MEGABYTE
+		freeDisk := reservedDisk - usedDisk
+		displayContainerStats.FreeDisk = freeDisk
+		displayContainerStats.ReservedDisk = reservedDisk
+	}
+}
+
 func (asUI *AppDetailView) convertToListData(containerStatsArray []*DisplayContainerStats) []uiCommon.IData {
 	listData := make([]uiCommon.IData, 0, len(containerStatsArray))
 	for _, d := range containerStatsArray {
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
@@ -162,6 +163,25 @@ func (w *AppInfoWidget) RefreshDisplay(g *gocui.Gui) error {
 		fmt.Fprintf(v, "   Mem per (total):  %8v (%8v)\n", memoryDisplay, totalMemoryDisplay)
 		fmt.Fprintf(v, "   Disk per (total): %8v (%8v)\n", diskQuotaDisplay, totalDiskDisplay)
 
+		displayAppStats := w.masterUI.GetCommonData().GetDisplayAppStatsMap()[appId]
+		if displayAppStats != nil && len(displayAppStats.ProcessDesiredContainers) > 0 {
+			fmt.Fprintf(v, "\n Processes (reporting / desired):\n")
+			processTypes := make([]string, 0, len(displayAppStats.ProcessDesiredContainers))
+			for processType := range displayAppStats.ProcessDesiredContainers {
+				processTypes = append(processTypes, processType)
+			}
+			sort.Strings(processTypes)
+			for _, processType := range processTypes {
+				desiredContainers := displayAppStats.ProcessDesiredContainers[processType]
+				reportingContainers := displayAppStats.ProcessReportingContainers[processType]
+				colorString := ""
+				if reportingContainers < desiredContainers {
+					colorString = util.BRIGHT_RED
+				}
+				fmt.Fprintf(v, "   %-15v %v%3v / %-3v%v\n", processType+":", colorString, reportingContainers, desiredContainers, util.CLEAR)
+			}
+		}
+
 	} else {
 		fmt.Fprintf(v, " \n Metadata not loaded yet...\n")
 	}
@@ -78,6 +78,25 @@ func ColumnContainerIndex() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("IDX", "IDX", defaultColSize,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, stateAttentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
+	return c
+}
+
+func ColumnProcessType() *uiCommon.ListColumn {
+	defaultColSize := 8
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayContainerStats).ProcessType, c2.(*DisplayContainerStats).ProcessType)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayContainerStats)
+		return util.FormatDisplayData(stats.ProcessType, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayContainerStats)
+		return stats.ProcessType
+	}
+	c := uiCommon.NewListColumn("PROCESS", "PROCESS", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
 	return c
 }
 
@@ -105,13 +105,15 @@ func (w *CrashInfoWidget) refreshDisplay(g *gocui.Gui) error {
 	}
 
 	fmt.Fprintf(v, "%11v", "")
-	fmt.Fprintf(v, "    10min   1hr  24hr\n")
+	fmt.Fprintf(v, "    10min   1hr  24hr  7day 30day\n")
 
 	fmt.Fprintf(v, "%11v", "    Crashes:  ")
 
 	fmt.Fprintf(v, "%v%6v", w.getCrashCountColor(w.detailView.Crash10mCount), w.getCrashCount(w.detailView.Crash10mCount))
 	fmt.Fprintf(v, "%v%6v", w.getCrashCountColor(w.detailView.Crash1hCount), w.getCrashCount(w.detailView.Crash1hCount))
 	fmt.Fprintf(v, "%v%6v", w.getCrashCountColor(w.detailView.Crash24hCount), w.getCrashCount(w.detailView.Crash24hCount))
+	fmt.Fprintf(v, "%v%6v", w.getCrashCountColor(w.detailView.Crash7dCount), w.getCrashCount(w.detailView.Crash7dCount))
+	fmt.Fprintf(v, "%v%6v", w.getCrashCountColor(w.detailView.Crash30dCount), w.getCrashCount(w.detailView.Crash30dCount))
 	fmt.Fprintf(v, "%v\n", util.CLEAR)
 	fmt.Fprintf(v, "%11v", " Last crash:")
 	fmt.Fprintf(v, " %v", lastCrashTimeDisplay)
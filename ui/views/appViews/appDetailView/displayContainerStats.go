@@ -68,8 +68,9 @@ func NewDisplayContainerStats(containerStats *eventApp.ContainerStats, appStats
 
 func (cs *DisplayContainerStats) Id() string {
 	if cs.key == "" {
-		// NOTE: Must include AppId and Index because this view is used by Diego cell view as well as App Detail view
-		cs.key = fmt.Sprintf("%v-%v", cs.AppId, strconv.FormatInt(int64(cs.ContainerIndex), 10))
+		// NOTE: Must include AppId and Index because this view is used by Diego cell view as well as App Detail view.
+		// Must include ProcessType as each process type (web, worker, etc) has its own set of container indexes
+		cs.key = fmt.Sprintf("%v-%v-%v", cs.AppId, cs.ProcessType, strconv.FormatInt(int64(cs.ContainerIndex), 10))
 	}
 	return cs.key
 }
@@ -42,12 +42,20 @@ reflected in the rate and response time values.
 Crash Info section shows how many application containers have crashed
 in the last 10 minutes, 1 hour, and 24 hours.  It also shows the last
 time a container crashed in the previous 24 hours.
+
+**Processes**
+Apps with multiple process types (e.g., web and worker) show the
+containers of all processes.  Only the web process has STATE information
+from the app instance API.  The App Info view (Display menu) shows the
+reporting vs desired container count of each process type.
 `
 
 const HelpColumnsText = `
 **Container Columns:**
 
   IDX - Application container index.
+  PROCESS - Process type of the container (web, worker, etc). Each
+      process type has its own set of container indexes.
   STATE - Current container state: 
       DOWN, STARTING, RUNNING, CRASHED, TERM, UNKNOWN.
   STATE_DUR - Duration of time container has been in current state.
@@ -97,7 +105,11 @@ PURPLE - App instance is terminated.
 
 const HelpLocalViewKeybindings = `
 **Display: **
-Press 'd' to show app detail view menu.
+Press 'd' to show app detail view menu.  The menu includes a log tail
+view of the app or of the highlighted container, a container
+lifecycle view (startup time, health check time and restarts) and
+the app deployments (old vs new containers, HTTP error rate before
+and after).
 
 **Clipboard menu: **
 Press 'c' to open the clipboard menu.  This will copy to clipboard a
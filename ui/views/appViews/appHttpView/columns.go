@@ -55,6 +55,7 @@ func ColumnStatusCode() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("CODE", "CODE", defaultColSize,
 		uiCommon.NUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, nil)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
 	return c
 }
 
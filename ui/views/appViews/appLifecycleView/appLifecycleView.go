@@ -0,0 +1,161 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLifecycleView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+// Container lifecycle summary of each instance of an application
+type AppLifecycleView struct {
+	*dataView.DataListView
+	appId    string
+	appMdMgr *app.AppMetadataManager
+}
+
+func NewAppLifecycleView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appId string) *AppLifecycleView {
+
+	appMdMgr := eventProcessor.GetMetadataManager().GetAppMdManager()
+
+	asUI := &AppLifecycleView{appId: appId, appMdMgr: appMdMgr}
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("IDX", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string {
+		return fmt.Sprintf("App: %v - Container Lifecycle", getAppName(asUI.appMdMgr, asUI.appId))
+	})
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *AppLifecycleView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppLifecycleView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppLifecycleView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 'a', gocui.ModNone, asUI.allEventsAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 'g', gocui.ModNone, asUI.startupHistogramAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *AppLifecycleView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnContainerIndex())
+	columns = append(columns, columnCellIp())
+	columns = append(columns, columnStartCount())
+	columns = append(columns, columnRestartCount())
+	columns = append(columns, columnCrashCount())
+	columns = append(columns, columnEvacuationCount())
+	columns = append(columns, columnLastStartupDuration())
+	columns = append(columns, columnAvgStartupDuration())
+	columns = append(columns, columnLastHealthCheckDuration())
+	columns = append(columns, columnLastEventType())
+	columns = append(columns, columnLastEventTime())
+	columns = append(columns, columnTimeline())
+	return columns
+}
+
+func (asUI *AppLifecycleView) enterAction(g *gocui.Gui, v *gocui.View) error {
+	instance, ok := asUI.GetListWidget().HighlightData().(*DisplayInstanceLifecycle)
+	if !ok {
+		return nil
+	}
+	return asUI.openEventListView(g, instance.ContainerIndex)
+}
+
+func (asUI *AppLifecycleView) allEventsAction(g *gocui.Gui, v *gocui.View) error {
+	return asUI.openEventListView(g, ALL_INSTANCES)
+}
+
+func (asUI *AppLifecycleView) openEventListView(g *gocui.Gui, containerIndex int) error {
+	_, bottomMargin := asUI.GetMargins()
+	view := NewLifecycleEventListView(asUI.GetMasterUI(), asUI, "lifecycleEventListView", bottomMargin,
+		asUI.GetEventProcessor(), asUI.appId, containerIndex)
+	return asUI.GetMasterUI().OpenView(g, view)
+}
+
+func (asUI *AppLifecycleView) startupHistogramAction(g *gocui.Gui, v *gocui.View) error {
+	_, bottomMargin := asUI.GetMargins()
+	view := NewStartupHistogramView(asUI.GetMasterUI(), asUI, "startupHistogramView", bottomMargin,
+		asUI.GetEventProcessor(), asUI.appId)
+	return asUI.GetMasterUI().OpenView(g, view)
+}
+
+func (asUI *AppLifecycleView) GetListData() []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0)
+	appStats := asUI.GetDisplayedEventData().AppMap[asUI.appId]
+	if appStats == nil {
+		return listData
+	}
+	for containerIndex, events := range eventsByInstance(appStats.LifecycleEvents) {
+		listData = append(listData, createInstanceLifecycle(containerIndex, events))
+	}
+	return listData
+}
+
+func (asUI *AppLifecycleView) closeAppLifecycleView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getAppName(appMdMgr *app.AppMetadataManager, appId string) string {
+	appMetadata := appMdMgr.FindItem(appId)
+	return appMetadata.Name
+}
+
+func getLifecycleEvents(eventData *eventdata.EventData, appId string) []*eventApp.ContainerLifecycleEvent {
+	appStats := eventData.AppMap[appId]
+	if appStats == nil {
+		return nil
+	}
+	return appStats.LifecycleEvents
+}
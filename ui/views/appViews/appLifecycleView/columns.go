@@ -0,0 +1,432 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLifecycleView
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+const histogramBarSize = 50
+
+// Format duration to 1/10 of a second.  Unknown (nil) durations are shown as "--"
+func formatDuration(d *time.Duration, size int) string {
+	if d == nil {
+		return fmt.Sprintf("%*v", size, "--")
+	}
+	rounded := *d / (100 * time.Millisecond) * (100 * time.Millisecond)
+	return fmt.Sprintf("%*v", size, util.FormatDuration(&rounded, true))
+}
+
+func lessDuration(d1, d2 *time.Duration) bool {
+	if d1 == nil || d2 == nil {
+		return d1 == nil && d2 != nil
+	}
+	return *d1 < *d2
+}
+
+func lifecycleEventAttentionType(eventType eventApp.LifecycleEventType) uiCommon.AttentionType {
+	switch eventType {
+	case eventApp.LIFECYCLE_CRASHED:
+		return uiCommon.ATTENTION_HOT
+	case eventApp.LIFECYCLE_EVACUATED:
+		return uiCommon.ATTENTION_WARM
+	case eventApp.LIFECYCLE_DESTROYING:
+		return uiCommon.ATTENTION_STATE_TERM
+	case eventApp.LIFECYCLE_CREATING, eventApp.LIFECYCLE_CREATED:
+		return uiCommon.ATTENTION_ACTIVITY
+	}
+	return uiCommon.ATTENTION_NORMAL
+}
+
+// ***** Instance lifecycle columns *****
+
+func columnContainerIndex() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayInstanceLifecycle).ContainerIndex < c2.(*DisplayInstanceLifecycle).ContainerIndex
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		return fmt.Sprintf("%3v", stats.ContainerIndex)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		return fmt.Sprintf("%v", stats.ContainerIndex)
+	}
+	c := uiCommon.NewListColumn("IDX", "IDX", 3,
+		uiCommon.NUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, nil)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
+	return c
+}
+
+func columnCellIp() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.Ip2long(c1.(*DisplayInstanceLifecycle).CellIp) < util.Ip2long(c2.(*DisplayInstanceLifecycle).CellIp)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		return fmt.Sprintf("%-15v", stats.CellIp)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return data.(*DisplayInstanceLifecycle).CellIp
+	}
+	c := uiCommon.NewListColumn("CELL_IP", "CELL_IP", 15,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnStartCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayInstanceLifecycle).StartCount < c2.(*DisplayInstanceLifecycle).StartCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		return fmt.Sprintf("%6v", util.Format(int64(stats.StartCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", data.(*DisplayInstanceLifecycle).StartCount)
+	}
+	c := uiCommon.NewListColumn("STARTS", "STARTS", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnRestartCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayInstanceLifecycle).RestartCount < c2.(*DisplayInstanceLifecycle).RestartCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		return fmt.Sprintf("%8v", util.Format(int64(stats.RestartCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", data.(*DisplayInstanceLifecycle).RestartCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		if data.(*DisplayInstanceLifecycle).RestartCount > 0 {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("RESTARTS", "RESTARTS", 8,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnCrashCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayInstanceLifecycle).CrashCount < c2.(*DisplayInstanceLifecycle).CrashCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		return fmt.Sprintf("%4v", util.Format(int64(stats.CrashCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", data.(*DisplayInstanceLifecycle).CrashCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		if data.(*DisplayInstanceLifecycle).CrashCount > 0 {
+			return uiCommon.ATTENTION_HOT
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("CRH", "CRH", 4,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnEvacuationCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayInstanceLifecycle).EvacuationCount < c2.(*DisplayInstanceLifecycle).EvacuationCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		return fmt.Sprintf("%4v", util.Format(int64(stats.EvacuationCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", data.(*DisplayInstanceLifecycle).EvacuationCount)
+	}
+	c := uiCommon.NewListColumn("EVAC", "EVAC", 4,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnLastStartupDuration() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return lessDuration(c1.(*DisplayInstanceLifecycle).LastStartupDuration, c2.(*DisplayInstanceLifecycle).LastStartupDuration)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return formatDuration(data.(*DisplayInstanceLifecycle).LastStartupDuration, 9)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return formatDuration(data.(*DisplayInstanceLifecycle).LastStartupDuration, 0)
+	}
+	c := uiCommon.NewListColumn("STARTUP", "STARTUP", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnAvgStartupDuration() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return lessDuration(c1.(*DisplayInstanceLifecycle).AvgStartupDuration, c2.(*DisplayInstanceLifecycle).AvgStartupDuration)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return formatDuration(data.(*DisplayInstanceLifecycle).AvgStartupDuration, 11)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return formatDuration(data.(*DisplayInstanceLifecycle).AvgStartupDuration, 0)
+	}
+	c := uiCommon.NewListColumn("AVG_STARTUP", "AVG_STARTUP", 11,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnLastHealthCheckDuration() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return lessDuration(c1.(*DisplayInstanceLifecycle).LastHealthCheckDuration, c2.(*DisplayInstanceLifecycle).LastHealthCheckDuration)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return formatDuration(data.(*DisplayInstanceLifecycle).LastHealthCheckDuration, 10)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return formatDuration(data.(*DisplayInstanceLifecycle).LastHealthCheckDuration, 0)
+	}
+	c := uiCommon.NewListColumn("HEALTH_CHK", "HEALTH_CHK", 10,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnLastEventType() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayInstanceLifecycle).LastEventType < c2.(*DisplayInstanceLifecycle).LastEventType
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		return fmt.Sprintf("%-10v", stats.LastEventType)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return string(data.(*DisplayInstanceLifecycle).LastEventType)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		return lifecycleEventAttentionType(data.(*DisplayInstanceLifecycle).LastEventType)
+	}
+	c := uiCommon.NewListColumn("LAST_EVENT", "LAST_EVENT", 10,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnLastEventTime() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		t1 := c1.(*DisplayInstanceLifecycle).LastEventTime
+		t2 := c2.(*DisplayInstanceLifecycle).LastEventTime
+		if t1 == nil || t2 == nil {
+			return t1 == nil && t2 != nil
+		}
+		return t1.Before(*t2)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		if stats.LastEventTime == nil {
+			return fmt.Sprintf("%-19v", "--")
+		}
+		return stats.LastEventTime.Local().Format("01-02-2006 15:04:05")
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayInstanceLifecycle)
+		if stats.LastEventTime == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", stats.LastEventTime.UnixNano())
+	}
+	c := uiCommon.NewListColumn("EVENT_TIME", "EVENT_TIME", 19,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTimeline() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayInstanceLifecycle).Timeline < c2.(*DisplayInstanceLifecycle).Timeline
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return data.(*DisplayInstanceLifecycle).Timeline
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return data.(*DisplayInstanceLifecycle).Timeline
+	}
+	c := uiCommon.NewListColumn("TIMELINE", "TIMELINE", 2*maxTimelineEvents-1,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+// ***** Lifecycle event list columns *****
+
+func columnEventTime() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		e1 := c1.(*DisplayLifecycleEvent)
+		e2 := c2.(*DisplayLifecycleEvent)
+		if e1.EventTime.Equal(*e2.EventTime) {
+			return e1.seq < e2.seq
+		}
+		return e1.EventTime.Before(*e2.EventTime)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return data.(*DisplayLifecycleEvent).EventTime.Local().Format("01-02-2006 15:04:05.000")
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", data.(*DisplayLifecycleEvent).EventTime.UnixNano())
+	}
+	c := uiCommon.NewListColumn("TIME", "TIME", 23,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnEventContainerIndex() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayLifecycleEvent).ContainerIndex < c2.(*DisplayLifecycleEvent).ContainerIndex
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return fmt.Sprintf("%3v", data.(*DisplayLifecycleEvent).ContainerIndex)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", data.(*DisplayLifecycleEvent).ContainerIndex)
+	}
+	c := uiCommon.NewListColumn("IDX", "IDX", 3,
+		uiCommon.NUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, nil)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
+	return c
+}
+
+func columnEventType() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayLifecycleEvent).EventType < c2.(*DisplayLifecycleEvent).EventType
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return fmt.Sprintf("%-10v", data.(*DisplayLifecycleEvent).EventType)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return string(data.(*DisplayLifecycleEvent).EventType)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		return lifecycleEventAttentionType(data.(*DisplayLifecycleEvent).EventType)
+	}
+	c := uiCommon.NewListColumn("EVENT", "EVENT", 10,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnEventCellIp() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.Ip2long(c1.(*DisplayLifecycleEvent).CellIp) < util.Ip2long(c2.(*DisplayLifecycleEvent).CellIp)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return fmt.Sprintf("%-15v", data.(*DisplayLifecycleEvent).CellIp)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return data.(*DisplayLifecycleEvent).CellIp
+	}
+	c := uiCommon.NewListColumn("CELL_IP", "CELL_IP", 15,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnEventMessage() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayLifecycleEvent).Message < c2.(*DisplayLifecycleEvent).Message
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return data.(*DisplayLifecycleEvent).Message
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return data.(*DisplayLifecycleEvent).Message
+	}
+	c := uiCommon.NewListColumn("MESSAGE", "MESSAGE", 60,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+// ***** Startup latency histogram columns *****
+
+func columnStartupLatency() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStartupBucket).LowerSecs < c2.(*DisplayStartupBucket).LowerSecs
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return fmt.Sprintf("%-10v", data.(*DisplayStartupBucket).Label)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return data.(*DisplayStartupBucket).Label
+	}
+	c := uiCommon.NewListColumn("LATENCY", "LATENCY", 10,
+		uiCommon.NUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnBucketStartCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStartupBucket).StartCount < c2.(*DisplayStartupBucket).StartCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return fmt.Sprintf("%6v", util.Format(int64(data.(*DisplayStartupBucket).StartCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", data.(*DisplayStartupBucket).StartCount)
+	}
+	c := uiCommon.NewListColumn("STARTS", "STARTS", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnBucketPercent() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStartupBucket).Percent < c2.(*DisplayStartupBucket).Percent
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return fmt.Sprintf("%6.1f", data.(*DisplayStartupBucket).Percent)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%.1f", data.(*DisplayStartupBucket).Percent)
+	}
+	c := uiCommon.NewListColumn("PERCENT", "PCT", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnBucketHistogram() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStartupBucket).StartCount < c2.(*DisplayStartupBucket).StartCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStartupBucket)
+		barSize := 0
+		if stats.MaxStartCount > 0 && stats.StartCount > 0 {
+			barSize = stats.StartCount * histogramBarSize / stats.MaxStartCount
+			if barSize == 0 {
+				barSize = 1
+			}
+		}
+		return fmt.Sprintf("%-50v", strings.Repeat("*", barSize))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", data.(*DisplayStartupBucket).StartCount)
+	}
+	c := uiCommon.NewListColumn("HISTOGRAM", "HISTOGRAM", histogramBarSize,
+		uiCommon.NUMERIC, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
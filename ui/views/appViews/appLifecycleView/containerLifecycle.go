@@ -0,0 +1,221 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLifecycleView
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+)
+
+// Max number of events shown in the instance TIMELINE column
+const maxTimelineEvents = 12
+
+var timelineEventAbbreviation = map[eventApp.LifecycleEventType]string{
+	eventApp.LIFECYCLE_CREATING:   "c",
+	eventApp.LIFECYCLE_CREATED:    "C",
+	eventApp.LIFECYCLE_HEALTHY:    "H",
+	eventApp.LIFECYCLE_CRASHED:    "X",
+	eventApp.LIFECYCLE_DESTROYING: "D",
+	eventApp.LIFECYCLE_EVACUATED:  "E",
+}
+
+// One attempt to start a container: from the "creating" event to the
+// "healthy" event (or the next "creating" event)
+type startAttempt struct {
+	creatingTime *time.Time
+	createdTime  *time.Time
+	healthyTime  *time.Time
+	crashed      bool
+}
+
+// Time from creating until healthy.  When no health check message is
+// logged (process health check) the time until created is used as long
+// as the container did not crash.  Returns nil if not known.
+func (a *startAttempt) startupDuration() *time.Duration {
+	var d time.Duration
+	switch {
+	case a.healthyTime != nil:
+		d = a.healthyTime.Sub(*a.creatingTime)
+	case a.createdTime != nil && !a.crashed:
+		d = a.createdTime.Sub(*a.creatingTime)
+	default:
+		return nil
+	}
+	return &d
+}
+
+// Time from created until healthy.  Returns nil if not known.
+func (a *startAttempt) healthCheckDuration() *time.Duration {
+	if a.createdTime == nil || a.healthyTime == nil {
+		return nil
+	}
+	d := a.healthyTime.Sub(*a.createdTime)
+	return &d
+}
+
+type lifecycleEventSlice []*eventApp.ContainerLifecycleEvent
+
+func (p lifecycleEventSlice) Len() int {
+	return len(p)
+}
+
+func (p lifecycleEventSlice) Less(i, j int) bool {
+	return p[i].EventTime.Before(*p[j].EventTime)
+}
+
+func (p lifecycleEventSlice) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Lifecycle events grouped by container index, each sorted by event time.
+// CELL messages are logged asynchronously so they are not always received in order.
+func eventsByInstance(lifecycleEvents []*eventApp.ContainerLifecycleEvent) map[int][]*eventApp.ContainerLifecycleEvent {
+	eventMap := make(map[int][]*eventApp.ContainerLifecycleEvent)
+	for _, event := range lifecycleEvents {
+		if event.EventTime == nil {
+			continue
+		}
+		eventMap[event.ContainerIndex] = append(eventMap[event.ContainerIndex], event)
+	}
+	for _, events := range eventMap {
+		sort.Stable(lifecycleEventSlice(events))
+	}
+	return eventMap
+}
+
+// Split the (time sorted) events of an instance into start attempts
+func findStartAttempts(events []*eventApp.ContainerLifecycleEvent) []*startAttempt {
+	attempts := make([]*startAttempt, 0)
+	var attempt *startAttempt
+	for _, event := range events {
+		switch event.EventType {
+		case eventApp.LIFECYCLE_CREATING:
+			attempt = &startAttempt{creatingTime: event.EventTime}
+			attempts = append(attempts, attempt)
+		case eventApp.LIFECYCLE_CREATED:
+			if attempt != nil && attempt.createdTime == nil {
+				attempt.createdTime = event.EventTime
+			}
+		case eventApp.LIFECYCLE_HEALTHY:
+			if attempt != nil && attempt.healthyTime == nil {
+				attempt.healthyTime = event.EventTime
+			}
+		case eventApp.LIFECYCLE_CRASHED:
+			if attempt != nil && attempt.healthyTime == nil {
+				attempt.crashed = true
+			}
+		}
+	}
+	return attempts
+}
+
+func createInstanceLifecycle(containerIndex int, events []*eventApp.ContainerLifecycleEvent) *DisplayInstanceLifecycle {
+
+	instance := NewDisplayInstanceLifecycle(containerIndex)
+
+	timeline := make([]string, 0, len(events))
+	for _, event := range events {
+		switch event.EventType {
+		case eventApp.LIFECYCLE_CREATING:
+			instance.StartCount++
+		case eventApp.LIFECYCLE_CRASHED:
+			instance.CrashCount++
+		case eventApp.LIFECYCLE_EVACUATED:
+			instance.EvacuationCount++
+		}
+		if event.CellIp != "" {
+			instance.CellIp = event.CellIp
+		}
+		instance.LastEventType = event.EventType
+		instance.LastEventTime = event.EventTime
+		timeline = append(timeline, timelineEventAbbreviation[event.EventType])
+	}
+	if instance.StartCount > 1 {
+		instance.RestartCount = instance.StartCount - 1
+	}
+	if len(timeline) > maxTimelineEvents {
+		timeline = timeline[len(timeline)-maxTimelineEvents:]
+	}
+	instance.Timeline = strings.Join(timeline, ">")
+
+	totalStartup := time.Duration(0)
+	startupCount := 0
+	for _, attempt := range findStartAttempts(events) {
+		if startup := attempt.startupDuration(); startup != nil {
+			instance.LastStartupDuration = startup
+			totalStartup = totalStartup + *startup
+			startupCount++
+		}
+		if healthCheck := attempt.healthCheckDuration(); healthCheck != nil {
+			instance.LastHealthCheckDuration = healthCheck
+		}
+	}
+	if startupCount > 0 {
+		avgStartup := totalStartup / time.Duration(startupCount)
+		instance.AvgStartupDuration = &avgStartup
+	}
+	return instance
+}
+
+func newStartupBuckets() []*DisplayStartupBucket {
+	return []*DisplayStartupBucket{
+		NewDisplayStartupBucket("< 5s", 0, 5),
+		NewDisplayStartupBucket("5s - 10s", 5, 10),
+		NewDisplayStartupBucket("10s - 20s", 10, 20),
+		NewDisplayStartupBucket("20s - 30s", 20, 30),
+		NewDisplayStartupBucket("30s - 1m", 30, 60),
+		NewDisplayStartupBucket("1m - 2m", 60, 120),
+		NewDisplayStartupBucket("2m - 5m", 120, 300),
+		NewDisplayStartupBucket(">= 5m", 300, 0),
+	}
+}
+
+// Startup latency histogram of all start attempts of all instances
+func createStartupHistogram(lifecycleEvents []*eventApp.ContainerLifecycleEvent) []*DisplayStartupBucket {
+	buckets := newStartupBuckets()
+	totalCount := 0
+	for _, events := range eventsByInstance(lifecycleEvents) {
+		for _, attempt := range findStartAttempts(events) {
+			startup := attempt.startupDuration()
+			if startup == nil {
+				continue
+			}
+			secs := startup.Seconds()
+			for _, bucket := range buckets {
+				if secs >= bucket.LowerSecs && (bucket.UpperSecs == 0 || secs < bucket.UpperSecs) {
+					bucket.StartCount++
+					totalCount++
+					break
+				}
+			}
+		}
+	}
+	maxCount := 0
+	for _, bucket := range buckets {
+		if bucket.StartCount > maxCount {
+			maxCount = bucket.StartCount
+		}
+	}
+	for _, bucket := range buckets {
+		bucket.MaxStartCount = maxCount
+		if totalCount > 0 {
+			bucket.Percent = float64(bucket.StartCount) / float64(totalCount) * 100
+		}
+	}
+	return buckets
+}
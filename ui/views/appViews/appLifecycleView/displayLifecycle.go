@@ -0,0 +1,93 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLifecycleView
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+)
+
+// Lifecycle summary of a single container instance
+type DisplayInstanceLifecycle struct {
+	ContainerIndex  int
+	CellIp          string
+	StartCount      int
+	RestartCount    int
+	CrashCount      int
+	EvacuationCount int
+
+	// nil if not known
+	LastStartupDuration     *time.Duration
+	AvgStartupDuration      *time.Duration
+	LastHealthCheckDuration *time.Duration
+
+	LastEventType eventApp.LifecycleEventType
+	LastEventTime *time.Time
+	// Abbreviated sequence of the most recent lifecycle events
+	Timeline string
+
+	key string
+}
+
+func NewDisplayInstanceLifecycle(containerIndex int) *DisplayInstanceLifecycle {
+	return &DisplayInstanceLifecycle{ContainerIndex: containerIndex}
+}
+
+func (d *DisplayInstanceLifecycle) Id() string {
+	if d.key == "" {
+		d.key = fmt.Sprintf("%v", d.ContainerIndex)
+	}
+	return d.key
+}
+
+type DisplayLifecycleEvent struct {
+	*eventApp.ContainerLifecycleEvent
+	// Sequence number to keep events with the same timestamp unique and in order
+	seq int
+	key string
+}
+
+func NewDisplayLifecycleEvent(event *eventApp.ContainerLifecycleEvent, seq int) *DisplayLifecycleEvent {
+	return &DisplayLifecycleEvent{ContainerLifecycleEvent: event, seq: seq}
+}
+
+func (d *DisplayLifecycleEvent) Id() string {
+	if d.key == "" {
+		d.key = fmt.Sprintf("%v-%v", d.ContainerIndex, d.seq)
+	}
+	return d.key
+}
+
+// Number of container starts that completed within a startup latency range
+type DisplayStartupBucket struct {
+	Label     string
+	LowerSecs float64
+	// Upper bound (exclusive), 0 for no upper bound
+	UpperSecs  float64
+	StartCount int
+	Percent    float64
+	// Largest StartCount of all buckets -- used to scale histogram bar
+	MaxStartCount int
+}
+
+func NewDisplayStartupBucket(label string, lowerSecs, upperSecs float64) *DisplayStartupBucket {
+	return &DisplayStartupBucket{Label: label, LowerSecs: lowerSecs, UpperSecs: upperSecs}
+}
+
+func (d *DisplayStartupBucket) Id() string {
+	return d.Label
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLifecycleView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	HelpLocalViewKeybindings +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**App Container Lifecycle View**
+
+Container lifecycle view shows a summary of the lifecycle events
+(creating, created, healthy, crashed, destroying and evacuated) of
+each application container instance.  Lifecycle events are captured
+from the CELL log messages and crash events seen since top was
+started.
+
+Startup time is measured from "creating" to "healthy".  If no
+"healthy" message is logged (health check type "process") startup
+time is measured from "creating" to "created" unless the container
+crashed.
+`
+
+const HelpColumnsText = `
+**Container Lifecycle Columns:**
+
+  IDX - Application container index
+  CELL_IP - IP address of the cell the container last ran on
+  STARTS - Number of times the container was created
+  RESTARTS - Number of times the container was re-created
+  CRH - Number of times the container crashed
+  EVAC - Number of times the container was evacuated from a cell
+  STARTUP - Last startup time (creating until healthy)
+  AVG_STARTUP - Average startup time
+  HEALTH_CHK - Last health check time (created until healthy)
+  LAST_EVENT - Most recent lifecycle event
+  EVENT_TIME - Time of most recent lifecycle event
+  TIMELINE - Sequence of the most recent lifecycle events:
+             c=creating C=created H=healthy X=crashed
+             D=destroying E=evacuated
+`
+
+const HelpLocalViewKeybindings = `
+**Lifecycle events: **
+Press ENTER to show the lifecycle events of the highlighted
+container.  Press 'a' to show the lifecycle events of all
+containers.
+
+**Startup histogram: **
+Press 'g' to show a histogram of container startup time.
+`
+
+const EventListHelpText = EventListHelpOverviewText +
+	helpView.HelpHeaderText +
+	EventListHelpColumnsText +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const EventListHelpOverviewText = `
+**Container Lifecycle Events View**
+
+Shows the full lifecycle event sequence of one or all containers
+of the application.
+`
+
+const EventListHelpColumnsText = `
+**Lifecycle Event Columns:**
+
+  TIME - Date/time of the event (local timezone)
+  IDX - Application container index
+  EVENT - Lifecycle event type
+  CELL_IP - IP address of the cell reporting the event
+  MESSAGE - CELL log message or crash exit description
+`
+
+const HistogramHelpText = HistogramHelpOverviewText +
+	helpView.HelpHeaderText +
+	HistogramHelpColumnsText +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HistogramHelpOverviewText = `
+**Container Startup Latency View**
+
+Histogram of the startup time of all containers of the application
+that started since top was started.
+`
+
+const HistogramHelpColumnsText = `
+**Startup Latency Columns:**
+
+  LATENCY - Startup time range
+  STARTS - Number of container starts within the range
+  PCT - Percent of all container starts within the range
+  HISTOGRAM - STARTS bar scaled to the range with the most starts
+`
@@ -0,0 +1,115 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLifecycleView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+// Container index used to show the lifecycle events of all instances
+const ALL_INSTANCES = -1
+
+// Lifecycle event sequence of one or all instances of an application
+type LifecycleEventListView struct {
+	*dataView.DataListView
+	appId          string
+	containerIndex int
+	appMdMgr       *app.AppMetadataManager
+}
+
+func NewLifecycleEventListView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appId string, containerIndex int) *LifecycleEventListView {
+
+	appMdMgr := eventProcessor.GetMetadataManager().GetAppMdManager()
+
+	asUI := &LifecycleEventListView{appId: appId, containerIndex: containerIndex, appMdMgr: appMdMgr}
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("TIME", true),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string {
+		appName := getAppName(asUI.appMdMgr, asUI.appId)
+		if asUI.containerIndex == ALL_INSTANCES {
+			return fmt.Sprintf("App: %v - Container Lifecycle Events (all instances)", appName)
+		}
+		return fmt.Sprintf("App: %v - Container Lifecycle Events (instance %v)", appName, asUI.containerIndex)
+	})
+	dataListView.HelpText = EventListHelpText
+	dataListView.HelpTextTips = EventListHelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *LifecycleEventListView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeLifecycleEventListView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeLifecycleEventListView); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *LifecycleEventListView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnEventTime())
+	columns = append(columns, columnEventContainerIndex())
+	columns = append(columns, columnEventType())
+	columns = append(columns, columnEventCellIp())
+	columns = append(columns, columnEventMessage())
+	return columns
+}
+
+func (asUI *LifecycleEventListView) GetListData() []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0)
+	for seq, event := range getLifecycleEvents(asUI.GetDisplayedEventData(), asUI.appId) {
+		if event.EventTime == nil {
+			continue
+		}
+		if asUI.containerIndex != ALL_INSTANCES && event.ContainerIndex != asUI.containerIndex {
+			continue
+		}
+		listData = append(listData, NewDisplayLifecycleEvent(event, seq))
+	}
+	return listData
+}
+
+func (asUI *LifecycleEventListView) closeLifecycleEventListView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
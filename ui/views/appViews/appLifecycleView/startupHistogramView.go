@@ -0,0 +1,101 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLifecycleView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+// Histogram of container startup latency of all instances of an application
+type StartupHistogramView struct {
+	*dataView.DataListView
+	appId    string
+	appMdMgr *app.AppMetadataManager
+}
+
+func NewStartupHistogramView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appId string) *StartupHistogramView {
+
+	appMdMgr := eventProcessor.GetMetadataManager().GetAppMdManager()
+
+	asUI := &StartupHistogramView{appId: appId, appMdMgr: appMdMgr}
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("LATENCY", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string {
+		return fmt.Sprintf("App: %v - Container Startup Latency", getAppName(asUI.appMdMgr, asUI.appId))
+	})
+	dataListView.HelpText = HistogramHelpText
+	dataListView.HelpTextTips = HistogramHelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *StartupHistogramView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeStartupHistogramView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeStartupHistogramView); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *StartupHistogramView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnStartupLatency())
+	columns = append(columns, columnBucketStartCount())
+	columns = append(columns, columnBucketPercent())
+	columns = append(columns, columnBucketHistogram())
+	return columns
+}
+
+func (asUI *StartupHistogramView) GetListData() []uiCommon.IData {
+	buckets := createStartupHistogram(getLifecycleEvents(asUI.GetDisplayedEventData(), asUI.appId))
+	listData := make([]uiCommon.IData, 0, len(buckets))
+	for _, bucket := range buckets {
+		listData = append(listData, bucket)
+	}
+	return listData
+}
+
+func (asUI *StartupHistogramView) closeStartupHistogramView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
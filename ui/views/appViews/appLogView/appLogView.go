@@ -0,0 +1,260 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLogView
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventLog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+type AppLogView struct {
+	*dataView.DataListView
+	appId    string
+	appMdMgr *app.AppMetadataManager
+
+	// Filters -- empty means show all
+	instanceFilter string
+	streamFilter   string
+	searchText     string
+	searchRegex    *regexp.Regexp
+
+	// Lines last read from the log buffer -- these are redisplayed while the display is paused
+	logLines []*eventLog.LogLine
+}
+
+func NewAppLogView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appId string, instanceFilter string) *AppLogView {
+
+	appMdMgr := eventProcessor.GetMetadataManager().GetAppMdManager()
+
+	asUI := &AppLogView{appId: appId, appMdMgr: appMdMgr, instanceFilter: instanceFilter}
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("TIME", true),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+	dataListView.SetTitle(asUI.title)
+
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *AppLogView) title() string {
+	filters := make([]string, 0, 3)
+	if asUI.instanceFilter != "" {
+		filters = append(filters, fmt.Sprintf("inst:%v", asUI.instanceFilter))
+	}
+	if asUI.streamFilter != "" {
+		filters = append(filters, fmt.Sprintf("stream:%v", asUI.streamFilter))
+	}
+	if asUI.searchText != "" {
+		filters = append(filters, fmt.Sprintf("search:/%v/", asUI.searchText))
+	}
+	title := fmt.Sprintf("App: %v - Log Tail", asUI.getAppName())
+	if len(filters) > 0 {
+		title = fmt.Sprintf("%v [%v]", title, strings.Join(filters, " "))
+	}
+	if asUI.GetMasterUI().GetDisplayPaused() {
+		title = title + " (PAUSED)"
+	}
+	return title
+}
+
+func (asUI *AppLogView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppLogView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppLogView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 'i', gocui.ModNone, asUI.editInstanceFilterAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 't', gocui.ModNone, asUI.toggleStreamFilterAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, '/', gocui.ModNone, asUI.editSearchAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *AppLogView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, ColumnTime())
+	columns = append(columns, ColumnSourceType())
+	columns = append(columns, ColumnSourceInstance())
+	columns = append(columns, ColumnStream())
+	columns = append(columns, ColumnMessage())
+	return columns
+}
+
+func (asUI *AppLogView) editInstanceFilterAction(g *gocui.Gui, v *gocui.View) error {
+
+	labelText := "Instance:"
+	maxLength := 5
+	titleText := "Show instance (blank for all)"
+	helpText := "no help"
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		asUI.instanceFilter = strings.TrimSpace(inputValue)
+		if err := w.(*uiCommon.InputDialogWidget).CloseWidget(g, v); err != nil {
+			return err
+		}
+		return asUI.UpdateDisplay(g)
+	}
+
+	instanceWidget := uiCommon.NewInputDialogWidget(asUI.GetMasterUI(),
+		"logInstanceFilterWidget", 34, 6, labelText, maxLength, titleText, helpText,
+		asUI.instanceFilter, applyCallbackFunc)
+
+	return instanceWidget.Init(g)
+}
+
+// Cycle through showing all lines, only stdout lines and only stderr lines
+func (asUI *AppLogView) toggleStreamFilterAction(g *gocui.Gui, v *gocui.View) error {
+	switch asUI.streamFilter {
+	case "":
+		asUI.streamFilter = "OUT"
+	case "OUT":
+		asUI.streamFilter = "ERR"
+	default:
+		asUI.streamFilter = ""
+	}
+	return asUI.UpdateDisplay(g)
+}
+
+func (asUI *AppLogView) editSearchAction(g *gocui.Gui, v *gocui.View) error {
+
+	labelText := "Regex:"
+	maxLength := 40
+	titleText := "Show lines matching regex (blank for all)"
+	helpText := "no help"
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		searchText := strings.TrimSpace(inputValue)
+		var searchRegex *regexp.Regexp
+		if searchText != "" {
+			var err error
+			searchRegex, err = regexp.Compile(searchText)
+			if err != nil {
+				toplog.Warn("Invalid log search regex: %v", err)
+				return nil
+			}
+		}
+		asUI.searchText = searchText
+		asUI.searchRegex = searchRegex
+		if err := w.(*uiCommon.InputDialogWidget).CloseWidget(g, v); err != nil {
+			return err
+		}
+		return asUI.UpdateDisplay(g)
+	}
+
+	searchWidget := uiCommon.NewInputDialogWidget(asUI.GetMasterUI(),
+		"logSearchWidget", 52, 6, labelText, maxLength, titleText, helpText,
+		asUI.searchText, applyCallbackFunc)
+
+	return searchWidget.Init(g)
+}
+
+func (asUI *AppLogView) GetListData() []uiCommon.IData {
+	displayLogLines := asUI.postProcessData()
+	listData := asUI.convertToListData(displayLogLines)
+	return listData
+}
+
+func (asUI *AppLogView) postProcessData() []*DisplayLogLine {
+
+	// Keep the app monitored while the log tail is being viewed so its log lines are buffered
+	now := time.Now()
+	asUI.GetMdGlobalMgr().MonitorAppDetails(asUI.appId, &now)
+
+	if !asUI.GetMasterUI().GetDisplayPaused() || asUI.logLines == nil {
+		logBuffer := asUI.GetEventProcessor().GetAppLogBuffer(asUI.appId)
+		if logBuffer != nil {
+			asUI.logLines = logBuffer.GetLines()
+		}
+	}
+
+	displayLogLines := make([]*DisplayLogLine, 0, len(asUI.logLines))
+	for _, logLine := range asUI.logLines {
+		displayLogLine := NewDisplayLogLine(logLine)
+		if asUI.isFilteredOut(displayLogLine) {
+			continue
+		}
+		displayLogLines = append(displayLogLines, displayLogLine)
+	}
+	return displayLogLines
+}
+
+func (asUI *AppLogView) isFilteredOut(logLine *DisplayLogLine) bool {
+	if asUI.instanceFilter != "" && logLine.SourceInstance != asUI.instanceFilter {
+		return true
+	}
+	if asUI.streamFilter != "" && logLine.Stream != asUI.streamFilter {
+		return true
+	}
+	if asUI.searchRegex != nil && !asUI.searchRegex.MatchString(logLine.Message) {
+		return true
+	}
+	return false
+}
+
+func (asUI *AppLogView) convertToListData(displayLogLines []*DisplayLogLine) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayLogLines))
+	for _, d := range displayLogLines {
+		listData = append(listData, d)
+	}
+	return listData
+}
+
+func (asUI *AppLogView) closeAppLogView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (asUI *AppLogView) getAppName() string {
+	appMetadata := asUI.appMdMgr.FindItem(asUI.appId)
+	appName := appMetadata.Name
+	return appName
+}
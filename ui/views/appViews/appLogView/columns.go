@@ -0,0 +1,136 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLogView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+// Color log lines by source type
+func sourceTypeAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	logLine := data.(*DisplayLogLine)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch logLine.SourceCategory {
+	case "CELL":
+		attentionType = uiCommon.ATTENTION_ACTIVITY
+	case "STG":
+		attentionType = uiCommon.ATTENTION_WARM
+	case "API":
+		attentionType = uiCommon.ATTENTION_STATE_TERM
+	case "RTR":
+		attentionType = uiCommon.ATTENTION_NOT_MONITORED
+	}
+	return attentionType
+}
+
+func ColumnTime() *uiCommon.ListColumn {
+	defaultColSize := 12
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayLogLine).Seq < c2.(*DisplayLogLine).Seq
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		logLine := data.(*DisplayLogLine)
+		return fmt.Sprintf("%12v", logLine.TimeFormatted)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		logLine := data.(*DisplayLogLine)
+		return fmt.Sprintf("%v", logLine.Timestamp.UnixNano())
+	}
+	c := uiCommon.NewListColumn("TIME", "TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnSourceType() *uiCommon.ListColumn {
+	defaultColSize := 16
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayLogLine).SourceType, c2.(*DisplayLogLine).SourceType)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		logLine := data.(*DisplayLogLine)
+		return util.FormatDisplayData(logLine.SourceType, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		logLine := data.(*DisplayLogLine)
+		return logLine.SourceType
+	}
+	c := uiCommon.NewListColumn("SOURCE", "SOURCE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, sourceTypeAttentionFunc)
+	return c
+}
+
+func ColumnSourceInstance() *uiCommon.ListColumn {
+	defaultColSize := 4
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayLogLine).SourceInstance, c2.(*DisplayLogLine).SourceInstance)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		logLine := data.(*DisplayLogLine)
+		return util.FormatDisplayDataRight(logLine.SourceInstance, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		logLine := data.(*DisplayLogLine)
+		return logLine.SourceInstance
+	}
+	c := uiCommon.NewListColumn("INST", "INST", defaultColSize,
+		uiCommon.ALPHANUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnStream() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayLogLine).Stream < c2.(*DisplayLogLine).Stream
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		logLine := data.(*DisplayLogLine)
+		return util.FormatDisplayData(logLine.Stream, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		logLine := data.(*DisplayLogLine)
+		return logLine.Stream
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		logLine := data.(*DisplayLogLine)
+		if logLine.Stream == "ERR" {
+			return uiCommon.ATTENTION_HOT
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("STREAM", "STREAM", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func ColumnMessage() *uiCommon.ListColumn {
+	defaultColSize := 200
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayLogLine).MessageText, c2.(*DisplayLogLine).MessageText)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		logLine := data.(*DisplayLogLine)
+		return util.FormatDisplayData(logLine.MessageText, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		logLine := data.(*DisplayLogLine)
+		return logLine.MessageText
+	}
+	c := uiCommon.NewListColumn("MESSAGE", "MESSAGE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, sourceTypeAttentionFunc)
+	return c
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLogView
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventLog"
+)
+
+type DisplayLogLine struct {
+	*eventLog.LogLine
+
+	TimeFormatted string
+	// Leading source type component (APP, CELL, STG, API, RTR, etc)
+	SourceCategory string
+	Stream         string
+	// Message text on a single line
+	MessageText string
+	key         string
+}
+
+func NewDisplayLogLine(logLine *eventLog.LogLine) *DisplayLogLine {
+	displayLogLine := &DisplayLogLine{LogLine: logLine}
+	displayLogLine.TimeFormatted = logLine.Timestamp.Local().Format("15:04:05.000")
+	displayLogLine.SourceCategory = strings.Split(logLine.SourceType, "/")[0]
+	displayLogLine.MessageText = strings.Replace(strings.TrimRight(logLine.Message, "\r\n"), "\n", " ", -1)
+	displayLogLine.Stream = "OUT"
+	if logLine.MessageType == events.LogMessage_ERR {
+		displayLogLine.Stream = "ERR"
+	}
+	return displayLogLine
+}
+
+func (ll *DisplayLogLine) Id() string {
+	if ll.key == "" {
+		ll.key = strconv.FormatInt(ll.Seq, 10)
+	}
+	return ll.key
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package appLogView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	HelpLocalViewKeybindings +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**App Log Tail View**
+
+App log tail view shows the most recent log lines of the selected
+application (up to 1000 lines).  Log lines are only kept for apps
+whose detail view has been visited in the last 15 minutes so lines
+written before the app was first selected are not available.
+
+Lines are colored by source type:  APP - white,  CELL - cyan,
+STG - yellow,  API - purple,  RTR - grey.  Press 'p' to pause the
+display to read lines without them scrolling away.
+`
+
+const HelpColumnsText = `
+**Log Tail Columns:**
+
+  TIME - Time log line was written (24 hour format in local timezone)
+  SOURCE - Source type of line (APP/PROC/WEB, CELL, STG, API, RTR, etc)
+  INST - Source instance (container index for APP and CELL lines)
+  STREAM - OUT for stdout, ERR for stderr
+  MESSAGE - Log message
+`
+
+const HelpLocalViewKeybindings = `
+**Instance filter: **
+Press 'i' to show only the lines of a single instance.  Leave the
+value blank to show all instances.
+
+**Stream filter: **
+Press 't' to toggle between showing all lines, only stdout (OUT)
+lines and only stderr (ERR) lines.
+
+**Search: **
+Press '/' to show only lines that match a regular expression.  Leave
+the value blank to show all lines.
+`
@@ -0,0 +1,157 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appTaskView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/taskViews/taskView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+type AppTaskView struct {
+	*dataView.DataListView
+	appId    string
+	appMdMgr *app.AppMetadataManager
+}
+
+func NewAppTaskView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appId string) *AppTaskView {
+
+	appMdMgr := eventProcessor.GetMetadataManager().GetAppMdManager()
+
+	asUI := &AppTaskView{appId: appId, appMdMgr: appMdMgr}
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("START_TIME", true),
+		uiCommon.NewSortColumn("TASK_NAME", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	titleFunc := func() string {
+		return fmt.Sprintf("App: %v - Task List", asUI.getAppName())
+	}
+	dataListView.SetTitle(titleFunc)
+	dataListView.RefreshDisplayCallback = asUI.refreshDisplay
+
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *AppTaskView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppTaskView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppTaskView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *AppTaskView) enterAction(g *gocui.Gui, v *gocui.View) error {
+	return taskView.OpenTaskDetailWidget(g, asUI)
+}
+
+func (asUI *AppTaskView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, taskView.ColumnTaskName())
+	columns = append(columns, taskView.ColumnSequenceId())
+	columns = append(columns, taskView.ColumnState())
+	columns = append(columns, taskView.ColumnStartTime())
+	columns = append(columns, taskView.ColumnDuration())
+	columns = append(columns, taskView.ColumnMemoryReserved())
+	columns = append(columns, taskView.ColumnDiskReserved())
+	columns = append(columns, taskView.ColumnLogStdout())
+	columns = append(columns, taskView.ColumnLogStderr())
+	columns = append(columns, taskView.ColumnStopTime())
+	columns = append(columns, taskView.ColumnFailureReason())
+	return columns
+}
+
+func (asUI *AppTaskView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+func (asUI *AppTaskView) postProcessData() []*taskView.DisplayTaskStats {
+	mdMgr := asUI.GetMdGlobalMgr()
+	mdMgr.RequestRefreshTaskMetadata()
+	appMap := asUI.GetDisplayedEventData().AppMap
+	return taskView.PostProcessTaskData(mdMgr, appMap, asUI.appId)
+}
+
+func (asUI *AppTaskView) convertToListData(displayTaskList []*taskView.DisplayTaskStats) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayTaskList))
+	for _, d := range displayTaskList {
+		listData = append(listData, d)
+	}
+	return listData
+}
+
+func (asUI *AppTaskView) closeAppTaskView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (asUI *AppTaskView) getAppName() string {
+	appMetadata := asUI.appMdMgr.FindItem(asUI.appId)
+	appName := appMetadata.Name
+	return appName
+}
+
+func (asUI *AppTaskView) refreshDisplay(g *gocui.Gui) (propagateRefresh bool, err error) {
+	v, err := g.View(asUI.DataListView.Name())
+	if err != nil {
+		return false, err
+	}
+	appId := asUI.appId
+	mdAppMgr := asUI.appMdMgr
+	if mdAppMgr.IsPendingDeleteFromCache(appId) || mdAppMgr.IsDeletedFromCache(appId) {
+		v.Clear()
+		fmt.Fprintf(v, " \n")
+		fmt.Fprintf(v, "%v", util.BRIGHT_RED)
+		fmt.Fprintf(v, " Application has been deleted")
+		fmt.Fprintf(v, "%v", util.CLEAR)
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appTaskView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**App Task View**
+
+App task view shows all Cloud Foundry tasks (cf run-task) for the
+selected application.  Press ENTER on a highlighted task to see
+its details including any failure reason.
+`
+
+const HelpColumnsText = `
+**Task List Columns:**
+
+  TASK_NAME - Name of task
+  SEQ - Task sequence id within the application
+  STATE - PENDING, RUNNING, CANCELING, SUCCEEDED or FAILED
+  START_TIME - Time task was created (24 hour format in local timezone)
+  DURATION - How long the task has been (or was) running
+  MEM_RSVD - Memory reserved for the task container
+  DISK_RSVD - Disk reserved for the task container
+  LOG_OUT - Number of stdout log messages since top was started
+  LOG_ERR - Number of stderr log messages since top was started
+  STOP_TIME - Time task completed (24 hour format in local timezone)
+  FAILURE_REASON - Reason given by cloud controller for a FAILED task
+`
@@ -20,6 +20,7 @@ import (
 	"log"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
@@ -36,15 +37,19 @@ type AppListView struct {
 	// If this is non-empty, we filter the app list by the supplied spaceId
 	// Used to support apps-by-space view.
 	spaceIdFilter string
+	// If this is non-empty, we filter the app list by the supplied isolation segment
+	// Used to support apps-by-isolation-segment view.
+	isoSegIdFilter string
 }
 
 func NewAppListView(masterUI masterUIInterface.MasterUIInterface,
 	parentView dataView.DataListViewInterface,
 	name string, bottomMargin int,
 	eventProcessor *eventdata.EventProcessor,
-	spaceIdFilter string) *AppListView {
+	spaceIdFilter string,
+	isoSegIdFilter string) *AppListView {
 
-	asUI := &AppListView{spaceIdFilter: spaceIdFilter}
+	asUI := &AppListView{spaceIdFilter: spaceIdFilter, isoSegIdFilter: isoSegIdFilter}
 
 	defaultSortColumns := []*uiCommon.SortColumn{
 		uiCommon.NewSortColumn("CPU_PER", true),
@@ -77,13 +82,16 @@ func NewAppListView(masterUI masterUIInterface.MasterUIInterface,
 			spaceMd := eventProcessor.GetMetadataManager().GetSpaceMdManager().FindItem(asUI.spaceIdFilter)
 			orgMd := eventProcessor.GetMetadataManager().GetOrgMdManager().FindItem(spaceMd.OrgGuid)
 			title = fmt.Sprintf("%v in Space %v Org %v", title, spaceMd.Name, orgMd.Name)
+		} else if asUI.isoSegIdFilter != "" {
+			isoSegMd := eventProcessor.GetMetadataManager().GetIsoSegMdManager().FindItem(asUI.isoSegIdFilter)
+			title = fmt.Sprintf("%v in Isolation Segment %v", title, isoSegMd.Name)
 		}
 		return title
 	}
 	dataListView.SetTitle(titleFunc)
 
 	dataListView.HelpText = HelpText
-	if asUI.spaceIdFilter == "" {
+	if !asUI.isFiltered() {
 		dataListView.HelpTextTips = HelpTextTips
 	} else {
 		dataListView.HelpTextTips = HelpTextTipsFiltered
@@ -95,6 +103,10 @@ func NewAppListView(masterUI masterUIInterface.MasterUIInterface,
 
 }
 
+func (asUI *AppListView) isFiltered() bool {
+	return asUI.spaceIdFilter != "" || asUI.isoSegIdFilter != ""
+}
+
 func (asUI *AppListView) GetAppId() string {
 	selectedAppId := asUI.GetListWidget().HighlightKey()
 	return selectedAppId
@@ -107,7 +119,7 @@ func (asUI *AppListView) initializeCallback(g *gocui.Gui, viewName string) error
 		log.Panicln(err)
 	}
 
-	if asUI.spaceIdFilter != "" {
+	if asUI.isFiltered() {
 		if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.CloseDetailView); err != nil {
 			log.Panicln(err)
 		}
@@ -170,8 +182,12 @@ func (asUI *AppListView) columnDefinitions() []*uiCommon.ListColumn {
 	columns = append(columns, column4XX())
 	columns = append(columns, column5XX())
 
+	columns = append(columns, columnCrash7dCount())
+	columns = append(columns, columnCrash30dCount())
+
 	columns = append(columns, columnIsolationSegmentName())
 	columns = append(columns, columnStackName())
+	columns = append(columns, columnBuildpackName())
 
 	return columns
 }
@@ -187,6 +203,15 @@ func (asUI *AppListView) getAppStatsMap() map[string]*dataCommon.DisplayAppStats
 		}
 		return filteredMap
 	}
+	if asUI.isoSegIdFilter != "" {
+		filteredMap := make(map[string]*dataCommon.DisplayAppStats)
+		for appId, appStats := range displayStatsMap {
+			if isolationSegment.ResolveGuid(appStats.IsolationSegmentGuid) == asUI.isoSegIdFilter {
+				filteredMap[appId] = appStats
+			}
+		}
+		return filteredMap
+	}
 	return displayStatsMap
 }
 
@@ -203,7 +228,7 @@ func (asUI *AppListView) postProcessData() map[string]*dataCommon.DisplayAppStat
 	for appId, appStats := range displayStatsMap {
 		logStdoutCount := int64(0)
 		logStderrCount := int64(0)
-		for _, cs := range appStats.ContainerArray {
+		for _, cs := range appStats.AllContainers() {
 			if cs != nil {
 				logStdoutCount = logStdoutCount + cs.OutCount
 				logStderrCount = logStderrCount + cs.ErrCount
@@ -237,6 +237,7 @@ func columnTotalMemoryUsed() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_USED", "MEM_USED", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, columnAttentionFunc)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -260,6 +261,7 @@ func columnTotalDiskUsed() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("DSK_USED", "DSK_USED", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, columnAttentionFunc)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -288,6 +290,7 @@ func columnAvgResponseTimeL60Info() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("RESP", "RESP", 6,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, columnAttentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
 	return c
 }
 
@@ -500,6 +503,24 @@ func columnStackName() *uiCommon.ListColumn {
 	return c
 }
 
+func columnBuildpackName() *uiCommon.ListColumn {
+	defaultColSize := 20
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*dataCommon.DisplayAppStats).BuildpackName, c2.(*dataCommon.DisplayAppStats).BuildpackName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return util.FormatDisplayData(appStats.BuildpackName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return appStats.BuildpackName
+	}
+	c := uiCommon.NewListColumn("BUILDPACK", "BUILDPACK", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, columnAttentionFunc)
+	return c
+}
+
 func columnIsolationSegmentName() *uiCommon.ListColumn {
 	defaultColSize := 15
 	sortFunc := func(c1, c2 util.Sortable) bool {
@@ -555,3 +576,62 @@ func columnCrashCount() *uiCommon.ListColumn {
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
 	return c
 }
+
+func columnCrash7dCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).Crash7dCount < c2.(*dataCommon.DisplayAppStats).Crash7dCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*dataCommon.DisplayAppStats)
+		return crashCountDisplay(stats.Crash7dCount, 6)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", appStats.Crash7dCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return crashCountAttention(appStats, appStats.Crash7dCount)
+	}
+	c := uiCommon.NewListColumn("CRH_7D", "CRH_7D", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnCrash30dCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).Crash30dCount < c2.(*dataCommon.DisplayAppStats).Crash30dCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*dataCommon.DisplayAppStats)
+		return crashCountDisplay(stats.Crash30dCount, 7)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", appStats.Crash30dCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return crashCountAttention(appStats, appStats.Crash30dCount)
+	}
+	c := uiCommon.NewListColumn("CRH_30D", "CRH_30D", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func crashCountDisplay(crashCount int, size int) string {
+	if crashCount > 0 || crashData.IsCacheLoaded() {
+		return fmt.Sprintf("%*v", size, util.Format(int64(crashCount)))
+	}
+	return fmt.Sprintf("%*v", size, "--")
+}
+
+func crashCountAttention(appStats *dataCommon.DisplayAppStats, crashCount int) uiCommon.AttentionType {
+	if !appStats.Monitored {
+		return uiCommon.ATTENTION_NOT_MONITORED
+	}
+	if crashCount > 0 {
+		return uiCommon.ATTENTION_WARM
+	}
+	return uiCommon.ATTENTION_NORMAL
+}
@@ -58,6 +58,8 @@ const HelpColumnsText = `
   3XX - Count of HTTP(S) responses with status code 300-399.
   4XX - Count of HTTP(S) responses with status code 400-499.
   5XX - Count of HTTP(S) responses with status code 500-599.
+  CRH_7D - Crashed container count in last 7 days.
+  CRH_30D - Crashed container count in last 30 days.
   ISO_SEG - Isolation Segment assigned to space.
   STACK - The Cloud Foundry stack used by this app.
 
@@ -24,6 +24,8 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/capacityPlanView/capacityScenarioView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/capacityPlanView/cellEvacuationView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellDetailView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
@@ -31,7 +33,6 @@ import (
 )
 
 const UNKNOWN = -1
-const DUMMY_CELL_NAME_FOR_TOTAL = "TOTAL"
 
 type CapacityPlanView struct {
 	*dataView.DataListView
@@ -56,9 +57,8 @@ func NewCapacityPlanView(masterUI masterUIInterface.MasterUIInterface,
 	dataListView.InitializeCallback = asUI.initializeCallback
 	//dataListView.UpdateHeaderCallback = asUI.updateHeader
 	dataListView.GetListData = asUI.GetListData
-	//dataListView.PreRowDisplayCallback = asUI.preRowDisplay
 
-	dataListView.SetTitle(func() string { return "Capacity Plan (memory)" })
+	dataListView.SetTitle(func() string { return "Capacity Plan" })
 	dataListView.HelpText = HelpText
 	dataListView.HelpTextTips = appView.HelpTextTips
 
@@ -80,6 +80,8 @@ func (asUI *CapacityPlanView) columnDefinitions() []*uiCommon.ListColumn {
 
 	columns = append(columns, columnCapacityTotalContainers())
 	columns = append(columns, columnContainerCount())
+	columns = append(columns, columnCapacityRemainingContainers())
+	columns = append(columns, columnCapacityDiskRemaining())
 	columns = append(columns, columnCapacityPlan0_5GMem())
 	columns = append(columns, columnCapacityPlan1_0GMem())
 	columns = append(columns, columnCapacityPlan1_5GMem())
@@ -97,6 +99,12 @@ func (asUI *CapacityPlanView) initializeCallback(g *gocui.Gui, viewName string)
 	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
 		log.Panicln(err)
 	}
+	if err := g.SetKeybinding(viewName, 'w', gocui.ModNone, asUI.scenarioAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 'e', gocui.ModNone, asUI.evacuationAction); err != nil {
+		log.Panicln(err)
+	}
 
 	return nil
 }
@@ -119,6 +127,30 @@ func (asUI *CapacityPlanView) enterAction(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+func (asUI *CapacityPlanView) scenarioAction(g *gocui.Gui, v *gocui.View) error {
+	topMargin, bottomMargin := asUI.GetMargins()
+
+	scenarioView := capacityScenarioView.NewCapacityScenarioView(asUI.GetMasterUI(), asUI,
+		"capacityScenarioView",
+		topMargin, bottomMargin,
+		asUI.GetEventProcessor())
+
+	asUI.SetDetailView(scenarioView)
+	return asUI.GetMasterUI().OpenView(g, scenarioView)
+}
+
+func (asUI *CapacityPlanView) evacuationAction(g *gocui.Gui, v *gocui.View) error {
+	topMargin, bottomMargin := asUI.GetMargins()
+
+	evacuationView := cellEvacuationView.NewCellEvacuationView(asUI.GetMasterUI(), asUI,
+		"cellEvacuationView",
+		topMargin, bottomMargin,
+		asUI.GetEventProcessor())
+
+	asUI.SetDetailView(evacuationView)
+	return asUI.GetMasterUI().OpenView(g, evacuationView)
+}
+
 func (asUI *CapacityPlanView) GetListData() []uiCommon.IData {
 	displayDataList := asUI.postProcessData()
 	listData := asUI.convertToListData(displayDataList)
@@ -134,14 +166,14 @@ func (asUI *CapacityPlanView) postProcessData() map[string]*cellView.DisplayCell
 		displayCellMap[ip] = displayStat
 
 		if cellStats.CapacityMemoryTotal > 0 {
-			displayStat.CapacityPlan0_5GMem = int(cellStats.CapacityMemoryRemaining / (util.GIGABYTE * 0.5))
-			displayStat.CapacityPlan1_0GMem = int(cellStats.CapacityMemoryRemaining / (util.GIGABYTE * 1))
-			displayStat.CapacityPlan1_5GMem = int(cellStats.CapacityMemoryRemaining / (util.GIGABYTE * 1.5))
-			displayStat.CapacityPlan2_0GMem = int(cellStats.CapacityMemoryRemaining / (util.GIGABYTE * 2))
-			displayStat.CapacityPlan2_5GMem = int(cellStats.CapacityMemoryRemaining / (util.GIGABYTE * 2.5))
-			displayStat.CapacityPlan3_0GMem = int(cellStats.CapacityMemoryRemaining / (util.GIGABYTE * 3))
-			displayStat.CapacityPlan3_5GMem = int(cellStats.CapacityMemoryRemaining / (util.GIGABYTE * 3.5))
-			displayStat.CapacityPlan4_0GMem = int(cellStats.CapacityMemoryRemaining / (util.GIGABYTE * 4))
+			displayStat.CapacityPlan0_5GMem = capacityPlan(cellStats, cellStats.CapacityMemoryRemaining/(util.GIGABYTE*0.5))
+			displayStat.CapacityPlan1_0GMem = capacityPlan(cellStats, cellStats.CapacityMemoryRemaining/(util.GIGABYTE*1))
+			displayStat.CapacityPlan1_5GMem = capacityPlan(cellStats, cellStats.CapacityMemoryRemaining/(util.GIGABYTE*1.5))
+			displayStat.CapacityPlan2_0GMem = capacityPlan(cellStats, cellStats.CapacityMemoryRemaining/(util.GIGABYTE*2))
+			displayStat.CapacityPlan2_5GMem = capacityPlan(cellStats, cellStats.CapacityMemoryRemaining/(util.GIGABYTE*2.5))
+			displayStat.CapacityPlan3_0GMem = capacityPlan(cellStats, cellStats.CapacityMemoryRemaining/(util.GIGABYTE*3))
+			displayStat.CapacityPlan3_5GMem = capacityPlan(cellStats, cellStats.CapacityMemoryRemaining/(util.GIGABYTE*3.5))
+			displayStat.CapacityPlan4_0GMem = capacityPlan(cellStats, cellStats.CapacityMemoryRemaining/(util.GIGABYTE*4))
 		} else {
 			displayStat.CapacityPlan0_5GMem = UNKNOWN
 			displayStat.CapacityPlan1_0GMem = UNKNOWN
@@ -157,7 +189,7 @@ func (asUI *CapacityPlanView) postProcessData() map[string]*cellView.DisplayCell
 
 	appMap := asUI.GetDisplayedEventData().AppMap
 	for _, appStats := range appMap {
-		for _, containerStats := range appStats.ContainerArray {
+		for _, containerStats := range appStats.AllContainers() {
 			if containerStats != nil {
 				cellStats := displayCellMap[containerStats.Ip]
 
@@ -171,7 +203,8 @@ func (asUI *CapacityPlanView) postProcessData() map[string]*cellView.DisplayCell
 					if containerStats.ContainerMetric != nil {
 
 						appMetadata := asUI.GetMdGlobalMgr().GetAppMdManager().FindItem(appStats.AppId)
-						cellStats.TotalContainerMemoryReserved = cellStats.TotalContainerMemoryReserved + uint64(appMetadata.MemoryMB*util.MEGABYTE)
+						memoryMB, _ := asUI.GetMdGlobalMgr().FindContainerReservation(appMetadata, containerStats.ProcessType)
+						cellStats.TotalContainerMemoryReserved = cellStats.TotalContainerMemoryReserved + uint64(memoryMB*util.MEGABYTE)
 
 						usedMemoryValue := containerStats.ContainerMetric.GetMemoryBytes()
 						cellStats.TotalContainerMemoryUsed = cellStats.TotalContainerMemoryUsed + usedMemoryValue
@@ -182,81 +215,17 @@ func (asUI *CapacityPlanView) postProcessData() map[string]*cellView.DisplayCell
 		}
 	}
 
-	asUI.addTotalRow(displayCellMap)
-
 	return displayCellMap
 }
 
-func (asUI *CapacityPlanView) addTotalRow(displayCellMap map[string]*cellView.DisplayCellStats) {
-
-	totalLabel := DUMMY_CELL_NAME_FOR_TOTAL
-	totalCellStats := eventCell.NewCellStats(totalLabel)
-	totalDisplayStat := cellView.NewDisplayCellStats(totalCellStats)
-	displayCellMap[totalLabel] = totalDisplayStat
-
-	totalDisplayStat.CapacityPlan0_5GMem = UNKNOWN
-	totalDisplayStat.CapacityPlan1_0GMem = UNKNOWN
-	totalDisplayStat.CapacityPlan1_5GMem = UNKNOWN
-	totalDisplayStat.CapacityPlan2_0GMem = UNKNOWN
-	totalDisplayStat.CapacityPlan2_5GMem = UNKNOWN
-	totalDisplayStat.CapacityPlan3_0GMem = UNKNOWN
-	totalDisplayStat.CapacityPlan3_5GMem = UNKNOWN
-	totalDisplayStat.CapacityPlan4_0GMem = UNKNOWN
-
-	//NumOfCpus := 0
-	CapacityMemoryTotal := int64(0)
-	CapacityMemoryRemaining := int64(0)
-	TotalContainerMemoryReserved := uint64(0)
-	CapacityTotalContainers := 0
-	ContainerCount := 0
-	CapacityPlan0_5GMem := 0
-	CapacityPlan1_0GMem := 0
-	CapacityPlan1_5GMem := 0
-	CapacityPlan2_0GMem := 0
-	CapacityPlan2_5GMem := 0
-	CapacityPlan3_0GMem := 0
-	CapacityPlan3_5GMem := 0
-	CapacityPlan4_0GMem := 0
-
-	capacityPlanHasValue := false
-	for _, cellStats := range displayCellMap {
-
-		//NumOfCpus = NumOfCpus + cellStats.NumOfCpus
-		CapacityMemoryTotal = CapacityMemoryTotal + cellStats.CapacityMemoryTotal
-		CapacityMemoryRemaining = CapacityMemoryRemaining + cellStats.CapacityMemoryRemaining
-		TotalContainerMemoryReserved = TotalContainerMemoryReserved + cellStats.TotalContainerMemoryReserved
-		CapacityTotalContainers = CapacityTotalContainers + cellStats.CapacityTotalContainers
-		ContainerCount = ContainerCount + cellStats.ContainerCount
-		if cellStats.CapacityMemoryTotal > 0 {
-			capacityPlanHasValue = true
-			CapacityPlan0_5GMem = CapacityPlan0_5GMem + cellStats.CapacityPlan0_5GMem
-			CapacityPlan1_0GMem = CapacityPlan1_0GMem + cellStats.CapacityPlan1_0GMem
-			CapacityPlan1_5GMem = CapacityPlan1_5GMem + cellStats.CapacityPlan1_5GMem
-			CapacityPlan2_0GMem = CapacityPlan2_0GMem + cellStats.CapacityPlan2_0GMem
-			CapacityPlan2_5GMem = CapacityPlan2_5GMem + cellStats.CapacityPlan2_5GMem
-			CapacityPlan3_0GMem = CapacityPlan3_0GMem + cellStats.CapacityPlan3_0GMem
-			CapacityPlan3_5GMem = CapacityPlan3_5GMem + cellStats.CapacityPlan3_5GMem
-			CapacityPlan4_0GMem = CapacityPlan4_0GMem + cellStats.CapacityPlan4_0GMem
-		}
+// Number of containers that fit in the remaining memory of the cell, limited by
+// the number of free container slots on the cell
+func capacityPlan(cellStats *eventCell.CellStats, containersByMemory int64) int {
+	containers := int(containersByMemory)
+	if cellStats.CapacityTotalContainers > 0 && containers > cellStats.CapacityRemainingContainers {
+		containers = cellStats.CapacityRemainingContainers
 	}
-	//totalDisplayStat.NumOfCpus = NumOfCpus
-	totalDisplayStat.CapacityMemoryTotal = CapacityMemoryTotal
-	totalDisplayStat.CapacityMemoryRemaining = CapacityMemoryRemaining
-	totalDisplayStat.TotalContainerMemoryReserved = TotalContainerMemoryReserved
-	totalDisplayStat.CapacityTotalContainers = CapacityTotalContainers
-	totalDisplayStat.ContainerCount = ContainerCount
-
-	if capacityPlanHasValue {
-		totalDisplayStat.CapacityPlan0_5GMem = CapacityPlan0_5GMem
-		totalDisplayStat.CapacityPlan1_0GMem = CapacityPlan1_0GMem
-		totalDisplayStat.CapacityPlan1_5GMem = CapacityPlan1_5GMem
-		totalDisplayStat.CapacityPlan2_0GMem = CapacityPlan2_0GMem
-		totalDisplayStat.CapacityPlan2_5GMem = CapacityPlan2_5GMem
-		totalDisplayStat.CapacityPlan3_0GMem = CapacityPlan3_0GMem
-		totalDisplayStat.CapacityPlan3_5GMem = CapacityPlan3_5GMem
-		totalDisplayStat.CapacityPlan4_0GMem = CapacityPlan4_0GMem
-	}
-
+	return containers
 }
 
 func (asUI *CapacityPlanView) convertToListData(displayCellMap map[string]*cellView.DisplayCellStats) []uiCommon.IData {
@@ -266,14 +235,3 @@ func (asUI *CapacityPlanView) convertToListData(displayCellMap map[string]*cellV
 	}
 	return listData
 }
-
-/*
-func (asUI *CapacityPlanView) preRowDisplay(data uiCommon.IData, isSelected bool) string {
-	cellStats := data.(*cellView.DisplayCellStats)
-	v := bytes.NewBufferString("")
-	if !isSelected && cellStats.Ip == DUMMY_CELL_NAME_FOR_TOTAL {
-		fmt.Fprintf(v, util.BRIGHT_WHITE)
-	}
-	return v.String()
-}
-*/
@@ -0,0 +1,118 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package capacityScenarioView
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A what-if deployment: Instances containers each reserving MemoryMB of memory and DiskMB of disk
+type CapacityScenario struct {
+	Name      string
+	Instances int
+	MemoryMB  int
+	DiskMB    int
+}
+
+const DefaultDiskMB = 1024
+
+var (
+	// Scenarios are kept for the life of the top session
+	scenarios     []*CapacityScenario
+	scenariosLock sync.Mutex
+)
+
+func GetScenarios() []*CapacityScenario {
+	scenariosLock.Lock()
+	defer scenariosLock.Unlock()
+	scenarioList := make([]*CapacityScenario, len(scenarios))
+	copy(scenarioList, scenarios)
+	return scenarioList
+}
+
+// Add a scenario.  A scenario with the same name is replaced.
+func AddScenario(scenario *CapacityScenario) {
+	scenariosLock.Lock()
+	defer scenariosLock.Unlock()
+	for i, existingScenario := range scenarios {
+		if existingScenario.Name == scenario.Name {
+			scenarios[i] = scenario
+			return
+		}
+	}
+	scenarios = append(scenarios, scenario)
+}
+
+func RemoveScenario(name string) {
+	scenariosLock.Lock()
+	defer scenariosLock.Unlock()
+	scenarioList := make([]*CapacityScenario, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		if scenario.Name != name {
+			scenarioList = append(scenarioList, scenario)
+		}
+	}
+	scenarios = scenarioList
+}
+
+// Parse a scenario definition in the form:  NAME INSTANCES MEMORY [DISK]
+// Memory and disk are in MB unless suffixed with M or G (e.g., "myapp 4 512M 1G")
+func ParseScenario(definition string) (*CapacityScenario, error) {
+	fields := strings.Fields(definition)
+	if len(fields) < 3 || len(fields) > 4 {
+		return nil, errors.New("Expected: NAME INSTANCES MEMORY [DISK]")
+	}
+	instances, err := strconv.Atoi(fields[1])
+	if err != nil || instances < 1 {
+		return nil, fmt.Errorf("Invalid number of instances: %v", fields[1])
+	}
+	memoryMB, err := parseSizeMB(fields[2])
+	if err != nil {
+		return nil, err
+	}
+	diskMB := DefaultDiskMB
+	if len(fields) == 4 {
+		diskMB, err = parseSizeMB(fields[3])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &CapacityScenario{Name: fields[0], Instances: instances, MemoryMB: memoryMB, DiskMB: diskMB}, nil
+}
+
+func parseSizeMB(value string) (int, error) {
+	multiplier := 1.0
+	numberValue := strings.ToUpper(value)
+	switch {
+	case strings.HasSuffix(numberValue, "GB"), strings.HasSuffix(numberValue, "G"):
+		multiplier = 1024
+		numberValue = strings.TrimRight(numberValue, "GB")
+	case strings.HasSuffix(numberValue, "MB"), strings.HasSuffix(numberValue, "M"):
+		numberValue = strings.TrimRight(numberValue, "MB")
+	}
+	size, err := strconv.ParseFloat(numberValue, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("Invalid size: %v", value)
+	}
+	return int(size * multiplier), nil
+}
+
+func (scenario *CapacityScenario) String() string {
+	return fmt.Sprintf("%v %v %vM %vM", scenario.Name, scenario.Instances, scenario.MemoryMB, scenario.DiskMB)
+}
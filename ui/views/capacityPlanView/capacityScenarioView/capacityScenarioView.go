@@ -0,0 +1,207 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package capacityScenarioView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+type CapacityScenarioView struct {
+	*dataView.DataListView
+	displayResultMap map[string]*DisplayScenarioResult
+}
+
+func NewCapacityScenarioView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, topMargin, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *CapacityScenarioView {
+
+	asUI := &CapacityScenarioView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("SCENARIO", false),
+		uiCommon.NewSortColumn("ISO_SEG", false),
+		uiCommon.NewSortColumn("STACK", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, topMargin, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+	dataListView.RefreshDisplayCallback = asUI.refreshDisplay
+
+	dataListView.SetTitle(func() string { return "Capacity Plan Scenarios" })
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *CapacityScenarioView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnScenarioName())
+	columns = append(columns, columnIsolationSegmentName())
+	columns = append(columns, columnStackGroupName())
+	columns = append(columns, columnInstances())
+	columns = append(columns, columnMemory())
+	columns = append(columns, columnDisk())
+	columns = append(columns, columnNumberOfCells())
+	columns = append(columns, columnCellsUsed())
+	columns = append(columns, columnPlacedInstances())
+	columns = append(columns, columnFits())
+	columns = append(columns, columnMemoryRemainingAfter())
+	columns = append(columns, columnDiskRemainingAfter())
+	columns = append(columns, columnContainersRemainingAfter())
+	columns = append(columns, columnAdditionalInstances())
+	return columns
+}
+
+func (asUI *CapacityScenarioView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeCapacityScenarioView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeCapacityScenarioView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 'a', gocui.ModNone, asUI.addScenarioAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyDelete, gocui.ModNone, asUI.removeScenarioAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *CapacityScenarioView) addScenarioAction(g *gocui.Gui, v *gocui.View) error {
+
+	labelText := "Scenario:"
+	maxLength := 40
+	titleText := "Add scenario: NAME INSTANCES MEMORY [DISK]  (e.g., myapp 4 512M 1G)"
+	helpText := "no help"
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		scenario, err := ParseScenario(inputValue)
+		if err != nil {
+			toplog.Warn("Capacity scenario not added: %v", err)
+			return nil
+		}
+		AddScenario(scenario)
+		toplog.Info("Capacity scenario added: %v", scenario)
+		if err := w.(*uiCommon.InputDialogWidget).CloseWidget(g, v); err != nil {
+			return err
+		}
+		return asUI.UpdateDisplay(g)
+	}
+
+	width := len(titleText) + 4
+	scenarioWidget := uiCommon.NewInputDialogWidget(asUI.GetMasterUI(),
+		"addScenarioWidget", width, 6, labelText, maxLength, titleText, helpText,
+		"", applyCallbackFunc)
+
+	return scenarioWidget.Init(g)
+}
+
+func (asUI *CapacityScenarioView) removeScenarioAction(g *gocui.Gui, v *gocui.View) error {
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	result := asUI.displayResultMap[highlightKey]
+	if result != nil {
+		RemoveScenario(result.Name)
+		toplog.Info("Capacity scenario removed: %v", result.Name)
+		return asUI.UpdateDisplay(g)
+	}
+	return nil
+}
+
+func (asUI *CapacityScenarioView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+// Evaluate every scenario against the cells of each isolation segment / stack group
+func (asUI *CapacityScenarioView) postProcessData() map[string]*DisplayScenarioResult {
+
+	mdGlobalMgr := asUI.GetMdGlobalMgr()
+	cellMap := asUI.GetDisplayedEventData().CellMap
+
+	cellGroupMap := make(map[string][]*eventCell.CellStats)
+	for _, cellStats := range cellMap {
+		groupKey := fmt.Sprintf("%v|%v", cellStats.IsolationSegmentGuid, cellStats.StackGroupId)
+		cellGroupMap[groupKey] = append(cellGroupMap[groupKey], cellStats)
+	}
+
+	displayResultMap := make(map[string]*DisplayScenarioResult)
+	for _, scenario := range GetScenarios() {
+		for _, cells := range cellGroupMap {
+			isoSegGuid := cells[0].IsolationSegmentGuid
+			stackGroupId := cells[0].StackGroupId
+
+			result := NewDisplayScenarioResult(scenario, isoSegGuid, stackGroupId)
+			result.IsolationSegmentName = mdGlobalMgr.GetIsoSegMdManager().FindItem(isoSegGuid).Name
+			stackGroup := mdGlobalMgr.GetStackMdManager().FindStackGroup(stackGroupId)
+			if stackGroup != nil {
+				result.StackGroupName = stackGroup.Name
+			}
+			evaluateScenario(scenario, cells, result)
+			displayResultMap[result.Id()] = result
+		}
+	}
+	asUI.displayResultMap = displayResultMap
+	return displayResultMap
+}
+
+func (asUI *CapacityScenarioView) convertToListData(displayResultMap map[string]*DisplayScenarioResult) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayResultMap))
+	for _, d := range displayResultMap {
+		listData = append(listData, d)
+	}
+	return listData
+}
+
+func (asUI *CapacityScenarioView) refreshDisplay(g *gocui.Gui) (propagateRefresh bool, err error) {
+	if len(GetScenarios()) > 0 {
+		return true, nil
+	}
+	v, err := g.View(asUI.DataListView.Name())
+	if err != nil {
+		return false, err
+	}
+	v.Clear()
+	fmt.Fprintf(v, " \n")
+	fmt.Fprintf(v, " No capacity scenarios defined.  Press 'a' to add a scenario.")
+	return false, nil
+}
+
+func (asUI *CapacityScenarioView) closeCapacityScenarioView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
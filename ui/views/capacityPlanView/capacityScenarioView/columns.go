@@ -0,0 +1,287 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package capacityScenarioView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func fitsAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	result := data.(*DisplayScenarioResult)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	if !result.Fits {
+		attentionType = uiCommon.ATTENTION_HOT
+	}
+	return attentionType
+}
+
+func columnScenarioName() *uiCommon.ListColumn {
+	defaultColSize := 20
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayScenarioResult).Name, c2.(*DisplayScenarioResult).Name)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return util.FormatDisplayData(result.Name, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return result.Name
+	}
+	c := uiCommon.NewListColumn("SCENARIO", "SCENARIO", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, fitsAttentionFunc)
+	return c
+}
+
+func columnIsolationSegmentName() *uiCommon.ListColumn {
+	defaultColSize := 15
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayScenarioResult).IsolationSegmentName, c2.(*DisplayScenarioResult).IsolationSegmentName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return util.FormatDisplayData(result.IsolationSegmentName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return result.IsolationSegmentName
+	}
+	c := uiCommon.NewListColumn("ISO_SEG", "ISO_SEG", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnStackGroupName() *uiCommon.ListColumn {
+	defaultColSize := 15
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayScenarioResult).StackGroupName, c2.(*DisplayScenarioResult).StackGroupName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return util.FormatDisplayData(result.StackGroupName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return result.StackGroupName
+	}
+	c := uiCommon.NewListColumn("STACK", "STACK", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnInstances() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).Instances < c2.(*DisplayScenarioResult).Instances
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%5v", result.Instances)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.Instances)
+	}
+	c := uiCommon.NewListColumn("INST", "INST", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnMemory() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).MemoryMB < c2.(*DisplayScenarioResult).MemoryMB
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%9v", util.ByteSize(int64(result.MemoryMB)*util.MEGABYTE).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.MemoryMB)
+	}
+	c := uiCommon.NewListColumn("MEM", "MEM", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnDisk() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).DiskMB < c2.(*DisplayScenarioResult).DiskMB
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%9v", util.ByteSize(int64(result.DiskMB)*util.MEGABYTE).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.DiskMB)
+	}
+	c := uiCommon.NewListColumn("DISK", "DISK", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnNumberOfCells() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).NumberOfCells < c2.(*DisplayScenarioResult).NumberOfCells
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%5v", result.NumberOfCells)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.NumberOfCells)
+	}
+	c := uiCommon.NewListColumn("CELLS", "CELLS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCellsUsed() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).CellsUsed < c2.(*DisplayScenarioResult).CellsUsed
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%6v", result.CellsUsed)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.CellsUsed)
+	}
+	c := uiCommon.NewListColumn("C_USED", "C_USED", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnPlacedInstances() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).PlacedInstances < c2.(*DisplayScenarioResult).PlacedInstances
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%6v", result.PlacedInstances)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.PlacedInstances)
+	}
+	c := uiCommon.NewListColumn("PLACED", "PLACED", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, fitsAttentionFunc)
+	return c
+}
+
+func columnFits() *uiCommon.ListColumn {
+	defaultColSize := 4
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return !c1.(*DisplayScenarioResult).Fits && c2.(*DisplayScenarioResult).Fits
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		display := "NO"
+		if result.Fits {
+			display = "YES"
+		}
+		return util.FormatDisplayData(display, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.Fits)
+	}
+	c := uiCommon.NewListColumn("FITS", "FITS", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, fitsAttentionFunc)
+	return c
+}
+
+func columnMemoryRemainingAfter() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).MemoryRemainingAfter < c2.(*DisplayScenarioResult).MemoryRemainingAfter
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%10v", util.ByteSize(result.MemoryRemainingAfter).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.MemoryRemainingAfter)
+	}
+	c := uiCommon.NewListColumn("MEM_AFTER", "MEM_AFTER", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnDiskRemainingAfter() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).DiskRemainingAfter < c2.(*DisplayScenarioResult).DiskRemainingAfter
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%10v", util.ByteSize(result.DiskRemainingAfter).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.DiskRemainingAfter)
+	}
+	c := uiCommon.NewListColumn("DISK_AFTER", "DISK_AFTER", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnContainersRemainingAfter() *uiCommon.ListColumn {
+	defaultColSize := 11
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).ContainersRemainingAfter < c2.(*DisplayScenarioResult).ContainersRemainingAfter
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%11v", result.ContainersRemainingAfter)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.ContainersRemainingAfter)
+	}
+	c := uiCommon.NewListColumn("SLOTS_AFTER", "SLOTS_AFTER", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnAdditionalInstances() *uiCommon.ListColumn {
+	defaultColSize := 8
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayScenarioResult).AdditionalInstances < c2.(*DisplayScenarioResult).AdditionalInstances
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%8v", result.AdditionalInstances)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayScenarioResult)
+		return fmt.Sprintf("%v", result.AdditionalInstances)
+	}
+	c := uiCommon.NewListColumn("ADD_INST", "ADD_INST", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package capacityScenarioView
+
+import "fmt"
+
+// Result of evaluating a scenario against the cells of one isolation segment / stack group
+type DisplayScenarioResult struct {
+	*CapacityScenario
+
+	IsolationSegmentGuid string
+	IsolationSegmentName string
+	StackGroupId         string
+	StackGroupName       string
+
+	NumberOfCells   int
+	CellsUsed       int
+	PlacedInstances int
+	Fits            bool
+
+	// Headroom after the scenario's instances have been placed
+	MemoryRemainingAfter     int64
+	DiskRemainingAfter       int64
+	ContainersRemainingAfter int
+	// Number of additional instances of the same size that would still fit
+	AdditionalInstances int
+
+	key string
+}
+
+func NewDisplayScenarioResult(scenario *CapacityScenario, isolationSegmentGuid string, stackGroupId string) *DisplayScenarioResult {
+	return &DisplayScenarioResult{
+		CapacityScenario:     scenario,
+		IsolationSegmentGuid: isolationSegmentGuid,
+		StackGroupId:         stackGroupId,
+	}
+}
+
+func (sr *DisplayScenarioResult) Id() string {
+	if sr.key == "" {
+		sr.key = fmt.Sprintf("%v|%v|%v", sr.Name, sr.IsolationSegmentGuid, sr.StackGroupId)
+	}
+	return sr.key
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package capacityScenarioView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	HelpScenarioKeybindings +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Capacity Plan Scenarios View**
+
+Capacity plan scenarios view answers "what if" questions such as
+"can I deploy app X with 10 instances of 2GB memory and 4GB disk?"
+Each scenario is evaluated separately against the cells of every
+isolation segment / stack combination.  Instances are placed one at
+a time on the cell with the most free memory that still has enough
+free memory, free disk and a free container slot.  A scenario FITS
+when all of its instances could be placed.
+
+Scenarios are defined in the form:  NAME INSTANCES MEMORY [DISK]
+Memory and disk are in MB unless suffixed with M or G.  If disk is
+not given it defaults to 1G.  Examples:
+
+  myapp 4 512M
+  bigapp 10 2G 4G
+
+Scenarios are kept until top exits.  Adding a scenario with the
+same name as an existing scenario replaces it.
+`
+
+const HelpColumnsText = `
+**Capacity Plan Scenarios Columns:**
+
+  SCENARIO - Name of scenario
+  ISO_SEG - Isolation segment of the cells the scenario was evaluated against
+  STACK - Stack of the cells the scenario was evaluated against
+  INST - Number of instances requested by scenario
+  MEM - Memory requested for each instance
+  DISK - Disk requested for each instance
+  CELLS - Number of cells in isolation segment / stack
+  C_USED - Number of cells instances were placed on
+  PLACED - Number of instances that could be placed
+  FITS - YES if all instances could be placed
+  MEM_AFTER - Free memory across cells after placement
+  DISK_AFTER - Free disk across cells after placement
+  SLOTS_AFTER - Free container slots across cells after placement
+  ADD_INST - Number of additional instances of the same size that
+    would still fit after placement
+`
+
+const HelpScenarioKeybindings = `
+**Add scenario: **
+Press 'a' to add a scenario.  A scenario with the same name as an
+existing scenario is replaced.
+
+**Remove scenario: **
+Press DELETE to remove the scenario of the highlighted row.
+`
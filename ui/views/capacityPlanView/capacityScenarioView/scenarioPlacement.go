@@ -0,0 +1,51 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package capacityScenarioView
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/capacityPlanView/cellPlacement"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+// Simulate placing the scenario's instances on the cells of a single isolation segment / stack group.
+func evaluateScenario(scenario *CapacityScenario, cells []*eventCell.CellStats, result *DisplayScenarioResult) {
+
+	memoryBytes := int64(scenario.MemoryMB) * util.MEGABYTE
+	diskBytes := int64(scenario.DiskMB) * util.MEGABYTE
+
+	cellCapacities := cellPlacement.NewCellCapacityList(cells)
+	result.NumberOfCells = len(cellCapacities)
+
+	cellsUsed := make(map[string]bool)
+	for i := 0; i < scenario.Instances; i++ {
+		bestCell := cellPlacement.FindBestCell(cellCapacities, memoryBytes, diskBytes)
+		if bestCell == nil {
+			break
+		}
+		bestCell.Place(memoryBytes, diskBytes)
+		cellsUsed[bestCell.Ip] = true
+		result.PlacedInstances++
+	}
+	result.Fits = result.PlacedInstances == scenario.Instances
+	result.CellsUsed = len(cellsUsed)
+
+	for _, cc := range cellCapacities {
+		result.MemoryRemainingAfter += cc.MemoryRemaining
+		result.DiskRemainingAfter += cc.DiskRemaining
+		result.ContainersRemainingAfter += cc.ContainersRemaining
+		result.AdditionalInstances += cc.ContainersThatFit(memoryBytes, diskBytes)
+	}
+}
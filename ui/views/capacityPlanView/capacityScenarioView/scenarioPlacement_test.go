@@ -0,0 +1,140 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capacityScenarioView
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/testhelpers"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("evaluateScenario", func() {
+	var (
+		cells    []*eventCell.CellStats
+		scenario *CapacityScenario
+		result   *DisplayScenarioResult
+	)
+
+	BeforeEach(func() {
+		scenario = &CapacityScenario{Instances: 2, MemoryMB: 1024, DiskMB: 1024}
+	})
+
+	JustBeforeEach(func() {
+		result = NewDisplayScenarioResult(scenario, "", "")
+		evaluateScenario(scenario, cells, result)
+	})
+
+	Context("when the instances fit on one cell", func() {
+		BeforeEach(func() {
+			cells = []*eventCell.CellStats{testhelpers.NewFakeCell("10.0.0.1", "", 4, 10, 10)}
+		})
+		It("places all instances", func() {
+			Expect(result.NumberOfCells).To(Equal(1))
+			Expect(result.CellsUsed).To(Equal(1))
+			Expect(result.PlacedInstances).To(Equal(2))
+			Expect(result.Fits).To(BeTrue())
+		})
+		It("reports the memory remaining and how many more instances fit", func() {
+			Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(2 * util.GIGABYTE))
+			Expect(result.AdditionalInstances).To(Equal(2))
+		})
+	})
+
+	Context("when there are several cells", func() {
+		BeforeEach(func() {
+			scenario.Instances = 3
+			cells = []*eventCell.CellStats{
+				testhelpers.NewFakeCell("10.0.0.1", "", 4, 10, 10),
+				testhelpers.NewFakeCell("10.0.0.2", "", 3, 10, 10),
+			}
+		})
+		It("spreads the instances to the least loaded cell", func() {
+			Expect(result.NumberOfCells).To(Equal(2))
+			Expect(result.CellsUsed).To(Equal(2))
+			Expect(result.PlacedInstances).To(Equal(3))
+			Expect(result.Fits).To(BeTrue())
+			Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(4 * util.GIGABYTE))
+			Expect(result.AdditionalInstances).To(Equal(4))
+		})
+	})
+
+	Context("when there is not enough memory", func() {
+		BeforeEach(func() {
+			scenario.Instances = 3
+			cells = []*eventCell.CellStats{testhelpers.NewFakeCell("10.0.0.1", "", 2, 10, 10)}
+		})
+		It("places as many instances as fit", func() {
+			Expect(result.PlacedInstances).To(Equal(2))
+			Expect(result.Fits).To(BeFalse())
+			Expect(result.MemoryRemainingAfter).To(BeZero())
+			Expect(result.AdditionalInstances).To(BeZero())
+		})
+	})
+
+	Context("when there is not enough disk", func() {
+		BeforeEach(func() {
+			scenario.Instances = 3
+			scenario.DiskMB = 2048
+			cells = []*eventCell.CellStats{testhelpers.NewFakeCell("10.0.0.1", "", 10, 3, 10)}
+		})
+		It("is limited by the disk", func() {
+			Expect(result.PlacedInstances).To(Equal(1))
+			Expect(result.Fits).To(BeFalse())
+			Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(9 * util.GIGABYTE))
+			Expect(result.AdditionalInstances).To(BeZero())
+		})
+	})
+
+	Context("when there are no container slots left", func() {
+		BeforeEach(func() {
+			cells = []*eventCell.CellStats{testhelpers.NewFakeCell("10.0.0.1", "", 10, 10, 1)}
+		})
+		It("is limited by the containers", func() {
+			Expect(result.PlacedInstances).To(Equal(1))
+			Expect(result.Fits).To(BeFalse())
+			Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(9 * util.GIGABYTE))
+		})
+	})
+
+	Context("when a cell has not reported its capacity", func() {
+		BeforeEach(func() {
+			scenario.Instances = 1
+			cells = []*eventCell.CellStats{testhelpers.NewFakeCell("10.0.0.1", "", 2, 10, 10), {Ip: "10.0.0.2"}}
+		})
+		It("ignores the cell", func() {
+			Expect(result.NumberOfCells).To(Equal(1))
+			Expect(result.CellsUsed).To(Equal(1))
+			Expect(result.Fits).To(BeTrue())
+			Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(1 * util.GIGABYTE))
+			Expect(result.AdditionalInstances).To(Equal(1))
+		})
+	})
+
+	Context("when there are no cells", func() {
+		BeforeEach(func() {
+			scenario.Instances = 1
+			cells = []*eventCell.CellStats{}
+		})
+		It("does not fit", func() {
+			Expect(result.NumberOfCells).To(BeZero())
+			Expect(result.CellsUsed).To(BeZero())
+			Expect(result.PlacedInstances).To(BeZero())
+			Expect(result.Fits).To(BeFalse())
+		})
+	})
+})
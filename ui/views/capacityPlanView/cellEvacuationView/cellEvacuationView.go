@@ -0,0 +1,236 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellEvacuationView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+type CellEvacuationView struct {
+	*dataView.DataListView
+	displayResultMap map[string]*DisplayEvacuationResult
+
+	// Number of cells whose loss would leave containers unplaced (N+1 check)
+	singleCellFailCount int
+	singleCellCount     int
+}
+
+func NewCellEvacuationView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, topMargin, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *CellEvacuationView {
+
+	asUI := &CellEvacuationView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("SURVIVES", false),
+		uiCommon.NewSortColumn("TYPE", true),
+		uiCommon.NewSortColumn("UNPLACED", true),
+		uiCommon.NewSortColumn("NAME", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, topMargin, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(asUI.title)
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *CellEvacuationView) title() string {
+	if asUI.singleCellCount == 0 {
+		return "Cell Evacuation Simulation"
+	}
+	if asUI.singleCellFailCount == 0 {
+		return fmt.Sprintf("Cell Evacuation Simulation - N+1: OK (loss of any 1 of %v cells)", asUI.singleCellCount)
+	}
+	return fmt.Sprintf("Cell Evacuation Simulation - N+1: FAIL (loss of %v of %v cells leaves containers unplaced)",
+		asUI.singleCellFailCount, asUI.singleCellCount)
+}
+
+func (asUI *CellEvacuationView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnName())
+	columns = append(columns, columnEvacuationType())
+	columns = append(columns, columnIsolationSegmentName())
+	columns = append(columns, columnCellsRemoved())
+	columns = append(columns, columnCellsRemaining())
+	columns = append(columns, columnContainersDisplaced())
+	columns = append(columns, columnMemoryDisplaced())
+	columns = append(columns, columnDiskDisplaced())
+	columns = append(columns, columnContainersUnplaced())
+	columns = append(columns, columnMemoryUnplaced())
+	columns = append(columns, columnSurvives())
+	columns = append(columns, columnMemoryRemainingAfter())
+	columns = append(columns, columnContainersRemainingAfter())
+	return columns
+}
+
+func (asUI *CellEvacuationView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeCellEvacuationView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeCellEvacuationView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 'a', gocui.ModNone, asUI.addGroupAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyDelete, gocui.ModNone, asUI.removeGroupAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *CellEvacuationView) addGroupAction(g *gocui.Gui, v *gocui.View) error {
+
+	labelText := "Group:"
+	maxLength := 60
+	titleText := "Add evacuation group: NAME CELL [CELL...]  (e.g., az1 10.0.16.5 10.0.16.6)"
+	helpText := "no help"
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		group, err := ParseEvacuationGroup(inputValue)
+		if err != nil {
+			toplog.Warn("Evacuation group not added: %v", err)
+			return nil
+		}
+		AddEvacuationGroup(group)
+		toplog.Info("Evacuation group added: %v", group)
+		if err := w.(*uiCommon.InputDialogWidget).CloseWidget(g, v); err != nil {
+			return err
+		}
+		return asUI.UpdateDisplay(g)
+	}
+
+	width := len(titleText) + 4
+	groupWidget := uiCommon.NewInputDialogWidget(asUI.GetMasterUI(),
+		"addEvacuationGroupWidget", width, 6, labelText, maxLength, titleText, helpText,
+		"", applyCallbackFunc)
+
+	return groupWidget.Init(g)
+}
+
+func (asUI *CellEvacuationView) removeGroupAction(g *gocui.Gui, v *gocui.View) error {
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	result := asUI.displayResultMap[highlightKey]
+	if result != nil && result.EvacuationType == EVACUATE_GROUP {
+		RemoveEvacuationGroup(result.Name)
+		toplog.Info("Evacuation group removed: %v", result.Name)
+		return asUI.UpdateDisplay(g)
+	}
+	return nil
+}
+
+func (asUI *CellEvacuationView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+// Simulate the loss of each individual cell (N+1) and of each evacuation group
+func (asUI *CellEvacuationView) postProcessData() map[string]*DisplayEvacuationResult {
+
+	mdGlobalMgr := asUI.GetMdGlobalMgr()
+	eventData := asUI.GetDisplayedEventData()
+	cellMap := eventData.CellMap
+
+	containersByCell := make(map[string][]*displacedContainer)
+	for _, appStats := range eventData.AppMap {
+		appMetadata := mdGlobalMgr.GetAppMdManager().FindItem(appStats.AppId)
+		for _, containerStats := range appStats.AllContainers() {
+			if containerStats != nil && containerStats.Ip != "" {
+				memoryMB, diskQuotaMB := mdGlobalMgr.FindContainerReservation(appMetadata, containerStats.ProcessType)
+				container := &displacedContainer{
+					memoryBytes: int64(memoryMB * util.MEGABYTE),
+					diskBytes:   int64(diskQuotaMB * util.MEGABYTE),
+				}
+				containersByCell[containerStats.Ip] = append(containersByCell[containerStats.Ip], container)
+			}
+		}
+	}
+
+	isolationSegmentNameFunc := func(isolationSegmentGuid string) string {
+		return mdGlobalMgr.GetIsoSegMdManager().FindItem(isolationSegmentGuid).Name
+	}
+
+	displayResultMap := make(map[string]*DisplayEvacuationResult)
+	singleCellFailCount := 0
+	singleCellCount := 0
+	for ip, cellStats := range cellMap {
+		if cellStats.CapacityMemoryTotal == 0 {
+			continue
+		}
+		result := NewDisplayEvacuationResult(ip, EVACUATE_CELL)
+		simulateEvacuation([]*eventCell.CellStats{cellStats}, cellMap, containersByCell, isolationSegmentNameFunc, result)
+		displayResultMap[result.Id()] = result
+		singleCellCount++
+		if !result.Survives {
+			singleCellFailCount++
+		}
+	}
+
+	for _, group := range GetEvacuationGroups() {
+		evacuatedCells := make([]*eventCell.CellStats, 0)
+		for _, cellStats := range cellMap {
+			if group.Matches(cellStats) {
+				evacuatedCells = append(evacuatedCells, cellStats)
+			}
+		}
+		result := NewDisplayEvacuationResult(group.Name, EVACUATE_GROUP)
+		simulateEvacuation(evacuatedCells, cellMap, containersByCell, isolationSegmentNameFunc, result)
+		displayResultMap[result.Id()] = result
+	}
+
+	asUI.singleCellFailCount = singleCellFailCount
+	asUI.singleCellCount = singleCellCount
+	asUI.displayResultMap = displayResultMap
+	return displayResultMap
+}
+
+func (asUI *CellEvacuationView) convertToListData(displayResultMap map[string]*DisplayEvacuationResult) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayResultMap))
+	for _, d := range displayResultMap {
+		listData = append(listData, d)
+	}
+	return listData
+}
+
+func (asUI *CellEvacuationView) closeCellEvacuationView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellEvacuationView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func survivesAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	result := data.(*DisplayEvacuationResult)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	if !result.Survives {
+		attentionType = uiCommon.ATTENTION_HOT
+	}
+	return attentionType
+}
+
+func columnName() *uiCommon.ListColumn {
+	defaultColSize := 20
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayEvacuationResult).Name, c2.(*DisplayEvacuationResult).Name)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return util.FormatDisplayData(result.Name, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return result.Name
+	}
+	c := uiCommon.NewListColumn("NAME", "NAME", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, survivesAttentionFunc)
+	return c
+}
+
+func columnEvacuationType() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayEvacuationResult).EvacuationType, c2.(*DisplayEvacuationResult).EvacuationType)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return util.FormatDisplayData(result.EvacuationType, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return result.EvacuationType
+	}
+	c := uiCommon.NewListColumn("TYPE", "TYPE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnIsolationSegmentName() *uiCommon.ListColumn {
+	defaultColSize := 15
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayEvacuationResult).IsolationSegmentName, c2.(*DisplayEvacuationResult).IsolationSegmentName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return util.FormatDisplayData(result.IsolationSegmentName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return result.IsolationSegmentName
+	}
+	c := uiCommon.NewListColumn("ISO_SEG", "ISO_SEG", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCellsRemoved() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).CellsRemoved < c2.(*DisplayEvacuationResult).CellsRemoved
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%9v", result.CellsRemoved)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.CellsRemoved)
+	}
+	c := uiCommon.NewListColumn("CELLS_OUT", "CELLS_OUT", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCellsRemaining() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).CellsRemaining < c2.(*DisplayEvacuationResult).CellsRemaining
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%10v", result.CellsRemaining)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.CellsRemaining)
+	}
+	c := uiCommon.NewListColumn("CELLS_LEFT", "CELLS_LEFT", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnContainersDisplaced() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).ContainersDisplaced < c2.(*DisplayEvacuationResult).ContainersDisplaced
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%9v", result.ContainersDisplaced)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.ContainersDisplaced)
+	}
+	c := uiCommon.NewListColumn("DISPLACED", "DISPLACED", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnMemoryDisplaced() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).MemoryDisplaced < c2.(*DisplayEvacuationResult).MemoryDisplaced
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%9v", util.ByteSize(result.MemoryDisplaced).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.MemoryDisplaced)
+	}
+	c := uiCommon.NewListColumn("MEM_DISP", "MEM_DISP", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnDiskDisplaced() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).DiskDisplaced < c2.(*DisplayEvacuationResult).DiskDisplaced
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%9v", util.ByteSize(result.DiskDisplaced).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.DiskDisplaced)
+	}
+	c := uiCommon.NewListColumn("DSK_DISP", "DSK_DISP", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnContainersUnplaced() *uiCommon.ListColumn {
+	defaultColSize := 8
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).ContainersUnplaced < c2.(*DisplayEvacuationResult).ContainersUnplaced
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%8v", result.ContainersUnplaced)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.ContainersUnplaced)
+	}
+	c := uiCommon.NewListColumn("UNPLACED", "UNPLACED", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, survivesAttentionFunc)
+	return c
+}
+
+func columnMemoryUnplaced() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).MemoryUnplaced < c2.(*DisplayEvacuationResult).MemoryUnplaced
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%9v", util.ByteSize(result.MemoryUnplaced).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.MemoryUnplaced)
+	}
+	c := uiCommon.NewListColumn("MEM_UNPLC", "MEM_UNPLC", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, survivesAttentionFunc)
+	return c
+}
+
+func columnSurvives() *uiCommon.ListColumn {
+	defaultColSize := 8
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return !c1.(*DisplayEvacuationResult).Survives && c2.(*DisplayEvacuationResult).Survives
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		display := "NO"
+		if result.Survives {
+			display = "YES"
+		}
+		return util.FormatDisplayData(display, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.Survives)
+	}
+	c := uiCommon.NewListColumn("SURVIVES", "SURVIVES", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, survivesAttentionFunc)
+	return c
+}
+
+func columnMemoryRemainingAfter() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).MemoryRemainingAfter < c2.(*DisplayEvacuationResult).MemoryRemainingAfter
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%10v", util.ByteSize(result.MemoryRemainingAfter).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.MemoryRemainingAfter)
+	}
+	c := uiCommon.NewListColumn("MEM_AFTER", "MEM_AFTER", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnContainersRemainingAfter() *uiCommon.ListColumn {
+	defaultColSize := 11
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayEvacuationResult).ContainersRemainingAfter < c2.(*DisplayEvacuationResult).ContainersRemainingAfter
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%11v", result.ContainersRemainingAfter)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*DisplayEvacuationResult)
+		return fmt.Sprintf("%v", result.ContainersRemainingAfter)
+	}
+	c := uiCommon.NewListColumn("SLOTS_AFTER", "SLOTS_AFTER", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellEvacuationView
+
+import "fmt"
+
+const (
+	EVACUATE_CELL  = "CELL"
+	EVACUATE_GROUP = "GROUP"
+)
+
+// Result of simulating the loss of a single cell or an evacuation group
+type DisplayEvacuationResult struct {
+	Name           string
+	EvacuationType string
+
+	IsolationSegmentName string
+
+	CellsRemoved   int
+	CellsRemaining int
+
+	ContainersDisplaced int
+	MemoryDisplaced     int64
+	DiskDisplaced       int64
+
+	ContainersPlaced   int
+	ContainersUnplaced int
+	MemoryUnplaced     int64
+	Survives           bool
+
+	// Headroom of the remaining cells after the displaced containers have been placed
+	MemoryRemainingAfter     int64
+	ContainersRemainingAfter int
+
+	key string
+}
+
+func NewDisplayEvacuationResult(name string, evacuationType string) *DisplayEvacuationResult {
+	return &DisplayEvacuationResult{Name: name, EvacuationType: evacuationType}
+}
+
+func (er *DisplayEvacuationResult) Id() string {
+	if er.key == "" {
+		er.key = fmt.Sprintf("%v|%v", er.EvacuationType, er.Name)
+	}
+	return er.key
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellEvacuationView
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+)
+
+// A named set of cells that are removed together (e.g., all cells of an AZ)
+type EvacuationGroup struct {
+	Name string
+	// Each member matches a cell by IP, job index, job name or job name/job index
+	Members []string
+}
+
+var (
+	// Evacuation groups are kept for the life of the top session
+	evacuationGroups     []*EvacuationGroup
+	evacuationGroupsLock sync.Mutex
+)
+
+func GetEvacuationGroups() []*EvacuationGroup {
+	evacuationGroupsLock.Lock()
+	defer evacuationGroupsLock.Unlock()
+	groupList := make([]*EvacuationGroup, len(evacuationGroups))
+	copy(groupList, evacuationGroups)
+	return groupList
+}
+
+// Add an evacuation group.  A group with the same name is replaced.
+func AddEvacuationGroup(group *EvacuationGroup) {
+	evacuationGroupsLock.Lock()
+	defer evacuationGroupsLock.Unlock()
+	for i, existingGroup := range evacuationGroups {
+		if existingGroup.Name == group.Name {
+			evacuationGroups[i] = group
+			return
+		}
+	}
+	evacuationGroups = append(evacuationGroups, group)
+}
+
+func RemoveEvacuationGroup(name string) {
+	evacuationGroupsLock.Lock()
+	defer evacuationGroupsLock.Unlock()
+	groupList := make([]*EvacuationGroup, 0, len(evacuationGroups))
+	for _, group := range evacuationGroups {
+		if group.Name != name {
+			groupList = append(groupList, group)
+		}
+	}
+	evacuationGroups = groupList
+}
+
+// Parse an evacuation group definition in the form:  NAME CELL [CELL...]
+// where CELL is a cell IP, job index, job name or job name/job index (e.g., "diego_cell/0")
+func ParseEvacuationGroup(definition string) (*EvacuationGroup, error) {
+	fields := strings.Fields(strings.Replace(definition, ",", " ", -1))
+	if len(fields) < 2 {
+		return nil, errors.New("Expected: NAME CELL [CELL...]")
+	}
+	return &EvacuationGroup{Name: fields[0], Members: fields[1:]}, nil
+}
+
+func (group *EvacuationGroup) Matches(cellStats *eventCell.CellStats) bool {
+	jobNameAndIndex := fmt.Sprintf("%v/%v", cellStats.JobName, cellStats.JobIndex)
+	for _, member := range group.Members {
+		switch member {
+		case cellStats.Ip, cellStats.JobIndex, cellStats.JobName, jobNameAndIndex:
+			return true
+		}
+	}
+	return false
+}
+
+func (group *EvacuationGroup) String() string {
+	return fmt.Sprintf("%v %v", group.Name, strings.Join(group.Members, " "))
+}
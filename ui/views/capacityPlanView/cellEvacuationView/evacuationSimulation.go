@@ -0,0 +1,106 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellEvacuationView
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/capacityPlanView/cellPlacement"
+)
+
+// Reservation of a container running on a cell that would need to be re-placed
+type displacedContainer struct {
+	memoryBytes int64
+	diskBytes   int64
+}
+
+// Sort largest memory reservation first so the hardest containers to place are placed first
+type displacedContainerSorter []*displacedContainer
+
+func (s displacedContainerSorter) Len() int      { return len(s) }
+func (s displacedContainerSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s displacedContainerSorter) Less(i, j int) bool {
+	return s[i].memoryBytes > s[j].memoryBytes
+}
+
+// Cells can only receive containers from cells of the same isolation segment and stack
+func placementGroupKey(cellStats *eventCell.CellStats) string {
+	return fmt.Sprintf("%v|%v", cellStats.IsolationSegmentGuid, cellStats.StackGroupId)
+}
+
+// Simulate removing the evacuated cells and re-placing their containers on the remaining
+// cells of the same isolation segment and stack.
+func simulateEvacuation(
+	evacuatedCells []*eventCell.CellStats,
+	cellMap map[string]*eventCell.CellStats,
+	containersByCell map[string][]*displacedContainer,
+	isolationSegmentNameFunc func(isolationSegmentGuid string) string,
+	result *DisplayEvacuationResult) {
+
+	evacuatedIps := make(map[string]bool)
+	displacedByGroup := make(map[string][]*displacedContainer)
+	isoSegNames := make(map[string]bool)
+	for _, cellStats := range evacuatedCells {
+		evacuatedIps[cellStats.Ip] = true
+		groupKey := placementGroupKey(cellStats)
+		displacedByGroup[groupKey] = append(displacedByGroup[groupKey], containersByCell[cellStats.Ip]...)
+		isoSegNames[isolationSegmentNameFunc(cellStats.IsolationSegmentGuid)] = true
+	}
+	result.CellsRemoved = len(evacuatedIps)
+
+	for groupKey, displacedContainers := range displacedByGroup {
+
+		remainingCells := make([]*eventCell.CellStats, 0)
+		for ip, cellStats := range cellMap {
+			if !evacuatedIps[ip] && placementGroupKey(cellStats) == groupKey {
+				remainingCells = append(remainingCells, cellStats)
+			}
+		}
+		cellCapacities := cellPlacement.NewCellCapacityList(remainingCells)
+		result.CellsRemaining += len(cellCapacities)
+
+		sort.Sort(displacedContainerSorter(displacedContainers))
+		for _, container := range displacedContainers {
+			result.ContainersDisplaced++
+			result.MemoryDisplaced += container.memoryBytes
+			result.DiskDisplaced += container.diskBytes
+
+			bestCell := cellPlacement.FindBestCell(cellCapacities, container.memoryBytes, container.diskBytes)
+			if bestCell == nil {
+				result.ContainersUnplaced++
+				result.MemoryUnplaced += container.memoryBytes
+				continue
+			}
+			bestCell.Place(container.memoryBytes, container.diskBytes)
+			result.ContainersPlaced++
+		}
+
+		for _, cc := range cellCapacities {
+			result.MemoryRemainingAfter += cc.MemoryRemaining
+			result.ContainersRemainingAfter += cc.ContainersRemaining
+		}
+	}
+	result.Survives = result.ContainersUnplaced == 0
+
+	names := make([]string, 0, len(isoSegNames))
+	for name := range isoSegNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result.IsolationSegmentName = strings.Join(names, ",")
+}
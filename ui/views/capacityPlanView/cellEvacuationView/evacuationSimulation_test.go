@@ -0,0 +1,159 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cellEvacuationView
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/testhelpers"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("simulateEvacuation", func() {
+	var (
+		cellMap          map[string]*eventCell.CellStats
+		evacuatedIps     []string
+		containersByCell map[string][]*displacedContainer
+		result           *DisplayEvacuationResult
+	)
+
+	addCell := func(ip string, isolationSegmentGuid string, memoryRemainingGB int64) {
+		cellMap[ip] = testhelpers.NewFakeCell(ip, isolationSegmentGuid, memoryRemainingGB, 100, 250)
+	}
+
+	containers := func(memoryGB ...int64) []*displacedContainer {
+		containers := make([]*displacedContainer, len(memoryGB))
+		for i, gb := range memoryGB {
+			containers[i] = &displacedContainer{memoryBytes: gb * util.GIGABYTE, diskBytes: util.GIGABYTE}
+		}
+		return containers
+	}
+
+	BeforeEach(func() {
+		cellMap = make(map[string]*eventCell.CellStats)
+		evacuatedIps = []string{"10.0.0.1"}
+		containersByCell = make(map[string][]*displacedContainer)
+	})
+
+	JustBeforeEach(func() {
+		evacuatedCells := make([]*eventCell.CellStats, 0)
+		for _, ip := range evacuatedIps {
+			evacuatedCells = append(evacuatedCells, cellMap[ip])
+		}
+		isolationSegmentNameFunc := func(isolationSegmentGuid string) string {
+			return isolationSegmentGuid + "-name"
+		}
+		result = NewDisplayEvacuationResult("test", EVACUATE_CELL)
+		simulateEvacuation(evacuatedCells, cellMap, containersByCell, isolationSegmentNameFunc, result)
+	})
+
+	Context("when the containers fit on the remaining cell", func() {
+		BeforeEach(func() {
+			addCell("10.0.0.1", "iso1", 4)
+			addCell("10.0.0.2", "iso1", 8)
+			containersByCell["10.0.0.1"] = containers(2, 2)
+		})
+		It("survives", func() {
+			Expect(result.CellsRemoved).To(Equal(1))
+			Expect(result.CellsRemaining).To(Equal(1))
+			Expect(result.ContainersDisplaced).To(Equal(2))
+			Expect(result.ContainersPlaced).To(Equal(2))
+			Expect(result.ContainersUnplaced).To(BeZero())
+			Expect(result.Survives).To(BeTrue())
+			Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(4 * util.GIGABYTE))
+			Expect(result.IsolationSegmentName).To(Equal("iso1-name"))
+		})
+	})
+
+	Context("when the remaining cell does not have enough memory", func() {
+		BeforeEach(func() {
+			addCell("10.0.0.1", "iso1", 4)
+			addCell("10.0.0.2", "iso1", 3)
+		})
+		Context("and the containers are the same size", func() {
+			BeforeEach(func() {
+				containersByCell["10.0.0.1"] = containers(2, 2)
+			})
+			It("reports the containers that could not be placed", func() {
+				Expect(result.ContainersPlaced).To(Equal(1))
+				Expect(result.ContainersUnplaced).To(Equal(1))
+				Expect(result.MemoryUnplaced).To(BeEquivalentTo(2 * util.GIGABYTE))
+				Expect(result.Survives).To(BeFalse())
+				Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(1 * util.GIGABYTE))
+			})
+		})
+		Context("and the containers are different sizes", func() {
+			BeforeEach(func() {
+				containersByCell["10.0.0.1"] = containers(1, 3)
+			})
+			It("places the largest container first", func() {
+				Expect(result.ContainersPlaced).To(Equal(1))
+				Expect(result.ContainersUnplaced).To(Equal(1))
+				Expect(result.MemoryUnplaced).To(BeEquivalentTo(1 * util.GIGABYTE))
+				Expect(result.MemoryRemainingAfter).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the only other cell is in another isolation segment", func() {
+		BeforeEach(func() {
+			addCell("10.0.0.1", "iso1", 4)
+			addCell("10.0.0.3", "iso2", 16)
+			containersByCell["10.0.0.1"] = containers(2)
+		})
+		It("does not use it", func() {
+			Expect(result.CellsRemaining).To(BeZero())
+			Expect(result.ContainersUnplaced).To(Equal(1))
+			Expect(result.MemoryUnplaced).To(BeEquivalentTo(2 * util.GIGABYTE))
+			Expect(result.Survives).To(BeFalse())
+			Expect(result.IsolationSegmentName).To(Equal("iso1-name"))
+		})
+	})
+
+	Context("when cells of two isolation segments are evacuated", func() {
+		BeforeEach(func() {
+			addCell("10.0.0.1", "iso2", 4)
+			addCell("10.0.0.2", "iso2", 8)
+			addCell("10.0.0.3", "iso1", 4)
+			addCell("10.0.0.4", "iso1", 8)
+			evacuatedIps = []string{"10.0.0.1", "10.0.0.3"}
+			containersByCell["10.0.0.1"] = containers(4)
+			containersByCell["10.0.0.3"] = containers(1)
+		})
+		It("places the containers within each isolation segment", func() {
+			Expect(result.CellsRemoved).To(Equal(2))
+			Expect(result.CellsRemaining).To(Equal(2))
+			Expect(result.ContainersDisplaced).To(Equal(2))
+			Expect(result.Survives).To(BeTrue())
+			Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(11 * util.GIGABYTE))
+			Expect(result.IsolationSegmentName).To(Equal("iso1-name,iso2-name"))
+		})
+	})
+
+	Context("when the evacuated cell has no containers", func() {
+		BeforeEach(func() {
+			addCell("10.0.0.1", "iso1", 16)
+			addCell("10.0.0.2", "iso1", 8)
+		})
+		It("survives with the capacity of the remaining cell", func() {
+			Expect(result.CellsRemoved).To(Equal(1))
+			Expect(result.ContainersDisplaced).To(BeZero())
+			Expect(result.Survives).To(BeTrue())
+			Expect(result.MemoryRemainingAfter).To(BeEquivalentTo(8 * util.GIGABYTE))
+		})
+	})
+})
@@ -0,0 +1,76 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellEvacuationView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	HelpEvacuationKeybindings +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Cell Evacuation Simulation View**
+
+Cell evacuation simulation view answers "does the foundation survive
+losing a cell (or a group of cells such as an AZ)?"  For each cell
+(TYPE CELL) the simulation removes the cell and re-places every
+container that was running on it onto the remaining cells of the
+same isolation segment and stack.  Containers are placed largest
+memory reservation first on the cell with the most free memory that
+still has enough free memory, free disk and a free container slot.
+The title shows the N+1 result: OK if the loss of any single cell
+can be absorbed.
+
+Evacuation groups (TYPE GROUP) remove several cells at once.  Groups
+are defined in the form:  NAME CELL [CELL...]
+where each CELL matches cells by IP, job index, job name or job
+name/job index.  Examples:
+
+  z1-cells 10.0.16.5 10.0.16.6 10.0.16.7
+  diego-0 diego_cell/0
+
+Evacuation groups are kept until top exits.
+`
+
+const HelpColumnsText = `
+**Cell Evacuation Columns:**
+
+  NAME - Cell IP or evacuation group name
+  TYPE - CELL for a single cell, GROUP for an evacuation group
+  ISO_SEG - Isolation segment(s) of the removed cells
+  CELLS_OUT - Number of cells removed
+  CELLS_LEFT - Number of remaining cells available for placement
+  DISPLACED - Number of containers running on the removed cells
+  MEM_DISP - Memory reserved by displaced containers
+  DSK_DISP - Disk reserved by displaced containers
+  UNPLACED - Number of displaced containers that did not fit
+  MEM_UNPLC - Memory reserved by containers that did not fit
+  SURVIVES - YES if all displaced containers could be placed
+  MEM_AFTER - Free memory across remaining cells after placement
+  SLOTS_AFTER - Free container slots across remaining cells after
+    placement
+`
+
+const HelpEvacuationKeybindings = `
+**Add evacuation group: **
+Press 'a' to add an evacuation group.  A group with the same name as
+an existing group is replaced.
+
+**Remove evacuation group: **
+Press DELETE to remove the evacuation group of the highlighted row.
+`
@@ -0,0 +1,82 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellPlacement
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+)
+
+// Remaining capacity of a cell during a placement simulation
+type CellCapacity struct {
+	Ip                  string
+	MemoryRemaining     int64
+	DiskRemaining       int64
+	ContainersRemaining int
+}
+
+func NewCellCapacity(cellStats *eventCell.CellStats) *CellCapacity {
+	return &CellCapacity{
+		Ip:                  cellStats.Ip,
+		MemoryRemaining:     cellStats.CapacityMemoryRemaining,
+		DiskRemaining:       cellStats.CapacityDiskRemaining,
+		ContainersRemaining: cellStats.CapacityRemainingContainers,
+	}
+}
+
+// Create capacity for all cells that have reported their capacity
+func NewCellCapacityList(cells []*eventCell.CellStats) []*CellCapacity {
+	cellCapacities := make([]*CellCapacity, 0, len(cells))
+	for _, cellStats := range cells {
+		if cellStats.CapacityMemoryTotal > 0 {
+			cellCapacities = append(cellCapacities, NewCellCapacity(cellStats))
+		}
+	}
+	return cellCapacities
+}
+
+func (cc *CellCapacity) Fits(memoryBytes int64, diskBytes int64) bool {
+	return cc.ContainersRemaining > 0 && cc.MemoryRemaining >= memoryBytes && cc.DiskRemaining >= diskBytes
+}
+
+// Number of containers of the given size that still fit on the cell
+func (cc *CellCapacity) ContainersThatFit(memoryBytes int64, diskBytes int64) int {
+	count := cc.ContainersRemaining
+	if memoryBytes > 0 && int(cc.MemoryRemaining/memoryBytes) < count {
+		count = int(cc.MemoryRemaining / memoryBytes)
+	}
+	if diskBytes > 0 && int(cc.DiskRemaining/diskBytes) < count {
+		count = int(cc.DiskRemaining / diskBytes)
+	}
+	return count
+}
+
+func (cc *CellCapacity) Place(memoryBytes int64, diskBytes int64) {
+	cc.MemoryRemaining -= memoryBytes
+	cc.DiskRemaining -= diskBytes
+	cc.ContainersRemaining--
+}
+
+// Find the cell with the most remaining memory that has enough memory, disk and a free
+// container slot.  This approximates the Diego auction which prefers the least loaded cell.
+// Returns nil if the container does not fit on any cell.
+func FindBestCell(cellCapacities []*CellCapacity, memoryBytes int64, diskBytes int64) *CellCapacity {
+	var bestCell *CellCapacity
+	for _, cc := range cellCapacities {
+		if cc.Fits(memoryBytes, diskBytes) && (bestCell == nil || cc.MemoryRemaining > bestCell.MemoryRemaining) {
+			bestCell = cc
+		}
+	}
+	return bestCell
+}
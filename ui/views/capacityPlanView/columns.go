@@ -170,6 +170,53 @@ func columnContainerCount() *uiCommon.ListColumn {
 	return c
 }
 
+func columnCapacityDiskRemaining() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*cellView.DisplayCellStats).CapacityDiskRemaining < c2.(*cellView.DisplayCellStats).CapacityDiskRemaining
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		CellStats := data.(*cellView.DisplayCellStats)
+		display := ""
+		if CellStats.CapacityDiskRemaining == 0 {
+			display = fmt.Sprintf("%9v", "--")
+		} else {
+			display = fmt.Sprintf("%9v", util.ByteSize(CellStats.CapacityDiskRemaining).StringWithPrecision(1))
+		}
+		return fmt.Sprintf("%9v", display)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		CellStats := data.(*cellView.DisplayCellStats)
+		return fmt.Sprintf("%v", CellStats.CapacityDiskRemaining)
+	}
+	c := uiCommon.NewListColumn("DSK_FREE", "DSK_FREE", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCapacityRemainingContainers() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return (c1.(*cellView.DisplayCellStats).CapacityRemainingContainers < c2.(*cellView.DisplayCellStats).CapacityRemainingContainers)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellStats := data.(*cellView.DisplayCellStats)
+		display := ""
+		if cellStats.CapacityTotalContainers == 0 {
+			display = fmt.Sprintf("%9v", "--")
+		} else {
+			display = fmt.Sprintf("%9v", cellStats.CapacityRemainingContainers)
+		}
+		return fmt.Sprintf("%9v", display)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellStats := data.(*cellView.DisplayCellStats)
+		return fmt.Sprintf("%v", cellStats.CapacityRemainingContainers)
+	}
+	c := uiCommon.NewListColumn("FREE_CNTR", "FREE_CNTR", defaultColSize,
+		uiCommon.NUMERIC, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
 func columnTotalContainerMemoryReserved() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
 		return c1.(*cellView.DisplayCellStats).TotalContainerMemoryReserved < c2.(*cellView.DisplayCellStats).TotalContainerMemoryReserved
@@ -210,6 +257,9 @@ func columnCapacityPlan0_5GMem() *uiCommon.ListColumn {
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		cellStats := data.(*cellView.DisplayCellStats)
+		if cellStats.CapacityPlan0_5GMem == UNKNOWN {
+			return ""
+		}
 		return fmt.Sprintf("%v", cellStats.CapacityPlan0_5GMem)
 	}
 	c := uiCommon.NewListColumn("0.5GB", "  0.5GB", defaultColSize,
@@ -234,6 +284,9 @@ func columnCapacityPlan1_0GMem() *uiCommon.ListColumn {
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		cellStats := data.(*cellView.DisplayCellStats)
+		if cellStats.CapacityPlan1_0GMem == UNKNOWN {
+			return ""
+		}
 		return fmt.Sprintf("%v", cellStats.CapacityPlan1_0GMem)
 	}
 	c := uiCommon.NewListColumn("1.0GB", "  1.0GB", defaultColSize,
@@ -258,6 +311,9 @@ func columnCapacityPlan1_5GMem() *uiCommon.ListColumn {
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		cellStats := data.(*cellView.DisplayCellStats)
+		if cellStats.CapacityPlan1_5GMem == UNKNOWN {
+			return ""
+		}
 		return fmt.Sprintf("%v", cellStats.CapacityPlan1_5GMem)
 	}
 	c := uiCommon.NewListColumn("1.5GB", "  1.5GB", defaultColSize,
@@ -282,6 +338,9 @@ func columnCapacityPlan2_0GMem() *uiCommon.ListColumn {
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		cellStats := data.(*cellView.DisplayCellStats)
+		if cellStats.CapacityPlan2_0GMem == UNKNOWN {
+			return ""
+		}
 		return fmt.Sprintf("%v", cellStats.CapacityPlan2_0GMem)
 	}
 	c := uiCommon.NewListColumn("2.0GB", "  2.0GB", defaultColSize,
@@ -305,6 +364,9 @@ func columnCapacityPlan2_5GMem() *uiCommon.ListColumn {
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		cellStats := data.(*cellView.DisplayCellStats)
+		if cellStats.CapacityPlan2_5GMem == UNKNOWN {
+			return ""
+		}
 		return fmt.Sprintf("%v", cellStats.CapacityPlan2_5GMem)
 	}
 	c := uiCommon.NewListColumn("2.5GB", "  2.5GB", defaultColSize,
@@ -329,6 +391,9 @@ func columnCapacityPlan3_0GMem() *uiCommon.ListColumn {
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		cellStats := data.(*cellView.DisplayCellStats)
+		if cellStats.CapacityPlan3_0GMem == UNKNOWN {
+			return ""
+		}
 		return fmt.Sprintf("%v", cellStats.CapacityPlan3_0GMem)
 	}
 	c := uiCommon.NewListColumn("3.0GB", "  3.0GB", defaultColSize,
@@ -353,6 +418,9 @@ func columnCapacityPlan3_5GMem() *uiCommon.ListColumn {
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		cellStats := data.(*cellView.DisplayCellStats)
+		if cellStats.CapacityPlan3_5GMem == UNKNOWN {
+			return ""
+		}
 		return fmt.Sprintf("%v", cellStats.CapacityPlan3_5GMem)
 	}
 	c := uiCommon.NewListColumn("3.5GB", "  3.5GB", defaultColSize,
@@ -377,6 +445,9 @@ func columnCapacityPlan4_0GMem() *uiCommon.ListColumn {
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		cellStats := data.(*cellView.DisplayCellStats)
+		if cellStats.CapacityPlan4_0GMem == UNKNOWN {
+			return ""
+		}
 		return fmt.Sprintf("%v", cellStats.CapacityPlan4_0GMem)
 	}
 	c := uiCommon.NewListColumn("4.0GB", "  4.0GB", defaultColSize,
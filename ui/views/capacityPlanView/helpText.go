@@ -17,18 +17,21 @@ package capacityPlanView
 
 import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
 
-const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + HelpScenarioKeybindings + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
 
 const HelpOverviewText = `
 **Capacity Plan View**
 
 Capacity plan view shows how many containers of various memory sizes
-can be deployed to the foundation based on current capacity.  
+can be deployed to the foundation based on current capacity.  The
+number of containers is limited by both the free memory and the free
+container slots of each cell.
 
-NOTE: 
-This view currently does not take into consideration the stack
-(e.g., cflinuxfs2 vs windows2012R2 ).  This will be a future
-enhancement.
+The fixed memory sizes do not take into consideration disk, the stack
+(e.g., cflinuxfs3 vs windows2016) or the isolation segment.  Press 'w'
+to open the capacity plan scenarios view where "what if" deployments
+of a given number of instances, memory and disk can be evaluated
+against each isolation segment and stack.
 `
 const HelpColumnsText = `
 **Capacity Plan Columns:**
@@ -41,6 +44,8 @@ const HelpColumnsText = `
   C_MEM_USD - Memory actually in use by all containers
   MAX_CNTR - Max containers a cell can handle
   CNTRS - Number of containers running on cell reported by cell
+  FREE_CNTR - Number of additional containers the cell can handle
+  DSK_FREE - Free disk in cell VM available for containers
   0.5GB - Number of 500Meg containers that could be deployed to foundation
   1.0GB - Number of 1GB containers that could be deployed to foundation
   1.5GB - Number of 1.5GB containers that could be deployed to foundation
@@ -48,5 +53,15 @@ const HelpColumnsText = `
   2.5GB - Number of 2.5GB containers that could be deployed to foundation
   3.0GB - Number of 3GB containers that could be deployed to foundation
   3.5GB - Number of 3.5GB containers that could be deployed to foundation
-  4.0GB - Number of 3GB containers that could be deployed to foundation
+  4.0GB - Number of 4GB containers that could be deployed to foundation
   `
+
+const HelpScenarioKeybindings = `
+**Capacity plan scenarios: **
+Press 'w' to show the capacity plan (what if) scenarios view.
+
+**Cell evacuation simulation: **
+Press 'e' to show the cell evacuation simulation view which checks
+whether the containers of a lost cell (N+1) or group of cells would
+fit on the remaining cells.
+`
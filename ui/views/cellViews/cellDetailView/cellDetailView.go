@@ -20,18 +20,17 @@ import (
 	"log"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
-	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appDetailView"
-	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
 
 type CellDetailView struct {
 	*dataView.DataListView
-	cellIp string
+	cellIp        string
+	cellResources *CellResources
 }
 
 func NewCellDetailView(masterUI masterUIInterface.MasterUIInterface,
@@ -77,14 +76,17 @@ func (asUI *CellDetailView) columnDefinitions() []*uiCommon.ListColumn {
 	columns = append(columns, appDetailView.ColumnSpaceName())
 	columns = append(columns, appDetailView.ColumnOrgName())
 	columns = append(columns, appDetailView.ColumnTotalCpuPercentage())
+	columns = append(columns, columnCpuShare())
 
 	columns = append(columns, appDetailView.ColumnMemoryReserved())
 	columns = append(columns, appDetailView.ColumnMemoryUsed())
 	columns = append(columns, appDetailView.ColumnMemoryFree())
+	columns = append(columns, columnMemoryShare())
 
 	columns = append(columns, appDetailView.ColumnDiskReserved())
 	columns = append(columns, appDetailView.ColumnDiskUsed())
 	columns = append(columns, appDetailView.ColumnDiskFree())
+	columns = append(columns, columnDiskShare())
 
 	columns = append(columns, appDetailView.ColumnLogStdout())
 	columns = append(columns, appDetailView.ColumnLogStderr())
@@ -100,6 +102,19 @@ func (asUI *CellDetailView) initializeCallback(g *gocui.Gui, viewName string) er
 	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView); err != nil {
 		log.Panicln(err)
 	}
+	if err := g.SetKeybinding(viewName, 'b', gocui.ModNone, asUI.openCellResourceView); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *CellDetailView) openCellResourceView(g *gocui.Gui, v *gocui.View) error {
+	topMargin, bottomMargin := asUI.GetMargins()
+	resourceView := NewCellResourceView(asUI.GetMasterUI(), asUI, "cellResourceView",
+		topMargin, bottomMargin,
+		asUI.GetEventProcessor(),
+		asUI.cellIp)
+	asUI.GetMasterUI().OpenView(g, resourceView)
 	return nil
 }
 
@@ -118,44 +133,8 @@ func (asUI *CellDetailView) GetListData() []uiCommon.IData {
 }
 
 func (asUI *CellDetailView) postProcessData() []*appDetailView.DisplayContainerStats {
-
-	containerStatsArray := make([]*appDetailView.DisplayContainerStats, 0)
-
-	mdMgr := asUI.GetMdGlobalMgr()
-	appMap := asUI.GetDisplayedEventData().AppMap
-	appStatsArray := eventApp.ConvertFromMap(appMap, mdMgr.GetAppMdManager())
-	for _, appStats := range appStatsArray {
-		appMetadata := asUI.GetMdGlobalMgr().GetAppMdManager().FindItem(appStats.AppId)
-		for _, containerStats := range appStats.ContainerArray {
-			if containerStats != nil {
-				if containerStats.Ip == asUI.cellIp {
-					// This is a container on the selected cell
-					displayContainerStats := appDetailView.NewDisplayContainerStats(containerStats, appStats)
-					displayContainerStats.AppName = appMetadata.Name
-
-					spaceMd := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
-					displayContainerStats.SpaceName = spaceMd.Name
-					org := mdMgr.GetOrgMdManager().FindItem(spaceMd.OrgGuid)
-					displayContainerStats.OrgName = org.Name
-
-					usedMemory := containerStats.ContainerMetric.GetMemoryBytes()
-					reservedMemory := uint64(appMetadata.MemoryMB) * util.MEGABYTE
-					freeMemory := reservedMemory - usedMemory
-					displayContainerStats.FreeMemory = freeMemory
-					displayContainerStats.ReservedMemory = reservedMemory
-
-					usedDisk := containerStats.ContainerMetric.GetDiskBytes()
-					reservedDisk := uint64(appMetadata.DiskQuotaMB) * util.MEGABYTE
-					freeDisk := reservedDisk - usedDisk
-					displayContainerStats.FreeDisk = freeDisk
-					displayContainerStats.ReservedDisk = reservedDisk
-
-					containerStatsArray = append(containerStatsArray, displayContainerStats)
-				}
-			}
-		}
-	}
-
+	containerStatsArray, cellResources := findCellContainers(asUI.DataListView, asUI.cellIp)
+	asUI.cellResources = cellResources
 	return containerStatsArray
 }
 
@@ -0,0 +1,398 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellDetailView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appDetailView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+const (
+	RESOURCE_CPU    = "CPU"
+	RESOURCE_MEMORY = "MEMORY"
+	RESOURCE_DISK   = "DISK"
+)
+
+const (
+	RESOURCE_STATUS_OK          = "OK"
+	RESOURCE_STATUS_OVERCOMMIT  = "OVERCOMMIT"
+	RESOURCE_STATUS_DISCREPANCY = "DISCREPANCY"
+)
+
+// One row of the cell resource breakdown.  Values that do not apply to
+// the resource (e.g., CPU capacity) are -1
+type DisplayCellResource struct {
+	Resource          string
+	Capacity          int64
+	Reserved          int64
+	Used              int64
+	UsedCpuPercentage float64
+	RemainingReported int64
+	RemainingExpected int64
+	Discrepancy       int64
+	Status            string
+
+	TopConsumer      string
+	TopConsumerShare float64
+}
+
+func (dr *DisplayCellResource) Id() string {
+	return dr.Resource
+}
+
+type CellResourceView struct {
+	*dataView.DataListView
+	cellIp string
+}
+
+func NewCellResourceView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, topMargin, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor, cellIp string) *CellResourceView {
+
+	asUI := &CellResourceView{
+		cellIp: cellIp,
+	}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("RESOURCE", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, topMargin, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string { return fmt.Sprintf("Cell IP:%v - Resource Breakdown", cellIp) })
+	dataListView.HelpText = HelpTextResource
+	dataListView.HelpTextTips = HelpTextTipsResource
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *CellResourceView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnResource())
+	columns = append(columns, columnResourceStatus())
+	columns = append(columns, columnResourceBytes("CAPACITY", "CAPACITY", func(dr *DisplayCellResource) int64 { return dr.Capacity }))
+	columns = append(columns, columnResourceBytes("RESERVED", "RESERVED", func(dr *DisplayCellResource) int64 { return dr.Reserved }))
+	columns = append(columns, columnResourceCommitPercent())
+	columns = append(columns, columnResourceUsed())
+	columns = append(columns, columnResourceBytes("RMN_RPT", "RMN_RPT", func(dr *DisplayCellResource) int64 { return dr.RemainingReported }))
+	columns = append(columns, columnResourceBytes("RMN_CALC", "RMN_CALC", func(dr *DisplayCellResource) int64 { return dr.RemainingExpected }))
+	columns = append(columns, columnResourceDiscrepancy())
+	columns = append(columns, columnTopConsumer())
+	columns = append(columns, columnTopConsumerShare())
+	return columns
+}
+
+func (asUI *CellResourceView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeCellResourceView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeCellResourceView); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *CellResourceView) closeCellResourceView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (asUI *CellResourceView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := make([]uiCommon.IData, 0, len(displayDataList))
+	for _, d := range displayDataList {
+		listData = append(listData, d)
+	}
+	return listData
+}
+
+func topConsumerName(containerStats *appDetailView.DisplayContainerStats) string {
+	if containerStats == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v/%v", containerStats.AppName, containerStats.ContainerIndex)
+}
+
+func (asUI *CellResourceView) postProcessData() []*DisplayCellResource {
+
+	_, cr := findCellContainers(asUI.DataListView, asUI.cellIp)
+
+	cpu := &DisplayCellResource{
+		Resource:          RESOURCE_CPU,
+		Capacity:          -1,
+		Reserved:          -1,
+		Used:              -1,
+		UsedCpuPercentage: cr.TotalCpuPercentage,
+		RemainingReported: -1,
+		RemainingExpected: -1,
+		Status:            RESOURCE_STATUS_OK,
+		TopConsumer:       topConsumerName(cr.TopCpuConsumer),
+		TopConsumerShare:  -1,
+	}
+	if cr.TopCpuConsumer != nil {
+		cpu.TopConsumerShare = cr.CpuShare(cr.TopCpuConsumer)
+	}
+
+	memory := &DisplayCellResource{
+		Resource:          RESOURCE_MEMORY,
+		Capacity:          cr.CapacityMemoryTotal,
+		Reserved:          int64(cr.MemoryReserved),
+		Used:              int64(cr.MemoryUsed),
+		RemainingReported: cr.CapacityMemoryRemaining,
+		RemainingExpected: cr.MemoryRemainingExpected(),
+		Discrepancy:       cr.MemoryDiscrepancy(),
+		Status:            RESOURCE_STATUS_OK,
+		TopConsumer:       topConsumerName(cr.TopMemoryConsumer),
+		TopConsumerShare:  -1,
+	}
+	switch {
+	case cr.IsMemoryOvercommitted():
+		memory.Status = RESOURCE_STATUS_OVERCOMMIT
+	case cr.IsMemoryDiscrepancy():
+		memory.Status = RESOURCE_STATUS_DISCREPANCY
+	}
+	if cr.TopMemoryConsumer != nil {
+		memory.TopConsumerShare = cr.MemoryShare(cr.TopMemoryConsumer)
+	}
+
+	// Cell reported remaining disk is not reconciled as it includes disk used
+	// outside of containers (e.g., droplet and buildpack caches)
+	disk := &DisplayCellResource{
+		Resource:          RESOURCE_DISK,
+		Capacity:          cr.CapacityDiskTotal,
+		Reserved:          int64(cr.DiskReserved),
+		Used:              int64(cr.DiskUsed),
+		RemainingReported: cr.CapacityDiskRemaining,
+		RemainingExpected: cr.DiskRemainingExpected(),
+		Status:            RESOURCE_STATUS_OK,
+		TopConsumer:       topConsumerName(cr.TopDiskConsumer),
+		TopConsumerShare:  -1,
+	}
+	if cr.IsDiskOvercommitted() {
+		disk.Status = RESOURCE_STATUS_OVERCOMMIT
+	}
+	if cr.TopDiskConsumer != nil {
+		disk.TopConsumerShare = cr.DiskShare(cr.TopDiskConsumer)
+	}
+
+	return []*DisplayCellResource{cpu, memory, disk}
+}
+
+func formatBytes(value int64, size int) string {
+	if value < 0 {
+		return fmt.Sprintf("%*v", size, "--")
+	}
+	return fmt.Sprintf("%*v", size, util.ByteSize(value).StringWithPrecision(1))
+}
+
+func resourceStatusAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	stats := data.(*DisplayCellResource)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch stats.Status {
+	case RESOURCE_STATUS_OVERCOMMIT:
+		attentionType = uiCommon.ATTENTION_HOT
+	case RESOURCE_STATUS_DISCREPANCY:
+		attentionType = uiCommon.ATTENTION_WARM
+	}
+	return attentionType
+}
+
+func columnResource() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellResource).Resource < c2.(*DisplayCellResource).Resource
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCellResource)
+		return fmt.Sprintf("%-8v", stats.Resource)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCellResource)
+		return stats.Resource
+	}
+	c := uiCommon.NewListColumn("RESOURCE", "RESOURCE", 8,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, resourceStatusAttentionFunc)
+	return c
+}
+
+func columnResourceStatus() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellResource).Status < c2.(*DisplayCellResource).Status
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCellResource)
+		return fmt.Sprintf("%-11v", stats.Status)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCellResource)
+		return stats.Status
+	}
+	c := uiCommon.NewListColumn("STATUS", "STATUS", 11,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, resourceStatusAttentionFunc)
+	return c
+}
+
+func columnResourceBytes(id, label string, valueFunc func(dr *DisplayCellResource) int64) *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return valueFunc(c1.(*DisplayCellResource)) < valueFunc(c2.(*DisplayCellResource))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return formatBytes(valueFunc(data.(*DisplayCellResource)), 9)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", valueFunc(data.(*DisplayCellResource)))
+	}
+	c := uiCommon.NewListColumn(id, label, 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnResourceCommitPercent() *uiCommon.ListColumn {
+	commitPercent := func(dr *DisplayCellResource) float64 {
+		if dr.Capacity <= 0 || dr.Reserved < 0 {
+			return -1
+		}
+		return float64(dr.Reserved) / float64(dr.Capacity) * 100
+	}
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return commitPercent(c1.(*DisplayCellResource)) < commitPercent(c2.(*DisplayCellResource))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return formatShare(commitPercent(data.(*DisplayCellResource)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", commitPercent(data.(*DisplayCellResource)))
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		percent := commitPercent(data.(*DisplayCellResource))
+		attentionType := uiCommon.ATTENTION_NORMAL
+		switch {
+		case percent > 100:
+			attentionType = uiCommon.ATTENTION_HOT
+		case percent >= 90:
+			attentionType = uiCommon.ATTENTION_WARM
+		}
+		return attentionType
+	}
+	c := uiCommon.NewListColumn("COMMIT_PERCENT", "CMT%", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnResourceUsed() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellResource).Used < c2.(*DisplayCellResource).Used
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCellResource)
+		if stats.Resource == RESOURCE_CPU {
+			return fmt.Sprintf("%8.1f%%", stats.UsedCpuPercentage)
+		}
+		return formatBytes(stats.Used, 9)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCellResource)
+		if stats.Resource == RESOURCE_CPU {
+			return fmt.Sprintf("%v", stats.UsedCpuPercentage)
+		}
+		return fmt.Sprintf("%v", stats.Used)
+	}
+	c := uiCommon.NewListColumn("USED", "USED", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnResourceDiscrepancy() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellResource).Discrepancy < c2.(*DisplayCellResource).Discrepancy
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCellResource)
+		if stats.Resource != RESOURCE_MEMORY {
+			return fmt.Sprintf("%9v", "--")
+		}
+		sign := "+"
+		discrepancy := stats.Discrepancy
+		if discrepancy < 0 {
+			sign = "-"
+			discrepancy = -discrepancy
+		}
+		return fmt.Sprintf("%9v", sign+util.ByteSize(discrepancy).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCellResource)
+		return fmt.Sprintf("%v", stats.Discrepancy)
+	}
+	c := uiCommon.NewListColumn("DIFF", "DIFF", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, resourceStatusAttentionFunc)
+	return c
+}
+
+func columnTopConsumer() *uiCommon.ListColumn {
+	defaultColSize := 35
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayCellResource).TopConsumer, c2.(*DisplayCellResource).TopConsumer)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCellResource)
+		return util.FormatDisplayData(stats.TopConsumer, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCellResource)
+		return stats.TopConsumer
+	}
+	c := uiCommon.NewListColumn("TOP_CONSUMER", "TOP_CONSUMER", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTopConsumerShare() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellResource).TopConsumerShare < c2.(*DisplayCellResource).TopConsumerShare
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayCellResource)
+		return formatShare(stats.TopConsumerShare)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayCellResource)
+		return fmt.Sprintf("%v", stats.TopConsumerShare)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		stats := data.(*DisplayCellResource)
+		return shareAttentionFunc(stats.TopConsumerShare)
+	}
+	c := uiCommon.NewListColumn("TOP_SHARE", "TOP_SHR%", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
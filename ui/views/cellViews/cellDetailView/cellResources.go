@@ -0,0 +1,194 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cellDetailView
+
+import (
+	"math"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appDetailView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+// Cell capacity as reported by the cell reconciled against the usage reported
+// by the containers running on the cell
+type CellResources struct {
+	*eventCell.CellStats
+
+	TotalCpuPercentage  float64
+	MemoryReserved      uint64
+	MemoryUsed          uint64
+	DiskReserved        uint64
+	DiskUsed            uint64
+	ReportingContainers int
+
+	TopCpuConsumer    *appDetailView.DisplayContainerStats
+	TopMemoryConsumer *appDetailView.DisplayContainerStats
+	TopDiskConsumer   *appDetailView.DisplayContainerStats
+}
+
+func NewCellResources(cellStats *eventCell.CellStats) *CellResources {
+	return &CellResources{CellStats: cellStats}
+}
+
+func (cr *CellResources) addContainer(containerStats *appDetailView.DisplayContainerStats) {
+	if containerStats.ContainerMetric == nil {
+		return
+	}
+	cr.ReportingContainers++
+	cr.TotalCpuPercentage = cr.TotalCpuPercentage + containerStats.ContainerMetric.GetCpuPercentage()
+	cr.MemoryReserved = cr.MemoryReserved + containerStats.ReservedMemory
+	cr.MemoryUsed = cr.MemoryUsed + containerStats.ContainerMetric.GetMemoryBytes()
+	cr.DiskReserved = cr.DiskReserved + containerStats.ReservedDisk
+	cr.DiskUsed = cr.DiskUsed + containerStats.ContainerMetric.GetDiskBytes()
+
+	if cr.TopCpuConsumer == nil || containerStats.ContainerMetric.GetCpuPercentage() > cr.TopCpuConsumer.ContainerMetric.GetCpuPercentage() {
+		cr.TopCpuConsumer = containerStats
+	}
+	if cr.TopMemoryConsumer == nil || containerStats.ContainerMetric.GetMemoryBytes() > cr.TopMemoryConsumer.ContainerMetric.GetMemoryBytes() {
+		cr.TopMemoryConsumer = containerStats
+	}
+	if cr.TopDiskConsumer == nil || containerStats.ContainerMetric.GetDiskBytes() > cr.TopDiskConsumer.ContainerMetric.GetDiskBytes() {
+		cr.TopDiskConsumer = containerStats
+	}
+}
+
+// Memory the cell should have remaining based on the memory reserved by containers
+func (cr *CellResources) MemoryRemainingExpected() int64 {
+	return cr.CapacityMemoryTotal - int64(cr.MemoryReserved)
+}
+
+// Difference between the remaining memory reported by the cell and the remaining memory
+// expected from the container reservations.
+func (cr *CellResources) MemoryDiscrepancy() int64 {
+	return MemoryDiscrepancy(cr.CapacityMemoryTotal, cr.CapacityMemoryRemaining, cr.MemoryReserved)
+}
+
+func (cr *CellResources) IsMemoryDiscrepancy() bool {
+	return IsMemoryDiscrepancy(cr.CapacityMemoryTotal, cr.CapacityMemoryRemaining, cr.MemoryReserved)
+}
+
+func (cr *CellResources) IsMemoryOvercommitted() bool {
+	return cr.CapacityMemoryTotal > 0 && int64(cr.MemoryReserved) > cr.CapacityMemoryTotal
+}
+
+func (cr *CellResources) DiskRemainingExpected() int64 {
+	return cr.CapacityDiskTotal - int64(cr.DiskReserved)
+}
+
+func (cr *CellResources) IsDiskOvercommitted() bool {
+	return cr.CapacityDiskTotal > 0 && int64(cr.DiskReserved) > cr.CapacityDiskTotal
+}
+
+// Percent of the cell CPU used by all containers that is used by the given container
+func (cr *CellResources) CpuShare(containerStats *appDetailView.DisplayContainerStats) float64 {
+	if containerStats.ContainerMetric == nil || cr.TotalCpuPercentage <= 0 {
+		return -1
+	}
+	return containerStats.ContainerMetric.GetCpuPercentage() / cr.TotalCpuPercentage * 100
+}
+
+// Percent of the cell memory capacity used by the given container
+func (cr *CellResources) MemoryShare(containerStats *appDetailView.DisplayContainerStats) float64 {
+	if containerStats.ContainerMetric == nil || cr.CapacityMemoryTotal <= 0 {
+		return -1
+	}
+	return float64(containerStats.ContainerMetric.GetMemoryBytes()) / float64(cr.CapacityMemoryTotal) * 100
+}
+
+// Percent of the cell disk capacity used by the given container
+func (cr *CellResources) DiskShare(containerStats *appDetailView.DisplayContainerStats) float64 {
+	if containerStats.ContainerMetric == nil || cr.CapacityDiskTotal <= 0 {
+		return -1
+	}
+	return float64(containerStats.ContainerMetric.GetDiskBytes()) / float64(cr.CapacityDiskTotal) * 100
+}
+
+// Percent of the cell memory capacity reserved by containers.  Over 100 is overcommitted.
+func MemoryCommitPercent(capacityMemoryTotal int64, memoryReserved uint64) float64 {
+	if capacityMemoryTotal <= 0 {
+		return -1
+	}
+	return float64(memoryReserved) / float64(capacityMemoryTotal) * 100
+}
+
+func MemoryDiscrepancy(capacityMemoryTotal, capacityMemoryRemaining int64, memoryReserved uint64) int64 {
+	return capacityMemoryRemaining - (capacityMemoryTotal - int64(memoryReserved))
+}
+
+func IsMemoryDiscrepancy(capacityMemoryTotal, capacityMemoryRemaining int64, memoryReserved uint64) bool {
+	if capacityMemoryTotal <= 0 {
+		return false
+	}
+	discrepancy := math.Abs(float64(MemoryDiscrepancy(capacityMemoryTotal, capacityMemoryRemaining, memoryReserved)))
+	return discrepancy > float64(capacityMemoryTotal)*config.CellMemoryDiscrepancyPercent/100
+}
+
+// Find all containers running on the given cell and total the resources they use
+func FindCellContainers(mdMgr *metadata.GlobalManager, appMap map[string]*eventApp.AppStats, cellStats *eventCell.CellStats) ([]*appDetailView.DisplayContainerStats, *CellResources) {
+
+	containerStatsArray := make([]*appDetailView.DisplayContainerStats, 0)
+	cellResources := NewCellResources(cellStats)
+
+	appStatsArray := eventApp.ConvertFromMap(appMap, mdMgr.GetAppMdManager())
+	for _, appStats := range appStatsArray {
+		appMetadata := mdMgr.GetAppMdManager().FindItem(appStats.AppId)
+		for _, containerStats := range appStats.AllContainers() {
+			if containerStats != nil {
+				if containerStats.Ip == cellStats.Ip {
+					// This is a container on the selected cell
+					displayContainerStats := appDetailView.NewDisplayContainerStats(containerStats, appStats)
+					displayContainerStats.AppName = appMetadata.Name
+
+					spaceMd := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
+					displayContainerStats.SpaceName = spaceMd.Name
+					org := mdMgr.GetOrgMdManager().FindItem(spaceMd.OrgGuid)
+					displayContainerStats.OrgName = org.Name
+
+					memoryMB, diskQuotaMB := mdMgr.FindContainerReservation(appMetadata, containerStats.ProcessType)
+					usedMemory := containerStats.ContainerMetric.GetMemoryBytes()
+					reservedMemory := uint64(memoryMB) * util.MEGABYTE
+					freeMemory := reservedMemory - usedMemory
+					displayContainerStats.FreeMemory = freeMemory
+					displayContainerStats.ReservedMemory = reservedMemory
+
+					usedDisk := containerStats.ContainerMetric.GetDiskBytes()
+					reservedDisk := uint64(diskQuotaMB) * util.MEGABYTE
+					freeDisk := reservedDisk - usedDisk
+					displayContainerStats.FreeDisk = freeDisk
+					displayContainerStats.ReservedDisk = reservedDisk
+
+					cellResources.addContainer(displayContainerStats)
+					containerStatsArray = append(containerStatsArray, displayContainerStats)
+				}
+			}
+		}
+	}
+
+	return containerStatsArray, cellResources
+}
+
+func findCellContainers(dataListView *dataView.DataListView, cellIp string) ([]*appDetailView.DisplayContainerStats, *CellResources) {
+	eventData := dataListView.GetDisplayedEventData()
+	cellStats := eventData.CellMap[cellIp]
+	if cellStats == nil {
+		cellStats = eventCell.NewCellStats(cellIp)
+	}
+	return FindCellContainers(dataListView.GetMdGlobalMgr(), eventData.AppMap, cellStats)
+}
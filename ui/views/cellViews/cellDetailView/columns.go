@@ -15,4 +15,94 @@
 
 package cellDetailView
 
-// EMPTY: We use class appDetailView.DisplayContainerStats and column defs from appDetailView package
+// Container list uses class appDetailView.DisplayContainerStats and column defs from appDetailView package.
+// The share columns below are specific to the cell detail view.
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appDetailView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func formatShare(share float64) string {
+	if share < 0 {
+		return fmt.Sprintf("%7v", "--")
+	}
+	return fmt.Sprintf("%7.1f", share)
+}
+
+func shareAttentionFunc(share float64) uiCommon.AttentionType {
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch {
+	case share >= 50:
+		attentionType = uiCommon.ATTENTION_HOT
+	case share >= 25:
+		attentionType = uiCommon.ATTENTION_WARM
+	}
+	return attentionType
+}
+
+func columnCpuShare() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*appDetailView.DisplayContainerStats).ContainerMetric.GetCpuPercentage() < c2.(*appDetailView.DisplayContainerStats).ContainerMetric.GetCpuPercentage()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return formatShare(columnOwner.(*CellDetailView).cellResources.CpuShare(stats))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return fmt.Sprintf("%v", stats.ContainerMetric.GetCpuPercentage())
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return shareAttentionFunc(columnOwner.(*CellDetailView).cellResources.CpuShare(stats))
+	}
+	c := uiCommon.NewListColumn("CPU_SHARE", "CPU_SHR%", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnMemoryShare() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*appDetailView.DisplayContainerStats).ContainerMetric.GetMemoryBytes() < c2.(*appDetailView.DisplayContainerStats).ContainerMetric.GetMemoryBytes()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return formatShare(columnOwner.(*CellDetailView).cellResources.MemoryShare(stats))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return fmt.Sprintf("%v", stats.ContainerMetric.GetMemoryBytes())
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return shareAttentionFunc(columnOwner.(*CellDetailView).cellResources.MemoryShare(stats))
+	}
+	c := uiCommon.NewListColumn("MEM_SHARE", "MEM_SHR%", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnDiskShare() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*appDetailView.DisplayContainerStats).ContainerMetric.GetDiskBytes() < c2.(*appDetailView.DisplayContainerStats).ContainerMetric.GetDiskBytes()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return formatShare(columnOwner.(*CellDetailView).cellResources.DiskShare(stats))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return fmt.Sprintf("%v", stats.ContainerMetric.GetDiskBytes())
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		stats := data.(*appDetailView.DisplayContainerStats)
+		return shareAttentionFunc(columnOwner.(*CellDetailView).cellResources.DiskShare(stats))
+	}
+	c := uiCommon.NewListColumn("DISK_SHARE", "DSK_SHR%", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
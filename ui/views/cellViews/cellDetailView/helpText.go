@@ -20,6 +20,13 @@ import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
 const HelpText = HelpOverviewText +
 	helpView.HelpHeaderText +
 	HelpColumnsText +
+	HelpLocalViewKeybindings +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpTextResource = HelpResourceOverviewText +
+	helpView.HelpHeaderText +
+	HelpResourceColumnsText +
 	helpView.HelpChildLevelDataViewKeybindings +
 	helpView.HelpCommonDataViewKeybindings
 
@@ -39,12 +46,52 @@ const HelpColumnsText = `
   SPACE - Space name
   ORG - Organization name
   CPU%% - Total CPU percent consumed by all containers on cell
+  CPU_SHR%% - Percent of the CPU used by all containers on cell used by this container
   MEM_RSVD - Total memory reserved by all containers on cell
   MEM_USED - Total memory actually in use by all containers
   MEM_FREE - Total memory actually in use by all containers
+  MEM_SHR%% - Percent of cell memory capacity used by this container
   DISK_RSVD - Total disk reserved by all containers on cell
   DISK_USED - Total disk actually in use by all containers
   DISK_FREE - Free Disk space in cell VM available for containers
+  DSK_SHR%% - Percent of cell disk capacity used by this container
   LOG_OUT - Number of stdout log events
   LOG_ERR - Number of stderr log events
 `
+
+const HelpLocalViewKeybindings = `
+**Display: **
+  b - Show cell resource breakdown (reserved vs used vs cell reported)
+`
+
+const HelpResourceOverviewText = `
+**Cell Resource Breakdown View**
+
+Resource breakdown reconciles the capacity reported by the diego cell
+with the resources reserved and used by the containers on the cell.
+Memory reserved is the app memory quota times the number of containers.
+Memory used is the total of container metric memory.
+
+A resource is flagged OVERCOMMIT when the containers reserve more then
+the cell capacity.  Memory is flagged DISCREPANCY when the remaining
+memory reported by the cell differs from the cell capacity less the
+reserved memory by more then 5%% of capacity.  This can happen during
+the warm-up period before all containers have reported metrics.
+`
+
+const HelpResourceColumnsText = `
+**Resource Columns:**
+
+  RESOURCE - CPU, MEMORY or DISK
+  STATUS - OK, OVERCOMMIT or DISCREPANCY
+  CAPACITY - Capacity reported by cell
+  RESERVED - Reserved by all containers on cell
+  CMT%% - Percent of capacity reserved by containers
+  USED - Used by all containers on cell
+  RMN_RPT - Remaining capacity reported by cell
+  RMN_CALC - Capacity less reserved
+  DIFF - Difference between RMN_RPT and RMN_CALC (memory only)
+  TOP_CONSUMER - Container using the most of the resource (app/index)
+  TOP_SHR%% - Percent of cell capacity used by top consumer (CPU is percent
+    of CPU used by all containers)
+`
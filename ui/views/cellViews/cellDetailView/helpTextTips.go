@@ -15,5 +15,8 @@
 
 package cellDetailView
 
-const HelpTextTips = `**x**:exit view  **o**:order  **f**:filter  **h**:help  **UP**/**DOWN** arrow to highlight row
+const HelpTextTips = `**x**:exit view  **b**:resources  **o**:order  **f**:filter  **h**:help  **UP**/**DOWN** arrow to highlight row
+**LEFT**/**RIGHT** arrow to scroll columns`
+
+const HelpTextTipsResource = `**x**:exit view  **o**:order  **h**:help  **UP**/**DOWN** arrow to highlight row
 **LEFT**/**RIGHT** arrow to scroll columns`
@@ -20,31 +20,38 @@ import (
 	"log"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellDetailView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/noisyNeighborView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
 
 type CellListView struct {
 	*dataView.DataListView
+	// If this is non-empty, we filter the cell list by the supplied isolation segment
+	// Used to support cells-by-isolation-segment view.
+	isoSegIdFilter string
 }
 
 func NewCellListView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
 	name string, bottomMargin int,
-	eventProcessor *eventdata.EventProcessor) *CellListView {
+	eventProcessor *eventdata.EventProcessor,
+	isoSegIdFilter string) *CellListView {
 
-	asUI := &CellListView{}
+	asUI := &CellListView{isoSegIdFilter: isoSegIdFilter}
 
 	defaultSortColumns := []*uiCommon.SortColumn{
 		uiCommon.NewSortColumn("CPU_PERCENT", true),
 		uiCommon.NewSortColumn("CELL_IP", false),
 	}
 
-	dataListView := dataView.NewDataListView(masterUI, nil,
+	dataListView := dataView.NewDataListView(masterUI, parentView,
 		name, 0, bottomMargin,
 		eventProcessor, asUI, asUI.columnDefinitions(),
 		defaultSortColumns)
@@ -53,9 +60,21 @@ func NewCellListView(masterUI masterUIInterface.MasterUIInterface,
 	dataListView.UpdateHeaderCallback = asUI.updateHeader
 	dataListView.GetListData = asUI.GetListData
 
-	dataListView.SetTitle(func() string { return "Cell List" })
+	titleFunc := func() string {
+		title := "Cell List"
+		if asUI.isoSegIdFilter != "" {
+			isoSegMd := eventProcessor.GetMetadataManager().GetIsoSegMdManager().FindItem(asUI.isoSegIdFilter)
+			title = fmt.Sprintf("%v in Isolation Segment %v", title, isoSegMd.Name)
+		}
+		return title
+	}
+	dataListView.SetTitle(titleFunc)
 	dataListView.HelpText = HelpText
-	dataListView.HelpTextTips = appView.HelpTextTips
+	if asUI.isoSegIdFilter == "" {
+		dataListView.HelpTextTips = appView.HelpTextTips
+	} else {
+		dataListView.HelpTextTips = appView.HelpTextTipsFiltered
+	}
 
 	asUI.DataListView = dataListView
 
@@ -68,20 +87,29 @@ func (asUI *CellListView) columnDefinitions() []*uiCommon.ListColumn {
 	columns = append(columns, columnCellIp())
 
 	columns = append(columns, columnTotalCpuPercentage())
+	columns = append(columns, columnNoisyNeighborSuspects())
 	columns = append(columns, columnTotalReportingContainers())
 
 	// ISSUE: numCPUS - PCF 2.6 no longer sending metric "numCPUS"
-	// columns = append(columns, columnNumOfCpus())
+	columns = append(columns, columnNumOfCpus())
+	columns = append(columns, columnHostCpuPercent())
+	columns = append(columns, columnHostCpuWait())
+	columns = append(columns, columnHostLoad1m())
+	columns = append(columns, columnHostMemPercent())
 
 	columns = append(columns, columnCapacityMemoryTotal())
 	columns = append(columns, columnCapacityMemoryRemaining())
 	columns = append(columns, columnTotalContainerMemoryReserved())
+	columns = append(columns, columnMemoryCommitPercent())
 	columns = append(columns, columnTotalContainerMemoryUsed())
+	columns = append(columns, columnMemoryDiscrepancy())
 
 	columns = append(columns, columnCapacityDiskTotal())
 	columns = append(columns, columnCapacityDiskRemaining())
 	columns = append(columns, columnTotalContainerDiskReserved())
 	columns = append(columns, columnTotalContainerDiskUsed())
+	columns = append(columns, columnHostDiskSystemPercent())
+	columns = append(columns, columnHostDiskEphemeralPercent())
 
 	columns = append(columns, columnCapacityTotalContainers())
 	columns = append(columns, columnContainerCount())
@@ -98,6 +126,15 @@ func (asUI *CellListView) columnDefinitions() []*uiCommon.ListColumn {
 
 func (asUI *CellListView) initializeCallback(g *gocui.Gui, viewName string) error {
 
+	if asUI.isoSegIdFilter != "" {
+		if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.CloseDetailView); err != nil {
+			log.Panicln(err)
+		}
+		if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.CloseDetailView); err != nil {
+			log.Panicln(err)
+		}
+	}
+
 	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
 		log.Panicln(err)
 	}
@@ -134,13 +171,16 @@ func (asUI *CellListView) postProcessData() map[string]*DisplayCellStats {
 
 	displayCellMap := make(map[string]*DisplayCellStats)
 	for ip, cellStats := range cellMap {
+		if asUI.isoSegIdFilter != "" && isolationSegment.ResolveGuid(cellStats.IsolationSegmentGuid) != asUI.isoSegIdFilter {
+			continue
+		}
 		displayCellStat := NewDisplayCellStats(cellStats)
 		displayCellMap[ip] = displayCellStat
 	}
 
 	appMap := asUI.GetDisplayedEventData().AppMap
 	for _, appStats := range appMap {
-		for _, containerStats := range appStats.ContainerArray {
+		for _, containerStats := range appStats.AllContainers() {
 			if containerStats != nil {
 				displayCellStat := displayCellMap[containerStats.Ip]
 
@@ -172,12 +212,13 @@ func (asUI *CellListView) postProcessData() map[string]*DisplayCellStats {
 						cpuValue := containerStats.ContainerMetric.GetCpuPercentage()
 						displayCellStat.TotalContainerCpuPercentage = displayCellStat.TotalContainerCpuPercentage + cpuValue
 
-						displayCellStat.TotalContainerMemoryReserved = displayCellStat.TotalContainerMemoryReserved + uint64(appMetadata.MemoryMB*util.MEGABYTE)
+						memoryMB, diskQuotaMB := asUI.GetMdGlobalMgr().FindContainerReservation(appMetadata, containerStats.ProcessType)
+						displayCellStat.TotalContainerMemoryReserved = displayCellStat.TotalContainerMemoryReserved + uint64(memoryMB*util.MEGABYTE)
 
 						usedMemoryValue := containerStats.ContainerMetric.GetMemoryBytes()
 						displayCellStat.TotalContainerMemoryUsed = displayCellStat.TotalContainerMemoryUsed + usedMemoryValue
 
-						displayCellStat.TotalContainerDiskReserved = displayCellStat.TotalContainerDiskReserved + uint64(appMetadata.DiskQuotaMB*util.MEGABYTE)
+						displayCellStat.TotalContainerDiskReserved = displayCellStat.TotalContainerDiskReserved + uint64(diskQuotaMB*util.MEGABYTE)
 
 						usedDiskValue := containerStats.ContainerMetric.GetDiskBytes()
 						displayCellStat.TotalContainerDiskUsed = displayCellStat.TotalContainerDiskUsed + usedDiskValue
@@ -187,6 +228,14 @@ func (asUI *CellListView) postProcessData() map[string]*DisplayCellStats {
 		}
 	}
 
+	suspectMap := noisyNeighborView.FindNoisyNeighbors(asUI.GetMdGlobalMgr(), appMap)
+	for ip, suspects := range suspectMap {
+		displayCellStat := displayCellMap[ip]
+		if displayCellStat != nil {
+			displayCellStat.NoisyNeighborSuspects = len(suspects)
+		}
+	}
+
 	return displayCellMap
 }
 
@@ -20,6 +20,7 @@ import (
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellDetailView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 )
 
@@ -37,7 +38,7 @@ func columnCellIp() *uiCommon.ListColumn {
 		return cellStats.Ip
 	}
 	c := uiCommon.NewListColumn("CELL_IP", "CELL_IP", defaultColSize,
-		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, noisyNeighborAttentionFunc)
 	return c
 }
 
@@ -122,7 +123,7 @@ func columnTotalReportingContainers() *uiCommon.ListColumn {
 	return c
 }
 
-/* ISSUE: numCPUS - PCF 2.6 no longer sending metric "numCPUS"
+// ISSUE: numCPUS - PCF 2.6 no longer sending metric "numCPUS" -- only shown if mapped to a metric that is sent
 func columnNumOfCpus() *uiCommon.ListColumn {
 	defaultColSize := 4
 	sortFunc := func(c1, c2 util.Sortable) bool {
@@ -146,7 +147,6 @@ func columnNumOfCpus() *uiCommon.ListColumn {
 		uiCommon.NUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
 	return c
 }
-*/
 
 func columnCapacityMemoryTotal() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
@@ -168,6 +168,7 @@ func columnCapacityMemoryTotal() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_TOT", "MEM_TOT", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -205,6 +206,7 @@ func columnCapacityMemoryRemaining() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_FREE", "MEM_FREE", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -228,6 +230,7 @@ func columnCapacityDiskTotal() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("DISK_TOT", "DISK_TOT", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -265,6 +268,7 @@ func columnCapacityDiskRemaining() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("DISK_FREE", "DISK_FREE", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -336,6 +340,7 @@ func columnTotalContainerMemoryReserved() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("C_MEM_RSVD", "C_MEM_RSVD", 10,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -359,6 +364,7 @@ func columnTotalContainerMemoryUsed() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("C_MEM_USD", "C_MEM_USD", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -382,6 +388,7 @@ func columnTotalContainerDiskReserved() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("C_DSK_RSVD", "C_DSK_RSVD", 10,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -405,6 +412,7 @@ func columnTotalContainerDiskUsed() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("C_DSK_USD", "C_DSK_USD", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -506,3 +514,178 @@ func columnJobIndex() *uiCommon.ListColumn {
 		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
 	return c
 }
+
+func columnMemoryCommitPercent() *uiCommon.ListColumn {
+	commitPercent := func(cellStats *DisplayCellStats) float64 {
+		return cellDetailView.MemoryCommitPercent(cellStats.CapacityMemoryTotal, cellStats.TotalContainerMemoryReserved)
+	}
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return commitPercent(c1.(*DisplayCellStats)) < commitPercent(c2.(*DisplayCellStats))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellStats := data.(*DisplayCellStats)
+		percent := commitPercent(cellStats)
+		if percent < 0 {
+			return fmt.Sprintf("%8v", "--")
+		}
+		return fmt.Sprintf("%8.1f", percent)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellStats := data.(*DisplayCellStats)
+		return fmt.Sprintf("%v", commitPercent(cellStats))
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		cellStats := data.(*DisplayCellStats)
+		percent := commitPercent(cellStats)
+		attentionType := uiCommon.ATTENTION_NORMAL
+		switch {
+		case percent > 100:
+			attentionType = uiCommon.ATTENTION_HOT
+		case percent >= 90:
+			attentionType = uiCommon.ATTENTION_WARM
+		}
+		return attentionType
+	}
+	c := uiCommon.NewListColumn("MEM_COMMIT_PERCENT", "MEM_CMT%", 8,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_AVG)
+	return c
+}
+
+func columnMemoryDiscrepancy() *uiCommon.ListColumn {
+	discrepancy := func(cellStats *DisplayCellStats) int64 {
+		return cellDetailView.MemoryDiscrepancy(cellStats.CapacityMemoryTotal, cellStats.CapacityMemoryRemaining, cellStats.TotalContainerMemoryReserved)
+	}
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return discrepancy(c1.(*DisplayCellStats)) < discrepancy(c2.(*DisplayCellStats))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellStats := data.(*DisplayCellStats)
+		if cellStats.CapacityMemoryTotal == 0 {
+			return fmt.Sprintf("%9v", "--")
+		}
+		sign := "+"
+		diff := discrepancy(cellStats)
+		if diff < 0 {
+			sign = "-"
+			diff = -diff
+		}
+		return fmt.Sprintf("%9v", sign+util.ByteSize(diff).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellStats := data.(*DisplayCellStats)
+		return fmt.Sprintf("%v", discrepancy(cellStats))
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		cellStats := data.(*DisplayCellStats)
+		if cellDetailView.IsMemoryDiscrepancy(cellStats.CapacityMemoryTotal, cellStats.CapacityMemoryRemaining, cellStats.TotalContainerMemoryReserved) {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("MEM_DIFF", "MEM_DIFF", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
+	return c
+}
+
+// Cells with noisy neighbor suspects are highlighted
+func noisyNeighborAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	cellStats := data.(*DisplayCellStats)
+	if cellStats.NoisyNeighborSuspects > 0 {
+		return uiCommon.ATTENTION_HOT
+	}
+	return uiCommon.ATTENTION_NORMAL
+}
+
+func columnNoisyNeighborSuspects() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellStats).NoisyNeighborSuspects < c2.(*DisplayCellStats).NoisyNeighborSuspects
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellStats := data.(*DisplayCellStats)
+		if cellStats.NoisyNeighborSuspects == 0 {
+			return fmt.Sprintf("%5v", "--")
+		}
+		return fmt.Sprintf("%5v", cellStats.NoisyNeighborSuspects)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellStats := data.(*DisplayCellStats)
+		return fmt.Sprintf("%v", cellStats.NoisyNeighborSuspects)
+	}
+	c := uiCommon.NewListColumn("NOISY", "NOISY", 5,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, noisyNeighborAttentionFunc)
+	return c
+}
+
+func hostPercentAttentionFunc(percent float64) uiCommon.AttentionType {
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch {
+	case percent >= 90:
+		attentionType = uiCommon.ATTENTION_HOT
+	case percent >= 80:
+		attentionType = uiCommon.ATTENTION_WARM
+	}
+	return attentionType
+}
+
+// Column for a host (BOSH system) metric.  Displays "--" if the cell has not reported host metrics
+func columnHostMetric(id, label string, size int, valueFunc func(cellStats *DisplayCellStats) float64, isPercent bool) *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return valueFunc(c1.(*DisplayCellStats)) < valueFunc(c2.(*DisplayCellStats))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellStats := data.(*DisplayCellStats)
+		if !cellStats.HostMetricsReported {
+			return fmt.Sprintf("%*v", size, "--")
+		}
+		if isPercent {
+			return fmt.Sprintf("%*.1f", size, valueFunc(cellStats))
+		}
+		return fmt.Sprintf("%*.2f", size, valueFunc(cellStats))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellStats := data.(*DisplayCellStats)
+		return fmt.Sprintf("%v", valueFunc(cellStats))
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		cellStats := data.(*DisplayCellStats)
+		if !cellStats.HostMetricsReported || !isPercent {
+			return uiCommon.ATTENTION_NORMAL
+		}
+		return hostPercentAttentionFunc(valueFunc(cellStats))
+	}
+	c := uiCommon.NewListColumn(id, label, size,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnHostCpuPercent() *uiCommon.ListColumn {
+	return columnHostMetric("HOST_CPU", "H_CPU%", 6,
+		func(cellStats *DisplayCellStats) float64 { return cellStats.HostCpuUser + cellStats.HostCpuSys }, true)
+}
+
+func columnHostCpuWait() *uiCommon.ListColumn {
+	return columnHostMetric("HOST_CPU_WAIT", "H_WAIT%", 7,
+		func(cellStats *DisplayCellStats) float64 { return cellStats.HostCpuWait }, true)
+}
+
+func columnHostLoad1m() *uiCommon.ListColumn {
+	return columnHostMetric("HOST_LOAD", "H_LOAD", 6,
+		func(cellStats *DisplayCellStats) float64 { return cellStats.HostLoad1m }, false)
+}
+
+func columnHostMemPercent() *uiCommon.ListColumn {
+	return columnHostMetric("HOST_MEM", "H_MEM%", 6,
+		func(cellStats *DisplayCellStats) float64 { return cellStats.HostMemPercent }, true)
+}
+
+func columnHostDiskSystemPercent() *uiCommon.ListColumn {
+	return columnHostMetric("HOST_DISK_SYSTEM", "H_SDSK%", 7,
+		func(cellStats *DisplayCellStats) float64 { return cellStats.HostDiskSystemPercent }, true)
+}
+
+func columnHostDiskEphemeralPercent() *uiCommon.ListColumn {
+	return columnHostMetric("HOST_DISK_EPHEMERAL", "H_EDSK%", 7,
+		func(cellStats *DisplayCellStats) float64 { return cellStats.HostDiskEphemeralPercent }, true)
+}
@@ -31,6 +31,8 @@ type DisplayCellStats struct {
 	TotalLogOutCount             int64
 	TotalLogErrCount             int64
 
+	NoisyNeighborSuspects int
+
 	CapacityPlan0_5GMem int
 	CapacityPlan1_0GMem int
 	CapacityPlan1_5GMem int
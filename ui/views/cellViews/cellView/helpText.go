@@ -31,20 +31,35 @@ const HelpColumnsText = `
 
   CELL_IP - IP address of Cloud Foundry diego cell
   CPU%% - CPU percent consumed by all containers on cell
+  NOISY - Number of noisy neighbor suspects on cell (see Noisy Neighbors view)
   RCR - Reporting containers
-  CPUS - Number of CPUs in cell VM
+  CPUS - Number of CPUs in cell VM (only if cell sends numCPUS metric)
+  H_CPU%% - Host CPU percent (user + sys) of the cell VM *
+  H_WAIT%% - Host CPU percent waiting on I/O *
+  H_LOAD - Host 1 minute load average *
+  H_MEM%% - Host memory percent used *
   MEM_TOT - Total Memory in cell VM available for containers
   MEM_FREE - Free Memory in cell VM available for containers
   C_MEM_RSVD - Memory reserved by all containers on cell
+  MEM_CMT%% - Percent of cell memory reserved by containers (over 100 is overcommitted)
   C_MEM_USD - Memory actually in use by all containers
+  MEM_DIFF - Difference between MEM_FREE reported by cell and MEM_TOT less
+    C_MEM_RSVD. Highlighted if more then 5%% of MEM_TOT.
   DISK_TOT - Total Disk space in cell VM
   DISK_FREE - Free Disk space in cell VM available for containers
   C_DSK_RSVD - Total disk reserved by all containers on cell
   C_DSK_USD - Total disk actually in use by all containers
+  H_SDSK%% - Host system disk percent used *
+  H_EDSK%% - Host ephemeral disk percent used *
   MAX_CNTR - Max containers a cell can handle
   CNTRS - Number of containers running on cell reported by cell
   ISO_SEG - Isolation Segment cell belongs to
   DNAME - BOSH deployment name
   JOB_NAME - BOSH job name
   JOB_IDX - BOSH job index
+
+* Host metrics are only available if BOSH system metrics are sent to the
+firehose (bosh-system-metrics-forwarder or system metrics agent).
+Metric names can be mapped with the -cell-metrics option, a JSON file of
+[{"origin":"rep","name":"numCPUS","metric":"NumOfCpus"}, ...]
 `
@@ -0,0 +1,246 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package noisyNeighborView
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func suspectAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	return uiCommon.ATTENTION_HOT
+}
+
+func formatCpuPercentage(cpuPercentage float64, size int) string {
+	switch {
+	case cpuPercentage >= 100.0:
+		return fmt.Sprintf("%*.0f", size, cpuPercentage)
+	case cpuPercentage >= 10.0:
+		return fmt.Sprintf("%*.1f", size, cpuPercentage)
+	}
+	return fmt.Sprintf("%*.2f", size, cpuPercentage)
+}
+
+func victimNames(suspect *NoisyNeighborSuspect) string {
+	names := make([]string, 0, len(suspect.Victims))
+	for _, victim := range suspect.Victims {
+		names = append(names, fmt.Sprintf("%v/%v", victim.AppName, victim.ContainerIndex))
+	}
+	return strings.Join(names, ",")
+}
+
+func columnCellIp() *uiCommon.ListColumn {
+	defaultColSize := 16
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.Ip2long(c1.(*NoisyNeighborSuspect).CellIp) < util.Ip2long(c2.(*NoisyNeighborSuspect).CellIp)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return util.FormatDisplayData(stats.CellIp, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return stats.CellIp
+	}
+	c := uiCommon.NewListColumn("CELL_IP", "CELL_IP", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnAppName() *uiCommon.ListColumn {
+	defaultColSize := 30
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*NoisyNeighborSuspect).AppName, c2.(*NoisyNeighborSuspect).AppName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return util.FormatDisplayData(stats.AppName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return stats.AppName
+	}
+	c := uiCommon.NewListColumn("appName", "SUSPECT_APP", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, suspectAttentionFunc)
+	return c
+}
+
+func columnContainerIndex() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*NoisyNeighborSuspect).ContainerIndex < c2.(*NoisyNeighborSuspect).ContainerIndex
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%3v", stats.ContainerIndex)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%v", stats.ContainerIndex)
+	}
+	c := uiCommon.NewListColumn("IDX", "IDX", 3,
+		uiCommon.NUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, nil)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
+	return c
+}
+
+func columnSpaceName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*NoisyNeighborSuspect).SpaceName, c2.(*NoisyNeighborSuspect).SpaceName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return util.FormatDisplayData(stats.SpaceName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return stats.SpaceName
+	}
+	c := uiCommon.NewListColumn("SPACE", "SPACE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnOrgName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*NoisyNeighborSuspect).OrgName, c2.(*NoisyNeighborSuspect).OrgName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return util.FormatDisplayData(stats.OrgName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return stats.OrgName
+	}
+	c := uiCommon.NewListColumn("ORG", "ORG", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCpuPercentage() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*NoisyNeighborSuspect).CpuPercentage < c2.(*NoisyNeighborSuspect).CpuPercentage
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return formatCpuPercentage(stats.CpuPercentage, 6)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%v", stats.CpuPercentage)
+	}
+	c := uiCommon.NewListColumn("CPU_PERCENT", "CPU%", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, suspectAttentionFunc)
+	return c
+}
+
+func columnCpuShare() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*NoisyNeighborSuspect).CpuShare < c2.(*NoisyNeighborSuspect).CpuShare
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%8.1f", stats.CpuShare)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%v", stats.CpuShare)
+	}
+	c := uiCommon.NewListColumn("CPU_SHARE", "CPU_SHR%", 8,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCellCpuPercentage() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*NoisyNeighborSuspect).CellCpuPercentage < c2.(*NoisyNeighborSuspect).CellCpuPercentage
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return formatCpuPercentage(stats.CellCpuPercentage, 9)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%v", stats.CellCpuPercentage)
+	}
+	c := uiCommon.NewListColumn("CELL_CPU_PERCENT", "CELL_CPU%", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnVictimCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return len(c1.(*NoisyNeighborSuspect).Victims) < len(c2.(*NoisyNeighborSuspect).Victims)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%7v", len(stats.Victims))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%v", len(stats.Victims))
+	}
+	c := uiCommon.NewListColumn("VICTIMS", "VICTIMS", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnMaxLatencyIncrease() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*NoisyNeighborSuspect).MaxLatencyIncrease < c2.(*NoisyNeighborSuspect).MaxLatencyIncrease
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%10v", fmt.Sprintf("x%.1f", stats.MaxLatencyIncrease))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return fmt.Sprintf("%v", stats.MaxLatencyIncrease)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		stats := data.(*NoisyNeighborSuspect)
+		if stats.MaxLatencyIncrease >= 2*config.NoisyNeighborLatencyFactor {
+			return uiCommon.ATTENTION_HOT
+		}
+		return uiCommon.ATTENTION_WARM
+	}
+	c := uiCommon.NewListColumn("MAX_LATENCY_INCREASE", "MAX_LAT_X", 10,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnVictims() *uiCommon.ListColumn {
+	defaultColSize := 50
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(victimNames(c1.(*NoisyNeighborSuspect)), victimNames(c2.(*NoisyNeighborSuspect)))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return util.FormatDisplayData(victimNames(stats), defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*NoisyNeighborSuspect)
+		return victimNames(stats)
+	}
+	c := uiCommon.NewListColumn("VICTIM_APPS", "VICTIM_APPS", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package noisyNeighborView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Noisy Neighbor View**
+
+Noisy neighbor view lists containers suspected of slowing down other
+apps on the same diego cell.  A container is a suspect when:
+  - It uses at least 80%% CPU, and
+  - It uses at least 40%% of the CPU used by all containers on its cell, and
+  - A container of another app on the same cell has a last 10 second
+    average response time at least 1.5 times its last 60 second average
+
+Cells with suspects are highlighted in the cell list view.
+`
+
+const HelpColumnsText = `
+**Columns:**
+
+  CELL_IP - IP address of diego cell
+  SUSPECT_APP - Application of the suspect container
+  IDX - Suspect container index
+  SPACE - Space name
+  ORG - Organization name
+  CPU%% - CPU percent consumed by the suspect container
+  CPU_SHR%% - Percent of CPU used by all containers on cell used by suspect
+  CELL_CPU%% - CPU percent consumed by all containers on cell
+  VICTIMS - Number of containers of other apps with increased response time
+  MAX_LAT_X - Largest response time increase (last 10 sec vs last 60 sec)
+  VICTIM_APPS - Victim containers (app/index)
+`
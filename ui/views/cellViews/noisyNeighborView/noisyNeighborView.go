@@ -0,0 +1,83 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package noisyNeighborView
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+)
+
+type NoisyNeighborView struct {
+	*dataView.DataListView
+}
+
+func NewNoisyNeighborView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *NoisyNeighborView {
+
+	asUI := &NoisyNeighborView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("MAX_LATENCY_INCREASE", true),
+		uiCommon.NewSortColumn("CPU_PERCENT", true),
+		uiCommon.NewSortColumn("CELL_IP", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string { return "Noisy Neighbor Suspects" })
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *NoisyNeighborView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnCellIp())
+	columns = append(columns, columnAppName())
+	columns = append(columns, columnContainerIndex())
+	columns = append(columns, columnSpaceName())
+	columns = append(columns, columnOrgName())
+	columns = append(columns, columnCpuPercentage())
+	columns = append(columns, columnCpuShare())
+	columns = append(columns, columnCellCpuPercentage())
+	columns = append(columns, columnVictimCount())
+	columns = append(columns, columnMaxLatencyIncrease())
+	columns = append(columns, columnVictims())
+	return columns
+}
+
+func (asUI *NoisyNeighborView) GetListData() []uiCommon.IData {
+	appMap := asUI.GetDisplayedEventData().AppMap
+	suspectMap := FindNoisyNeighbors(asUI.GetMdGlobalMgr(), appMap)
+	listData := make([]uiCommon.IData, 0)
+	for _, suspects := range suspectMap {
+		for _, suspect := range suspects {
+			listData = append(listData, suspect)
+		}
+	}
+	return listData
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package noisyNeighborView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
+)
+
+// A container of another app on the same cell whose response time increased
+// while the suspect was using a large share of the cell CPU
+type NoisyNeighborVictim struct {
+	AppId              string
+	AppName            string
+	ContainerIndex     int
+	AvgResponseL10Time float64
+	AvgResponseL60Time float64
+}
+
+// Increase of the last 10 second average response time over the last 60 second average
+func (v *NoisyNeighborVictim) LatencyIncrease() float64 {
+	return v.AvgResponseL10Time / v.AvgResponseL60Time
+}
+
+type NoisyNeighborSuspect struct {
+	CellIp         string
+	AppId          string
+	AppName        string
+	SpaceName      string
+	OrgName        string
+	ContainerIndex int
+
+	CpuPercentage float64
+	// Percent of the CPU used by all containers on the cell
+	CpuShare          float64
+	CellCpuPercentage float64
+
+	Victims            []*NoisyNeighborVictim
+	MaxLatencyIncrease float64
+
+	key string
+}
+
+func (s *NoisyNeighborSuspect) Id() string {
+	if s.key == "" {
+		s.key = fmt.Sprintf("%v/%v/%v", s.CellIp, s.AppId, s.ContainerIndex)
+	}
+	return s.key
+}
+
+type cellContainer struct {
+	appId          string
+	containerIndex int
+	cpuPercentage  float64
+	traffic        *eventApp.TrafficStats
+}
+
+// Correlate container CPU usage on each cell with response time increases of
+// other apps on the same cell.  Returns the noisy neighbor suspects by cell IP.
+func FindNoisyNeighbors(mdMgr *metadata.GlobalManager, appMap map[string]*eventApp.AppStats) map[string][]*NoisyNeighborSuspect {
+
+	cellContainerMap := make(map[string][]*cellContainer)
+	cellCpuMap := make(map[string]float64)
+
+	for _, appStats := range appMap {
+		trafficByIndex := make(map[int]*eventApp.TrafficStats)
+		for _, containerTraffic := range appStats.ContainerTrafficMap {
+			trafficByIndex[int(containerTraffic.InstanceIndex)] = containerTraffic
+		}
+		for _, containerStats := range appStats.AllContainers() {
+			if containerStats == nil || containerStats.ContainerMetric == nil {
+				continue
+			}
+			container := &cellContainer{
+				appId:          appStats.AppId,
+				containerIndex: containerStats.ContainerIndex,
+				cpuPercentage:  containerStats.ContainerMetric.GetCpuPercentage(),
+				traffic:        trafficByIndex[containerStats.ContainerIndex],
+			}
+			cellContainerMap[containerStats.Ip] = append(cellContainerMap[containerStats.Ip], container)
+			cellCpuMap[containerStats.Ip] = cellCpuMap[containerStats.Ip] + container.cpuPercentage
+		}
+	}
+
+	suspectMap := make(map[string][]*NoisyNeighborSuspect)
+	for cellIp, containers := range cellContainerMap {
+		cellCpu := cellCpuMap[cellIp]
+		if cellCpu <= 0 {
+			continue
+		}
+		for _, container := range containers {
+			cpuShare := container.cpuPercentage / cellCpu * 100
+			if container.cpuPercentage < config.NoisyNeighborMinCpuPercent || cpuShare < config.NoisyNeighborMinCpuShare {
+				continue
+			}
+			victims := findVictims(mdMgr, container, containers)
+			if len(victims) == 0 {
+				continue
+			}
+
+			appMetadata := mdMgr.GetAppMdManager().FindItem(container.appId)
+			spaceMd := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
+			orgMd := mdMgr.GetOrgMdManager().FindItem(spaceMd.OrgGuid)
+
+			suspect := &NoisyNeighborSuspect{
+				CellIp:            cellIp,
+				AppId:             container.appId,
+				AppName:           appMetadata.Name,
+				SpaceName:         spaceMd.Name,
+				OrgName:           orgMd.Name,
+				ContainerIndex:    container.containerIndex,
+				CpuPercentage:     container.cpuPercentage,
+				CpuShare:          cpuShare,
+				CellCpuPercentage: cellCpu,
+				Victims:           victims,
+			}
+			for _, victim := range victims {
+				if victim.LatencyIncrease() > suspect.MaxLatencyIncrease {
+					suspect.MaxLatencyIncrease = victim.LatencyIncrease()
+				}
+			}
+			suspectMap[cellIp] = append(suspectMap[cellIp], suspect)
+		}
+	}
+	return suspectMap
+}
+
+func findVictims(mdMgr *metadata.GlobalManager, suspect *cellContainer, containers []*cellContainer) []*NoisyNeighborVictim {
+	victims := make([]*NoisyNeighborVictim, 0)
+	for _, container := range containers {
+		if container.appId == suspect.appId || container.traffic == nil {
+			continue
+		}
+		traffic := container.traffic
+		if traffic.EventL10Rate == 0 || traffic.AvgResponseL60Time <= 0 {
+			continue
+		}
+		if traffic.AvgResponseL10Time < traffic.AvgResponseL60Time*config.NoisyNeighborLatencyFactor {
+			continue
+		}
+		victim := &NoisyNeighborVictim{
+			AppId:              container.appId,
+			AppName:            mdMgr.GetAppMdManager().FindItem(container.appId).Name,
+			ContainerIndex:     container.containerIndex,
+			AvgResponseL10Time: traffic.AvgResponseL10Time,
+			AvgResponseL60Time: traffic.AvgResponseL60Time,
+		}
+		victims = append(victims, victim)
+	}
+	return victims
+}
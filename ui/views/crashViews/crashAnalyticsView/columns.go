@@ -0,0 +1,249 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashAnalyticsView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func crashLoopAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	crashGroup := data.(*DisplayCrashGroup)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	if crashGroup.CrashLoopCount > 0 {
+		attentionType = uiCommon.ATTENTION_HOT
+	} else if crashGroup.Crash1hCount > 0 {
+		attentionType = uiCommon.ATTENTION_WARM
+	}
+	return attentionType
+}
+
+func columnName() *uiCommon.ListColumn {
+	defaultColSize := 40
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayCrashGroup).Name, c2.(*DisplayCrashGroup).Name)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return util.FormatDisplayData(crashGroup.Name, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return crashGroup.Name
+	}
+	c := uiCommon.NewListColumn("NAME", "NAME", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, crashLoopAttentionFunc)
+	return c
+}
+
+func columnCrash1hCount() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).Crash1hCount < c2.(*DisplayCrashGroup).Crash1hCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%6v", util.Format(int64(crashGroup.Crash1hCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%v", crashGroup.Crash1hCount)
+	}
+	c := uiCommon.NewListColumn("CRH_1H", "CRH_1H", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, crashLoopAttentionFunc)
+	return c
+}
+
+func columnCrash24hCount() *uiCommon.ListColumn {
+	defaultColSize := 7
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).Crash24hCount < c2.(*DisplayCrashGroup).Crash24hCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%7v", util.Format(int64(crashGroup.Crash24hCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%v", crashGroup.Crash24hCount)
+	}
+	c := uiCommon.NewListColumn("CRH_24H", "CRH_24H", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCrashLoopCount() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).CrashLoopCount < c2.(*DisplayCrashGroup).CrashLoopCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%5v", util.Format(int64(crashGroup.CrashLoopCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%v", crashGroup.CrashLoopCount)
+	}
+	c := uiCommon.NewListColumn("LOOPS", "LOOPS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, crashLoopAttentionFunc)
+	return c
+}
+
+func columnAppCount() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).AppCount < c2.(*DisplayCrashGroup).AppCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%5v", util.Format(int64(crashGroup.AppCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%v", crashGroup.AppCount)
+	}
+	c := uiCommon.NewListColumn("APPS", "APPS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnOrgCount() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).OrgCount < c2.(*DisplayCrashGroup).OrgCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%5v", util.Format(int64(crashGroup.OrgCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%v", crashGroup.OrgCount)
+	}
+	c := uiCommon.NewListColumn("ORGS", "ORGS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCellCount() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).CellCount < c2.(*DisplayCrashGroup).CellCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%5v", util.Format(int64(crashGroup.CellCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%v", crashGroup.CellCount)
+	}
+	c := uiCommon.NewListColumn("CELLS", "CELLS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTopAppName() *uiCommon.ListColumn {
+	defaultColSize := 25
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayCrashGroup).TopAppName, c2.(*DisplayCrashGroup).TopAppName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return util.FormatDisplayData(crashGroup.TopAppName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return crashGroup.TopAppName
+	}
+	c := uiCommon.NewListColumn("TOP_APP", "TOP_APP", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTopAppPercent() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).TopAppPercent < c2.(*DisplayCrashGroup).TopAppPercent
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%5.0f", crashGroup.TopAppPercent)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%.0f", crashGroup.TopAppPercent)
+	}
+	c := uiCommon.NewListColumn("TOP_APP_PERCENT", "APP%", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTopCellIp() *uiCommon.ListColumn {
+	defaultColSize := 16
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayCrashGroup).TopCellIp, c2.(*DisplayCrashGroup).TopCellIp)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return util.FormatDisplayData(crashGroup.TopCellIp, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return crashGroup.TopCellIp
+	}
+	c := uiCommon.NewListColumn("TOP_CELL", "TOP_CELL", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTopCellPercent() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).TopCellPercent < c2.(*DisplayCrashGroup).TopCellPercent
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%5.0f", crashGroup.TopCellPercent)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%.0f", crashGroup.TopCellPercent)
+	}
+	c := uiCommon.NewListColumn("TOP_CELL_PERCENT", "CELL%", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnLastCrashTime() *uiCommon.ListColumn {
+	defaultColSize := 19
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCrashGroup).LastCrashTime.Before(*c2.(*DisplayCrashGroup).LastCrashTime)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%19v", crashGroup.LastCrashTime.Local().Format("01-02-2006 15:04:05"))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		crashGroup := data.(*DisplayCrashGroup)
+		return fmt.Sprintf("%v", crashGroup.LastCrashTime.UnixNano())
+	}
+	c := uiCommon.NewListColumn("LAST_CRASH", "LAST_CRASH", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashAnalyticsView
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+)
+
+const (
+	GROUP_BY_EXIT_STATUS = "EXIT_STATUS"
+	GROUP_BY_APP         = "APP"
+	GROUP_BY_ORG         = "ORG"
+	GROUP_BY_CELL        = "CELL"
+)
+
+// A single container crash with the names needed for grouping
+type crashRecord struct {
+	appId          string
+	appName        string
+	orgName        string
+	cellIp         string
+	exitStatus     string
+	containerIndex int
+	crashTime      time.Time
+}
+
+func (cr *crashRecord) instanceKey() string {
+	return fmt.Sprintf("%v|%v", cr.appId, cr.containerIndex)
+}
+
+func (cr *crashRecord) groupName(groupBy string) string {
+	switch groupBy {
+	case GROUP_BY_APP:
+		return cr.appName
+	case GROUP_BY_ORG:
+		return cr.orgName
+	case GROUP_BY_CELL:
+		return cr.cellIp
+	default:
+		return cr.exitStatus
+	}
+}
+
+// Collect all crashes in the last 24 hours -- crashes that occurred before top was
+// started come from the cloud controller events API, later crashes are captured live
+func collectCrashRecords(mdGlobalMgr *metadata.GlobalManager, appMap map[string]*eventApp.AppStats) []*crashRecord {
+	crashRecords := make([]*crashRecord, 0)
+	for appId, appStats := range appMap {
+		crashInfoList := crashData.FindSinceByApp(appId, -24*time.Hour)
+		crashInfoList = append(crashInfoList, appStats.CrashSince(-24*time.Hour)...)
+		if len(crashInfoList) == 0 {
+			continue
+		}
+
+		appMetadata := mdGlobalMgr.GetAppMdManager().FindItem(appId)
+		spaceMetadata := mdGlobalMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
+		orgMetadata := mdGlobalMgr.GetOrgMdManager().FindItem(spaceMetadata.OrgGuid)
+
+		for _, crashInfo := range crashInfoList {
+			if crashInfo == nil || crashInfo.CrashTime == nil {
+				continue
+			}
+			cellIp := crashInfo.CellIp
+			if cellIp == "" {
+				cellIp = crashData.UnknownName
+			}
+			record := &crashRecord{
+				appId:          appId,
+				appName:        appMetadata.Name,
+				orgName:        orgMetadata.Name,
+				cellIp:         cellIp,
+				exitStatus:     crashData.ExtractExitStatusFromExitDescription(crashInfo.ExitDescription),
+				containerIndex: crashInfo.ContainerIndex,
+				crashTime:      *crashInfo.CrashTime,
+			}
+			crashRecords = append(crashRecords, record)
+		}
+	}
+	return crashRecords
+}
+
+// Find the container instances (app + index) that are in a crash loop
+func findCrashLoops(crashRecords []*crashRecord, now time.Time) map[string]bool {
+	loopWindowStart := now.Add(-config.CrashLoopWindowSeconds * time.Second)
+	recentCrashCount := make(map[string]int)
+	for _, record := range crashRecords {
+		if record.crashTime.After(loopWindowStart) {
+			recentCrashCount[record.instanceKey()]++
+		}
+	}
+	crashLoops := make(map[string]bool)
+	for instanceKey, count := range recentCrashCount {
+		if count >= config.CrashLoopMinCrashes {
+			crashLoops[instanceKey] = true
+		}
+	}
+	return crashLoops
+}
+
+// Group the crashes by the given dimension
+func groupCrashRecords(crashRecords []*crashRecord, groupBy string, now time.Time) map[string]*DisplayCrashGroup {
+
+	crashLoops := findCrashLoops(crashRecords, now)
+	oneHourAgo := now.Add(-1 * time.Hour)
+
+	recordsByGroup := make(map[string][]*crashRecord)
+	for _, record := range crashRecords {
+		name := record.groupName(groupBy)
+		recordsByGroup[name] = append(recordsByGroup[name], record)
+	}
+
+	displayGroupMap := make(map[string]*DisplayCrashGroup)
+	for name, records := range recordsByGroup {
+		crashGroup := NewDisplayCrashGroup(groupBy, name)
+
+		appCrashCount := make(map[string]int)
+		appNames := make(map[string]string)
+		orgs := make(map[string]bool)
+		cellCrashCount := make(map[string]int)
+		loopingInstances := make(map[string]bool)
+		for _, record := range records {
+			crashGroup.Crash24hCount++
+			if record.crashTime.After(oneHourAgo) {
+				crashGroup.Crash1hCount++
+			}
+			appCrashCount[record.appId]++
+			appNames[record.appId] = record.appName
+			orgs[record.orgName] = true
+			cellCrashCount[record.cellIp]++
+			if crashLoops[record.instanceKey()] {
+				loopingInstances[record.instanceKey()] = true
+			}
+			if crashGroup.LastCrashTime == nil || record.crashTime.After(*crashGroup.LastCrashTime) {
+				crashTime := record.crashTime
+				crashGroup.LastCrashTime = &crashTime
+			}
+		}
+		crashGroup.AppCount = len(appCrashCount)
+		crashGroup.OrgCount = len(orgs)
+		crashGroup.CellCount = len(cellCrashCount)
+		crashGroup.CrashLoopCount = len(loopingInstances)
+
+		topAppId, topAppCount := maxCount(appCrashCount)
+		crashGroup.TopAppName = appNames[topAppId]
+		crashGroup.TopAppPercent = float64(topAppCount) / float64(len(records)) * 100
+		topCellIp, topCellCount := maxCount(cellCrashCount)
+		crashGroup.TopCellIp = topCellIp
+		crashGroup.TopCellPercent = float64(topCellCount) / float64(len(records)) * 100
+
+		displayGroupMap[crashGroup.Id()] = crashGroup
+	}
+	return displayGroupMap
+}
+
+func maxCount(countMap map[string]int) (string, int) {
+	maxKey := ""
+	max := 0
+	for key, count := range countMap {
+		if count > max || (count == max && key < maxKey) {
+			maxKey = key
+			max = count
+		}
+	}
+	return maxKey, max
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashAnalyticsView
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+type CrashAnalyticsView struct {
+	*dataView.DataListView
+	groupBy string
+}
+
+func NewCrashAnalyticsView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *CrashAnalyticsView {
+
+	asUI := &CrashAnalyticsView{groupBy: GROUP_BY_EXIT_STATUS}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("LOOPS", true),
+		uiCommon.NewSortColumn("CRH_1H", true),
+		uiCommon.NewSortColumn("CRH_24H", true),
+		uiCommon.NewSortColumn("NAME", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string {
+		return fmt.Sprintf("Crash Analytics - Grouped by %v (last 24 hours)", asUI.groupBy)
+	})
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *CrashAnalyticsView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnName())
+	columns = append(columns, columnCrash1hCount())
+	columns = append(columns, columnCrash24hCount())
+	columns = append(columns, columnCrashLoopCount())
+	columns = append(columns, columnAppCount())
+	columns = append(columns, columnOrgCount())
+	columns = append(columns, columnCellCount())
+	columns = append(columns, columnTopAppName())
+	columns = append(columns, columnTopAppPercent())
+	columns = append(columns, columnTopCellIp())
+	columns = append(columns, columnTopCellPercent())
+	columns = append(columns, columnLastCrashTime())
+	return columns
+}
+
+func (asUI *CrashAnalyticsView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'g', gocui.ModNone, asUI.selectGroupByAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *CrashAnalyticsView) selectGroupByAction(g *gocui.Gui, v *gocui.View) error {
+
+	menuItems := make([]*uiCommon.MenuItem, 0, 4)
+	menuItems = append(menuItems, uiCommon.NewMenuItem(GROUP_BY_EXIT_STATUS, "Exit Status"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem(GROUP_BY_APP, "Application"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem(GROUP_BY_ORG, "Organization"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem(GROUP_BY_CELL, "Cell IP"))
+
+	selectGroupByView := uiCommon.NewSelectMenuWidget(asUI.GetMasterUI(), "selectGroupByView", "Group Crashes By", menuItems, asUI.selectGroupByCallback)
+	selectGroupByView.SetMenuId(asUI.groupBy)
+
+	asUI.GetMasterUI().LayoutManager().Add(selectGroupByView)
+	asUI.GetMasterUI().SetCurrentViewOnTop(g)
+	return nil
+}
+
+func (asUI *CrashAnalyticsView) selectGroupByCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
+	asUI.groupBy = menuId
+	return asUI.UpdateDisplay(g)
+}
+
+func (asUI *CrashAnalyticsView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+func (asUI *CrashAnalyticsView) postProcessData() map[string]*DisplayCrashGroup {
+	appMap := asUI.GetDisplayedEventData().AppMap
+	crashRecords := collectCrashRecords(asUI.GetMdGlobalMgr(), appMap)
+	return groupCrashRecords(crashRecords, asUI.groupBy, time.Now())
+}
+
+func (asUI *CrashAnalyticsView) convertToListData(displayGroupMap map[string]*DisplayCrashGroup) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayGroupMap))
+	for _, d := range displayGroupMap {
+		listData = append(listData, d)
+	}
+	return listData
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashAnalyticsView
+
+import (
+	"fmt"
+	"time"
+)
+
+// Crash statistics for one group (exit status, app, org or cell)
+type DisplayCrashGroup struct {
+	GroupBy string
+	Name    string
+
+	Crash1hCount  int
+	Crash24hCount int
+
+	AppCount  int
+	OrgCount  int
+	CellCount int
+
+	// Number of container instances in the group that are in a crash loop
+	CrashLoopCount int
+
+	// App and cell with the most crashes within the group -- a single app with most
+	// of the crashes of a cell points to a bad app, many apps points to a bad cell
+	TopAppName     string
+	TopAppPercent  float64
+	TopCellIp      string
+	TopCellPercent float64
+
+	LastCrashTime *time.Time
+
+	key string
+}
+
+func NewDisplayCrashGroup(groupBy string, name string) *DisplayCrashGroup {
+	return &DisplayCrashGroup{GroupBy: groupBy, Name: name}
+}
+
+func (cg *DisplayCrashGroup) Id() string {
+	if cg.key == "" {
+		cg.key = fmt.Sprintf("%v|%v", cg.GroupBy, cg.Name)
+	}
+	return cg.key
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashAnalyticsView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	HelpLocalViewKeybindings +
+	helpView.HelpTopLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Crash Analytics View**
+
+Crash analytics view groups all container crashes of the last 24 hours
+by exit status, application, organization or cell IP.  This helps tell
+"one bad app" (crashes of a cell come from a single app, high APP%%)
+apart from "one bad cell" (crashes of a cell come from many apps).
+
+A container instance that has crashed 3 or more times in the last 10
+minutes is considered to be in a crash loop.
+
+NOTE: Crashes that occurred before top was started are loaded from the
+cloud controller events and do not include the cell the container ran
+on.  These crashes are grouped under cell "unknown".
+`
+
+const HelpColumnsText = `
+**Crash Analytics Columns:**
+
+  NAME - Exit status, application name, org name or cell IP
+  CRH_1H - Number of crashes in the last hour
+  CRH_24H - Number of crashes in the last 24 hours
+  LOOPS - Number of container instances currently in a crash loop
+  APPS - Number of different applications that crashed
+  ORGS - Number of different orgs with crashed applications
+  CELLS - Number of different cells crashed containers ran on
+  TOP_APP - Application with the most crashes
+  APP%% - Percent of crashes from TOP_APP
+  TOP_CELL - Cell with the most crashes
+  CELL%% - Percent of crashes on TOP_CELL
+  LAST_CRASH - Time of most recent crash
+`
+
+const HelpLocalViewKeybindings = `
+**Group by: **
+Press 'g' to select how crashes are grouped: exit status,
+application, organization or cell IP.
+`
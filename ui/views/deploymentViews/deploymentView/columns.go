@@ -0,0 +1,298 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package deploymentView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func stateAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	stats := data.(*DisplayDeploymentStats)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch stats.State {
+	case STATE_ACTIVE:
+		attentionType = uiCommon.ATTENTION_ACTIVITY
+	case STATE_TIMED_OUT:
+		attentionType = uiCommon.ATTENTION_WARM
+	}
+	return attentionType
+}
+
+// Highlight an error rate after the deployment that is higher then before
+func errorPercentAfterAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	stats := data.(*DisplayDeploymentStats)
+	if stats.ErrorPercentAfter > 0 && stats.ErrorPercentAfter > stats.ErrorPercentBefore {
+		return uiCommon.ATTENTION_HOT
+	}
+	return uiCommon.ATTENTION_NORMAL
+}
+
+func formatErrorPercent(errorPercent float64) string {
+	if errorPercent < 0 {
+		return fmt.Sprintf("%11v", "--")
+	}
+	return fmt.Sprintf("%11.2f", errorPercent)
+}
+
+func ColumnAppName() *uiCommon.ListColumn {
+	defaultColSize := 30
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayDeploymentStats).AppName, c2.(*DisplayDeploymentStats).AppName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return util.FormatDisplayData(stats.AppName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return stats.AppName
+	}
+	c := uiCommon.NewListColumn("appName", "APPLICATION", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnSpaceName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayDeploymentStats).SpaceName, c2.(*DisplayDeploymentStats).SpaceName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return util.FormatDisplayData(stats.SpaceName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return stats.SpaceName
+	}
+	c := uiCommon.NewListColumn("SPACE", "SPACE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnOrgName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayDeploymentStats).OrgName, c2.(*DisplayDeploymentStats).OrgName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return util.FormatDisplayData(stats.OrgName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return stats.OrgName
+	}
+	c := uiCommon.NewListColumn("ORG", "ORG", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnState() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).State < c2.(*DisplayDeploymentStats).State
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%-9v", stats.State)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return stats.State
+	}
+	c := uiCommon.NewListColumn("STATE", "STATE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnTrigger() *uiCommon.ListColumn {
+	defaultColSize := 15
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).Trigger < c2.(*DisplayDeploymentStats).Trigger
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return util.FormatDisplayData(stats.Trigger, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return stats.Trigger
+	}
+	c := uiCommon.NewListColumn("TRIGGER", "TRIGGER", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnStartTime() *uiCommon.ListColumn {
+	defaultColSize := 19
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).StartTime.Before(*c2.(*DisplayDeploymentStats).StartTime)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%-19v", stats.StartTime.Local().Format("01-02-2006 15:04:05"))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%v", stats.StartTime)
+	}
+	c := uiCommon.NewListColumn("START_TIME", "START_TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnElapsed() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).Elapsed.Seconds() < c2.(*DisplayDeploymentStats).Elapsed.Seconds()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%11v", util.FormatDuration(stats.Elapsed, true))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%v", stats.Elapsed.Seconds())
+	}
+	c := uiCommon.NewListColumn("ELAPSED", "ELAPSED", 11,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnOldContainers() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).OldContainers < c2.(*DisplayDeploymentStats).OldContainers
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%3v", stats.OldContainers)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%v", stats.OldContainers)
+	}
+	c := uiCommon.NewListColumn("OLD", "OLD", 3,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnNewContainers() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).NewContainers < c2.(*DisplayDeploymentStats).NewContainers
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%3v", stats.NewContainers)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%v", stats.NewContainers)
+	}
+	c := uiCommon.NewListColumn("NEW", "NEW", 3,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnDesiredContainers() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).DesiredContainers < c2.(*DisplayDeploymentStats).DesiredContainers
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%3v", stats.DesiredContainers)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%v", stats.DesiredContainers)
+	}
+	c := uiCommon.NewListColumn("DCR", "DCR", 3,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnErrorPercentBefore() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).ErrorPercentBefore < c2.(*DisplayDeploymentStats).ErrorPercentBefore
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return formatErrorPercent(stats.ErrorPercentBefore)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%v", stats.ErrorPercentBefore)
+	}
+	c := uiCommon.NewListColumn("ERR_PER_BEFORE", "ERR%_BEFORE", 11,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnErrorPercentAfter() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).ErrorPercentAfter < c2.(*DisplayDeploymentStats).ErrorPercentAfter
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return formatErrorPercent(stats.ErrorPercentAfter)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%v", stats.ErrorPercentAfter)
+	}
+	c := uiCommon.NewListColumn("ERR_PER_AFTER", "ERR%_AFTER", 11,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, errorPercentAfterAttentionFunc)
+	return c
+}
+
+func ColumnHttpCountAfter() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).HttpCountAfter < c2.(*DisplayDeploymentStats).HttpCountAfter
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%9v", util.Format(stats.HttpCountAfter))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return fmt.Sprintf("%v", stats.HttpCountAfter)
+	}
+	c := uiCommon.NewListColumn("REQ_AFTER", "REQ_AFTER", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnVersion() *uiCommon.ListColumn {
+	defaultColSize := 36
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayDeploymentStats).NewVersion < c2.(*DisplayDeploymentStats).NewVersion
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayDeploymentStats)
+		if stats.NewVersion == "" {
+			return fmt.Sprintf("%-36v", "--")
+		}
+		return fmt.Sprintf("%-36v", stats.NewVersion)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayDeploymentStats)
+		return stats.NewVersion
+	}
+	c := uiCommon.NewListColumn("NEW_VERSION", "NEW_VERSION", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
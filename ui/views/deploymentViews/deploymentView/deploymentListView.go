@@ -0,0 +1,136 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package deploymentView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+type DeploymentListView struct {
+	*dataView.DataListView
+	// If set, only deployments of this app are shown
+	appIdFilter string
+}
+
+func NewDeploymentListView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appIdFilter string) *DeploymentListView {
+
+	asUI := &DeploymentListView{appIdFilter: appIdFilter}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("STATE", false),
+		uiCommon.NewSortColumn("START_TIME", true),
+		uiCommon.NewSortColumn("appName", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	titleFunc := func() string {
+		if asUI.appIdFilter == "" {
+			return "Deployment List"
+		}
+		appMetadata := eventProcessor.GetMetadataManager().GetAppMdManager().FindItem(asUI.appIdFilter)
+		return fmt.Sprintf("App: %v - Deployment List", appMetadata.Name)
+	}
+	dataListView.SetTitle(titleFunc)
+
+	if asUI.appIdFilter == "" {
+		dataListView.HelpText = HelpText
+		dataListView.HelpTextTips = HelpTextTips
+	} else {
+		dataListView.HelpText = HelpTextFiltered
+		dataListView.HelpTextTips = HelpTextTipsFiltered
+	}
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *DeploymentListView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	if asUI.appIdFilter == "" {
+		columns = append(columns, ColumnAppName())
+		columns = append(columns, ColumnSpaceName())
+		columns = append(columns, ColumnOrgName())
+	}
+	columns = append(columns, ColumnState())
+	columns = append(columns, ColumnTrigger())
+	columns = append(columns, ColumnStartTime())
+	columns = append(columns, ColumnElapsed())
+	columns = append(columns, ColumnOldContainers())
+	columns = append(columns, ColumnNewContainers())
+	columns = append(columns, ColumnDesiredContainers())
+	columns = append(columns, ColumnErrorPercentBefore())
+	columns = append(columns, ColumnErrorPercentAfter())
+	columns = append(columns, ColumnHttpCountAfter())
+	columns = append(columns, ColumnVersion())
+	return columns
+}
+
+func (asUI *DeploymentListView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if asUI.appIdFilter != "" {
+		if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeDeploymentListView); err != nil {
+			log.Panicln(err)
+		}
+		if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeDeploymentListView); err != nil {
+			log.Panicln(err)
+		}
+	}
+	return nil
+}
+
+func (asUI *DeploymentListView) closeDeploymentListView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (asUI *DeploymentListView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+func (asUI *DeploymentListView) postProcessData() []*DisplayDeploymentStats {
+	appMap := asUI.GetDisplayedEventData().AppMap
+	return PostProcessDeploymentData(asUI.GetMdGlobalMgr(), appMap, asUI.appIdFilter)
+}
+
+func (asUI *DeploymentListView) convertToListData(displayDeploymentList []*DisplayDeploymentStats) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayDeploymentList))
+	for _, d := range displayDeploymentList {
+		listData = append(listData, d)
+	}
+	return listData
+}
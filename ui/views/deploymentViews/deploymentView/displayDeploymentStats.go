@@ -0,0 +1,122 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package deploymentView
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
+)
+
+const (
+	STATE_ACTIVE    = "ACTIVE"
+	STATE_COMPLETE  = "COMPLETE"
+	STATE_TIMED_OUT = "TIMED_OUT"
+)
+
+type DisplayDeploymentStats struct {
+	*eventApp.DeploymentStats
+	AppId string
+
+	AppName   string
+	SpaceName string
+	OrgName   string
+
+	State             string
+	Elapsed           *time.Duration
+	OldContainers     int
+	NewContainers     int
+	DesiredContainers int
+
+	// Error (5xx) percent of HTTP requests, -1 if there were no requests
+	ErrorPercentBefore float64
+	ErrorPercentAfter  float64
+	HttpCountAfter     int64
+
+	key string
+}
+
+func NewDisplayDeploymentStats(appId string, deploymentStats *eventApp.DeploymentStats) *DisplayDeploymentStats {
+	stats := &DisplayDeploymentStats{AppId: appId, DeploymentStats: deploymentStats}
+	return stats
+}
+
+func (ds *DisplayDeploymentStats) Id() string {
+	if ds.key == "" {
+		ds.key = fmt.Sprintf("%v/%v", ds.AppId, ds.StartTime.UnixNano())
+	}
+	return ds.key
+}
+
+func errorPercent(snapshot *eventApp.HttpCountSnapshot) float64 {
+	if snapshot == nil || snapshot.HttpCount <= 0 {
+		return -1
+	}
+	return float64(snapshot.HttpErrorCount) / float64(snapshot.HttpCount) * 100
+}
+
+// Build the list of deployments to display from the deployments detected on the
+// firehose.  If appId is empty, deployments for all apps are returned.
+func PostProcessDeploymentData(mdMgr *metadata.GlobalManager, appMap map[string]*eventApp.AppStats, appId string) []*DisplayDeploymentStats {
+
+	now := time.Now()
+	displayDeploymentList := make([]*DisplayDeploymentStats, 0)
+
+	for _, appStats := range appMap {
+		if appId != "" && appStats.AppId != appId {
+			continue
+		}
+		if len(appStats.Deployments) == 0 {
+			continue
+		}
+		appMetadata := mdMgr.GetAppMdManager().FindItem(appStats.AppId)
+		spaceMd := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
+		orgMd := mdMgr.GetOrgMdManager().FindItem(spaceMd.OrgGuid)
+
+		for _, deploymentStats := range appStats.Deployments {
+			displayStats := NewDisplayDeploymentStats(appStats.AppId, deploymentStats)
+			displayStats.AppName = appMetadata.Name
+			displayStats.SpaceName = spaceMd.Name
+			displayStats.OrgName = orgMd.Name
+			displayStats.DesiredContainers = mdMgr.FindDesiredContainers(appMetadata)
+
+			endTime := now
+			switch {
+			case deploymentStats.EndTime == nil:
+				displayStats.State = STATE_ACTIVE
+			case deploymentStats.TimedOut:
+				displayStats.State = STATE_TIMED_OUT
+				endTime = *deploymentStats.EndTime
+			default:
+				displayStats.State = STATE_COMPLETE
+				endTime = *deploymentStats.EndTime
+			}
+			elapsed := endTime.Sub(*deploymentStats.StartTime)
+			displayStats.Elapsed = &elapsed
+
+			displayStats.OldContainers, displayStats.NewContainers = deploymentStats.ContainerCounts(appStats.AllContainers())
+
+			after := deploymentStats.After(appStats.ContainerTrafficMap)
+			displayStats.HttpCountAfter = after.HttpCount
+			displayStats.ErrorPercentBefore = errorPercent(deploymentStats.Before)
+			displayStats.ErrorPercentAfter = errorPercent(after)
+
+			displayDeploymentList = append(displayDeploymentList, displayStats)
+		}
+	}
+	return displayDeploymentList
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package deploymentView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpTextFiltered = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + helpView.HelpChildLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Deployment List View**
+
+Deployment list view shows application deployments (cf push, restage,
+restart or rolling deploy) seen since top was started.  A deployment
+is detected from any of:
+  - API events that start, restage or deploy the app
+  - A new app version reported in an API event (e.g., crash)
+  - A change in the app package_updated_at in cloud controller
+
+Containers created after the deployment started are counted as NEW,
+all others as OLD.  A deployment is COMPLETE when no OLD containers
+remain and the desired number of NEW containers are running.  A
+deployment not complete within 30 minutes is TIMED_OUT.
+
+HTTP error rates are the percent of 5xx responses before the deployment
+(since top started) and after the deployment started.
+`
+
+const HelpColumnsText = `
+**Deployment Columns:**
+
+  APPLICATION - Application being deployed
+  SPACE - Space name
+  ORG - Organization name
+  STATE - ACTIVE, COMPLETE or TIMED_OUT
+  TRIGGER - What the deployment was detected from
+  START_TIME - Time deployment started (24 hour format in local timezone)
+  ELAPSED - Time since deployment started (or until complete)
+  OLD - Containers created before the deployment started
+  NEW - Containers created after the deployment started
+  DCR - Desired containers (instances)
+  ERR%%_BEFORE - Percent of HTTP 5xx responses before the deployment
+  ERR%%_AFTER - Percent of HTTP 5xx responses after the deployment started
+  REQ_AFTER - Number of HTTP requests after the deployment started
+  NEW_VERSION - New app version (if reported by an API event)
+`
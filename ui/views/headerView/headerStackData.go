@@ -189,7 +189,7 @@ func (asUI *HeaderWidget) updateHeaderStack(g *gocui.Gui, v *gocui.View) (int, e
 		}
 
 		// Track how much CPU is consumed per cell
-		for _, containerStats := range appStats.ContainerArray {
+		for _, containerStats := range appStats.AllContainers() {
 			if containerStats != nil {
 				cellIP := containerStats.Ip
 				cpuPercent := containerStats.ContainerMetric.GetCpuPercentage()
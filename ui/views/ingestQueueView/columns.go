@@ -0,0 +1,162 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestQueueView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func queueAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	stats := data.(*eventdata.IngestQueueStats)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch {
+	case stats.Dropped > 0 || stats.FullPercent() >= 90:
+		attentionType = uiCommon.ATTENTION_HOT
+	case stats.FullPercent() >= 50:
+		attentionType = uiCommon.ATTENTION_WARM
+	}
+	return attentionType
+}
+
+func columnQueue() *uiCommon.ListColumn {
+	defaultColSize := 14
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*eventdata.IngestQueueStats).Name, c2.(*eventdata.IngestQueueStats).Name)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return util.FormatDisplayData(stats.Name, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return stats.Name
+	}
+	c := uiCommon.NewListColumn("QUEUE", "QUEUE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, queueAttentionFunc)
+	return c
+}
+
+func columnType() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*eventdata.IngestQueueStats).QueueType < c2.(*eventdata.IngestQueueStats).QueueType
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return util.FormatDisplayData(stats.QueueType, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return stats.QueueType
+	}
+	c := uiCommon.NewListColumn("TYPE", "TYPE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnDepth() *uiCommon.ListColumn {
+	defaultColSize := 8
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*eventdata.IngestQueueStats).Depth < c2.(*eventdata.IngestQueueStats).Depth
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%8v", util.Format(int64(stats.Depth)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%v", stats.Depth)
+	}
+	c := uiCommon.NewListColumn("DEPTH", "DEPTH", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, queueAttentionFunc)
+	return c
+}
+
+func columnCapacity() *uiCommon.ListColumn {
+	defaultColSize := 8
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*eventdata.IngestQueueStats).Capacity < c2.(*eventdata.IngestQueueStats).Capacity
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%8v", util.Format(int64(stats.Capacity)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%v", stats.Capacity)
+	}
+	c := uiCommon.NewListColumn("CAPACITY", "CAPACITY", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnFullPercent() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*eventdata.IngestQueueStats).FullPercent() < c2.(*eventdata.IngestQueueStats).FullPercent()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%6.1f", stats.FullPercent())
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%.1f", stats.FullPercent())
+	}
+	c := uiCommon.NewListColumn("FULL_PERCENT", "FULL%", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, queueAttentionFunc)
+	return c
+}
+
+func columnProcessed() *uiCommon.ListColumn {
+	defaultColSize := 14
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*eventdata.IngestQueueStats).Processed < c2.(*eventdata.IngestQueueStats).Processed
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%14v", util.FormatUint64(stats.Processed))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%v", stats.Processed)
+	}
+	c := uiCommon.NewListColumn("PROCESSED", "PROCESSED", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnDropped() *uiCommon.ListColumn {
+	defaultColSize := 12
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*eventdata.IngestQueueStats).Dropped < c2.(*eventdata.IngestQueueStats).Dropped
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%12v", util.FormatUint64(stats.Dropped))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*eventdata.IngestQueueStats)
+		return fmt.Sprintf("%v", stats.Dropped)
+	}
+	c := uiCommon.NewListColumn("DROPPED", "DROPPED", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, queueAttentionFunc)
+	return c
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestQueueView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Ingest Queues View**
+
+Ingest queues view shows how well cf top is keeping up with the firehose.
+Each nozzle puts the envelopes it receives on its own queue.  The envelopes
+are then handed to one of the partitions (one per CPU) which update the
+stats.  Envelopes of an app are always processed by the same partition,
+all others by the partition of the VM that sent them.
+
+If a nozzle queue is full the envelope is dropped and counted.  Dropped
+envelopes or queues that stay full mean cf top itself is the bottleneck.
+A doppler reporting dropped messages while these queues are empty means
+the nozzle connections are not keeping up.
+`
+
+const HelpColumnsText = `
+**Columns:**
+
+  QUEUE - Nozzle or partition queue
+  TYPE - NOZZLE or PARTITION
+  DEPTH - Envelopes waiting in queue
+  CAPACITY - Maximum envelopes the queue can hold
+  FULL%% - Depth as a percent of capacity
+  PROCESSED - Envelopes taken off the queue
+  DROPPED - Envelopes dropped because the queue was full (nozzle only)
+`
@@ -0,0 +1,77 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestQueueView
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+)
+
+type IngestQueueView struct {
+	*dataView.DataListView
+}
+
+func NewIngestQueueView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *IngestQueueView {
+
+	asUI := &IngestQueueView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("TYPE", false),
+		uiCommon.NewSortColumn("QUEUE", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string { return "Ingest Queues" })
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *IngestQueueView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnQueue())
+	columns = append(columns, columnType())
+	columns = append(columns, columnDepth())
+	columns = append(columns, columnCapacity())
+	columns = append(columns, columnFullPercent())
+	columns = append(columns, columnProcessed())
+	columns = append(columns, columnDropped())
+	return columns
+}
+
+// Queue depth is live (not from the displayed snapshot)
+func (asUI *IngestQueueView) GetListData() []uiCommon.IData {
+	queueStatsList := asUI.GetEventProcessor().GetIngestQueueStats()
+	listData := make([]uiCommon.IData, 0, len(queueStatsList))
+	for _, queueStats := range queueStatsList {
+		listData = append(listData, queueStats)
+	}
+	return listData
+}
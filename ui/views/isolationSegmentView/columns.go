@@ -0,0 +1,250 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package isolationSegmentView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+const ATTENTION_HOT_PERCENT = 90
+const ATTENTION_WARM_PERCENT = 80
+
+func columnIsolationSegmentName() *uiCommon.ListColumn {
+	defaultColSize := 20
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayIsolationSegment).Name, c2.(*DisplayIsolationSegment).Name)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayIsolationSegment)
+		return util.FormatDisplayData(stats.Name, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayIsolationSegment)
+		return stats.Name
+	}
+	c := uiCommon.NewListColumn("ISO_SEG", "ISO_SEG", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+// Column for a count.  Displays "--" if the count is zero and dashIfZero is set
+func columnCount(id, label string, size int, valueFunc func(stats *DisplayIsolationSegment) int64, dashIfZero bool) *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return valueFunc(c1.(*DisplayIsolationSegment)) < valueFunc(c2.(*DisplayIsolationSegment))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		value := valueFunc(data.(*DisplayIsolationSegment))
+		if value == 0 && dashIfZero {
+			return fmt.Sprintf("%*v", size, "--")
+		}
+		return fmt.Sprintf("%*v", size, util.Format(value))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", valueFunc(data.(*DisplayIsolationSegment)))
+	}
+	c := uiCommon.NewListColumn(id, label, size,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+// Column for a size in bytes.  Displays "--" if the size is zero
+func columnByteSize(id, label string, size int, valueFunc func(stats *DisplayIsolationSegment) int64) *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return valueFunc(c1.(*DisplayIsolationSegment)) < valueFunc(c2.(*DisplayIsolationSegment))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		value := valueFunc(data.(*DisplayIsolationSegment))
+		if value == 0 {
+			return fmt.Sprintf("%*v", size, "--")
+		}
+		return fmt.Sprintf("%*v", size, util.ByteSize(value).StringWithPrecision(1))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", valueFunc(data.(*DisplayIsolationSegment)))
+	}
+	c := uiCommon.NewListColumn(id, label, size,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
+	return c
+}
+
+func columnNumberOfCells() *uiCommon.ListColumn {
+	return columnCount("CELLS", "CELLS", 5,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.NumberOfCells) }, false)
+}
+
+func columnNumberOfApps() *uiCommon.ListColumn {
+	return columnCount("APPS", "APPS", 6,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.NumberOfApps) }, false)
+}
+
+func columnNumberOfEntitledOrgs() *uiCommon.ListColumn {
+	return columnCount("ORGS", "ORGS", 5,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.NumberOfEntitledOrgs) }, true)
+}
+
+func columnDesiredContainers() *uiCommon.ListColumn {
+	return columnCount("DCR", "DCR", 6,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.DesiredContainers) }, false)
+}
+
+func columnReportingContainers() *uiCommon.ListColumn {
+	return columnCount("RCR", "RCR", 6,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.TotalReportingContainers) }, false)
+}
+
+func columnTotalCpu() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayIsolationSegment).TotalCpuPercentage < c2.(*DisplayIsolationSegment).TotalCpuPercentage
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayIsolationSegment)
+		cpuPercentage := stats.TotalCpuPercentage
+		totalCpuInfo := ""
+		if stats.TotalReportingContainers == 0 {
+			totalCpuInfo = "--"
+		} else if cpuPercentage >= 100.0 {
+			totalCpuInfo = fmt.Sprintf("%.0f", cpuPercentage)
+		} else if cpuPercentage >= 10.0 {
+			totalCpuInfo = fmt.Sprintf("%.1f", cpuPercentage)
+		} else {
+			totalCpuInfo = fmt.Sprintf("%.2f", cpuPercentage)
+		}
+		return fmt.Sprintf("%6v", totalCpuInfo)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayIsolationSegment)
+		return fmt.Sprintf("%.2f", stats.TotalCpuPercentage)
+	}
+	c := uiCommon.NewListColumn("CPU_PER", "CPU%", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCapacityMemoryTotal() *uiCommon.ListColumn {
+	return columnByteSize("MEM_TOT", "MEM_TOT", 9,
+		func(stats *DisplayIsolationSegment) int64 { return stats.CapacityMemoryTotal })
+}
+
+func columnCapacityMemoryRemaining() *uiCommon.ListColumn {
+	return columnByteSize("MEM_FREE", "MEM_FREE", 9,
+		func(stats *DisplayIsolationSegment) int64 { return stats.CapacityMemoryRemaining })
+}
+
+func columnMemoryUsedPercent() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayIsolationSegment).MemoryUsedPercent() < c2.(*DisplayIsolationSegment).MemoryUsedPercent()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayIsolationSegment)
+		if stats.CapacityMemoryTotal == 0 {
+			return fmt.Sprintf("%6v", "--")
+		}
+		return fmt.Sprintf("%6.1f", stats.MemoryUsedPercent())
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayIsolationSegment)
+		if stats.CapacityMemoryTotal == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%.1f", stats.MemoryUsedPercent())
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		stats := data.(*DisplayIsolationSegment)
+		attentionType := uiCommon.ATTENTION_NORMAL
+		switch {
+		case stats.CapacityMemoryTotal == 0:
+		case stats.MemoryUsedPercent() >= ATTENTION_HOT_PERCENT:
+			attentionType = uiCommon.ATTENTION_HOT
+		case stats.MemoryUsedPercent() >= ATTENTION_WARM_PERCENT:
+			attentionType = uiCommon.ATTENTION_WARM
+		}
+		return attentionType
+	}
+	c := uiCommon.NewListColumn("MEM_PER", "MEM%", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_AVG)
+	return c
+}
+
+func columnTotalMemoryReserved() *uiCommon.ListColumn {
+	return columnByteSize("MEM_RSVD", "MEM_RSVD", 9,
+		func(stats *DisplayIsolationSegment) int64 { return stats.TotalMemoryReserved })
+}
+
+func columnTotalMemoryUsed() *uiCommon.ListColumn {
+	return columnByteSize("MEM_USED", "MEM_USED", 9,
+		func(stats *DisplayIsolationSegment) int64 { return stats.TotalMemoryUsed })
+}
+
+func columnCapacityDiskTotal() *uiCommon.ListColumn {
+	return columnByteSize("DISK_TOT", "DISK_TOT", 9,
+		func(stats *DisplayIsolationSegment) int64 { return stats.CapacityDiskTotal })
+}
+
+func columnCapacityDiskRemaining() *uiCommon.ListColumn {
+	return columnByteSize("DISK_FREE", "DISK_FREE", 9,
+		func(stats *DisplayIsolationSegment) int64 { return stats.CapacityDiskRemaining })
+}
+
+func columnTotalDiskReserved() *uiCommon.ListColumn {
+	return columnByteSize("DSK_RSVD", "DSK_RSVD", 9,
+		func(stats *DisplayIsolationSegment) int64 { return stats.TotalDiskReserved })
+}
+
+func columnTotalDiskUsed() *uiCommon.ListColumn {
+	return columnByteSize("DSK_USED", "DSK_USED", 9,
+		func(stats *DisplayIsolationSegment) int64 { return stats.TotalDiskUsed })
+}
+
+func columnCapacityTotalContainers() *uiCommon.ListColumn {
+	return columnCount("MAX_CNTR", "MAX_CNTR", 8,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.CapacityTotalContainers) }, true)
+}
+
+func columnCapacityRemainingContainers() *uiCommon.ListColumn {
+	return columnCount("FREE_CNTR", "FREE_CNTR", 9,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.CapacityRemainingContainers) }, true)
+}
+
+func columnContainerCount() *uiCommon.ListColumn {
+	return columnCount("CNTRS", "CNTRS", 6,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.ContainerCount) }, true)
+}
+
+func columnReq1() *uiCommon.ListColumn {
+	return columnCount("REQ1", "REQ/1", 6,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.EventL1Rate) }, false)
+}
+
+func columnReq10() *uiCommon.ListColumn {
+	return columnCount("REQ10", "REQ/10", 7,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.EventL10Rate) }, false)
+}
+
+func columnReq60() *uiCommon.ListColumn {
+	return columnCount("REQ60", "REQ/60", 7,
+		func(stats *DisplayIsolationSegment) int64 { return int64(stats.EventL60Rate) }, false)
+}
+
+func columnTotalReq() *uiCommon.ListColumn {
+	return columnCount("TOT_REQ", "TOT_REQ", 10,
+		func(stats *DisplayIsolationSegment) int64 { return stats.HttpAllCount }, false)
+}
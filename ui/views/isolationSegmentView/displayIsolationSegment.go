@@ -0,0 +1,66 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package isolationSegmentView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
+
+type DisplayIsolationSegment struct {
+	*isolationSegment.IsolationSegmentMetadata
+
+	NumberOfCells        int
+	NumberOfApps         int
+	NumberOfEntitledOrgs int
+
+	// Capacity as reported by the cells in the segment
+	CapacityMemoryTotal         int64
+	CapacityMemoryRemaining     int64
+	CapacityDiskTotal           int64
+	CapacityDiskRemaining       int64
+	CapacityTotalContainers     int
+	CapacityRemainingContainers int
+	ContainerCount              int
+
+	// Usage by the apps in the segment
+	DesiredContainers        int
+	TotalReportingContainers int
+	TotalCpuPercentage       float64
+	TotalMemoryReserved      int64
+	TotalMemoryUsed          int64
+	TotalDiskReserved        int64
+	TotalDiskUsed            int64
+
+	EventL1Rate  int
+	EventL10Rate int
+	EventL60Rate int
+	HttpAllCount int64
+}
+
+func NewDisplayIsolationSegment(isoSegMd *isolationSegment.IsolationSegmentMetadata) *DisplayIsolationSegment {
+	stats := &DisplayIsolationSegment{}
+	stats.IsolationSegmentMetadata = isoSegMd
+	return stats
+}
+
+func (ds *DisplayIsolationSegment) Id() string {
+	return ds.Guid
+}
+
+// Percent of the segment's cell memory that is in use by containers
+func (ds *DisplayIsolationSegment) MemoryUsedPercent() float64 {
+	if ds.CapacityMemoryTotal == 0 {
+		return 0
+	}
+	return (1 - (float64(ds.CapacityMemoryRemaining) / float64(ds.CapacityMemoryTotal))) * 100
+}
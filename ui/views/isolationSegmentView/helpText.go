@@ -0,0 +1,69 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package isolationSegmentView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	HelpLocalViewKeybindings +
+	helpView.HelpTopLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Isolation Segment View**
+
+Isolation segment view shows the capacity and utilization of each
+isolation segment on the foundation.  Capacity is the total reported by
+the cells assigned to the segment.  Apps are assigned to a segment by
+their space (or the org default segment if the space has none).  This
+list may not be complete until the warm-up period is complete.
+`
+
+const HelpColumnsText = `
+**Isolation Segment Columns:**
+
+  ISO_SEG - Isolation segment name
+  CELLS - Number of cells assigned to the segment
+  ORGS - Number of orgs entitled to use the segment
+  APPS - Number of apps running in the segment
+  DCR - Number of desired containers (app instances)
+  RCR - Number of reporting containers which are the the actual number of app
+      instances running.  Normally DCR and RCR are equal.
+  CPU%% - Total CPU used by all containers within segment
+  MEM_TOT - Total memory of all cells available for containers
+  MEM_FREE - Free memory of all cells available for containers
+  MEM%% - Percent of cell memory in use (MEM_TOT less MEM_FREE)
+  MEM_RSVD - Total memory reserved by all desired containers
+  MEM_USED - Memory actually in use by all containers
+  DISK_TOT - Total disk space of all cells
+  DISK_FREE - Free disk space of all cells available for containers
+  DSK_RSVD - Total disk reserved by all desired containers
+  DSK_USED - Disk actually in use by all containers
+  MAX_CNTR - Max containers the cells can handle
+  FREE_CNTR - Remaining containers the cells can handle
+  CNTRS - Number of containers running reported by the cells
+  REQ/1 - Number of HTTP(S) request/responses in last 1 second.
+  REQ/10 - Number of HTTP(S) request/responses in last 10 seconds.
+  REQ/60 - Number of HTTP(S) request/responses in last 60 seconds.
+  TOT_REQ - Count of all of the HTTP(S) request/responses
+`
+
+const HelpLocalViewKeybindings = `
+**Drill-down: **
+Press ENTER when a row is selected to view the cells or the apps in
+the isolation segment.
+`
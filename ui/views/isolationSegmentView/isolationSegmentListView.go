@@ -0,0 +1,236 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package isolationSegmentView
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellView"
+)
+
+type IsolationSegmentListView struct {
+	*dataView.DataListView
+}
+
+func NewIsolationSegmentListView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *IsolationSegmentListView {
+
+	asUI := &IsolationSegmentListView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("ISO_SEG", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string { return "Isolation Segment List" })
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = appView.HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *IsolationSegmentListView) initializeCallback(g *gocui.Gui, viewName string) error {
+
+	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+		log.Panicln(err)
+	}
+
+	return nil
+}
+
+func (asUI *IsolationSegmentListView) enterAction(g *gocui.Gui, v *gocui.View) error {
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	if highlightKey != "" {
+		menuItems := make([]*uiCommon.MenuItem, 0, 2)
+		menuItems = append(menuItems, uiCommon.NewMenuItem("cellByIsoSegView", "View Cells"))
+		menuItems = append(menuItems, uiCommon.NewMenuItem("appByIsoSegView", "View Apps"))
+
+		isoSegMd := asUI.GetMdGlobalMgr().GetIsoSegMdManager().FindItem(highlightKey)
+		windowTitle := fmt.Sprintf("Select View for %v", isoSegMd.Name)
+		selectDisplayView := uiCommon.NewSelectMenuWidget(asUI.GetMasterUI(), "selectDisplayView", windowTitle, menuItems, asUI.selectDisplayCallback)
+
+		asUI.GetMasterUI().LayoutManager().Add(selectDisplayView)
+		asUI.GetMasterUI().SetCurrentViewOnTop(g)
+	}
+	return nil
+}
+
+func (asUI *IsolationSegmentListView) selectDisplayCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	if highlightKey == "" {
+		return nil
+	}
+	_, bottomMargin := asUI.GetMargins()
+
+	var detailView dataView.DataListViewInterface
+	switch menuId {
+	case "cellByIsoSegView":
+		detailView = cellView.NewCellListView(asUI.GetMasterUI(), asUI, "cellByIsoSegView",
+			bottomMargin,
+			asUI.GetEventProcessor(),
+			highlightKey)
+	case "appByIsoSegView":
+		detailView = appView.NewAppListView(asUI.GetMasterUI(), asUI, "appByIsoSegView",
+			bottomMargin,
+			asUI.GetEventProcessor(),
+			"", highlightKey)
+	default:
+		return errors.New("Unable to find view " + menuId)
+	}
+	asUI.SetDetailView(detailView)
+	return asUI.GetMasterUI().OpenView(g, detailView)
+}
+
+func (asUI *IsolationSegmentListView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnIsolationSegmentName())
+
+	columns = append(columns, columnNumberOfCells())
+	columns = append(columns, columnNumberOfEntitledOrgs())
+	columns = append(columns, columnNumberOfApps())
+
+	columns = append(columns, columnDesiredContainers())
+	columns = append(columns, columnReportingContainers())
+
+	columns = append(columns, columnTotalCpu())
+
+	columns = append(columns, columnCapacityMemoryTotal())
+	columns = append(columns, columnCapacityMemoryRemaining())
+	columns = append(columns, columnMemoryUsedPercent())
+	columns = append(columns, columnTotalMemoryReserved())
+	columns = append(columns, columnTotalMemoryUsed())
+
+	columns = append(columns, columnCapacityDiskTotal())
+	columns = append(columns, columnCapacityDiskRemaining())
+	columns = append(columns, columnTotalDiskReserved())
+	columns = append(columns, columnTotalDiskUsed())
+
+	columns = append(columns, columnCapacityTotalContainers())
+	columns = append(columns, columnCapacityRemainingContainers())
+	columns = append(columns, columnContainerCount())
+
+	columns = append(columns, columnReq1())
+	columns = append(columns, columnReq10())
+	columns = append(columns, columnReq60())
+	columns = append(columns, columnTotalReq())
+
+	return columns
+}
+
+func (asUI *IsolationSegmentListView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+func (asUI *IsolationSegmentListView) postProcessData() map[string]*DisplayIsolationSegment {
+
+	mdMgr := asUI.GetMdGlobalMgr()
+	isoSegMdMgr := mdMgr.GetIsoSegMdManager()
+	appMdMgr := mdMgr.GetAppMdManager()
+
+	displayIsoSegMap := make(map[string]*DisplayIsolationSegment)
+	findDisplayIsoSeg := func(isoSegGuid string) *DisplayIsolationSegment {
+		isoSegGuid = isolationSegment.ResolveGuid(isoSegGuid)
+		displayIsoSeg := displayIsoSegMap[isoSegGuid]
+		if displayIsoSeg == nil {
+			displayIsoSeg = NewDisplayIsolationSegment(isoSegMdMgr.FindItem(isoSegGuid))
+			displayIsoSegMap[isoSegGuid] = displayIsoSeg
+		}
+		return displayIsoSeg
+	}
+
+	for _, isoSegMd := range isoSegMdMgr.GetAll() {
+		displayIsoSeg := findDisplayIsoSeg(isoSegMd.Guid)
+		displayIsoSeg.NumberOfEntitledOrgs = len(isoSegMd.EntitledOrgGuids)
+	}
+
+	eventData := asUI.GetDisplayedEventData()
+	for _, cellStats := range eventData.CellMap {
+		if cellStats.IsolationSegmentGuid == isolationSegment.UnknownIsolationSegmentGuid {
+			// Do another attempt to resolve unknown IsoSeg
+			eventData.AssignIsolationSegment(cellStats)
+		}
+		displayIsoSeg := findDisplayIsoSeg(cellStats.IsolationSegmentGuid)
+		displayIsoSeg.NumberOfCells++
+		displayIsoSeg.CapacityMemoryTotal += cellStats.CapacityMemoryTotal
+		displayIsoSeg.CapacityMemoryRemaining += cellStats.CapacityMemoryRemaining
+		displayIsoSeg.CapacityDiskTotal += cellStats.CapacityDiskTotal
+		displayIsoSeg.CapacityDiskRemaining += cellStats.CapacityDiskRemaining
+		displayIsoSeg.CapacityTotalContainers += cellStats.CapacityTotalContainers
+		displayIsoSeg.CapacityRemainingContainers += cellStats.CapacityRemainingContainers
+		displayIsoSeg.ContainerCount += cellStats.ContainerCount
+	}
+
+	displayStatsMap := asUI.GetMasterUI().GetCommonData().GetDisplayAppStatsMap()
+	for _, appStats := range displayStatsMap {
+		if appStats.StackId == "" {
+			// No app metadata (yet), the isolation segment of the app is not known
+			continue
+		}
+		displayIsoSeg := findDisplayIsoSeg(appStats.IsolationSegmentGuid)
+		displayIsoSeg.NumberOfApps++
+
+		if appStats.IsStarted {
+			displayIsoSeg.TotalCpuPercentage += appStats.TotalCpuPercentage
+			displayIsoSeg.TotalMemoryUsed += appStats.TotalMemoryUsed
+			displayIsoSeg.TotalDiskUsed += appStats.TotalDiskUsed
+			appMetadata := appMdMgr.FindItem(appStats.AppId)
+			displayIsoSeg.TotalMemoryReserved += (int64(appMetadata.MemoryMB) * util.MEGABYTE) * int64(appMetadata.Instances)
+			displayIsoSeg.TotalDiskReserved += (int64(appMetadata.DiskQuotaMB) * util.MEGABYTE) * int64(appMetadata.Instances)
+		}
+		displayIsoSeg.TotalReportingContainers += appStats.TotalReportingContainers
+		displayIsoSeg.DesiredContainers += appStats.DesiredContainers
+
+		if appStats.TotalTraffic != nil {
+			displayIsoSeg.EventL1Rate += appStats.TotalTraffic.EventL1Rate
+			displayIsoSeg.EventL10Rate += appStats.TotalTraffic.EventL10Rate
+			displayIsoSeg.EventL60Rate += appStats.TotalTraffic.EventL60Rate
+			displayIsoSeg.HttpAllCount += appStats.HttpAllCount
+		}
+	}
+
+	return displayIsoSegMap
+}
+
+func (asUI *IsolationSegmentListView) convertToListData(displayIsoSegMap map[string]*DisplayIsolationSegment) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayIsoSegMap))
+	for _, d := range displayIsoSegMap {
+		listData = append(listData, d)
+	}
+	return listData
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nozzleView
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func dataLossAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	stats := data.(*DisplayNozzleStats)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch {
+	case stats.DataLoss:
+		attentionType = uiCommon.ATTENTION_HOT
+	case !stats.Connected:
+		attentionType = uiCommon.ATTENTION_WARM
+	}
+	return attentionType
+}
+
+func columnNozzle() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).InstanceId < c2.(*DisplayNozzleStats).InstanceId
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%6v", stats.InstanceId)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return strconv.Itoa(stats.InstanceId)
+	}
+	c := uiCommon.NewListColumn("NOZZLE", "NOZZLE", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, dataLossAttentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
+	return c
+}
+
+func columnState() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).State < c2.(*DisplayNozzleStats).State
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return util.FormatDisplayData(stats.State, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return stats.State
+	}
+	c := uiCommon.NewListColumn("STATE", "STATE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, dataLossAttentionFunc)
+	return c
+}
+
+func columnAppName() *uiCommon.ListColumn {
+	defaultColSize := 30
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayNozzleStats).AppName, c2.(*DisplayNozzleStats).AppName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return util.FormatDisplayData(stats.AppName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return stats.AppName
+	}
+	c := uiCommon.NewListColumn("APP_NAME", "APPLICATION", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnMessageRate() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).MessageRate < c2.(*DisplayNozzleStats).MessageRate
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%10v", util.Format(int64(stats.MessageRate)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%.1f", stats.MessageRate)
+	}
+	c := uiCommon.NewListColumn("RATE", "RATE", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnMessageCount() *uiCommon.ListColumn {
+	defaultColSize := 14
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).MessageCount < c2.(*DisplayNozzleStats).MessageCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%14v", util.FormatUint64(stats.MessageCount))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%v", stats.MessageCount)
+	}
+	c := uiCommon.NewListColumn("EVENTS", "EVENTS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnLastMessageTime() *uiCommon.ListColumn {
+	defaultColSize := 19
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		t1 := c1.(*DisplayNozzleStats).LastMessageTime
+		t2 := c2.(*DisplayNozzleStats).LastMessageTime
+		if t1 == nil {
+			return true
+		}
+		if t2 == nil {
+			return false
+		}
+		return t1.Before(*t2)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		if stats.LastMessageTime == nil {
+			return fmt.Sprintf("%-19v", "--")
+		} else {
+			return fmt.Sprintf("%-19v", stats.LastMessageTime.Local().Format("01-02-2006 15:04:05"))
+		}
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%v", stats.LastMessageTime)
+	}
+	c := uiCommon.NewListColumn("LAST_EVENT_TIME", "LAST_EVENT_TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnReconnectCount() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).ReconnectCount < c2.(*DisplayNozzleStats).ReconnectCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%6v", util.Format(int64(stats.ReconnectCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return strconv.Itoa(stats.ReconnectCount)
+	}
+	c := uiCommon.NewListColumn("RECONNECTS", "RECONN", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnErrorCount() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).ErrorCount < c2.(*DisplayNozzleStats).ErrorCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%6v", util.Format(int64(stats.ErrorCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return strconv.Itoa(stats.ErrorCount)
+	}
+	c := uiCommon.NewListColumn("ERRORS", "ERRORS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnSlowConsumerCount() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).SlowConsumerCount < c2.(*DisplayNozzleStats).SlowConsumerCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%6v", util.Format(int64(stats.SlowConsumerCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return strconv.Itoa(stats.SlowConsumerCount)
+	}
+	c := uiCommon.NewListColumn("SLOW_CONSUMER", "SLOW", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, dataLossAttentionFunc)
+	return c
+}
+
+func columnDopplerDropped() *uiCommon.ListColumn {
+	defaultColSize := 12
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).DopplerDropped < c2.(*DisplayNozzleStats).DopplerDropped
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%12v", util.FormatUint64(stats.DopplerDropped))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%v", stats.DopplerDropped)
+	}
+	c := uiCommon.NewListColumn("DOPPLER_DROPPED", "DOPPLER_DROP", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, dataLossAttentionFunc)
+	return c
+}
+
+func columnIngestDropped() *uiCommon.ListColumn {
+	defaultColSize := 12
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayNozzleStats).IngestDropped < c2.(*DisplayNozzleStats).IngestDropped
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%12v", util.FormatUint64(stats.IngestDropped))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%v", stats.IngestDropped)
+	}
+	c := uiCommon.NewListColumn("QUEUE_DROPPED", "QUEUE_DROP", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, dataLossAttentionFunc)
+	return c
+}
+
+func columnLastErrorTime() *uiCommon.ListColumn {
+	defaultColSize := 19
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		t1 := c1.(*DisplayNozzleStats).LastErrorTime
+		t2 := c2.(*DisplayNozzleStats).LastErrorTime
+		if t1 == nil {
+			return true
+		}
+		if t2 == nil {
+			return false
+		}
+		return t1.Before(*t2)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		if stats.LastErrorTime == nil {
+			return fmt.Sprintf("%-19v", "--")
+		} else {
+			return fmt.Sprintf("%-19v", stats.LastErrorTime.Local().Format("01-02-2006 15:04:05"))
+		}
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return fmt.Sprintf("%v", stats.LastErrorTime)
+	}
+	c := uiCommon.NewListColumn("LAST_ERROR_TIME", "LAST_ERROR_TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnLastError() *uiCommon.ListColumn {
+	defaultColSize := 60
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayNozzleStats).LastError, c2.(*DisplayNozzleStats).LastError)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayNozzleStats)
+		return util.FormatDisplayData(stats.LastError, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayNozzleStats)
+		return stats.LastError
+	}
+	c := uiCommon.NewListColumn("LAST_ERROR", "LAST_ERROR", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
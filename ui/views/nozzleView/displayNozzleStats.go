@@ -0,0 +1,44 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nozzleView
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
+)
+
+const (
+	STATE_CONNECTED    = "CONNECTED"
+	STATE_DISCONNECTED = "DOWN"
+)
+
+type DisplayNozzleStats struct {
+	*eventrouting.NozzleStats
+	State    string
+	AppName  string
+	DataLoss bool
+}
+
+func NewDisplayNozzleStats(nozzleStats *eventrouting.NozzleStats, appName string, now time.Time) *DisplayNozzleStats {
+	stats := &DisplayNozzleStats{NozzleStats: nozzleStats, AppName: appName}
+	stats.State = STATE_DISCONNECTED
+	if nozzleStats.Connected {
+		stats.State = STATE_CONNECTED
+	}
+	stats.DataLoss = nozzleStats.IsDataLoss(now)
+	return stats
+}
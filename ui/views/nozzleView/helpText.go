@@ -0,0 +1,64 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nozzleView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + HelpScalingKeybindings + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Nozzles View**
+
+Nozzles view shows the health of each connection cf top has to the
+firehose (or to the app streams when not a privileged user).
+
+Data loss shows up in three ways: doppler reports dropped messages
+(DOPPLER_DROP) because a nozzle connection is not reading fast enough,
+doppler closes a connection as a slow consumer (SLOW), or the ingest
+queue of the nozzle is full (QUEUE_DROP).  A nozzle that recently lost
+data is highlighted and a DLOSS alert is shown in the header.
+`
+
+const HelpColumnsText = `
+**Columns:**
+
+  NOZZLE - Nozzle instance number
+  STATE - CONNECTED or DOWN (reconnecting)
+  APPLICATION - Application of the app stream (non-privileged user only)
+  RATE - Envelopes per second received by the nozzle
+  EVENTS - Total envelopes received by the nozzle
+  LAST_EVENT_TIME - Time the last envelope was received
+  RECONN - Number of times the nozzle reconnected
+  ERRORS - Number of connection errors
+  SLOW - Connections closed by doppler as a slow consumer
+  DOPPLER_DROP - Envelopes doppler reported as dropped for this nozzle
+  QUEUE_DROP - Envelopes dropped because the ingest queue was full
+  LAST_ERROR_TIME - Time of the last error
+  LAST_ERROR - Last error reported by the connection
+`
+
+const HelpScalingKeybindings = `
+**Nozzle scaling: **
+Firehose nozzles share one subscription so doppler spreads the envelopes
+across them.  With automatic scaling a nozzle is added when doppler drops
+messages or closes a nozzle as a slow consumer, and one is removed when
+the envelope rate stays low.  Scaling stays within the -nozzles-min and
+-nozzles-max options.  App stream nozzles (non-privileged user) are not
+scaled.
+
+Press '+' or '-' to add or remove a nozzle.  This turns off automatic
+scaling.  Press 'a' to turn automatic scaling on or off.
+`
@@ -0,0 +1,151 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nozzleView
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+type NozzleView struct {
+	*dataView.DataListView
+	router *eventrouting.EventRouter
+}
+
+func NewNozzleView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	router *eventrouting.EventRouter) *NozzleView {
+
+	asUI := &NozzleView{router: router}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("NOZZLE", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(asUI.title)
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+	if router.GetNozzleController() == nil {
+		dataListView.HelpTextTips = HelpTextTipsNoScaling
+	}
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *NozzleView) initializeCallback(g *gocui.Gui, viewName string) error {
+
+	// App stream nozzles (non-privileged user) can not be scaled
+	if asUI.router.GetNozzleController() == nil {
+		return nil
+	}
+	if err := g.SetKeybinding(viewName, '+', gocui.ModNone, asUI.addNozzleAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, '-', gocui.ModNone, asUI.removeNozzleAction); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, 'a', gocui.ModNone, asUI.toggleAutoScaleAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *NozzleView) title() string {
+	nozzleController := asUI.router.GetNozzleController()
+	if nozzleController == nil {
+		return "Nozzles"
+	}
+	scaling := "manual"
+	if nozzleController.IsAutoScale() {
+		min, max := nozzleController.GetScaleBounds()
+		scaling = fmt.Sprintf("auto scaling %v-%v", min, max)
+	}
+	return fmt.Sprintf("Nozzles (%v running, %v)", nozzleController.GetNozzleCount(), scaling)
+}
+
+func (asUI *NozzleView) addNozzleAction(g *gocui.Gui, v *gocui.View) error {
+	nozzleController := asUI.router.GetNozzleController()
+	nozzleController.SetNozzleCount(nozzleController.GetNozzleCount() + 1)
+	return asUI.UpdateDisplay(g)
+}
+
+func (asUI *NozzleView) removeNozzleAction(g *gocui.Gui, v *gocui.View) error {
+	nozzleController := asUI.router.GetNozzleController()
+	nozzleController.SetNozzleCount(nozzleController.GetNozzleCount() - 1)
+	return asUI.UpdateDisplay(g)
+}
+
+func (asUI *NozzleView) toggleAutoScaleAction(g *gocui.Gui, v *gocui.View) error {
+	nozzleController := asUI.router.GetNozzleController()
+	nozzleController.SetAutoScale(!nozzleController.IsAutoScale())
+	return asUI.UpdateDisplay(g)
+}
+
+func (asUI *NozzleView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnNozzle())
+	columns = append(columns, columnState())
+	if !asUI.GetMasterUI().IsPrivileged() {
+		columns = append(columns, columnAppName())
+	}
+	columns = append(columns, columnMessageRate())
+	columns = append(columns, columnMessageCount())
+	columns = append(columns, columnLastMessageTime())
+	columns = append(columns, columnReconnectCount())
+	columns = append(columns, columnErrorCount())
+	columns = append(columns, columnSlowConsumerCount())
+	columns = append(columns, columnDopplerDropped())
+	columns = append(columns, columnIngestDropped())
+	columns = append(columns, columnLastErrorTime())
+	columns = append(columns, columnLastError())
+	return columns
+}
+
+// Nozzle stats are live (not from the displayed snapshot)
+func (asUI *NozzleView) GetListData() []uiCommon.IData {
+	now := time.Now()
+	appMdMgr := asUI.GetEventProcessor().GetMetadataManager().GetAppMdManager()
+	nozzleStatsList := asUI.router.GetNozzleStats()
+	listData := make([]uiCommon.IData, 0, len(nozzleStatsList))
+	for _, nozzleStats := range nozzleStatsList {
+		appName := ""
+		if nozzleStats.AppGuid != "" {
+			appName = appMdMgr.FindItem(nozzleStats.AppGuid).Name
+		}
+		listData = append(listData, NewDisplayNozzleStats(nozzleStats, appName, now))
+	}
+	return listData
+}
@@ -207,6 +207,7 @@ func columnMemoryLimit() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_MAX", "MEM_MAX", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -230,6 +231,7 @@ func columnTotalMemoryReserved() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_RSVD", "MEM_RSVD", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, closeToMemoryQuotaAttentionFunc)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -253,6 +255,7 @@ func columnTotalMemoryReservedPercentOfQuota() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("O_MEM_PER", "O_MEM%", 7,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, closeToMemoryQuotaAttentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_AVG)
 	return c
 }
 
@@ -291,6 +294,7 @@ func columnTotalMemoryUsed() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_USED", "MEM_USED", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -314,6 +318,7 @@ func columnTotalDiskReserved() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("DSK_RSVD", "DSK_RSVD", 10,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -337,6 +342,7 @@ func columnTotalDiskUsed() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("DSK_USED", "DSK_USED", 10,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -15,7 +15,10 @@
 
 package orgView
 
-import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+)
 
 type DisplayOrg struct {
 	*org.OrgMetadata
@@ -41,6 +44,9 @@ type DisplayOrg struct {
 	TotalLogStderr           int64
 
 	HttpAllCount int64
+
+	// Quota usage across all quota dimensions (memory, instances, routes, etc)
+	QuotaUsage *dataCommon.QuotaUsage
 }
 
 func NewDisplayOrg(orgMd *org.OrgMetadata) *DisplayOrg {
@@ -20,6 +20,7 @@ import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
 const HelpText = HelpOverviewText +
 	helpView.HelpHeaderText +
 	HelpColumnsText +
+	HelpQuotaText +
 	HelpLocalViewKeybindings +
 	helpView.HelpTopLevelDataViewKeybindings +
 	helpView.HelpCommonDataViewKeybindings
@@ -46,6 +47,14 @@ const HelpColumnsText = `
   MEM_RSVD - Total memory reserved by all desired containers
   O_MEM%% - Percent of org quota consumed
   MEM_USED - Memory actually in use by all containers
+  O_IMEM%% - Largest container memory as percent of org quota instance
+      memory limit
+  O_INST%% - Percent of org quota app instance limit consumed
+  O_RTE%% - Percent of org quota route limit consumed
+  O_SVC%% - Percent of org quota service instance limit consumed
+  O_TSK%% - Percent of org quota running app task limit consumed
+  Q_FULL - Time until a org quota limit is forecast to be reached based on the
+      reservation trend over the last hour.  Blank if not trending toward a limit.
   DSK_RSVD - Disk reserved by all containers on cell
   DSK_USED - Disk actually in use by all containers
   LOG_OUT - Total number of stdout log events for all instance of app
@@ -54,6 +63,18 @@ const HelpColumnsText = `
 
 `
 
+const HelpQuotaText = `
+**Quota Alerts:**
+
+Quota columns are based on reservations from the cloud controller (apps,
+processes, running tasks, routes and service instances) rather then the
+firehose.  A column is shown in yellow at the warn threshold and red at
+the alert threshold.  An alert message is shown in the header when any
+org or space reaches a threshold.  The thresholds default to 80%% (warn)
+and 95%% (alert) and can be changed with the -quota-warn and -quota-alert
+options.
+`
+
 const HelpLocalViewKeybindings = `
 **Clipboard menu: **
 Press 'c' when a row is selected to open the clipboard menu.
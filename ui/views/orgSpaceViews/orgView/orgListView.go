@@ -27,6 +27,7 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/orgSpaceViews/quotaColumns"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 
@@ -117,6 +118,16 @@ func (asUI *OrgListView) columnDefinitions() []*uiCommon.ListColumn {
 	columns = append(columns, columnTotalMemoryReservedPercentOfQuota())
 	columns = append(columns, columnTotalMemoryUsed())
 
+	quotaUsage := func(data uiCommon.IData) *dataCommon.QuotaUsage {
+		return data.(*DisplayOrg).QuotaUsage
+	}
+	columns = append(columns, quotaColumns.ColumnQuotaInstanceMemoryPercent("O", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaInstancesPercent("O", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaRoutesPercent("O", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaServicesPercent("O", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaAppTasksPercent("O", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaForecast("O", quotaUsage))
+
 	columns = append(columns, columnTotalDiskReserved())
 	columns = append(columns, columnTotalDiskUsed())
 
@@ -225,6 +236,7 @@ func (asUI *OrgListView) postProcessData() map[string]*DisplayOrg {
 
 	// Build Map of Orgs
 	orgs := mdMgr.GetOrgMdManager().GetAll()
+	orgQuotaUsageMap := asUI.GetMasterUI().GetCommonData().GetOrgQuotaUsageMap()
 
 	displayOrgMap := make(map[string]*DisplayOrg)
 	for _, anOrg := range orgs {
@@ -236,6 +248,7 @@ func (asUI *OrgListView) postProcessData() map[string]*DisplayOrg {
 		orgQuotaMd := orgQuotaMdMgr.FindItem(anOrg.QuotaGuid)
 		displayOrg.QuotaName = orgQuotaMd.Name
 		displayOrg.MemoryLimitInBytes = int64(orgQuotaMd.MemoryLimit) * util.MEGABYTE
+		displayOrg.QuotaUsage = orgQuotaUsageMap[anOrg.Guid]
 
 		for _, appStats := range appsByOrgMap[anOrg.Guid] {
 
@@ -254,7 +267,7 @@ func (asUI *OrgListView) postProcessData() map[string]*DisplayOrg {
 				displayOrg.HttpAllCount += appStats.HttpAllCount
 			}
 
-			for _, cs := range appStats.ContainerArray {
+			for _, cs := range appStats.AllContainers() {
 				if cs != nil {
 					displayOrg.TotalLogStdout += cs.OutCount
 					displayOrg.TotalLogStderr += cs.ErrCount
@@ -0,0 +1,152 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package quotaColumns
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+// Quota columns shared by the org and space list views.  The view supplies a function
+// to get the quota usage of a row and a prefix ("O" or "S") for the column ids.
+
+// Get the quota usage of a list row.  Can return nil if the row has no quota usage.
+type QuotaUsageFunc func(data uiCommon.IData) *dataCommon.QuotaUsage
+
+// Percent of quota limit used.  Returns false if there is no quota or the dimension is unlimited
+func quotaPercentOfLimit(quotaUsage *dataCommon.QuotaUsage, dimension dataCommon.QuotaDimension) (float64, bool) {
+	if quotaUsage == nil || !quotaUsage.HasQuota {
+		return 0, false
+	}
+	dimensionUsage := quotaUsage.Dimensions[dimension]
+	if !dimensionUsage.IsLimited() {
+		return 0, false
+	}
+	return dimensionUsage.PercentOfLimit, true
+}
+
+func columnQuotaPercentOfLimit(dimension dataCommon.QuotaDimension, quotaUsageFunc QuotaUsageFunc, id string, label string) *uiCommon.ListColumn {
+	defaultColSize := 7
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		percent1, _ := quotaPercentOfLimit(quotaUsageFunc(c1.(uiCommon.IData)), dimension)
+		percent2, _ := quotaPercentOfLimit(quotaUsageFunc(c2.(uiCommon.IData)), dimension)
+		return percent1 < percent2
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		percent, limited := quotaPercentOfLimit(quotaUsageFunc(data), dimension)
+		if !limited {
+			return fmt.Sprintf("%7v", "--")
+		}
+		return fmt.Sprintf("%7.1f", percent)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		percent, _ := quotaPercentOfLimit(quotaUsageFunc(data), dimension)
+		return fmt.Sprintf("%v", percent)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		attentionType := uiCommon.ATTENTION_NORMAL
+		percent, limited := quotaPercentOfLimit(quotaUsageFunc(data), dimension)
+		if limited {
+			switch {
+			case percent >= float64(config.QuotaAlertPercent):
+				attentionType = uiCommon.ATTENTION_HOT
+			case percent >= float64(config.QuotaWarnPercent):
+				attentionType = uiCommon.ATTENTION_WARM
+			}
+		}
+		return attentionType
+	}
+	c := uiCommon.NewListColumn(id, label, defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func ColumnQuotaInstanceMemoryPercent(idPrefix string, quotaUsageFunc QuotaUsageFunc) *uiCommon.ListColumn {
+	return columnQuotaPercentOfLimit(dataCommon.QUOTA_INSTANCE_MEMORY, quotaUsageFunc, idPrefix+"_IMEM_PER", idPrefix+"_IMEM%")
+}
+
+func ColumnQuotaInstancesPercent(idPrefix string, quotaUsageFunc QuotaUsageFunc) *uiCommon.ListColumn {
+	return columnQuotaPercentOfLimit(dataCommon.QUOTA_INSTANCES, quotaUsageFunc, idPrefix+"_INST_PER", idPrefix+"_INST%")
+}
+
+func ColumnQuotaRoutesPercent(idPrefix string, quotaUsageFunc QuotaUsageFunc) *uiCommon.ListColumn {
+	return columnQuotaPercentOfLimit(dataCommon.QUOTA_ROUTES, quotaUsageFunc, idPrefix+"_RTE_PER", idPrefix+"_RTE%")
+}
+
+func ColumnQuotaServicesPercent(idPrefix string, quotaUsageFunc QuotaUsageFunc) *uiCommon.ListColumn {
+	return columnQuotaPercentOfLimit(dataCommon.QUOTA_SERVICES, quotaUsageFunc, idPrefix+"_SVC_PER", idPrefix+"_SVC%")
+}
+
+func ColumnQuotaAppTasksPercent(idPrefix string, quotaUsageFunc QuotaUsageFunc) *uiCommon.ListColumn {
+	return columnQuotaPercentOfLimit(dataCommon.QUOTA_APP_TASKS, quotaUsageFunc, idPrefix+"_TSK_PER", idPrefix+"_TSK%")
+}
+
+// Time until the first quota limit is forecast to be reached
+func quotaForecastDuration(quotaUsage *dataCommon.QuotaUsage) *time.Duration {
+	if quotaUsage == nil || !quotaUsage.HasQuota {
+		return nil
+	}
+	_, forecastTime := quotaUsage.EarliestForecastLimitTime()
+	if forecastTime == nil {
+		return nil
+	}
+	forecastDuration := forecastTime.Sub(time.Now())
+	return &forecastDuration
+}
+
+func ColumnQuotaForecast(idPrefix string, quotaUsageFunc QuotaUsageFunc) *uiCommon.ListColumn {
+	defaultColSize := 8
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		d1 := quotaForecastDuration(quotaUsageFunc(c1.(uiCommon.IData)))
+		d2 := quotaForecastDuration(quotaUsageFunc(c2.(uiCommon.IData)))
+		switch {
+		case d1 == nil:
+			return false
+		case d2 == nil:
+			return true
+		}
+		return *d1 < *d2
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		forecastDuration := quotaForecastDuration(quotaUsageFunc(data))
+		if forecastDuration == nil {
+			return fmt.Sprintf("%8v", "--")
+		}
+		return fmt.Sprintf("%8v", util.FormatDuration(forecastDuration, false))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		forecastDuration := quotaForecastDuration(quotaUsageFunc(data))
+		if forecastDuration == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", forecastDuration.Seconds())
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		attentionType := uiCommon.ATTENTION_NORMAL
+		forecastDuration := quotaForecastDuration(quotaUsageFunc(data))
+		if forecastDuration != nil && *forecastDuration < time.Hour*config.QuotaForecastWarnHours {
+			attentionType = uiCommon.ATTENTION_WARM
+		}
+		return attentionType
+	}
+	c := uiCommon.NewListColumn(idPrefix+"_Q_FULL", "Q_FULL", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
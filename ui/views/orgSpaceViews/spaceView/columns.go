@@ -174,6 +174,7 @@ func columnMemoryLimit() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_MAX", "MEM_MAX", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -197,6 +198,7 @@ func columnTotalMemoryReserved() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_RSVD", "MEM_RSVD", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, closeToMemoryEitherQuotaAttentionFunc)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -220,6 +222,7 @@ func columnTotalMemoryReservedPercentOfSpaceQuota() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("S_MEM_PER", "S_MEM%", 7,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, closeToMemorySpaceQuotaAttentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_AVG)
 	return c
 }
 
@@ -243,6 +246,7 @@ func columnTotalMemoryReservedPercentOfOrgQuota() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("O_MEM_PER", "O_MEM%", 7,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, closeToMemoryOrgQuotaAttentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_AVG)
 	return c
 }
 
@@ -313,6 +317,7 @@ func columnTotalMemoryUsed() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_USED", "MEM_USED", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -336,6 +341,7 @@ func columnTotalDiskReserved() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("DSK_RSVD", "DSK_RSVD", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
@@ -359,6 +365,7 @@ func columnTotalDiskUsed() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("DSK_USED", "DSK_USED", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
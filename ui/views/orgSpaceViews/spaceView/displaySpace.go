@@ -15,7 +15,10 @@
 
 package spaceView
 
-import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+)
 
 type DisplaySpace struct {
 	*space.SpaceMetadata
@@ -43,6 +46,9 @@ type DisplaySpace struct {
 
 	HttpAllCount int64
 
+	// Quota usage across all quota dimensions (memory, instances, routes, etc)
+	QuotaUsage *dataCommon.QuotaUsage
+
 	IsolationSegmentName string
 }
 
@@ -20,6 +20,7 @@ import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
 const HelpText = HelpOverviewText +
 	helpView.HelpHeaderText +
 	HelpColumnsText +
+	HelpQuotaText +
 	HelpLocalViewKeybindings +
 	helpView.HelpTopLevelDataViewKeybindings +
 	helpView.HelpCommonDataViewKeybindings
@@ -45,6 +46,14 @@ const HelpColumnsText = `
   S_MEM%% - Percent of space quota consumed
   O_MEM%% - Percent of org quota consumed
   MEM_USED - Memory actually in use by all containers
+  S_IMEM%% - Largest container memory as percent of space quota instance
+      memory limit
+  S_INST%% - Percent of space quota app instance limit consumed
+  S_RTE%% - Percent of space quota route limit consumed
+  S_SVC%% - Percent of space quota service instance limit consumed
+  S_TSK%% - Percent of space quota running app task limit consumed
+  Q_FULL - Time until a space quota limit is forecast to be reached based on the
+      reservation trend over the last hour.  Blank if not trending toward a limit.
   DSK_RSVD - Disk reserved by all containers on cell
   DSK_USED - Disk actually in use by all containers
   LOG_OUT - Total number of stdout log events for all instance of app
@@ -53,6 +62,18 @@ const HelpColumnsText = `
   ISO_SEG - Isolation Segment assigned to space
 `
 
+const HelpQuotaText = `
+**Quota Alerts:**
+
+Quota columns are based on reservations from the cloud controller (apps,
+processes, running tasks, routes and service instances) rather then the
+firehose.  A column is shown in yellow at the warn threshold and red at
+the alert threshold.  An alert message is shown in the header when any
+org or space reaches a threshold.  The thresholds default to 80%% (warn)
+and 95%% (alert) and can be changed with the -quota-warn and -quota-alert
+options.
+`
+
 const HelpLocalViewKeybindings = `
 **Clipboard menu: **
 Press 'c' when a row is selected to open the clipboard menu.
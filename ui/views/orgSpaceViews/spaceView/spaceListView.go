@@ -28,6 +28,7 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/orgSpaceViews/quotaColumns"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
@@ -99,7 +100,7 @@ func (asUI *SpaceListView) enterAction(g *gocui.Gui, v *gocui.View) error {
 		detailView := appView.NewAppListView(asUI.GetMasterUI(), asUI, "appBySpaceView",
 			bottomMargin,
 			asUI.GetEventProcessor(),
-			highlightKey)
+			highlightKey, "")
 
 		asUI.SetDetailView(detailView)
 		asUI.GetMasterUI().OpenView(g, detailView)
@@ -126,6 +127,16 @@ func (asUI *SpaceListView) columnDefinitions() []*uiCommon.ListColumn {
 	columns = append(columns, columnTotalMemoryReservedPercentOfOrgQuota())
 	columns = append(columns, columnTotalMemoryUsed())
 
+	quotaUsage := func(data uiCommon.IData) *dataCommon.QuotaUsage {
+		return data.(*DisplaySpace).QuotaUsage
+	}
+	columns = append(columns, quotaColumns.ColumnQuotaInstanceMemoryPercent("S", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaInstancesPercent("S", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaRoutesPercent("S", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaServicesPercent("S", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaAppTasksPercent("S", quotaUsage))
+	columns = append(columns, quotaColumns.ColumnQuotaForecast("S", quotaUsage))
+
 	columns = append(columns, columnTotalDiskReserved())
 	columns = append(columns, columnTotalDiskUsed())
 
@@ -234,10 +245,12 @@ func (asUI *SpaceListView) postProcessData() map[string]*DisplaySpace {
 
 	// Build Map of Spaces
 	displaySpaceMap := make(map[string]*DisplaySpace)
+	spaceQuotaUsageMap := asUI.GetMasterUI().GetCommonData().GetSpaceQuotaUsageMap()
 	for _, spaceMetadata := range orgSpaces {
 		displaySpace := NewDisplaySpace(spaceMetadata)
 		displaySpaceMap[spaceMetadata.Guid] = displaySpace
 		displaySpace.NumberOfApps = len(appsBySpaceMap[spaceMetadata.Guid])
+		displaySpace.QuotaUsage = spaceQuotaUsageMap[spaceMetadata.Guid]
 
 		if spaceMetadata.QuotaGuid != "" {
 			spaceQuotaMd := spaceQuotaMdMgr.FindItem(spaceMetadata.QuotaGuid)
@@ -268,7 +281,7 @@ func (asUI *SpaceListView) postProcessData() map[string]*DisplaySpace {
 				displaySpace.HttpAllCount += appStats.HttpAllCount
 			}
 
-			for _, cs := range appStats.ContainerArray {
+			for _, cs := range appStats.AllContainers() {
 				if cs != nil {
 					displaySpace.TotalLogStdout += cs.OutCount
 					displaySpace.TotalLogStderr += cs.ErrCount
@@ -0,0 +1,313 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package platformHealthView
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func componentAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	return statusAttentionType(data.(*DisplayComponentStats).Status)
+}
+
+func metricAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	return statusAttentionType(data.(*DisplayComponentMetric).Status)
+}
+
+func columnComponent() *uiCommon.ListColumn {
+	defaultColSize := 16
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayComponentStats).Component, c2.(*DisplayComponentStats).Component)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentStats)
+		return util.FormatDisplayData(stats.Component, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentStats)
+		return stats.Component
+	}
+	c := uiCommon.NewListColumn("COMPONENT", "COMPONENT", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, componentAttentionFunc)
+	return c
+}
+
+func columnStatus() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentStats).Status < c2.(*DisplayComponentStats).Status
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentStats)
+		return fmt.Sprintf("%-6v", stats.Status)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentStats)
+		return stats.Status
+	}
+	c := uiCommon.NewListColumn("STATUS", "STATUS", 6,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, componentAttentionFunc)
+	return c
+}
+
+func columnJobName() *uiCommon.ListColumn {
+	defaultColSize := 20
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayComponentStats).JobName, c2.(*DisplayComponentStats).JobName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentStats)
+		return util.FormatDisplayData(stats.JobName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentStats)
+		return stats.JobName
+	}
+	c := uiCommon.NewListColumn("JOB_NAME", "JOB_NAME", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+// Job Index in PCF 1.8 is now a GUID not a integer number
+func columnJobIndex() *uiCommon.ListColumn {
+	defaultColSize := 36
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentStats).JobIndex < c2.(*DisplayComponentStats).JobIndex
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentStats)
+		return util.FormatDisplayData(stats.JobIndex, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentStats)
+		return stats.JobIndex
+	}
+	c := uiCommon.NewListColumn("JOB_IDX", "JOB_IDX", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnIp() *uiCommon.ListColumn {
+	defaultColSize := 16
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.Ip2long(c1.(*DisplayComponentStats).Ip) < util.Ip2long(c2.(*DisplayComponentStats).Ip)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentStats)
+		return util.FormatDisplayData(stats.Ip, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentStats)
+		return stats.Ip
+	}
+	c := uiCommon.NewListColumn("IP", "IP", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnSummary() *uiCommon.ListColumn {
+	defaultColSize := 70
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentStats).Summary < c2.(*DisplayComponentStats).Summary
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentStats)
+		return util.FormatDisplayData(stats.Summary, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentStats)
+		return stats.Summary
+	}
+	c := uiCommon.NewListColumn("SUMMARY", "SUMMARY", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, componentAttentionFunc)
+	return c
+}
+
+func columnLastUpdateTime() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentStats).LastUpdateTime.Before(*c2.(*DisplayComponentStats).LastUpdateTime)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentStats)
+		return formatTime(stats.LastUpdateTime)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentStats)
+		return fmt.Sprintf("%v", stats.LastUpdateTime)
+	}
+	c := uiCommon.NewListColumn("LAST_UPDATE", "LAST_UPDATE", 11,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return fmt.Sprintf("%-11v", "--")
+	}
+	return fmt.Sprintf("%-11v", t.Local().Format("15:04:05"))
+}
+
+func columnMetricLabel() *uiCommon.ListColumn {
+	defaultColSize := 16
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentMetric).Def.Label < c2.(*DisplayComponentMetric).Def.Label
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentMetric)
+		return util.FormatDisplayData(stats.Def.Label, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentMetric)
+		return stats.Def.Label
+	}
+	c := uiCommon.NewListColumn("LABEL", "METRIC", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, metricAttentionFunc)
+	return c
+}
+
+func columnMetricName() *uiCommon.ListColumn {
+	defaultColSize := 35
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentMetric).Def.Name < c2.(*DisplayComponentMetric).Def.Name
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentMetric)
+		return util.FormatDisplayData(stats.Def.Name, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentMetric)
+		return stats.Def.Name
+	}
+	c := uiCommon.NewListColumn("NAME", "FIREHOSE_NAME", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnMetricValue() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		m1 := c1.(*DisplayComponentMetric)
+		m2 := c2.(*DisplayComponentMetric)
+		return m1.ThresholdValue(m1.Def) < m2.ThresholdValue(m2.Def)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentMetric)
+		return fmt.Sprintf("%12v", stats.FormatValue(stats.Def))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentMetric)
+		return fmt.Sprintf("%v", stats.ThresholdValue(stats.Def))
+	}
+	c := uiCommon.NewListColumn("VALUE", "VALUE", 12,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, metricAttentionFunc)
+	return c
+}
+
+func columnMetricTotal() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentMetric).Value < c2.(*DisplayComponentMetric).Value
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentMetric)
+		if !stats.Def.IsCounter() {
+			return fmt.Sprintf("%14v", "--")
+		}
+		return fmt.Sprintf("%14v", util.Format(int64(stats.Value)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentMetric)
+		return fmt.Sprintf("%v", stats.Value)
+	}
+	c := uiCommon.NewListColumn("TOTAL", "COUNTER_TOTAL", 14,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func formatThreshold(threshold float64) string {
+	if threshold <= 0 {
+		return fmt.Sprintf("%7v", "--")
+	}
+	return fmt.Sprintf("%7v", threshold)
+}
+
+func columnMetricWarnThreshold() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentMetric).Def.WarnThreshold < c2.(*DisplayComponentMetric).Def.WarnThreshold
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentMetric)
+		return formatThreshold(stats.Def.WarnThreshold)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentMetric)
+		return fmt.Sprintf("%v", stats.Def.WarnThreshold)
+	}
+	c := uiCommon.NewListColumn("WARN", "WARN", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnMetricAlertThreshold() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentMetric).Def.AlertThreshold < c2.(*DisplayComponentMetric).Def.AlertThreshold
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentMetric)
+		return formatThreshold(stats.Def.AlertThreshold)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentMetric)
+		return fmt.Sprintf("%v", stats.Def.AlertThreshold)
+	}
+	c := uiCommon.NewListColumn("ALERT", "ALERT", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnMetricStatus() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentMetric).Status < c2.(*DisplayComponentMetric).Status
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentMetric)
+		return fmt.Sprintf("%-6v", stats.Status)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentMetric)
+		return stats.Status
+	}
+	c := uiCommon.NewListColumn("STATUS", "STATUS", 6,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, metricAttentionFunc)
+	return c
+}
+
+func columnMetricLastUpdateTime() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayComponentMetric).LastUpdateTime.Before(*c2.(*DisplayComponentMetric).LastUpdateTime)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayComponentMetric)
+		return formatTime(stats.LastUpdateTime)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayComponentMetric)
+		return fmt.Sprintf("%v", stats.LastUpdateTime)
+	}
+	c := uiCommon.NewListColumn("LAST_UPDATE", "LAST_UPDATE", 11,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
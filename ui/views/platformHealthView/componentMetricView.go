@@ -0,0 +1,122 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package platformHealthView
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventComponent"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+// List of the well-known metrics of a single component instance
+type ComponentMetricView struct {
+	*dataView.DataListView
+	componentKey string
+}
+
+func NewComponentMetricView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, topMargin, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	componentKey string) *ComponentMetricView {
+
+	asUI := &ComponentMetricView{componentKey: componentKey}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("LABEL", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, topMargin, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string {
+		componentStats := asUI.GetDisplayedEventData().ComponentMap[componentKey]
+		if componentStats == nil {
+			return "Component Metrics"
+		}
+		return fmt.Sprintf("%v %v/%v (%v) - Metrics", componentStats.Component,
+			componentStats.JobName, componentStats.JobIndex, componentStats.Ip)
+	})
+	dataListView.HelpText = HelpTextMetric
+	dataListView.HelpTextTips = HelpTextTipsMetric
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *ComponentMetricView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnMetricLabel())
+	columns = append(columns, columnMetricStatus())
+	columns = append(columns, columnMetricValue())
+	columns = append(columns, columnMetricWarnThreshold())
+	columns = append(columns, columnMetricAlertThreshold())
+	columns = append(columns, columnMetricTotal())
+	columns = append(columns, columnMetricName())
+	columns = append(columns, columnMetricLastUpdateTime())
+	return columns
+}
+
+func (asUI *ComponentMetricView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeComponentMetricView); err != nil {
+		log.Panicln(err)
+	}
+	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeComponentMetricView); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *ComponentMetricView) closeComponentMetricView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (asUI *ComponentMetricView) GetListData() []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0)
+	componentStats := asUI.GetDisplayedEventData().ComponentMap[asUI.componentKey]
+	if componentStats == nil {
+		return listData
+	}
+	now := time.Now()
+	for _, def := range eventComponent.ComponentMetricDefs(componentStats.Origin) {
+		metricValue := componentStats.MetricMap[def.Name]
+		if metricValue == nil {
+			continue
+		}
+		displayMetric := &DisplayComponentMetric{
+			ComponentMetricValue: metricValue,
+			Def:                  def,
+			Status:               metricValue.Status(def, now),
+		}
+		listData = append(listData, displayMetric)
+	}
+	return listData
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package platformHealthView
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventComponent"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+)
+
+type DisplayComponentStats struct {
+	*eventComponent.ComponentStats
+	Status  string
+	Summary string
+}
+
+func NewDisplayComponentStats(componentStats *eventComponent.ComponentStats, now time.Time) *DisplayComponentStats {
+	stats := &DisplayComponentStats{ComponentStats: componentStats}
+	stats.Status = componentStats.Status(now)
+
+	summary := make([]string, 0)
+	for _, def := range eventComponent.ComponentMetricDefs(componentStats.Origin) {
+		if !def.Summary {
+			continue
+		}
+		metricValue := componentStats.MetricMap[def.Name]
+		if metricValue == nil {
+			continue
+		}
+		summary = append(summary, fmt.Sprintf("%v:%v", def.Label, metricValue.FormatValue(def)))
+	}
+	stats.Summary = strings.Join(summary, "  ")
+	return stats
+}
+
+// A single metric of a component instance
+type DisplayComponentMetric struct {
+	*eventComponent.ComponentMetricValue
+	Def    *eventComponent.ComponentMetricDef
+	Status string
+}
+
+func (dm *DisplayComponentMetric) Id() string {
+	return dm.Def.Name
+}
+
+func statusAttentionType(status string) uiCommon.AttentionType {
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch status {
+	case eventComponent.STATUS_ALERT:
+		attentionType = uiCommon.ATTENTION_HOT
+	case eventComponent.STATUS_WARN:
+		attentionType = uiCommon.ATTENTION_WARM
+	case eventComponent.STATUS_STALE:
+		attentionType = uiCommon.ATTENTION_STATE_UNKNOWN
+	}
+	return attentionType
+}
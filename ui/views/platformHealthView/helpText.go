@@ -0,0 +1,79 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package platformHealthView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + HelpLocalViewKeybindings + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpTextMetric = HelpMetricOverviewText + helpView.HelpHeaderText + HelpMetricColumnsText + helpView.HelpChildLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Platform Health View**
+
+Platform health view interprets well-known metrics sent on the firehose
+by core platform components:
+  Router - gorouter latency, requests, bad gateways (502) and 5xx responses
+  Doppler - envelopes received (ingress) and dropped
+  UAA - completed, unhealthy and in-flight requests
+  Cloud Controller - job queue length, failed jobs and outstanding requests
+  Diego BBS - desired, running, missing and crashed LRPs, request latency
+
+Each component instance is shown with the worst status of its metrics:
+  OK - All metrics within thresholds
+  WARN - A metric is at or above its warning threshold
+  ALERT - A metric is at or above its alert threshold
+  STALE - No metrics received from the instance in the last 3 minutes
+
+Counter metrics (e.g., bad gateways) are compared per minute.  Use the
+Event Stats view to see all envelopes sent by each origin.
+`
+
+const HelpColumnsText = `
+**Columns:**
+
+  COMPONENT - Platform component
+  STATUS - OK, WARN, ALERT or STALE
+  SUMMARY - Key metrics of the component instance
+  IP - IP address of component VM
+  JOB_NAME - BOSH job name
+  JOB_IDX - BOSH job index
+  LAST_UPDATE - Time of last metric received from the instance
+`
+
+const HelpLocalViewKeybindings = `
+**Display: **
+  ENTER - Show all metrics of the selected component instance
+`
+
+const HelpMetricOverviewText = `
+**Component Metrics View**
+
+Component metrics view shows each well-known metric received from the
+selected platform component instance.
+`
+
+const HelpMetricColumnsText = `
+**Columns:**
+
+  METRIC - Metric (/MIN is the count in the last full minute)
+  STATUS - OK, WARN, ALERT or STALE
+  VALUE - Last value (or count per minute for counters)
+  WARN - Warning threshold
+  ALERT - Alert threshold
+  COUNTER_TOTAL - Total reported by counter metrics
+  FIREHOSE_NAME - Name of the ValueMetric or CounterEvent on the firehose
+  LAST_UPDATE - Time metric was last received
+`
@@ -0,0 +1,106 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package platformHealthView
+
+import (
+	"log"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+type PlatformHealthView struct {
+	*dataView.DataListView
+}
+
+func NewPlatformHealthView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *PlatformHealthView {
+
+	asUI := &PlatformHealthView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("COMPONENT", false),
+		uiCommon.NewSortColumn("JOB_NAME", false),
+		uiCommon.NewSortColumn("IP", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string { return "Platform Health" })
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *PlatformHealthView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnComponent())
+	columns = append(columns, columnStatus())
+	columns = append(columns, columnSummary())
+	columns = append(columns, columnIp())
+	columns = append(columns, columnJobName())
+	columns = append(columns, columnJobIndex())
+	columns = append(columns, columnLastUpdateTime())
+	return columns
+}
+
+func (asUI *PlatformHealthView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *PlatformHealthView) enterAction(g *gocui.Gui, v *gocui.View) error {
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	if highlightKey != "" {
+		topMargin, bottomMargin := asUI.GetMargins()
+
+		detailView := NewComponentMetricView(asUI.GetMasterUI(), asUI,
+			"componentMetricView",
+			topMargin, bottomMargin,
+			asUI.GetEventProcessor(),
+			highlightKey)
+
+		asUI.SetDetailView(detailView)
+		asUI.GetMasterUI().OpenView(g, detailView)
+	}
+	return nil
+}
+
+func (asUI *PlatformHealthView) GetListData() []uiCommon.IData {
+	now := time.Now()
+	componentMap := asUI.GetDisplayedEventData().ComponentMap
+	listData := make([]uiCommon.IData, 0, len(componentMap))
+	for _, componentStats := range componentMap {
+		listData = append(listData, NewDisplayComponentStats(componentStats, now))
+	}
+	return listData
+}
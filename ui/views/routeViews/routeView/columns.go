@@ -174,6 +174,7 @@ func columnPort() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("PORT", "PORT", defaultColSize,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
 	return c
 }
 
@@ -310,6 +311,7 @@ func columnResponseContentLength() *uiCommon.ListColumn {
 	c := uiCommon.NewListColumn("RESP_DATA", "RESP_DATA", defaultColSize,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
 
+	c.SetAggregateDisplayFunc(uiCommon.AggregateByteSizeDisplay)
 	return c
 }
 
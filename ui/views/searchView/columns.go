@@ -0,0 +1,92 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package searchView
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func columnType() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*SearchResult).Type < c2.(*SearchResult).Type
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*SearchResult)
+		return util.FormatDisplayData(result.Type, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*SearchResult)
+		return result.Type
+	}
+	c := uiCommon.NewListColumn("TYPE", "TYPE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnName() *uiCommon.ListColumn {
+	defaultColSize := 50
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*SearchResult).Name, c2.(*SearchResult).Name)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*SearchResult)
+		return util.FormatDisplayData(result.Name, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*SearchResult)
+		return result.Name
+	}
+	c := uiCommon.NewListColumn("NAME", "NAME", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnDetail() *uiCommon.ListColumn {
+	defaultColSize := 40
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*SearchResult).Detail, c2.(*SearchResult).Detail)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*SearchResult)
+		return util.FormatDisplayData(result.Detail, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*SearchResult)
+		return result.Detail
+	}
+	c := uiCommon.NewListColumn("DETAIL", "DETAIL", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnKey() *uiCommon.ListColumn {
+	defaultColSize := 36
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*SearchResult).Key < c2.(*SearchResult).Key
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		result := data.(*SearchResult)
+		return util.FormatDisplayData(result.Key, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		result := data.(*SearchResult)
+		return result.Key
+	}
+	c := uiCommon.NewListColumn("ID", "ID", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
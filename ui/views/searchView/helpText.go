@@ -0,0 +1,40 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package searchView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + HelpColumnsText + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Search View**
+
+Search view lists every app, route, space, org and cell whose name
+contains the search text (ignoring case).  Apps are also matched by
+guid.  Routes are matched by host, domain and path.  Cells are matched
+by IP.
+
+Press '/' from any top level view to start a new search.  Highlight a
+row and press ENTER to open the detail view of the match.
+`
+
+const HelpColumnsText = `
+**Columns:**
+
+  TYPE - APP, ROUTE, SPACE, ORG or CELL
+  NAME - Name of the match (route URL, cell IP)
+  DETAIL - Space and org of an app or route, org of a space, job of a cell
+  ID - Guid of the match (IP of a cell)
+`
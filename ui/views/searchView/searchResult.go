@@ -0,0 +1,40 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package searchView
+
+const (
+	TYPE_APP   = "APP"
+	TYPE_ROUTE = "ROUTE"
+	TYPE_SPACE = "SPACE"
+	TYPE_ORG   = "ORG"
+	TYPE_CELL  = "CELL"
+)
+
+// A single match of a global search.  Key is the id the detail view of
+// the match is opened with (guid or cell IP)
+type SearchResult struct {
+	Type   string
+	Key    string
+	Name   string
+	Detail string
+}
+
+func NewSearchResult(resultType, key, name, detail string) *SearchResult {
+	return &SearchResult{Type: resultType, Key: key, Name: name, Detail: detail}
+}
+
+func (sr *SearchResult) Id() string {
+	return sr.Type + "/" + sr.Key
+}
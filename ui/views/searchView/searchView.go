@@ -0,0 +1,238 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package searchView
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appDetailView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellDetailView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/orgSpaceViews/spaceView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/routeViews/routeMapView"
+	"github.com/jroimartin/gocui"
+)
+
+type SearchView struct {
+	*dataView.DataListView
+	query     string
+	resultMap map[string]*SearchResult
+}
+
+func NewSearchView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	query string) *SearchView {
+
+	asUI := &SearchView{query: query, resultMap: make(map[string]*SearchResult)}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("TYPE", false),
+		uiCommon.NewSortColumn("NAME", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(asUI.title)
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *SearchView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *SearchView) title() string {
+	return fmt.Sprintf("Search results for \"%v\"", asUI.query)
+}
+
+func (asUI *SearchView) GetQuery() string {
+	return asUI.query
+}
+
+func (asUI *SearchView) SetQuery(query string) {
+	asUI.query = query
+}
+
+func (asUI *SearchView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnType())
+	columns = append(columns, columnName())
+	columns = append(columns, columnDetail())
+	columns = append(columns, columnKey())
+	return columns
+}
+
+func (asUI *SearchView) enterAction(g *gocui.Gui, v *gocui.View) error {
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	result := asUI.resultMap[highlightKey]
+	if result == nil {
+		return nil
+	}
+	topMargin, bottomMargin := asUI.GetMargins()
+
+	var detailView dataView.DataListViewInterface
+	switch result.Type {
+	case TYPE_APP:
+		detailView = appDetailView.NewAppDetailView(asUI.GetMasterUI(), asUI,
+			"appDetailView",
+			bottomMargin,
+			asUI.GetEventProcessor(),
+			result.Key)
+	case TYPE_ROUTE:
+		detailView = routeMapView.NewRouteMapListView(asUI.GetMasterUI(), asUI,
+			"routeMapListView",
+			topMargin, bottomMargin,
+			asUI.GetEventProcessor(),
+			result.Key)
+	case TYPE_SPACE:
+		detailView = appView.NewAppListView(asUI.GetMasterUI(), asUI,
+			"appBySpaceView",
+			bottomMargin,
+			asUI.GetEventProcessor(),
+			result.Key, "")
+	case TYPE_ORG:
+		detailView = spaceView.NewSpaceListView(asUI.GetMasterUI(), asUI,
+			"spaceListView",
+			bottomMargin,
+			asUI.GetEventProcessor(),
+			result.Key)
+	case TYPE_CELL:
+		detailView = cellDetailView.NewCellDetailView(asUI.GetMasterUI(), asUI,
+			"cellDetailView",
+			topMargin, bottomMargin,
+			asUI.GetEventProcessor(),
+			result.Key)
+	default:
+		return nil
+	}
+
+	asUI.SetDetailView(detailView)
+	asUI.GetMasterUI().OpenView(g, detailView)
+	return nil
+}
+
+// Search is run against the metadata on each refresh so newly loaded
+// metadata shows up without having to search again
+func (asUI *SearchView) GetListData() []uiCommon.IData {
+	resultMap := make(map[string]*SearchResult)
+	query := strings.ToLower(strings.TrimSpace(asUI.query))
+	if query != "" {
+		for _, result := range asUI.search(query) {
+			resultMap[result.Id()] = result
+		}
+	}
+	asUI.resultMap = resultMap
+
+	listData := make([]uiCommon.IData, 0, len(resultMap))
+	for _, result := range resultMap {
+		listData = append(listData, result)
+	}
+	return listData
+}
+
+func (asUI *SearchView) search(query string) []*SearchResult {
+	mdGlobalMgr := asUI.GetMdGlobalMgr()
+	spaceMdMgr := mdGlobalMgr.GetSpaceMdManager()
+	orgMdMgr := mdGlobalMgr.GetOrgMdManager()
+
+	matches := func(values ...string) bool {
+		for _, value := range values {
+			if strings.Contains(strings.ToLower(value), query) {
+				return true
+			}
+		}
+		return false
+	}
+	spaceDetail := func(spaceGuid string) string {
+		if spaceGuid == "" {
+			return ""
+		}
+		spaceMd := spaceMdMgr.FindItem(spaceGuid)
+		orgMd := orgMdMgr.FindItem(spaceMd.OrgGuid)
+		return fmt.Sprintf("%v / %v", orgMd.Name, spaceMd.Name)
+	}
+
+	results := make([]*SearchResult, 0)
+
+	for _, appMd := range mdGlobalMgr.GetAppMdManager().AllApps() {
+		if matches(appMd.Name, appMd.Guid) {
+			results = append(results, NewSearchResult(TYPE_APP, appMd.Guid, appMd.Name, spaceDetail(appMd.SpaceGuid)))
+		}
+	}
+
+	domainFinder := mdGlobalMgr.GetDomainFinder()
+	for _, routeMd := range mdGlobalMgr.GetRouteMdManager().GetAll() {
+		domainName := domainFinder.FindDomainMetadata(routeMd.DomainGuid).Name
+		if matches(routeMd.Host, domainName, routeMd.Path) {
+			results = append(results, NewSearchResult(TYPE_ROUTE, routeMd.Guid,
+				routeName(routeMd.Host, domainName, routeMd.Path, routeMd.Port), spaceDetail(routeMd.SpaceGuid)))
+		}
+	}
+
+	for _, spaceMd := range spaceMdMgr.GetAll() {
+		if matches(spaceMd.Name) {
+			results = append(results, NewSearchResult(TYPE_SPACE, spaceMd.Guid, spaceMd.Name, orgMdMgr.FindItem(spaceMd.OrgGuid).Name))
+		}
+	}
+
+	for _, orgMd := range orgMdMgr.GetAll() {
+		if matches(orgMd.Name) {
+			results = append(results, NewSearchResult(TYPE_ORG, orgMd.Guid, orgMd.Name, ""))
+		}
+	}
+
+	for cellIp, cellStats := range asUI.GetDisplayedEventData().CellMap {
+		if matches(cellIp) {
+			detail := ""
+			if cellStats.JobName != "" {
+				detail = fmt.Sprintf("%v/%v", cellStats.JobName, cellStats.JobIndex)
+			}
+			results = append(results, NewSearchResult(TYPE_CELL, cellIp, cellIp, detail))
+		}
+	}
+
+	return results
+}
+
+func routeName(hostName, domainName, pathName string, port int) string {
+	if port > 0 {
+		return fmt.Sprintf("%v:%v", domainName, port)
+	}
+	if hostName == "" {
+		return domainName + pathName
+	}
+	return fmt.Sprintf("%v.%v%v", hostName, domainName, pathName)
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package stagingView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func stateAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	stats := data.(*DisplayStagingStats)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch stats.State {
+	case STATE_FAILED:
+		attentionType = uiCommon.ATTENTION_STATE_CRASHED
+	case STATE_STAGING:
+		attentionType = uiCommon.ATTENTION_ACTIVITY
+	case STATE_UNKNOWN:
+		attentionType = uiCommon.ATTENTION_STATE_UNKNOWN
+	}
+	return attentionType
+}
+
+func ColumnAppName() *uiCommon.ListColumn {
+	defaultColSize := 30
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayStagingStats).AppName, c2.(*DisplayStagingStats).AppName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		return util.FormatDisplayData(stats.AppName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return stats.AppName
+	}
+	c := uiCommon.NewListColumn("appName", "APPLICATION", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnSpaceName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayStagingStats).SpaceName, c2.(*DisplayStagingStats).SpaceName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		return util.FormatDisplayData(stats.SpaceName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return stats.SpaceName
+	}
+	c := uiCommon.NewListColumn("SPACE", "SPACE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnOrgName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayStagingStats).OrgName, c2.(*DisplayStagingStats).OrgName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		return util.FormatDisplayData(stats.OrgName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return stats.OrgName
+	}
+	c := uiCommon.NewListColumn("ORG", "ORG", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnState() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStagingStats).State < c2.(*DisplayStagingStats).State
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		return fmt.Sprintf("%-9v", stats.State)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return stats.State
+	}
+	c := uiCommon.NewListColumn("STATE", "STATE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnStartTime() *uiCommon.ListColumn {
+	defaultColSize := 19
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStagingStats).StartTime.Before(*c2.(*DisplayStagingStats).StartTime)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		return fmt.Sprintf("%-19v", stats.StartTime.Local().Format("01-02-2006 15:04:05"))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return fmt.Sprintf("%v", stats.StartTime)
+	}
+	c := uiCommon.NewListColumn("START_TIME", "START_TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnDuration() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		d1 := c1.(*DisplayStagingStats).Duration
+		d2 := c2.(*DisplayStagingStats).Duration
+		if d1 == nil {
+			return true
+		}
+		if d2 == nil {
+			return false
+		}
+		return d1.Seconds() < d2.Seconds()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		if stats.Duration == nil {
+			return fmt.Sprintf("%11v", "--")
+		} else {
+			return fmt.Sprintf("%11v", util.FormatDuration(stats.Duration, true))
+		}
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		if stats.Duration == nil {
+			return "0"
+		}
+		return fmt.Sprintf("%v", stats.Duration.Seconds())
+	}
+	c := uiCommon.NewListColumn("DURATION", "DURATION", 11,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnBuildpack() *uiCommon.ListColumn {
+	defaultColSize := 25
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayStagingStats).Buildpack, c2.(*DisplayStagingStats).Buildpack)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		if stats.Buildpack == "" {
+			return fmt.Sprintf("%-25v", "--")
+		}
+		return util.FormatDisplayData(stats.Buildpack, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return stats.Buildpack
+	}
+	c := uiCommon.NewListColumn("BUILDPACK", "BUILDPACK", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnLogStdout() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStagingStats).OutCount < c2.(*DisplayStagingStats).OutCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		return fmt.Sprintf("%7v", util.Format(stats.OutCount))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return fmt.Sprintf("%v", stats.OutCount)
+	}
+	c := uiCommon.NewListColumn("LOG_OUT", "LOG_OUT", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnLogStderr() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStagingStats).ErrCount < c2.(*DisplayStagingStats).ErrCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		return fmt.Sprintf("%7v", util.Format(stats.ErrCount))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return fmt.Sprintf("%v", stats.ErrCount)
+	}
+	c := uiCommon.NewListColumn("LOG_ERR", "LOG_ERR", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnEndTime() *uiCommon.ListColumn {
+	defaultColSize := 19
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		t1 := c1.(*DisplayStagingStats).EndTime
+		t2 := c2.(*DisplayStagingStats).EndTime
+		if t1 == nil {
+			return true
+		}
+		if t2 == nil {
+			return false
+		}
+		return t1.Before(*t2)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		if stats.EndTime == nil {
+			return fmt.Sprintf("%-19v", "--")
+		} else {
+			return fmt.Sprintf("%-19v", stats.EndTime.Local().Format("01-02-2006 15:04:05"))
+		}
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return fmt.Sprintf("%v", stats.EndTime)
+	}
+	c := uiCommon.NewListColumn("END_TIME", "END_TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnFailureReason() *uiCommon.ListColumn {
+	defaultColSize := 40
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayStagingStats).FailureReason < c2.(*DisplayStagingStats).FailureReason
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayStagingStats)
+		return util.FormatDisplayData(stats.FailureReason, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayStagingStats)
+		return stats.FailureReason
+	}
+	c := uiCommon.NewListColumn("FAILURE_REASON", "FAILURE_REASON", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
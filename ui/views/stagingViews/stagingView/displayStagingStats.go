@@ -0,0 +1,145 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package stagingView
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+)
+
+const (
+	STATE_STAGING   = "STAGING"
+	STATE_SUCCEEDED = "SUCCEEDED"
+	STATE_FAILED    = "FAILED"
+	STATE_UNKNOWN   = "UNKNOWN"
+)
+
+type DisplayStagingStats struct {
+	*eventApp.StagingStats
+	AppId string
+
+	AppName   string
+	SpaceName string
+	OrgName   string
+
+	State         string
+	FailureReason string
+	Duration      *time.Duration
+
+	key string
+}
+
+func NewDisplayStagingStats(appId string, stagingStats *eventApp.StagingStats) *DisplayStagingStats {
+	stats := &DisplayStagingStats{AppId: appId, StagingStats: stagingStats}
+	return stats
+}
+
+func (ss *DisplayStagingStats) Id() string {
+	if ss.key == "" {
+		ss.key = fmt.Sprintf("%v/%v", ss.AppId, ss.StartTime.UnixNano())
+	}
+	return ss.key
+}
+
+// Build the list of staging builds to display from the staging activity
+// captured from the firehose.  The outcome of the latest build of an app is taken
+// from the app metadata (package_state) once it has been reloaded after the build.
+func PostProcessStagingData(mdMgr *metadata.GlobalManager, appMap map[string]*eventApp.AppStats) []*DisplayStagingStats {
+
+	now := time.Now()
+	displayStagingList := make([]*DisplayStagingStats, 0)
+
+	for _, appStats := range appMap {
+		if len(appStats.StagingArray) == 0 {
+			continue
+		}
+		appMetadata := mdMgr.GetAppMdManager().FindItem(appStats.AppId)
+		spaceMd := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
+		orgMd := mdMgr.GetOrgMdManager().FindItem(spaceMd.OrgGuid)
+
+		lastIndex := len(appStats.StagingArray) - 1
+		for i, stagingStats := range appStats.StagingArray {
+			displayStagingStats := NewDisplayStagingStats(appStats.AppId, stagingStats)
+			displayStagingStats.AppName = appMetadata.Name
+			displayStagingStats.SpaceName = spaceMd.Name
+			displayStagingStats.OrgName = orgMd.Name
+			if displayStagingStats.Buildpack == "" && i == lastIndex {
+				displayStagingStats.Buildpack = appMetadata.DetectedBuildpack
+			}
+			setStagingState(displayStagingStats, appMetadata, i == lastIndex, now)
+
+			stopTime := now
+			if stagingStats.EndTime != nil {
+				stopTime = *stagingStats.EndTime
+			} else if stagingStats.IsEnded(now) {
+				stopTime = *stagingStats.LastLogTime
+			}
+			duration := stopTime.Sub(*stagingStats.StartTime)
+			displayStagingStats.Duration = &duration
+
+			displayStagingList = append(displayStagingList, displayStagingStats)
+		}
+	}
+	return displayStagingList
+}
+
+func setStagingState(stats *DisplayStagingStats, appMetadata *app.AppMetadata, isLatest bool, now time.Time) {
+
+	switch {
+	case !stats.IsEnded(now):
+		stats.State = STATE_STAGING
+		return
+	case stats.EndTime == nil:
+		// No staging output for a while and staging container was never destroyed
+		stats.State = STATE_UNKNOWN
+		return
+	case stats.ExitStatus != "" && stats.ExitStatus != "0":
+		stats.State = STATE_FAILED
+		stats.FailureReason = fmt.Sprintf("Exit status %v", stats.ExitStatus)
+	}
+
+	// App metadata only reflects the latest build and only once reloaded after the build ended
+	cacheTime := appMetadata.GetCacheTime()
+	if isLatest && appMetadata.App != nil && cacheTime != nil && cacheTime.After(*stats.EndTime) {
+		switch appMetadata.PackageState {
+		case "FAILED":
+			stats.State = STATE_FAILED
+			if appMetadata.StagingFailedReason != "" {
+				stats.FailureReason = appMetadata.StagingFailedReason
+				if appMetadata.StagingFailedDesc != "" {
+					stats.FailureReason = fmt.Sprintf("%v: %v", appMetadata.StagingFailedReason, appMetadata.StagingFailedDesc)
+				}
+			}
+			return
+		case "STAGED":
+			if stats.State == "" {
+				stats.State = STATE_SUCCEEDED
+			}
+			return
+		}
+	}
+
+	if stats.State == "" {
+		if stats.ExitStatus == "0" {
+			stats.State = STATE_SUCCEEDED
+		} else {
+			stats.State = STATE_UNKNOWN
+		}
+	}
+}
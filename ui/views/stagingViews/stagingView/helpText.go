@@ -0,0 +1,49 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package stagingView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Staging List View**
+
+Staging list view shows in-progress and recent application staging
+(builds) seen on the firehose since top was started.  Up to the last
+10 builds of each app are shown.  A build starts with the first STG
+log output and ends when the staging container is destroyed.
+
+The outcome of the latest build of an app is taken from the cloud
+controller (package_state, staging_failed_reason) once the app metadata
+is reloaded after the build.  A build with no staging output for 15
+minutes that never completed has a state of UNKNOWN.
+`
+
+const HelpColumnsText = `
+**Staging Columns:**
+
+  APPLICATION - Application being staged
+  SPACE - Space name
+  ORG - Organization name
+  STATE - STAGING, SUCCEEDED, FAILED or UNKNOWN
+  START_TIME - Time of first staging output (24 hour format in local timezone)
+  DURATION - How long the build has been (or was) running
+  BUILDPACK - Buildpack detected in staging output
+  LOG_OUT - Number of stdout staging log messages
+  LOG_ERR - Number of stderr staging log messages
+  END_TIME - Time staging completed (24 hour format in local timezone)
+  FAILURE_REASON - Reason staging failed
+`
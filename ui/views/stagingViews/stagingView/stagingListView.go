@@ -0,0 +1,89 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package stagingView
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+)
+
+type StagingListView struct {
+	*dataView.DataListView
+}
+
+func NewStagingListView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *StagingListView {
+
+	asUI := &StagingListView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("START_TIME", true),
+		uiCommon.NewSortColumn("appName", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string { return "Staging List" })
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *StagingListView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, ColumnAppName())
+	columns = append(columns, ColumnSpaceName())
+	columns = append(columns, ColumnOrgName())
+	columns = append(columns, ColumnState())
+	columns = append(columns, ColumnStartTime())
+	columns = append(columns, ColumnDuration())
+	columns = append(columns, ColumnBuildpack())
+	columns = append(columns, ColumnLogStdout())
+	columns = append(columns, ColumnLogStderr())
+	columns = append(columns, ColumnEndTime())
+	columns = append(columns, ColumnFailureReason())
+	return columns
+}
+
+func (asUI *StagingListView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+func (asUI *StagingListView) postProcessData() []*DisplayStagingStats {
+	appMap := asUI.GetDisplayedEventData().AppMap
+	return PostProcessStagingData(asUI.GetMdGlobalMgr(), appMap)
+}
+
+func (asUI *StagingListView) convertToListData(displayStagingList []*DisplayStagingStats) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayStagingList))
+	for _, d := range displayStagingList {
+		listData = append(listData, d)
+	}
+	return listData
+}
@@ -0,0 +1,340 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/task"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func stateAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	stats := data.(*DisplayTaskStats)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	switch stats.State {
+	case task.StateFailed:
+		attentionType = uiCommon.ATTENTION_STATE_CRASHED
+	case task.StateRunning:
+		attentionType = uiCommon.ATTENTION_ACTIVITY
+	case task.StatePending, task.StateCanceling:
+		attentionType = uiCommon.ATTENTION_STATE_STARTING
+	case UNKNOWN_STATE:
+		attentionType = uiCommon.ATTENTION_STATE_UNKNOWN
+	}
+	return attentionType
+}
+
+func ColumnTaskName() *uiCommon.ListColumn {
+	defaultColSize := 20
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayTaskStats).TaskName, c2.(*DisplayTaskStats).TaskName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		return util.FormatDisplayData(stats.TaskName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return stats.TaskName
+	}
+	c := uiCommon.NewListColumn("TASK_NAME", "TASK_NAME", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnSequenceId() *uiCommon.ListColumn {
+	defaultColSize := 5
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTaskStats).SequenceId < c2.(*DisplayTaskStats).SequenceId
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		if stats.TaskGuid == "" {
+			return fmt.Sprintf("%5v", "--")
+		}
+		return fmt.Sprintf("%5v", stats.SequenceId)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%v", stats.SequenceId)
+	}
+	c := uiCommon.NewListColumn("SEQ", "SEQ", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetDefaultAggregate(uiCommon.AGGREGATE_NONE)
+	return c
+}
+
+func ColumnAppName() *uiCommon.ListColumn {
+	defaultColSize := 30
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayTaskStats).AppName, c2.(*DisplayTaskStats).AppName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		return util.FormatDisplayData(stats.AppName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return stats.AppName
+	}
+	c := uiCommon.NewListColumn("appName", "APPLICATION", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnSpaceName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayTaskStats).SpaceName, c2.(*DisplayTaskStats).SpaceName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		return util.FormatDisplayData(stats.SpaceName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return stats.SpaceName
+	}
+	c := uiCommon.NewListColumn("spaceName", "SPACE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnOrgName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayTaskStats).OrgName, c2.(*DisplayTaskStats).OrgName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		return util.FormatDisplayData(stats.OrgName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return stats.OrgName
+	}
+	c := uiCommon.NewListColumn("orgName", "ORG", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnState() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTaskStats).State < c2.(*DisplayTaskStats).State
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		return util.FormatDisplayData(stats.State, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return stats.State
+	}
+	c := uiCommon.NewListColumn("STATE", "STATE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnStartTime() *uiCommon.ListColumn {
+	defaultColSize := 19
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		t1 := c1.(*DisplayTaskStats).StartTime
+		t2 := c2.(*DisplayTaskStats).StartTime
+		if t1 == nil {
+			return true
+		}
+		if t2 == nil {
+			return false
+		}
+		return t1.Before(*t2)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		if stats.StartTime == nil {
+			return fmt.Sprintf("%-19v", "--")
+		} else {
+			return fmt.Sprintf("%-19v", stats.StartTime.Local().Format("01-02-2006 15:04:05"))
+		}
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%v", stats.StartTime)
+	}
+	c := uiCommon.NewListColumn("START_TIME", "START_TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnStopTime() *uiCommon.ListColumn {
+	defaultColSize := 19
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		t1 := c1.(*DisplayTaskStats).StopTime
+		t2 := c2.(*DisplayTaskStats).StopTime
+		if t1 == nil {
+			return true
+		}
+		if t2 == nil {
+			return false
+		}
+		return t1.Before(*t2)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		if stats.StopTime == nil {
+			return fmt.Sprintf("%-19v", "--")
+		} else {
+			return fmt.Sprintf("%-19v", stats.StopTime.Local().Format("01-02-2006 15:04:05"))
+		}
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%v", stats.StopTime)
+	}
+	c := uiCommon.NewListColumn("STOP_TIME", "STOP_TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnDuration() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		d1 := c1.(*DisplayTaskStats).Duration
+		d2 := c2.(*DisplayTaskStats).Duration
+		if d1 == nil {
+			return true
+		}
+		if d2 == nil {
+			return false
+		}
+		return d1.Seconds() < d2.Seconds()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		if stats.Duration == nil {
+			return fmt.Sprintf("%11v", "--")
+		} else {
+			return fmt.Sprintf("%11v", util.FormatDuration(stats.Duration, true))
+		}
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		if stats.Duration == nil {
+			return "0"
+		}
+		return fmt.Sprintf("%v", stats.Duration.Seconds())
+	}
+	c := uiCommon.NewListColumn("DURATION", "DURATION", 11,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
+
+func ColumnMemoryReserved() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTaskStats).MemoryMB < c2.(*DisplayTaskStats).MemoryMB
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		if stats.MemoryMB == 0 {
+			return fmt.Sprintf("%9v", "--")
+		}
+		return fmt.Sprintf("%9v", util.ByteSize(stats.MemoryMB*util.MEGABYTE).StringWithPrecision(0))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%v", stats.MemoryMB*util.MEGABYTE)
+	}
+	c := uiCommon.NewListColumn("MEM_RSVD", "MEM_RSVD", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnDiskReserved() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTaskStats).DiskQuotaMB < c2.(*DisplayTaskStats).DiskQuotaMB
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		if stats.DiskQuotaMB == 0 {
+			return fmt.Sprintf("%9v", "--")
+		}
+		return fmt.Sprintf("%9v", util.ByteSize(stats.DiskQuotaMB*util.MEGABYTE).StringWithPrecision(0))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%v", stats.DiskQuotaMB*util.MEGABYTE)
+	}
+	c := uiCommon.NewListColumn("DISK_RSVD", "DISK_RSVD", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnLogStdout() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTaskStats).OutCount < c2.(*DisplayTaskStats).OutCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%11v", util.Format(stats.OutCount))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%v", stats.OutCount)
+	}
+	c := uiCommon.NewListColumn("LOG_OUT", "LOG_OUT", 11,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnLogStderr() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTaskStats).ErrCount < c2.(*DisplayTaskStats).ErrCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%11v", util.Format(stats.ErrCount))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return fmt.Sprintf("%v", stats.ErrCount)
+	}
+	c := uiCommon.NewListColumn("LOG_ERR", "LOG_ERR", 11,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnFailureReason() *uiCommon.ListColumn {
+	defaultColSize := 40
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTaskStats).FailureReason < c2.(*DisplayTaskStats).FailureReason
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayTaskStats)
+		failureReason := stats.FailureReason
+		if failureReason == "" {
+			failureReason = "--"
+		}
+		return util.FormatDisplayData(failureReason, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayTaskStats)
+		return stats.FailureReason
+	}
+	c := uiCommon.NewListColumn("FAILURE_REASON", "FAILURE_REASON", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, stateAttentionFunc)
+	return c
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskView
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/task"
+)
+
+const UNKNOWN_STATE = "UNKNOWN"
+
+type DisplayTaskStats struct {
+	TaskGuid   string
+	TaskName   string
+	SequenceId int
+	AppId      string
+
+	AppName   string
+	SpaceName string
+	OrgName   string
+
+	State         string
+	FailureReason string
+	MemoryMB      float64
+	DiskQuotaMB   float64
+
+	StartTime *time.Time
+	StopTime  *time.Time
+	Duration  *time.Duration
+
+	OutCount    int64
+	ErrCount    int64
+	LastLogTime *time.Time
+
+	key string
+}
+
+func NewDisplayTaskStats(appId string, taskName string) *DisplayTaskStats {
+	stats := &DisplayTaskStats{AppId: appId, TaskName: taskName}
+	return stats
+}
+
+func (ts *DisplayTaskStats) Id() string {
+	if ts.key == "" {
+		if ts.TaskGuid != "" {
+			ts.key = ts.TaskGuid
+		} else {
+			// Task seen on firehose but not (yet) in cloud controller metadata
+			ts.key = ts.AppId + "/" + ts.TaskName
+		}
+	}
+	return ts.key
+}
+
+// Build the list of tasks to display by merging the task metadata from
+// cloud controller (/v3/tasks) with the log counts captured from the firehose.
+// If appId is empty, tasks for all apps are returned.
+func PostProcessTaskData(mdMgr *metadata.GlobalManager, appMap map[string]*eventApp.AppStats, appId string) []*DisplayTaskStats {
+
+	now := time.Now()
+	displayTaskList := make([]*DisplayTaskStats, 0)
+
+	var taskMetadataList []*task.TaskMetadata
+	if appId == "" {
+		taskMetadataList = mdMgr.GetTaskMdManager().GetAll()
+	} else {
+		taskMetadataList = mdMgr.GetTaskMdManager().FindByApp(appId)
+	}
+
+	displayTaskByGuid := make(map[string]*DisplayTaskStats)
+	// Task names can be reused (e.g., cf run-task myapp "rake db:migrate" --name migrate).
+	// Log counts of a task not yet matched to its metadata are attributed to the most
+	// recent task with a given name.  Key: appId/taskName
+	latestTaskByName := make(map[string]*DisplayTaskStats)
+
+	for _, taskMetadata := range taskMetadataList {
+		displayTaskStats := NewDisplayTaskStats(taskMetadata.AppGuid, taskMetadata.Name)
+		displayTaskStats.TaskGuid = taskMetadata.Guid
+		displayTaskStats.SequenceId = taskMetadata.SequenceId
+		displayTaskStats.State = taskMetadata.State
+		displayTaskStats.FailureReason = taskMetadata.Result.FailureReason
+		displayTaskStats.MemoryMB = taskMetadata.MemoryMB
+		displayTaskStats.DiskQuotaMB = taskMetadata.DiskQuotaMB
+		displayTaskStats.StartTime = taskMetadata.CreatedTime
+		if taskMetadata.IsComplete() {
+			displayTaskStats.StopTime = taskMetadata.UpdatedTime
+		}
+		displayTaskList = append(displayTaskList, displayTaskStats)
+		displayTaskByGuid[taskMetadata.Guid] = displayTaskStats
+
+		nameKey := taskMetadata.AppGuid + "/" + taskMetadata.Name
+		latest := latestTaskByName[nameKey]
+		if latest == nil || latest.StartTime == nil ||
+			(displayTaskStats.StartTime != nil && displayTaskStats.StartTime.After(*latest.StartTime)) {
+			latestTaskByName[nameKey] = displayTaskStats
+		}
+	}
+
+	for _, appStats := range appMap {
+		if appId != "" && appStats.AppId != appId {
+			continue
+		}
+		for _, taskStats := range appStats.TaskMap {
+			var displayTaskStats *DisplayTaskStats
+			if taskStats.TaskGuid != "" {
+				displayTaskStats = displayTaskByGuid[taskStats.TaskGuid]
+			} else {
+				displayTaskStats = latestTaskByName[appStats.AppId+"/"+taskStats.TaskName]
+				if displayTaskStats != nil && displayTaskStats.LastLogTime != nil {
+					// Latest task with this name already has its own log counts
+					displayTaskStats = nil
+				}
+			}
+			if displayTaskStats == nil {
+				displayTaskStats = NewDisplayTaskStats(appStats.AppId, taskStats.TaskName)
+				displayTaskStats.TaskGuid = taskStats.TaskGuid
+				displayTaskStats.State = UNKNOWN_STATE
+				displayTaskStats.StartTime = taskStats.FirstLogTime
+				displayTaskList = append(displayTaskList, displayTaskStats)
+			}
+			displayTaskStats.OutCount += taskStats.OutCount
+			displayTaskStats.ErrCount += taskStats.ErrCount
+			if displayTaskStats.LastLogTime == nil || (taskStats.LastLogTime != nil && taskStats.LastLogTime.After(*displayTaskStats.LastLogTime)) {
+				displayTaskStats.LastLogTime = taskStats.LastLogTime
+			}
+		}
+	}
+
+	for _, displayTaskStats := range displayTaskList {
+		appMetadata := mdMgr.GetAppMdManager().FindItem(displayTaskStats.AppId)
+		displayTaskStats.AppName = appMetadata.Name
+		spaceMd := mdMgr.GetSpaceMdManager().FindItem(appMetadata.SpaceGuid)
+		displayTaskStats.SpaceName = spaceMd.Name
+		orgMd := mdMgr.GetOrgMdManager().FindItem(spaceMd.OrgGuid)
+		displayTaskStats.OrgName = orgMd.Name
+
+		if displayTaskStats.StartTime != nil {
+			stopTime := now
+			if displayTaskStats.StopTime != nil {
+				stopTime = *displayTaskStats.StopTime
+			} else if displayTaskStats.State == UNKNOWN_STATE && displayTaskStats.LastLogTime != nil {
+				stopTime = *displayTaskStats.LastLogTime
+			}
+			duration := stopTime.Sub(*displayTaskStats.StartTime)
+			displayTaskStats.Duration = &duration
+		}
+	}
+
+	return displayTaskList
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskView
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+type TaskDetailWidget struct {
+	masterUI  masterUIInterface.MasterUIInterface
+	name      string
+	width     int
+	height    int
+	taskStats *DisplayTaskStats
+}
+
+func NewTaskDetailWidget(masterUI masterUIInterface.MasterUIInterface, name string, width, height int,
+	taskStats *DisplayTaskStats) *TaskDetailWidget {
+	return &TaskDetailWidget{masterUI: masterUI, name: name, width: width, height: height, taskStats: taskStats}
+}
+
+// Open the task detail widget for the highlighted task of the given list view
+func OpenTaskDetailWidget(g *gocui.Gui, listView dataView.DataListViewInterface) error {
+	highlightKey := listView.GetListWidget().HighlightKey()
+	if highlightKey != "" {
+		idata := listView.GetListWidget().HighlightData()
+		if idata != nil {
+			taskStats := idata.(*DisplayTaskStats)
+			view := NewTaskDetailWidget(listView.GetMasterUI(), "taskDetailWidget", 70, 18, taskStats)
+			return listView.GetMasterUI().OpenView(g, view)
+		}
+	}
+	return nil
+}
+
+func (w *TaskDetailWidget) Name() string {
+	return w.name
+}
+
+func (w *TaskDetailWidget) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	sideMargin := 5
+	left := sideMargin
+	right := maxX - sideMargin
+	if right <= left+1 {
+		right = left + 2
+	}
+	top := maxY/2 - (w.height / 2)
+	if top < 1 {
+		top = 1
+	}
+	bottom := top + w.height
+	if bottom > maxY-2 {
+		bottom = maxY - 2
+	}
+	if bottom <= top {
+		bottom = top + 1
+	}
+	v, err := g.SetView(w.name, left, top, right, bottom)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return errors.New(w.name + " layout error:" + err.Error())
+		}
+		v.Title = "Task Detail"
+		v.Frame = true
+		v.Wrap = true
+		if err := g.SetKeybinding(w.name, 'x', gocui.ModNone, w.closeTaskDetailWidget); err != nil {
+			return err
+		}
+		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.closeTaskDetailWidget); err != nil {
+			return err
+		}
+		if err := w.masterUI.SetCurrentViewOnTop(g); err != nil {
+			log.Panicln(err)
+		}
+	}
+	w.RefreshDisplay(g)
+	return nil
+}
+
+func (w *TaskDetailWidget) closeTaskDetailWidget(g *gocui.Gui, v *gocui.View) error {
+	if err := w.masterUI.CloseView(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *TaskDetailWidget) UpdateDisplay(g *gocui.Gui) error {
+	return w.RefreshDisplay(g)
+}
+
+func (w *TaskDetailWidget) RefreshDisplay(g *gocui.Gui) error {
+
+	v, err := g.View(w.name)
+	if err != nil {
+		return err
+	}
+	v.Clear()
+
+	taskStats := w.taskStats
+
+	fmt.Fprintf(v, " \n")
+	fmt.Fprintf(v, "      Task Name: %v\n", taskStats.TaskName)
+	if taskStats.TaskGuid != "" {
+		fmt.Fprintf(v, "      Task GUID: %v\n", taskStats.TaskGuid)
+		fmt.Fprintf(v, "       Sequence: %v\n", taskStats.SequenceId)
+	}
+	fmt.Fprintf(v, "    Application: %v\n", taskStats.AppName)
+	fmt.Fprintf(v, "      Org/Space: %v / %v\n", taskStats.OrgName, taskStats.SpaceName)
+	fmt.Fprintf(v, "          State: %v\n", taskStats.State)
+	if taskStats.StartTime != nil {
+		fmt.Fprintf(v, "     Start Time: %v\n", taskStats.StartTime.Local().Format("01-02-2006 15:04:05"))
+	}
+	if taskStats.StopTime != nil {
+		fmt.Fprintf(v, "      Stop Time: %v\n", taskStats.StopTime.Local().Format("01-02-2006 15:04:05"))
+	}
+	if taskStats.Duration != nil {
+		fmt.Fprintf(v, "       Duration: %v\n", util.FormatDuration(taskStats.Duration, true))
+	}
+	if taskStats.MemoryMB > 0 {
+		fmt.Fprintf(v, "         Memory: %v\n", util.ByteSize(taskStats.MemoryMB*util.MEGABYTE).StringWithPrecision(0))
+	}
+	if taskStats.DiskQuotaMB > 0 {
+		fmt.Fprintf(v, "           Disk: %v\n", util.ByteSize(taskStats.DiskQuotaMB*util.MEGABYTE).StringWithPrecision(0))
+	}
+	fmt.Fprintf(v, "  Stdout/Stderr: %v / %v\n", util.Format(taskStats.OutCount), util.Format(taskStats.ErrCount))
+
+	if taskStats.FailureReason != "" {
+		fmt.Fprintf(v, " Failure Reason: ")
+		fmt.Fprintf(v, "%v", util.BRIGHT_RED)
+		fmt.Fprintf(v, "%v", taskStats.FailureReason)
+		fmt.Fprintf(v, "%v\n", util.CLEAR)
+	}
+
+	return nil
+}
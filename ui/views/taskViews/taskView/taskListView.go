@@ -0,0 +1,111 @@
+// Copyright (c) 2017 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskView
+
+import (
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+type TaskListView struct {
+	*dataView.DataListView
+}
+
+func NewTaskListView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *TaskListView {
+
+	asUI := &TaskListView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("START_TIME", true),
+		uiCommon.NewSortColumn("appName", false),
+		uiCommon.NewSortColumn("TASK_NAME", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	dataListView.SetTitle(func() string { return "Task List" })
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *TaskListView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, ColumnTaskName())
+	columns = append(columns, ColumnAppName())
+	columns = append(columns, ColumnSpaceName())
+	columns = append(columns, ColumnOrgName())
+	columns = append(columns, ColumnSequenceId())
+	columns = append(columns, ColumnState())
+	columns = append(columns, ColumnStartTime())
+	columns = append(columns, ColumnDuration())
+	columns = append(columns, ColumnMemoryReserved())
+	columns = append(columns, ColumnDiskReserved())
+	columns = append(columns, ColumnLogStdout())
+	columns = append(columns, ColumnLogStderr())
+	columns = append(columns, ColumnStopTime())
+	columns = append(columns, ColumnFailureReason())
+	return columns
+}
+
+func (asUI *TaskListView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *TaskListView) enterAction(g *gocui.Gui, v *gocui.View) error {
+	return OpenTaskDetailWidget(g, asUI)
+}
+
+func (asUI *TaskListView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+func (asUI *TaskListView) postProcessData() []*DisplayTaskStats {
+	mdMgr := asUI.GetMdGlobalMgr()
+	mdMgr.RequestRefreshTaskMetadata()
+	appMap := asUI.GetDisplayedEventData().AppMap
+	return PostProcessTaskData(mdMgr, appMap, "")
+}
+
+func (asUI *TaskListView) convertToListData(displayTaskList []*DisplayTaskStats) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayTaskList))
+	for _, d := range displayTaskList {
+		listData = append(listData, d)
+	}
+	return listData
+}
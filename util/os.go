@@ -17,6 +17,7 @@ package util
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -36,3 +37,16 @@ func IsMSWindows() bool {
 	}
 	return false
 }
+
+// Directory where top keeps its local files (crash history, named filters, etc).
+// This is the "top" directory in the cf CLI config directory ($CF_HOME/.cf/top)
+func GetTopConfigDir() string {
+	homeDir := os.Getenv("CF_HOME")
+	if homeDir == "" {
+		homeDir = os.Getenv("HOME")
+	}
+	if homeDir == "" {
+		homeDir = os.Getenv("USERPROFILE")
+	}
+	return filepath.Join(homeDir, ".cf", "top")
+}